@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	ConvertInputFlag = &cli.PathFlag{
+		Name:      "input",
+		Usage:     "path of input state. JSON, or the binary state encoding if the path ends in .bin or .bin.gz.",
+		TakesFile: true,
+		Required:  true,
+	}
+	ConvertOutputFlag = &cli.PathFlag{
+		Name:      "output",
+		Usage:     "path of output state. Binary if the path ends in .bin or .bin.gz, JSON otherwise. Use - to write JSON to Stdout.",
+		TakesFile: true,
+		Required:  true,
+	}
+)
+
+func Convert(ctx *cli.Context) error {
+	return convert(ctx.Path(ConvertInputFlag.Name), ctx.Path(ConvertOutputFlag.Name))
+}
+
+func convert(input string, output string) error {
+	state, err := loadState(input)
+	if err != nil {
+		return fmt.Errorf("invalid input state (%v): %w", input, err)
+	}
+	if err := writeState(output, state); err != nil {
+		return fmt.Errorf("failed to write output state (%v): %w", output, err)
+	}
+	return nil
+}
+
+var ConvertCommand = &cli.Command{
+	Name:        "convert",
+	Usage:       "Convert a Cannon state between JSON and binary encodings",
+	Description: "Convert a Cannon state between JSON and binary encodings. The encoding is selected by file extension: .bin or .bin.gz for binary and JSON for anything else, with .gz indicating compression.",
+	Action:      Convert,
+	Flags: []cli.Flag{
+		ConvertInputFlag,
+		ConvertOutputFlag,
+	},
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	state := &mipsevm.State{Memory: mipsevm.NewMemory(), PC: 4, NextPC: 8, Step: 99}
+	state.Memory.SetMemory(0x40, 0x1234)
+	jsonPath := filepath.Join(dir, "state.json")
+	binPath := filepath.Join(dir, "state.bin.gz")
+	roundTripPath := filepath.Join(dir, "roundtrip.json")
+	require.NoError(t, writeState(jsonPath, state))
+
+	require.NoError(t, convert(jsonPath, binPath))
+	require.NoError(t, convert(binPath, roundTripPath))
+
+	expected, err := os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	actual, err := os.ReadFile(roundTripPath)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected), string(actual))
+
+	// The binary file must not be JSON
+	_, err = loadJSON[mipsevm.State](binPath)
+	require.Error(t, err)
+}
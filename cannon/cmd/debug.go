@@ -0,0 +1,482 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+var (
+	DebugInputFlag = &cli.PathFlag{
+		Name:      "input",
+		Usage:     "path of input state. JSON, or the binary state encoding if the path ends in .bin or .bin.gz.",
+		TakesFile: true,
+		Required:  true,
+	}
+	DebugMetaFlag = &cli.PathFlag{
+		Name:     "meta",
+		Usage:    "path to metadata file for symbol lookup. Symbols are not available if empty.",
+		Value:    "meta.json",
+		Required: false,
+	}
+	DebugTraceOutFlag = &cli.PathFlag{
+		Name:     "trace-out",
+		Usage:    "path to write a JSON lines instruction trace to, use - for Stdout. If set, the trace is exported without starting the interactive debugger.",
+		Required: false,
+	}
+	DebugTraceFromFlag = &cli.Uint64Flag{
+		Name:     "trace-from",
+		Usage:    "first step to include in the instruction trace.",
+		Required: false,
+	}
+	DebugTraceToFlag = &cli.Uint64Flag{
+		Name:     "trace-to",
+		Usage:    "step to stop the instruction trace at (exclusive). The trace continues until the program exits if 0.",
+		Required: false,
+	}
+)
+
+const (
+	// syscallInsn is the encoding of the MIPS syscall instruction
+	syscallInsn = 0x0000000c
+	// regV0 is the register holding the syscall number
+	regV0 = 2
+)
+
+// TraceEntry describes the execution of a single instruction.
+// Registers are the values prior to executing the instruction.
+type TraceEntry struct {
+	Step      uint64           `json:"step"`
+	PC        mipsevm.HexU32   `json:"pc"`
+	NextPC    mipsevm.HexU32   `json:"nextPC"`
+	Insn      mipsevm.HexU32   `json:"insn"`
+	Symbol    string           `json:"symbol"`
+	Registers []mipsevm.HexU32 `json:"registers"`
+	LO        mipsevm.HexU32   `json:"lo"`
+	HI        mipsevm.HexU32   `json:"hi"`
+
+	// Memory access made by the instruction, if any.
+	MemAddr   *mipsevm.HexU32 `json:"memAddr,omitempty"`
+	MemBefore *mipsevm.HexU32 `json:"memBefore,omitempty"`
+	MemAfter  *mipsevm.HexU32 `json:"memAfter,omitempty"`
+
+	// Pre-image data read by the instruction, if any.
+	PreimageKey    *common.Hash `json:"preimageKey,omitempty"`
+	PreimageOffset *uint32      `json:"preimageOffset,omitempty"`
+
+	// Post is the hash of the state witness after executing the instruction.
+	Post common.Hash `json:"post"`
+}
+
+// breakpoint stops execution before the instruction at the current PC is executed.
+type breakpoint struct {
+	desc  string
+	match func(state *mipsevm.State) bool
+}
+
+// Debugger executes a VM state one instruction at a time, supporting breakpoints and inspection of the state.
+type Debugger struct {
+	state       *mipsevm.State
+	vm          *mipsevm.InstrumentedState
+	stepFn      StepFn
+	meta        *mipsevm.Metadata
+	out         io.Writer
+	breakpoints []breakpoint
+}
+
+func NewDebugger(state *mipsevm.State, po mipsevm.PreimageOracle, meta *mipsevm.Metadata, out io.Writer, stdOut, stdErr io.Writer) *Debugger {
+	vm := mipsevm.NewInstrumentedState(state, po, stdOut, stdErr)
+	return &Debugger{
+		state:  state,
+		vm:     vm,
+		stepFn: vm.Step,
+		meta:   meta,
+		out:    out,
+	}
+}
+
+// Step executes a single instruction, returning the trace entry for it.
+func (d *Debugger) Step() (*TraceEntry, error) {
+	if d.state.Exited {
+		return nil, errors.New("program has exited")
+	}
+	entry := &TraceEntry{
+		Step:      d.state.Step,
+		PC:        mipsevm.HexU32(d.state.PC),
+		NextPC:    mipsevm.HexU32(d.state.NextPC),
+		Insn:      mipsevm.HexU32(d.state.Memory.GetMemory(d.state.PC)),
+		Symbol:    d.meta.LookupSymbol(d.state.PC),
+		Registers: make([]mipsevm.HexU32, len(d.state.Registers)),
+		LO:        mipsevm.HexU32(d.state.LO),
+		HI:        mipsevm.HexU32(d.state.HI),
+	}
+	for i, reg := range d.state.Registers {
+		entry.Registers[i] = mipsevm.HexU32(reg)
+	}
+	witness, err := d.stepFn(true)
+	if err != nil {
+		return nil, fmt.Errorf("failed at step %d (PC: %08x): %w", entry.Step, uint32(entry.PC), err)
+	}
+	if addr, preValue, ok := d.vm.LastMemAccess(); ok {
+		memAddr := mipsevm.HexU32(addr)
+		before := mipsevm.HexU32(preValue)
+		after := mipsevm.HexU32(d.state.Memory.GetMemory(addr))
+		entry.MemAddr = &memAddr
+		entry.MemBefore = &before
+		entry.MemAfter = &after
+	}
+	if witness.HasPreimage() {
+		key := common.Hash(witness.PreimageKey)
+		offset := witness.PreimageOffset
+		entry.PreimageKey = &key
+		entry.PreimageOffset = &offset
+	}
+	entry.Post, err = d.state.EncodeWitness().StateHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash poststate witness: %w", err)
+	}
+	return entry, nil
+}
+
+// Continue executes instructions until a breakpoint is hit, the program exits or the context is done.
+// At least one instruction is executed, so continuing from a breakpoint does not immediately stop again.
+func (d *Debugger) Continue(ctx context.Context) (*breakpoint, error) {
+	for first := true; !d.state.Exited; first = false {
+		if d.state.Step%100 == 0 { // don't do the ctx err check (includes lock) too often
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if !first {
+			for i := range d.breakpoints {
+				if d.breakpoints[i].match(d.state) {
+					return &d.breakpoints[i], nil
+				}
+			}
+		}
+		if _, err := d.stepFn(false); err != nil {
+			return nil, fmt.Errorf("failed at step %d (PC: %08x): %w", d.state.Step, d.state.PC, err)
+		}
+	}
+	return nil, nil
+}
+
+// BreakOnSymbol adds a breakpoint at the entry of the named symbol.
+func (d *Debugger) BreakOnSymbol(name string) error {
+	for _, sym := range d.meta.Symbols {
+		if sym.Name == name {
+			start := sym.Start
+			d.breakpoints = append(d.breakpoints, breakpoint{
+				desc: fmt.Sprintf("symbol %v (%08x)", name, start),
+				match: func(state *mipsevm.State) bool {
+					return state.PC == start
+				},
+			})
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown symbol %q", name)
+}
+
+// BreakOnSyscall adds a breakpoint before any syscall is executed, or only the syscall number num if num is not nil.
+func (d *Debugger) BreakOnSyscall(num *uint32) {
+	desc := "any syscall"
+	if num != nil {
+		desc = fmt.Sprintf("syscall %d", *num)
+	}
+	d.breakpoints = append(d.breakpoints, breakpoint{
+		desc: desc,
+		match: func(state *mipsevm.State) bool {
+			if state.Memory.GetMemory(state.PC) != syscallInsn {
+				return false
+			}
+			return num == nil || state.Registers[regV0] == *num
+		},
+	})
+}
+
+// ExportTrace executes instructions from the current step until step to (exclusive) or the program exits,
+// writing a JSON trace entry to out for each instruction at or after step from.
+// If to is 0, execution continues until the program exits.
+func (d *Debugger) ExportTrace(ctx context.Context, out io.Writer, from uint64, to uint64) error {
+	if to == 0 {
+		to = math.MaxUint64
+	}
+	enc := json.NewEncoder(out)
+	for !d.state.Exited && d.state.Step < to {
+		if d.state.Step%100 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		if d.state.Step < from {
+			if _, err := d.stepFn(false); err != nil {
+				return fmt.Errorf("failed at step %d (PC: %08x): %w", d.state.Step, d.state.PC, err)
+			}
+			continue
+		}
+		entry, err := d.Step()
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(entry); err != nil {
+			return fmt.Errorf("failed to write trace entry: %w", err)
+		}
+	}
+	return nil
+}
+
+func (d *Debugger) printInfo() {
+	fmt.Fprintf(d.out, "step:     %d\n", d.state.Step)
+	fmt.Fprintf(d.out, "pc:       %08x (%s)\n", d.state.PC, d.meta.LookupSymbol(d.state.PC))
+	fmt.Fprintf(d.out, "next pc:  %08x\n", d.state.NextPC)
+	fmt.Fprintf(d.out, "insn:     %08x\n", d.state.Memory.GetMemory(d.state.PC))
+	fmt.Fprintf(d.out, "exited:   %v (code %d)\n", d.state.Exited, d.state.ExitCode)
+	fmt.Fprintf(d.out, "preimage: %v offset %d\n", d.state.PreimageKey, d.state.PreimageOffset)
+}
+
+func (d *Debugger) printRegisters() {
+	for i, reg := range d.state.Registers {
+		fmt.Fprintf(d.out, "r%-2d %08x", i, reg)
+		if i%4 == 3 {
+			fmt.Fprintln(d.out)
+		} else {
+			fmt.Fprint(d.out, "  ")
+		}
+	}
+	fmt.Fprintf(d.out, "lo  %08x  hi  %08x  heap %08x\n", d.state.LO, d.state.HI, d.state.Heap)
+}
+
+func (d *Debugger) printMemory(addr uint32, words uint32) {
+	addr &^= 3
+	for i := uint32(0); i < words; i++ {
+		a := addr + i*4
+		if i%4 == 0 {
+			if i > 0 {
+				fmt.Fprintln(d.out)
+			}
+			fmt.Fprintf(d.out, "%08x:", a)
+		}
+		fmt.Fprintf(d.out, " %08x", d.state.Memory.GetMemory(a))
+	}
+	fmt.Fprintln(d.out)
+}
+
+func (d *Debugger) printEntry(entry *TraceEntry) {
+	fmt.Fprintf(d.out, "step %d pc %v (%s) insn %v", entry.Step, entry.PC, entry.Symbol, entry.Insn)
+	if entry.MemAddr != nil {
+		fmt.Fprintf(d.out, " mem[%v] %v -> %v", *entry.MemAddr, *entry.MemBefore, *entry.MemAfter)
+	}
+	if entry.PreimageKey != nil {
+		fmt.Fprintf(d.out, " preimage %v offset %d", *entry.PreimageKey, *entry.PreimageOffset)
+	}
+	fmt.Fprintln(d.out)
+}
+
+const debugHelp = `Commands:
+  step [n], s [n]          execute n instructions (default 1)
+  continue, c              execute until a breakpoint is hit or the program exits
+  break <symbol>, b        break at the entry of a symbol
+  break-syscall [num]      break before a syscall, optionally only syscall number num
+  breakpoints              list breakpoints
+  clear                    remove all breakpoints
+  info, i                  show the current step, pc and exit status
+  regs, r                  show registers
+  mem <addr> [words], m    show memory words, addr in hex
+  help, h                  show this help
+  quit, q                  exit the debugger`
+
+// Run reads commands from in until it is exhausted or a quit command is received.
+func (d *Debugger) Run(ctx context.Context, in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	fmt.Fprint(d.out, "(cannon) ")
+	for scanner.Scan() {
+		args := strings.Fields(scanner.Text())
+		if len(args) > 0 {
+			if args[0] == "quit" || args[0] == "q" {
+				return nil
+			}
+			if err := d.exec(ctx, args[0], args[1:]); err != nil {
+				if ctx.Err() != nil {
+					return err
+				}
+				fmt.Fprintf(d.out, "error: %v\n", err)
+			}
+		}
+		fmt.Fprint(d.out, "(cannon) ")
+	}
+	fmt.Fprintln(d.out)
+	return scanner.Err()
+}
+
+func (d *Debugger) exec(ctx context.Context, cmd string, args []string) error {
+	switch cmd {
+	case "step", "s":
+		count := uint64(1)
+		if len(args) > 0 {
+			n, err := strconv.ParseUint(args[0], 0, 64)
+			if err != nil {
+				return fmt.Errorf("invalid step count: %w", err)
+			}
+			count = n
+		}
+		for i := uint64(0); i < count; i++ {
+			entry, err := d.Step()
+			if err != nil {
+				return err
+			}
+			d.printEntry(entry)
+		}
+	case "continue", "c":
+		bp, err := d.Continue(ctx)
+		if err != nil {
+			return err
+		}
+		if bp != nil {
+			fmt.Fprintf(d.out, "breakpoint: %v\n", bp.desc)
+		} else {
+			fmt.Fprintln(d.out, "program exited")
+		}
+		d.printInfo()
+	case "break", "b":
+		if len(args) != 1 {
+			return errors.New("usage: break <symbol>")
+		}
+		return d.BreakOnSymbol(args[0])
+	case "break-syscall":
+		var num *uint32
+		if len(args) > 0 {
+			n, err := strconv.ParseUint(args[0], 0, 32)
+			if err != nil {
+				return fmt.Errorf("invalid syscall number: %w", err)
+			}
+			v := uint32(n)
+			num = &v
+		}
+		d.BreakOnSyscall(num)
+	case "breakpoints":
+		for i, bp := range d.breakpoints {
+			fmt.Fprintf(d.out, "%d: %v\n", i, bp.desc)
+		}
+	case "clear":
+		d.breakpoints = nil
+	case "info", "i":
+		d.printInfo()
+	case "regs", "r":
+		d.printRegisters()
+	case "mem", "m":
+		if len(args) < 1 {
+			return errors.New("usage: mem <addr> [words]")
+		}
+		addr, err := strconv.ParseUint(strings.TrimPrefix(args[0], "0x"), 16, 32)
+		if err != nil {
+			return fmt.Errorf("invalid address: %w", err)
+		}
+		words := uint64(4)
+		if len(args) > 1 {
+			words, err = strconv.ParseUint(args[1], 0, 32)
+			if err != nil {
+				return fmt.Errorf("invalid word count: %w", err)
+			}
+		}
+		d.printMemory(uint32(addr), uint32(words))
+	case "help", "h":
+		fmt.Fprintln(d.out, debugHelp)
+	default:
+		return fmt.Errorf("unknown command %q, see help", cmd)
+	}
+	return nil
+}
+
+func Debug(ctx *cli.Context) error {
+	state, err := loadState(ctx.Path(DebugInputFlag.Name))
+	if err != nil {
+		return err
+	}
+	meta := &mipsevm.Metadata{Symbols: nil}
+	if metaPath := ctx.Path(DebugMetaFlag.Name); metaPath != "" {
+		meta, err = loadJSON[mipsevm.Metadata](metaPath)
+		if err != nil {
+			return fmt.Errorf("failed to load metadata: %w", err)
+		}
+	}
+
+	l := Logger(os.Stderr, log.LvlInfo)
+	outLog := &mipsevm.LoggingWriter{Name: "program std-out", Log: l}
+	errLog := &mipsevm.LoggingWriter{Name: "program std-err", Log: l}
+
+	// split CLI args after first '--'
+	args := ctx.Args().Slice()
+	for i, arg := range args {
+		if arg == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	po, err := NewProcessPreimageOracle(args[0], args[1:])
+	if err != nil {
+		return fmt.Errorf("failed to create pre-image oracle process: %w", err)
+	}
+	if err := po.Start(); err != nil {
+		return fmt.Errorf("failed to start pre-image oracle server: %w", err)
+	}
+	defer func() {
+		if err := po.Close(); err != nil {
+			l.Error("failed to close pre-image server", "err", err)
+		}
+	}()
+
+	debugger := NewDebugger(state, po, meta, ctx.App.Writer, outLog, errLog)
+	if po.cmd != nil {
+		debugger.stepFn = Guard(po.cmd.ProcessState, debugger.stepFn)
+	}
+
+	if tracePath := ctx.Path(DebugTraceOutFlag.Name); tracePath != "" {
+		out := ctx.App.Writer
+		if tracePath != "-" {
+			f, err := os.Create(tracePath)
+			if err != nil {
+				return fmt.Errorf("failed to create trace file: %w", err)
+			}
+			defer f.Close()
+			bufOut := bufio.NewWriter(f)
+			defer bufOut.Flush()
+			out = bufOut
+		}
+		return debugger.ExportTrace(ctx.Context, out, ctx.Uint64(DebugTraceFromFlag.Name), ctx.Uint64(DebugTraceToFlag.Name))
+	}
+	debugger.printInfo()
+	return debugger.Run(ctx.Context, ctx.App.Reader)
+}
+
+var DebugCommand = &cli.Command{
+	Name:  "debug",
+	Usage: "Interactively debug VM execution or export an instruction trace",
+	Description: "Load a VM state and step through execution, with breakpoints on symbols and syscalls and inspection of registers and memory. " +
+		"If --trace-out is set, a JSON lines instruction trace is exported for the requested step range instead. " +
+		"As with run, a pre-image oracle server command may be provided after --.",
+	Action: Debug,
+	Flags: []cli.Flag{
+		DebugInputFlag,
+		DebugMetaFlag,
+		DebugTraceOutFlag,
+		DebugTraceFromFlag,
+		DebugTraceToFlag,
+	},
+}
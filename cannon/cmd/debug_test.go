@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/stretchr/testify/require"
+)
+
+const testDataAddr = 0x00100000
+
+// testDebugState creates a state for a small program that stores and loads a word, then exits.
+func testDebugState() *mipsevm.State {
+	state := &mipsevm.State{Memory: mipsevm.NewMemory(), NextPC: 4}
+	program := []uint32{
+		0x25080001, // addiu $t0, $t0, 1
+		0x3c090010, // lui $t1, 0x10
+		0xad280000, // sw $t0, 0($t1)
+		0x8d2a0000, // lw $t2, 0($t1)
+		0x24021096, // addiu $v0, $zero, 4246 (exit_group)
+		0x24040000, // addiu $a0, $zero, 0
+		0x0000000c, // syscall
+	}
+	for i, insn := range program {
+		state.Memory.SetMemory(uint32(i*4), insn)
+	}
+	state.Memory.SetMemory(testDataAddr, 0xdeadbeef)
+	return state
+}
+
+func testDebugMeta() *mipsevm.Metadata {
+	return &mipsevm.Metadata{Symbols: []mipsevm.Symbol{
+		{Name: "main", Start: 0, Size: 16},
+		{Name: "exit", Start: 16, Size: 12},
+	}}
+}
+
+func newTestDebugger(out *bytes.Buffer) (*Debugger, *mipsevm.State) {
+	state := testDebugState()
+	return NewDebugger(state, nil, testDebugMeta(), out, nil, nil), state
+}
+
+func TestDebuggerStep(t *testing.T) {
+	d, state := newTestDebugger(new(bytes.Buffer))
+	entry, err := d.Step()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), entry.Step)
+	require.Equal(t, mipsevm.HexU32(0), entry.PC)
+	require.Equal(t, mipsevm.HexU32(0x25080001), entry.Insn)
+	require.Equal(t, "main", entry.Symbol)
+	require.Equal(t, mipsevm.HexU32(0), entry.Registers[8], "should record registers before the step")
+	require.Nil(t, entry.MemAddr)
+	require.Equal(t, uint32(1), state.Registers[8])
+
+	_, err = d.Step()
+	require.NoError(t, err)
+	entry, err = d.Step()
+	require.NoError(t, err)
+	require.NotNil(t, entry.MemAddr)
+	require.Equal(t, mipsevm.HexU32(testDataAddr), *entry.MemAddr)
+	require.Equal(t, mipsevm.HexU32(0xdeadbeef), *entry.MemBefore)
+	require.Equal(t, mipsevm.HexU32(1), *entry.MemAfter)
+	expected, err := state.EncodeWitness().StateHash()
+	require.NoError(t, err)
+	require.Equal(t, expected, entry.Post)
+}
+
+func TestDebuggerBreakpoints(t *testing.T) {
+	t.Run("Symbol", func(t *testing.T) {
+		d, state := newTestDebugger(new(bytes.Buffer))
+		require.NoError(t, d.BreakOnSymbol("exit"))
+		bp, err := d.Continue(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, bp)
+		require.Equal(t, uint32(16), state.PC)
+	})
+
+	t.Run("UnknownSymbol", func(t *testing.T) {
+		d, _ := newTestDebugger(new(bytes.Buffer))
+		require.ErrorContains(t, d.BreakOnSymbol("nope"), "unknown symbol")
+	})
+
+	t.Run("Syscall", func(t *testing.T) {
+		d, state := newTestDebugger(new(bytes.Buffer))
+		num := uint32(4246)
+		d.BreakOnSyscall(&num)
+		bp, err := d.Continue(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, bp)
+		require.Equal(t, uint32(24), state.PC)
+		require.False(t, state.Exited)
+
+		// Continuing from a breakpoint executes at least one instruction
+		bp, err = d.Continue(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, bp)
+		require.True(t, state.Exited)
+	})
+
+	t.Run("OtherSyscall", func(t *testing.T) {
+		d, state := newTestDebugger(new(bytes.Buffer))
+		num := uint32(4004)
+		d.BreakOnSyscall(&num)
+		bp, err := d.Continue(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, bp)
+		require.True(t, state.Exited)
+	})
+}
+
+func TestDebuggerExportTrace(t *testing.T) {
+	t.Run("Range", func(t *testing.T) {
+		d, state := newTestDebugger(new(bytes.Buffer))
+		var out bytes.Buffer
+		require.NoError(t, d.ExportTrace(context.Background(), &out, 2, 4))
+		entries := readTrace(t, &out)
+		require.Len(t, entries, 2)
+		require.Equal(t, uint64(2), entries[0].Step)
+		require.Equal(t, mipsevm.HexU32(8), entries[0].PC)
+		require.Equal(t, mipsevm.HexU32(0xdeadbeef), *entries[0].MemBefore)
+		require.Equal(t, uint64(3), entries[1].Step)
+		require.Equal(t, mipsevm.HexU32(testDataAddr), *entries[1].MemAddr)
+		require.Equal(t, uint64(4), state.Step)
+	})
+
+	t.Run("UntilExit", func(t *testing.T) {
+		d, state := newTestDebugger(new(bytes.Buffer))
+		var out bytes.Buffer
+		require.NoError(t, d.ExportTrace(context.Background(), &out, 0, 0))
+		entries := readTrace(t, &out)
+		require.Len(t, entries, 7)
+		require.Equal(t, "exit", entries[6].Symbol)
+		require.True(t, state.Exited)
+	})
+}
+
+func TestDebuggerRun(t *testing.T) {
+	var out bytes.Buffer
+	d, state := newTestDebugger(&out)
+	commands := strings.Join([]string{
+		"step 2",
+		"regs",
+		"mem 0x100000 1",
+		"break-syscall",
+		"breakpoints",
+		"continue",
+		"bogus",
+		"quit",
+		"step",
+	}, "\n")
+	require.NoError(t, d.Run(context.Background(), strings.NewReader(commands)))
+	require.Equal(t, uint32(24), state.PC, "should stop at syscall breakpoint and not execute commands after quit")
+
+	output := out.String()
+	require.Contains(t, output, "step 1 pc 00000004 (main)")
+	require.Contains(t, output, "r8  00000001")
+	require.Contains(t, output, "r9  00100000")
+	require.Contains(t, output, "00100000: deadbeef")
+	require.Contains(t, output, "0: any syscall")
+	require.Contains(t, output, "breakpoint: any syscall")
+	require.Contains(t, output, `error: unknown command "bogus"`)
+}
+
+func readTrace(t *testing.T, out *bytes.Buffer) []TraceEntry {
+	var entries []TraceEntry
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		var entry TraceEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/evmdiff"
+)
+
+var (
+	DiffFuzzSeedFlag = &cli.Int64Flag{
+		Name:     "seed",
+		Usage:    "seed for generating random cases. A time based seed is used if 0.",
+		Required: false,
+	}
+	DiffFuzzIterationsFlag = &cli.Uint64Flag{
+		Name:     "iterations",
+		Usage:    "number of random cases to check. Runs until interrupted if 0.",
+		Value:    10_000,
+		Required: false,
+	}
+	DiffFuzzOutFlag = &cli.PathFlag{
+		Name:     "out",
+		Usage:    "directory to write minimized reproducers of divergences to.",
+		Value:    ".",
+		Required: false,
+	}
+	DiffFuzzReplayFlag = &cli.PathFlag{
+		Name:      "replay",
+		Usage:     "path of a reproducer to check, instead of generating random cases.",
+		TakesFile: true,
+		Required:  false,
+	}
+)
+
+var ErrDivergence = errors.New("mipsevm and MIPS contract diverged")
+
+func DiffFuzz(ctx *cli.Context) error {
+	l := Logger(os.Stderr, log.LvlInfo)
+	evm, err := evmdiff.NewEVMRunner()
+	if err != nil {
+		return fmt.Errorf("failed to create EVM: %w", err)
+	}
+	if path := ctx.Path(DiffFuzzReplayFlag.Name); path != "" {
+		c, err := evmdiff.LoadCase(path)
+		if err != nil {
+			return err
+		}
+		res := evmdiff.Check(evm, c)
+		l.Info("Replayed case", "insn", fmt.Sprintf("%08x", c.Insn()), "result", res)
+		if res.Diverged() {
+			return ErrDivergence
+		}
+		return nil
+	}
+	seed := ctx.Int64(DiffFuzzSeedFlag.Name)
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return diffFuzz(ctx.Context, l, evm, seed, ctx.Uint64(DiffFuzzIterationsFlag.Name), ctx.Path(DiffFuzzOutFlag.Name))
+}
+
+// diffFuzz checks random cases generated from seed, writing a minimized reproducer to outDir for each divergence.
+func diffFuzz(ctx context.Context, l log.Logger, evm evmdiff.EVMStepper, seed int64, iterations uint64, outDir string) error {
+	l.Info("Starting differential fuzzing", "seed", seed, "iterations", iterations)
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	rng := rand.New(rand.NewSource(seed))
+	divergences := 0
+	start := time.Now()
+	for i := uint64(0); iterations == 0 || i < iterations; i++ {
+		if i%100 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		if i > 0 && i%10_000 == 0 {
+			l.Info("Processing", "cases", i, "divergences", divergences, "cps", float64(i)/time.Since(start).Seconds())
+		}
+		c := evmdiff.Generate(rng)
+		if !evmdiff.Check(evm, c).Diverged() {
+			continue
+		}
+		divergences++
+		minimized := evmdiff.Minimize(evm, c)
+		path := filepath.Join(outDir, fmt.Sprintf("divergence-%d-%d.json", seed, i))
+		if err := evmdiff.WriteCase(path, minimized); err != nil {
+			return fmt.Errorf("failed to write reproducer: %w", err)
+		}
+		l.Error("Found divergence", "case", i, "insn", fmt.Sprintf("%08x", minimized.Insn()),
+			"result", evmdiff.Check(evm, minimized), "reproducer", path)
+	}
+	l.Info("Completed differential fuzzing", "seed", seed, "divergences", divergences)
+	if divergences > 0 {
+		return fmt.Errorf("%w: found %d divergences", ErrDivergence, divergences)
+	}
+	return nil
+}
+
+var DiffFuzzCommand = &cli.Command{
+	Name:  "diff-fuzz",
+	Usage: "Differentially fuzz mipsevm against the on-chain MIPS contract",
+	Description: "Generate random instructions, registers and memory, execute a single step with both mipsevm and the MIPS contract in an in-memory EVM, " +
+		"and report any difference in the resulting state. Each divergence is minimized and written to the output directory as a reproducer, " +
+		"which can be checked again with --replay.",
+	Action: DiffFuzz,
+	Flags: []cli.Flag{
+		DiffFuzzSeedFlag,
+		DiffFuzzIterationsFlag,
+		DiffFuzzOutFlag,
+		DiffFuzzReplayFlag,
+	},
+}
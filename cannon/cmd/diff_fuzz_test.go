@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/evmdiff"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type corruptingStepper struct {
+	evm *evmdiff.EVMRunner
+}
+
+func (s *corruptingStepper) Step(wit *mipsevm.StepWitness) ([]byte, error) {
+	post, err := s.evm.Step(wit)
+	if err == nil {
+		post[0] ^= 1
+	}
+	return post, err
+}
+
+func TestDiffFuzz(t *testing.T) {
+	evm, err := evmdiff.NewEVMRunner()
+	require.NoError(t, err)
+	logger := testlog.Logger(t, log.LvlInfo)
+
+	t.Run("NoDivergence", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, diffFuzz(context.Background(), logger, evm, 1, 100, dir))
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("WritesReproducers", func(t *testing.T) {
+		dir := t.TempDir()
+		err := diffFuzz(context.Background(), logger, &corruptingStepper{evm: evm}, 1, 5, dir)
+		require.ErrorIs(t, err, ErrDivergence)
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.NotEmpty(t, entries)
+		c, err := evmdiff.LoadCase(filepath.Join(dir, entries[0].Name()))
+		require.NoError(t, err)
+		require.True(t, evmdiff.Check(&corruptingStepper{evm: evm}, c).Diverged())
+		require.False(t, evmdiff.Check(evm, c).Diverged())
+	})
+}
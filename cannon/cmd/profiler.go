@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/google/pprof/profile"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+const (
+	// regG is the register used by the Go runtime on MIPS to hold the current goroutine.
+	regG = 30
+	// maxCallDepth bounds the depth of the shadow call stacks.
+	maxCallDepth = 1024
+)
+
+// guestCounts are the values attributed to a function executing with a particular call stack.
+type guestCounts struct {
+	instructions  int64
+	pages         int64
+	preimageBytes int64
+}
+
+// callNode is a node in the tree of call stacks seen during execution.
+type callNode struct {
+	children map[uint32]*callNode
+	// leaves are the counts of each function executing at this call stack, by symbol index
+	leaves map[int]*guestCounts
+}
+
+func newCallNode() *callNode {
+	return &callNode{
+		children: make(map[uint32]*callNode),
+		leaves:   make(map[int]*guestCounts),
+	}
+}
+
+func (n *callNode) child(callSite uint32) *callNode {
+	c, ok := n.children[callSite]
+	if !ok {
+		c = newCallNode()
+		n.children[callSite] = c
+	}
+	return c
+}
+
+func (n *callNode) leaf(sym int) *guestCounts {
+	c, ok := n.leaves[sym]
+	if !ok {
+		c = new(guestCounts)
+		n.leaves[sym] = c
+	}
+	return c
+}
+
+type callFrame struct {
+	ret  uint32
+	node *callNode
+}
+
+// GuestProfiler attributes executed instructions, newly allocated memory pages and pre-image bytes read to the
+// functions of the MIPS program, using the symbols from the program metadata.
+//
+// Call stacks are reconstructed by tracking jal and jalr instructions, with a separate shadow stack for each
+// goroutine identified by the Go g register. A frame is popped when execution reaches its return address.
+type GuestProfiler struct {
+	meta  *mipsevm.Metadata
+	state *mipsevm.State
+
+	root   *callNode
+	stacks map[uint32][]callFrame
+
+	// cached symbol range of the last executed instruction, to avoid symbol lookups every step
+	symStart uint32
+	symEnd   uint64
+	sym      int
+	node     *callNode
+	counts   *guestCounts
+
+	// call site of a call executed by the previous step, which takes effect after the delay slot
+	pendingCall bool
+	callSite    uint32
+}
+
+func NewGuestProfiler(meta *mipsevm.Metadata, state *mipsevm.State) *GuestProfiler {
+	return &GuestProfiler{
+		meta:   meta,
+		state:  state,
+		root:   newCallNode(),
+		stacks: make(map[uint32][]callFrame),
+		sym:    -1,
+	}
+}
+
+// lookup returns the index of the symbol containing addr, or -1 if there is none, along with the address range
+// for which that result applies.
+func (p *GuestProfiler) lookup(addr uint32) (sym int, start uint32, end uint64) {
+	syms := p.meta.Symbols
+	i := sort.Search(len(syms), func(i int) bool {
+		return syms[i].Start > addr
+	})
+	end = math.MaxUint32 + 1
+	if i < len(syms) {
+		end = uint64(syms[i].Start)
+	}
+	if i > 0 {
+		s := &syms[i-1]
+		symEnd := uint64(s.Start) + uint64(s.Size)
+		if uint64(addr) < symEnd {
+			return i - 1, s.Start, min(symEnd, end)
+		}
+		return -1, uint32(symEnd), end
+	}
+	return -1, 0, end
+}
+
+func (p *GuestProfiler) inSymbol(addr uint32) bool {
+	return addr >= p.symStart && uint64(addr) < p.symEnd
+}
+
+// Wrap returns a StepFn that records each step executed by fn.
+func (p *GuestProfiler) Wrap(fn StepFn) StepFn {
+	return func(proof bool) (*mipsevm.StepWitness, error) {
+		pc := p.state.PC
+		insn := p.state.Memory.GetMemory(pc)
+		pages := p.state.Memory.PageCount()
+		preimageOffset := p.state.PreimageOffset
+		wit, err := fn(proof)
+		if err != nil {
+			return nil, err
+		}
+		p.record(pc, insn, pages, preimageOffset)
+		return wit, nil
+	}
+}
+
+// record attributes a step to the current call stack, then updates the call stack for the next step.
+func (p *GuestProfiler) record(pc uint32, insn uint32, pagesBefore int, preimageOffsetBefore uint32) {
+	g := p.state.Registers[regG]
+	stack := p.stacks[g]
+	node := p.root
+	if len(stack) > 0 {
+		node = stack[len(stack)-1].node
+	}
+	if p.counts == nil || node != p.node || !p.inSymbol(pc) {
+		p.sym, p.symStart, p.symEnd = p.lookup(pc)
+		p.node = node
+		p.counts = node.leaf(p.sym)
+	}
+	p.counts.instructions++
+	p.counts.pages += int64(p.state.Memory.PageCount() - pagesBefore)
+	if p.state.PreimageOffset > preimageOffsetBefore {
+		p.counts.preimageBytes += int64(p.state.PreimageOffset - preimageOffsetBefore)
+	}
+
+	if p.pendingCall {
+		p.pendingCall = false
+		if len(stack) < maxCallDepth {
+			// The call returns to the instruction after the delay slot.
+			p.stacks[g] = append(stack, callFrame{ret: p.callSite + 8, node: node.child(p.callSite)})
+		}
+		return
+	}
+	opcode := insn >> 26
+	if opcode == 3 || (opcode == 0 && insn&0x3f == 9) { // jal, jalr
+		p.pendingCall = true
+		p.callSite = pc
+	} else if len(stack) > 0 && !p.inSymbol(p.state.PC) {
+		// Execution left the current function, pop any frames returned from.
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i].ret == p.state.PC {
+				p.stacks[g] = stack[:i]
+				break
+			}
+		}
+	}
+}
+
+// WriteProfile writes the recorded counts as a gzipped pprof profile.
+func (p *GuestProfiler) WriteProfile(w io.Writer) error {
+	prof := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "instructions", Unit: "count"},
+			{Type: "pages", Unit: "count"},
+			{Type: "preimage", Unit: "bytes"},
+		},
+		DefaultSampleType: "instructions",
+	}
+	locations := make(map[int]*profile.Location)
+	location := func(sym int) *profile.Location {
+		if loc, ok := locations[sym]; ok {
+			return loc
+		}
+		name := "!unknown"
+		var addr uint64
+		if sym >= 0 {
+			name = p.meta.Symbols[sym].Name
+			addr = uint64(p.meta.Symbols[sym].Start)
+		}
+		fn := &profile.Function{ID: uint64(len(prof.Function) + 1), Name: name, SystemName: name}
+		prof.Function = append(prof.Function, fn)
+		loc := &profile.Location{ID: uint64(len(prof.Location) + 1), Address: addr, Line: []profile.Line{{Function: fn}}}
+		prof.Location = append(prof.Location, loc)
+		locations[sym] = loc
+		return loc
+	}
+
+	var visit func(n *callNode, callers []*profile.Location)
+	visit = func(n *callNode, callers []*profile.Location) {
+		syms := make([]int, 0, len(n.leaves))
+		for sym := range n.leaves {
+			syms = append(syms, sym)
+		}
+		sort.Ints(syms)
+		for _, sym := range syms {
+			c := n.leaves[sym]
+			prof.Sample = append(prof.Sample, &profile.Sample{
+				Location: append([]*profile.Location{location(sym)}, callers...),
+				Value:    []int64{c.instructions, c.pages, c.preimageBytes},
+			})
+		}
+		callSites := make([]uint32, 0, len(n.children))
+		for callSite := range n.children {
+			callSites = append(callSites, callSite)
+		}
+		sort.Slice(callSites, func(i, j int) bool { return callSites[i] < callSites[j] })
+		for _, callSite := range callSites {
+			sym, _, _ := p.lookup(callSite)
+			visit(n.children[callSite], append([]*profile.Location{location(sym)}, callers...))
+		}
+	}
+	visit(p.root, nil)
+
+	if err := prof.CheckValid(); err != nil {
+		return fmt.Errorf("invalid profile: %w", err)
+	}
+	return prof.Write(w)
+}
+
+func writeGuestProfile(path string, profiler *GuestProfiler) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create profile file: %w", err)
+	}
+	defer f.Close()
+	if err := profiler.WriteProfile(f); err != nil {
+		return err
+	}
+	return f.Close()
+}
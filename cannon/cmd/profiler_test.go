@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+type fixedOracle []byte
+
+func (o fixedOracle) Hint(v []byte) {}
+
+func (o fixedOracle) GetPreimage(k [32]byte) []byte {
+	return o
+}
+
+// testProfileState creates a state for a program where main calls f, which stores to a new memory page and reads
+// four bytes of pre-image data before returning to main which then exits.
+func testProfileState() (*mipsevm.State, *mipsevm.Metadata) {
+	state := &mipsevm.State{Memory: mipsevm.NewMemory(), PC: 0x1000, NextPC: 0x1004, PreimageKey: [32]byte{0x02, 0xaa}}
+	state.Registers[9] = 0x10000000 // $t1
+	state.Registers[5] = 0x10000000 // $a1
+	program := map[uint32][]uint32{
+		0x1000: {
+			0x0c000800, // jal 0x2000
+			0x00000000, // nop
+			0x24021096, // addiu $v0, $zero, 4246 (exit_group)
+			0x0000000c, // syscall
+		},
+		0x2000: {
+			0x25080001, // addiu $t0, $t0, 1
+			0xad280000, // sw $t0, 0($t1)
+			0x24020fa3, // addiu $v0, $zero, 4003 (read)
+			0x24040005, // addiu $a0, $zero, 5 (pre-image read)
+			0x24060004, // addiu $a2, $zero, 4
+			0x0000000c, // syscall
+			0x03e00008, // jr $ra
+			0x00000000, // nop
+		},
+	}
+	for addr, insns := range program {
+		for i, insn := range insns {
+			state.Memory.SetMemory(addr+uint32(i*4), insn)
+		}
+	}
+	meta := &mipsevm.Metadata{Symbols: []mipsevm.Symbol{
+		{Name: "main", Start: 0x1000, Size: 0x10},
+		{Name: "f", Start: 0x2000, Size: 0x20},
+	}}
+	return state, meta
+}
+
+func TestGuestProfiler(t *testing.T) {
+	state, meta := testProfileState()
+	vm := mipsevm.NewInstrumentedState(state, fixedOracle("hello world"), nil, nil)
+	profiler := NewGuestProfiler(meta, state)
+	stepFn := profiler.Wrap(vm.Step)
+	for !state.Exited {
+		_, err := stepFn(false)
+		require.NoError(t, err)
+	}
+	require.Equal(t, uint64(12), state.Step)
+
+	var buf bytes.Buffer
+	require.NoError(t, profiler.WriteProfile(&buf))
+	prof, err := profile.Parse(&buf)
+	require.NoError(t, err)
+	require.Equal(t, "instructions", prof.DefaultSampleType)
+
+	samples := make(map[string][]int64)
+	for _, sample := range prof.Sample {
+		var stack []string
+		for _, loc := range sample.Location {
+			stack = append(stack, loc.Line[0].Function.Name)
+		}
+		samples[strings.Join(stack, ";")] = sample.Value
+	}
+	require.Equal(t, map[string][]int64{
+		"main":   {4, 0, 0},
+		"f;main": {8, 1, 4},
+	}, samples)
+}
+
+func TestGuestProfilerUnknownSymbols(t *testing.T) {
+	state, _ := testProfileState()
+	vm := mipsevm.NewInstrumentedState(state, fixedOracle("hello world"), nil, nil)
+	profiler := NewGuestProfiler(&mipsevm.Metadata{}, state)
+	stepFn := profiler.Wrap(vm.Step)
+	for !state.Exited {
+		_, err := stepFn(false)
+		require.NoError(t, err)
+	}
+	var buf bytes.Buffer
+	require.NoError(t, profiler.WriteProfile(&buf))
+	prof, err := profile.Parse(&buf)
+	require.NoError(t, err)
+	var total int64
+	for _, sample := range prof.Sample {
+		require.Equal(t, "!unknown", sample.Location[0].Line[0].Function.Name)
+		total += sample.Value[0]
+	}
+	require.Equal(t, int64(12), total)
+}
@@ -21,14 +21,14 @@ import (
 var (
 	RunInputFlag = &cli.PathFlag{
 		Name:      "input",
-		Usage:     "path of input JSON state. Stdin if left empty.",
+		Usage:     "path of input state. JSON, or the binary state encoding if the path ends in .bin or .bin.gz.",
 		TakesFile: true,
 		Value:     "state.json",
 		Required:  true,
 	}
 	RunOutputFlag = &cli.PathFlag{
 		Name:      "output",
-		Usage:     "path of output JSON state. Not written if empty, use - to write to Stdout.",
+		Usage:     "path of output state. Binary if the path ends in .bin or .bin.gz, JSON otherwise. Not written if empty, use - to write JSON to Stdout.",
 		TakesFile: true,
 		Value:     "out.json",
 		Required:  false,
@@ -54,7 +54,7 @@ var (
 	}
 	RunSnapshotFmtFlag = &cli.StringFlag{
 		Name:     "snapshot-fmt",
-		Usage:    "format for snapshot output file names.",
+		Usage:    "format for snapshot output file names. Snapshots are binary if the format ends in .bin or .bin.gz.",
 		Value:    "state-%d.json",
 		Required: false,
 	}
@@ -80,6 +80,12 @@ var (
 		Name:  "pprof.cpu",
 		Usage: "enable pprof cpu profiling",
 	}
+	RunPProfGuestFlag = &cli.PathFlag{
+		Name:      "pprof.guest",
+		Usage:     "path to write a pprof profile of the MIPS program to, attributing instructions, memory pages and pre-image bytes to the functions in --meta. Not profiled if empty.",
+		TakesFile: true,
+		Required:  false,
+	}
 )
 
 type Proof struct {
@@ -224,7 +230,7 @@ func Run(ctx *cli.Context) error {
 		defer profile.Start(profile.NoShutdownHook, profile.ProfilePath("."), profile.CPUProfile).Stop()
 	}
 
-	state, err := loadJSON[mipsevm.State](ctx.Path(RunInputFlag.Name))
+	state, err := loadState(ctx.Path(RunInputFlag.Name))
 	if err != nil {
 		return err
 	}
@@ -283,6 +289,16 @@ func Run(ctx *cli.Context) error {
 	if po.cmd != nil {
 		stepFn = Guard(po.cmd.ProcessState, stepFn)
 	}
+	if profilePath := ctx.Path(RunPProfGuestFlag.Name); profilePath != "" {
+		profiler := NewGuestProfiler(meta, state)
+		stepFn = profiler.Wrap(stepFn)
+		// Write the profile even if execution fails or is interrupted, as it shows where the time went.
+		defer func() {
+			if err := writeGuestProfile(profilePath, profiler); err != nil {
+				l.Error("failed to write guest profile", "err", err)
+			}
+		}()
+	}
 
 	start := time.Now()
 	startStep := state.Step
@@ -321,7 +337,7 @@ func Run(ctx *cli.Context) error {
 		}
 
 		if snapshotAt(state) {
-			if err := writeJSON(fmt.Sprintf(snapshotFmt, step), state); err != nil {
+			if err := writeState(fmt.Sprintf(snapshotFmt, step), state); err != nil {
 				return fmt.Errorf("failed to write state snapshot: %w", err)
 			}
 		}
@@ -362,7 +378,7 @@ func Run(ctx *cli.Context) error {
 		}
 	}
 
-	if err := writeJSON(ctx.Path(RunOutputFlag.Name), state); err != nil {
+	if err := writeState(ctx.Path(RunOutputFlag.Name), state); err != nil {
 		return fmt.Errorf("failed to write state output: %w", err)
 	}
 	return nil
@@ -384,5 +400,6 @@ var RunCommand = &cli.Command{
 		RunMetaFlag,
 		RunInfoAtFlag,
 		RunPProfCPU,
+		RunPProfGuestFlag,
 	},
 }
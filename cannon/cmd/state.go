@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+// loadState loads a VM state from inputPath, which may be either JSON or the binary state encoding.
+func loadState(inputPath string) (*mipsevm.State, error) {
+	if inputPath == "" {
+		return nil, errors.New("no path specified")
+	}
+	return mipsevm.LoadStateFromFile(inputPath)
+}
+
+// writeState writes the VM state to outputPath, using the binary encoding if the path has a .bin or .bin.gz
+// extension and JSON otherwise. The state is written to stdout as JSON if outputPath is -.
+func writeState(outputPath string, state *mipsevm.State) error {
+	if outputPath == "" {
+		return nil
+	}
+	if outputPath == "-" {
+		return writeJSON(outputPath, state)
+	}
+	return mipsevm.WriteStateToFile(outputPath, state, 0755)
+}
@@ -0,0 +1,126 @@
+package evmdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+)
+
+// MemWord is a single initialized 32-bit word of memory.
+type MemWord struct {
+	Addr  mipsevm.HexU32 `json:"addr"`
+	Value mipsevm.HexU32 `json:"value"`
+}
+
+// Case is the pre-state of a single VM step.
+// Cases are kept small so they can be shared as reproducers: only the listed memory words are initialized.
+type Case struct {
+	PC             mipsevm.HexU32     `json:"pc"`
+	NextPC         mipsevm.HexU32     `json:"nextPC"`
+	LO             mipsevm.HexU32     `json:"lo"`
+	HI             mipsevm.HexU32     `json:"hi"`
+	Heap           mipsevm.HexU32     `json:"heap"`
+	Step           uint64             `json:"step"`
+	Registers      [32]mipsevm.HexU32 `json:"registers"`
+	Memory         []MemWord          `json:"memory"`
+	PreimageKey    common.Hash        `json:"preimageKey"`
+	PreimageOffset uint32             `json:"preimageOffset"`
+	// Preimage is the data returned by the pre-image oracle for PreimageKey.
+	Preimage hexutil.Bytes `json:"preimage"`
+}
+
+// Insn returns the instruction at the case PC.
+func (c *Case) Insn() uint32 {
+	for _, w := range c.Memory {
+		if w.Addr == c.PC {
+			return uint32(w.Value)
+		}
+	}
+	return 0
+}
+
+// State creates a new VM state from the case.
+func (c *Case) State() *mipsevm.State {
+	state := &mipsevm.State{
+		Memory:         mipsevm.NewMemory(),
+		PreimageKey:    c.PreimageKey,
+		PreimageOffset: c.PreimageOffset,
+		PC:             uint32(c.PC),
+		NextPC:         uint32(c.NextPC),
+		LO:             uint32(c.LO),
+		HI:             uint32(c.HI),
+		Heap:           uint32(c.Heap),
+		Step:           c.Step,
+	}
+	for i, reg := range c.Registers {
+		state.Registers[i] = uint32(reg)
+	}
+	for _, w := range c.Memory {
+		state.Memory.SetMemory(uint32(w.Addr), uint32(w.Value))
+	}
+	return state
+}
+
+// Copy returns a deep copy of the case.
+func (c *Case) Copy() *Case {
+	cpy := *c
+	cpy.Memory = append([]MemWord(nil), c.Memory...)
+	cpy.Preimage = common.CopyBytes(c.Preimage)
+	return &cpy
+}
+
+// setMemory sets the word at the aligned address addr, replacing any existing value.
+func (c *Case) setMemory(addr uint32, value uint32) {
+	addr &^= 3
+	for i, w := range c.Memory {
+		if uint32(w.Addr) == addr {
+			c.Memory[i].Value = mipsevm.HexU32(value)
+			return
+		}
+	}
+	c.Memory = append(c.Memory, MemWord{Addr: mipsevm.HexU32(addr), Value: mipsevm.HexU32(value)})
+	sort.Slice(c.Memory, func(i, j int) bool { return c.Memory[i].Addr < c.Memory[j].Addr })
+}
+
+// caseOracle serves the pre-image of a case, regardless of the requested key.
+type caseOracle struct {
+	preimage []byte
+}
+
+func (o *caseOracle) Hint(v []byte) {}
+
+func (o *caseOracle) GetPreimage(k [32]byte) []byte {
+	return o.preimage
+}
+
+var _ mipsevm.PreimageOracle = (*caseOracle)(nil)
+
+// LoadCase reads a case previously written by WriteCase.
+func LoadCase(path string) (*Case, error) {
+	f, err := ioutil.OpenDecompressed(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open case %q: %w", path, err)
+	}
+	defer f.Close()
+	var c Case
+	if err := json.NewDecoder(f).Decode(&c); err != nil {
+		return nil, fmt.Errorf("failed to decode case %q: %w", path, err)
+	}
+	return &c, nil
+}
+
+// WriteCase writes the case as JSON to path.
+func WriteCase(path string, c *Case) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode case: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
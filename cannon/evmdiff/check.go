@@ -0,0 +1,81 @@
+package evmdiff
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+// EVMStepper computes the post-state of a step from its witness using the on-chain implementation.
+type EVMStepper interface {
+	Step(wit *mipsevm.StepWitness) ([]byte, error)
+}
+
+// Result is the outcome of executing a case with both mipsevm and the MIPS contract.
+type Result struct {
+	GoPost  []byte
+	GoErr   error
+	EVMPost []byte
+	EVMErr  error
+}
+
+// Diverged returns true if only one implementation failed, or both succeeded with a different post-state.
+// Both implementations failing is expected for invalid instructions and is not a divergence.
+func (r *Result) Diverged() bool {
+	if (r.GoErr == nil) != (r.EVMErr == nil) {
+		return true
+	}
+	return r.GoErr == nil && !bytes.Equal(r.GoPost, r.EVMPost)
+}
+
+// kind identifies which implementations failed, so minimization can preserve the type of divergence.
+func (r *Result) kind() [2]bool {
+	return [2]bool{r.GoErr == nil, r.EVMErr == nil}
+}
+
+func (r *Result) String() string {
+	describe := func(post []byte, err error) string {
+		if err != nil {
+			return fmt.Sprintf("error: %v", err)
+		}
+		return hexutil.Bytes(post).String()
+	}
+	return fmt.Sprintf("mipsevm: %v, evm: %v", describe(r.GoPost, r.GoErr), describe(r.EVMPost, r.EVMErr))
+}
+
+// Check executes a single step of the case with mipsevm and, using the resulting witness, with the MIPS contract.
+func Check(evm EVMStepper, c *Case) *Result {
+	var res Result
+	var wit *mipsevm.StepWitness
+	state := c.State()
+	wit, res.GoErr = goStep(state, c.Preimage)
+	if res.GoErr == nil {
+		res.GoPost = state.EncodeWitness()
+	} else {
+		// Give the contract the same pre-state, with just the instruction proof like an honest actor would
+		// provide, so it can reject the step as well.
+		pre := c.State()
+		insnProof := pre.Memory.MerkleProof(pre.PC)
+		wit = &mipsevm.StepWitness{
+			State:    pre.EncodeWitness(),
+			MemProof: insnProof[:],
+		}
+	}
+	res.EVMPost, res.EVMErr = evm.Step(wit)
+	return &res
+}
+
+// goStep executes a single step, converting any panic into an error. mipsevm panics on invalid instructions.
+func goStep(state *mipsevm.State, preimage []byte) (wit *mipsevm.StepWitness, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("vm panicked: %v", r)
+		}
+	}()
+	vm := mipsevm.NewInstrumentedState(state, &caseOracle{preimage: preimage}, io.Discard, io.Discard)
+	return vm.Step(true)
+}
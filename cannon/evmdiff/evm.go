@@ -0,0 +1,82 @@
+package evmdiff
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+var ErrStateHashMismatch = errors.New("logged state does not match returned state hash")
+
+// EVMRunner executes single steps with the MIPS contract in an in-memory EVM.
+type EVMRunner struct {
+	env      *vm.EVM
+	evmState *state.StateDB
+	addrs    *mipsevm.Addresses
+}
+
+func NewEVMRunner() (*EVMRunner, error) {
+	contracts, err := mipsevm.LoadContracts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load contracts: %w", err)
+	}
+	addrs := &mipsevm.Addresses{
+		MIPS:         common.Address{0: 0xff, 19: 1},
+		Oracle:       common.Address{0: 0xff, 19: 2},
+		Sender:       common.Address{0x13, 0x37},
+		FeeRecipient: common.Address{0xaa},
+	}
+	env, evmState := mipsevm.NewEVMEnv(contracts, addrs)
+	return &EVMRunner{env: env, evmState: evmState, addrs: addrs}, nil
+}
+
+// Step computes the post-state witness from the state encoded in the step witness.
+// Any pre-image data in the witness is loaded into the PreimageOracle first.
+// The EVM state is reverted after each step so steps are independent.
+func (r *EVMRunner) Step(wit *mipsevm.StepWitness) ([]byte, error) {
+	startingGas := uint64(30_000_000)
+
+	snap := r.env.StateDB.Snapshot()
+	defer r.env.StateDB.RevertToSnapshot(snap)
+
+	if wit.HasPreimage() {
+		poInput, err := wit.EncodePreimageOracleInput(mipsevm.LocalContext{})
+		if err != nil {
+			return nil, fmt.Errorf("encode pre-image oracle input: %w", err)
+		}
+		if _, _, err := r.env.Call(vm.AccountRef(r.addrs.Sender), r.addrs.Oracle, poInput, startingGas, big.NewInt(0)); err != nil {
+			return nil, fmt.Errorf("load pre-image: %w", err)
+		}
+	}
+
+	input, err := wit.EncodeStepInput(mipsevm.LocalContext{})
+	if err != nil {
+		return nil, fmt.Errorf("encode step input: %w", err)
+	}
+	ret, _, err := r.env.Call(vm.AccountRef(r.addrs.Sender), r.addrs.MIPS, input, startingGas, big.NewInt(0))
+	if err != nil {
+		return nil, fmt.Errorf("step: %w", err)
+	}
+	if len(ret) != 32 {
+		return nil, fmt.Errorf("expected 32 byte state hash but got %d bytes", len(ret))
+	}
+	logs := r.evmState.Logs()
+	if len(logs) != 1 {
+		return nil, fmt.Errorf("expected a single log with the post-state but got %d", len(logs))
+	}
+	evmPost := logs[0].Data
+	stateHash, err := mipsevm.StateWitness(evmPost).StateHash()
+	if err != nil {
+		return nil, fmt.Errorf("invalid logged state: %w", err)
+	}
+	if stateHash != common.Hash(ret) {
+		return nil, fmt.Errorf("%w: logged %v, returned %v", ErrStateHashMismatch, stateHash, common.Hash(ret))
+	}
+	return evmPost, nil
+}
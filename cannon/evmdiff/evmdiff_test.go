@@ -0,0 +1,140 @@
+package evmdiff
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+func newRunner(t require.TestingT) *EVMRunner {
+	evm, err := NewEVMRunner()
+	require.NoError(t, err)
+	return evm
+}
+
+func TestGeneratedCasesAgree(t *testing.T) {
+	evm := newRunner(t)
+	rng := rand.New(rand.NewSource(1234))
+	for i := 0; i < 500; i++ {
+		c := Generate(rng)
+		res := Check(evm, c)
+		require.Falsef(t, res.Diverged(), "case %d diverged: %v", i, res)
+	}
+}
+
+type stepperFn func(wit *mipsevm.StepWitness) ([]byte, error)
+
+func (fn stepperFn) Step(wit *mipsevm.StepWitness) ([]byte, error) {
+	return fn(wit)
+}
+
+// corruptingStepper modifies the post-state of the real contract when shouldCorrupt returns true.
+func corruptingStepper(evm *EVMRunner, shouldCorrupt func(wit *mipsevm.StepWitness) bool) EVMStepper {
+	return stepperFn(func(wit *mipsevm.StepWitness) ([]byte, error) {
+		post, err := evm.Step(wit)
+		if err == nil && shouldCorrupt(wit) {
+			post[len(post)-1] ^= 1
+		}
+		return post, err
+	})
+}
+
+func addiuCase() *Case {
+	c := &Case{PC: 0x100, NextPC: 0x104}
+	c.setMemory(0x100, 0x25080001) // addiu $t0, $t0, 1
+	return c
+}
+
+func TestCheck(t *testing.T) {
+	evm := newRunner(t)
+
+	t.Run("Agree", func(t *testing.T) {
+		res := Check(evm, addiuCase())
+		require.NoError(t, res.GoErr)
+		require.NoError(t, res.EVMErr)
+		require.False(t, res.Diverged())
+	})
+
+	t.Run("BothFail", func(t *testing.T) {
+		c := &Case{PC: 0, NextPC: 4}
+		c.setMemory(0, 0xffffffff)
+		res := Check(evm, c)
+		require.Error(t, res.GoErr)
+		require.Error(t, res.EVMErr)
+		require.False(t, res.Diverged())
+	})
+
+	t.Run("DifferentPostState", func(t *testing.T) {
+		res := Check(corruptingStepper(evm, func(*mipsevm.StepWitness) bool { return true }), addiuCase())
+		require.True(t, res.Diverged())
+	})
+
+	t.Run("OnlyEVMFails", func(t *testing.T) {
+		res := Check(stepperFn(func(*mipsevm.StepWitness) ([]byte, error) {
+			return nil, errors.New("boom")
+		}), addiuCase())
+		require.True(t, res.Diverged())
+	})
+}
+
+func TestMinimize(t *testing.T) {
+	evm := newRunner(t)
+	// Offset of $t0 in the encoded state witness
+	const t0Offset = 32 + 32 + 4 + 4*5 + 1 + 1 + 8 + 8*4
+	stepper := corruptingStepper(evm, func(wit *mipsevm.StepWitness) bool {
+		return binary.BigEndian.Uint32(wit.State[t0Offset:]) == 5
+	})
+
+	c := addiuCase()
+	c.NextPC = 0x400
+	c.LO = 7
+	c.Step = 99
+	for i := range c.Registers {
+		c.Registers[i] = mipsevm.HexU32(i + 1)
+	}
+	c.Registers[8] = 5
+	c.setMemory(0x2000, 0x1234)
+	require.True(t, Check(stepper, c).Diverged())
+
+	m := Minimize(stepper, c)
+	require.True(t, Check(stepper, m).Diverged())
+	var expectedRegs [32]mipsevm.HexU32
+	expectedRegs[8] = 5
+	require.Equal(t, expectedRegs, m.Registers)
+	require.Equal(t, []MemWord{{Addr: 0x100, Value: 0x25080001}}, m.Memory)
+	require.Equal(t, m.PC+4, m.NextPC)
+	require.Zero(t, m.LO)
+	require.Zero(t, m.Step)
+
+	// The original case is not modified
+	require.Equal(t, mipsevm.HexU32(0x400), c.NextPC)
+}
+
+func TestCaseRoundTrip(t *testing.T) {
+	c := Generate(rand.New(rand.NewSource(1)))
+	c.Preimage = []byte{1, 2, 3}
+	path := filepath.Join(t.TempDir(), "case.json")
+	require.NoError(t, WriteCase(path, c))
+	loaded, err := LoadCase(path)
+	require.NoError(t, err)
+	require.Equal(t, c, loaded)
+}
+
+func FuzzDifferential(f *testing.F) {
+	evm := newRunner(f)
+	f.Add(int64(0))
+	f.Add(int64(1))
+	f.Fuzz(func(t *testing.T, seed int64) {
+		c := Generate(rand.New(rand.NewSource(seed)))
+		if res := Check(evm, c); res.Diverged() {
+			m := Minimize(evm, c)
+			t.Fatalf("mipsevm and EVM diverged: %v\nminimized case: %+v", Check(evm, m), m)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package evmdiff
+
+import (
+	"math/rand"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+const (
+	sysMmap      = 4090
+	sysBrk       = 4045
+	sysClone     = 4120
+	sysExitGroup = 4246
+	sysRead      = 4003
+	sysWrite     = 4004
+	sysFcntl     = 4055
+
+	fdPreimageRead = 5
+)
+
+var (
+	specialFuncts  = []uint32{0x00, 0x02, 0x03, 0x04, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0f, 0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x2a, 0x2b}
+	hiLoFuncts     = []uint32{0x10, 0x11, 0x12, 0x13, 0x18, 0x19, 0x1a, 0x1b}
+	special2Functs = []uint32{0x02, 0x20, 0x21}
+	immOpcodes     = []uint32{0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}
+	branchOpcodes  = []uint32{0x01, 0x04, 0x05, 0x06, 0x07}
+	regimmRts      = []uint32{0x00, 0x01, 0x10, 0x11}
+	memOpcodes     = []uint32{0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x28, 0x29, 0x2a, 0x2b, 0x2e, 0x30, 0x38}
+	syscallNums    = []uint32{sysMmap, sysBrk, sysClone, sysExitGroup, sysRead, sysWrite, sysFcntl}
+	edgeValues     = []uint32{0, 1, 0x7fff_ffff, 0x8000_0000, 0xffff_ffff, 0xffff, 0x8000}
+)
+
+// generator creates random cases. Instructions are drawn from the instruction set supported by mipsevm, with
+// registers and memory initialized so that memory accesses and syscalls exercise interesting paths.
+type generator struct {
+	rng *rand.Rand
+	c   *Case
+	// data is the base address of memory initialized for loads, stores and syscalls
+	data uint32
+}
+
+// Generate creates a random case from rng.
+func Generate(rng *rand.Rand) *Case {
+	g := &generator{rng: rng, c: &Case{}, data: rng.Uint32() &^ 3}
+	g.c.PC = mipsevm.HexU32(rng.Uint32() &^ 3)
+	g.c.NextPC = g.c.PC + 4
+	if rng.Intn(10) == 0 {
+		// execute a delay slot
+		g.c.NextPC = mipsevm.HexU32(g.word() &^ 3)
+	}
+	g.c.LO = mipsevm.HexU32(g.word())
+	g.c.HI = mipsevm.HexU32(g.word())
+	g.c.Heap = mipsevm.HexU32(rng.Uint32() &^ 0xfff)
+	g.c.Step = uint64(rng.Uint32())
+	for i := 1; i < len(g.c.Registers); i++ {
+		g.c.Registers[i] = mipsevm.HexU32(g.word())
+	}
+	g.c.setMemory(uint32(g.c.PC), g.insn())
+	return g.c
+}
+
+// word returns a random register or memory value, biased towards edge cases and pointers to initialized memory.
+func (g *generator) word() uint32 {
+	switch g.rng.Intn(5) {
+	case 0:
+		return edgeValues[g.rng.Intn(len(edgeValues))]
+	case 1:
+		return uint32(g.rng.Intn(32))
+	case 2:
+		return g.pointer()
+	default:
+		return g.rng.Uint32()
+	}
+}
+
+// pointer returns an address in the initialized data region, which may be unaligned.
+func (g *generator) pointer() uint32 {
+	return g.data + uint32(g.rng.Intn(16)*4) + uint32(g.rng.Intn(4))
+}
+
+func (g *generator) reg() uint32 {
+	return uint32(g.rng.Intn(32))
+}
+
+func (g *generator) pick(options []uint32) uint32 {
+	return options[g.rng.Intn(len(options))]
+}
+
+// initMemory sets a random value for the word containing addr, unless it holds the instruction.
+func (g *generator) initMemory(addr uint32) {
+	if addr&^3 == uint32(g.c.PC) {
+		return
+	}
+	g.c.setMemory(addr, g.word())
+}
+
+func (g *generator) insn() uint32 {
+	rs, rt, rd := g.reg(), g.reg(), g.reg()
+	imm := g.rng.Uint32() & 0xffff
+	shamt := g.rng.Uint32() & 0x1f
+	rType := func(opcode uint32, funct uint32) uint32 {
+		return opcode<<26 | rs<<21 | rt<<16 | rd<<11 | shamt<<6 | funct
+	}
+	switch g.rng.Intn(20) {
+	case 0, 1, 2, 3:
+		return rType(0, g.pick(specialFuncts))
+	case 4, 5:
+		return rType(0, g.pick(hiLoFuncts))
+	case 6:
+		return rType(0x1c, g.pick(special2Functs))
+	case 7, 8, 9:
+		return g.pick(immOpcodes)<<26 | rs<<21 | rt<<16 | imm
+	case 10, 11:
+		opcode := g.pick(branchOpcodes)
+		if opcode == 1 {
+			rt = g.pick(regimmRts)
+		}
+		return opcode<<26 | rs<<21 | rt<<16 | imm
+	case 12:
+		return (2+g.rng.Uint32()%2)<<26 | g.rng.Uint32()&0x03ff_ffff
+	case 13, 14, 15, 16:
+		if rs == 0 {
+			rs = 1
+		}
+		g.c.Registers[rs] = mipsevm.HexU32(g.pointer())
+		g.initMemory(uint32(g.c.Registers[rs]) + mipsevm.SE(imm, 16))
+		return g.pick(memOpcodes)<<26 | rs<<21 | rt<<16 | imm
+	case 17, 18:
+		g.syscall()
+		return 0x0000000c
+	default:
+		return g.rng.Uint32()
+	}
+}
+
+// syscall initializes the syscall number and arguments.
+func (g *generator) syscall() {
+	num := g.pick(syscallNums)
+	if g.rng.Intn(10) == 0 {
+		num = g.rng.Uint32()
+	}
+	fd := uint32(g.rng.Intn(8))
+	addr := g.pointer()
+	count := uint32(g.rng.Intn(8))
+	if num == sysMmap {
+		fd = 0
+		if g.rng.Intn(2) == 0 {
+			fd = g.rng.Uint32() &^ 0xfff
+		}
+		addr = g.rng.Uint32() & 0xff_ffff
+	}
+	g.c.Registers[2] = mipsevm.HexU32(num)
+	g.c.Registers[4] = mipsevm.HexU32(fd)
+	g.c.Registers[5] = mipsevm.HexU32(addr)
+	g.c.Registers[6] = mipsevm.HexU32(count)
+	g.initMemory(addr)
+
+	// Pre-image reads require the key to match the pre-image data, as the oracle contract verifies it.
+	if num == sysRead && fd == fdPreimageRead {
+		data := make([]byte, g.rng.Intn(100))
+		g.rng.Read(data)
+		g.c.Preimage = data
+		g.c.PreimageKey = preimage.Keccak256Key(crypto.Keccak256Hash(data)).PreimageKey()
+		g.c.PreimageOffset = uint32(g.rng.Intn(len(data) + 9))
+	}
+}
@@ -0,0 +1,103 @@
+package evmdiff
+
+// Minimize reduces a diverging case to a simpler case with the same kind of divergence.
+// Memory words, registers and other state fields are removed or zeroed one at a time, keeping each
+// simplification only if the case still diverges, until no further simplification applies.
+func Minimize(evm EVMStepper, c *Case) *Case {
+	res := Check(evm, c)
+	if !res.Diverged() {
+		return c
+	}
+	kind := res.kind()
+	stillDiverges := func(candidate *Case) bool {
+		res := Check(evm, candidate)
+		return res.Diverged() && res.kind() == kind
+	}
+	for {
+		simplified := false
+		for _, simplify := range simplifications(c) {
+			candidate := c.Copy()
+			if !simplify(candidate) {
+				continue
+			}
+			if stillDiverges(candidate) {
+				c = candidate
+				simplified = true
+			}
+		}
+		if !simplified {
+			return c
+		}
+	}
+}
+
+// simplifications returns the candidate simplifications of c.
+// Each function modifies the case it is given and returns false if it would not change it.
+func simplifications(c *Case) []func(c *Case) bool {
+	var out []func(c *Case) bool
+	for _, w := range c.Memory {
+		addr := w.Addr
+		out = append(out, func(c *Case) bool {
+			if addr == c.PC {
+				return false
+			}
+			for i, w := range c.Memory {
+				if w.Addr == addr {
+					c.Memory = append(c.Memory[:i], c.Memory[i+1:]...)
+					return true
+				}
+			}
+			return false
+		})
+	}
+	for i := range c.Registers {
+		i := i
+		out = append(out, func(c *Case) bool {
+			if c.Registers[i] == 0 {
+				return false
+			}
+			c.Registers[i] = 0
+			return true
+		})
+	}
+	out = append(out,
+		func(c *Case) bool {
+			if c.NextPC == c.PC+4 {
+				return false
+			}
+			c.NextPC = c.PC + 4
+			return true
+		},
+		func(c *Case) bool {
+			if c.LO == 0 && c.HI == 0 {
+				return false
+			}
+			c.LO, c.HI = 0, 0
+			return true
+		},
+		func(c *Case) bool {
+			if c.Heap == 0 {
+				return false
+			}
+			c.Heap = 0
+			return true
+		},
+		func(c *Case) bool {
+			if c.Step == 0 {
+				return false
+			}
+			c.Step = 0
+			return true
+		},
+		func(c *Case) bool {
+			if c.PreimageKey == ([32]byte{}) && c.PreimageOffset == 0 && len(c.Preimage) == 0 {
+				return false
+			}
+			c.PreimageKey = [32]byte{}
+			c.PreimageOffset = 0
+			c.Preimage = nil
+			return true
+		},
+	)
+	return out
+}
@@ -22,6 +22,9 @@ func main() {
 		cmd.LoadELFCommand,
 		cmd.WitnessCommand,
 		cmd.RunCommand,
+		cmd.ConvertCommand,
+		cmd.DebugCommand,
+		cmd.DiffFuzzCommand,
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -3,8 +3,6 @@ package mipsevm
 import (
 	"bytes"
 	"debug/elf"
-	"errors"
-	"fmt"
 	"io"
 	"math/big"
 	"os"
@@ -13,8 +11,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
-	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -106,50 +102,13 @@ func (m *MIPSEVM) Step(t *testing.T, stepWitness *StepWitness) []byte {
 }
 
 func encodeStepInput(t *testing.T, wit *StepWitness, localContext LocalContext) []byte {
-	mipsAbi, err := bindings.MIPSMetaData.GetAbi()
-	require.NoError(t, err)
-
-	input, err := mipsAbi.Pack("step", wit.State, wit.MemProof, localContext)
+	input, err := wit.EncodeStepInput(localContext)
 	require.NoError(t, err)
 	return input
 }
 
 func encodePreimageOracleInput(t *testing.T, wit *StepWitness, localContext LocalContext) ([]byte, error) {
-	if wit.PreimageKey == ([32]byte{}) {
-		return nil, errors.New("cannot encode pre-image oracle input, witness has no pre-image to proof")
-	}
-
-	preimageAbi, err := bindings.PreimageOracleMetaData.GetAbi()
-	require.NoError(t, err, "failed to load pre-image oracle ABI")
-
-	switch preimage.KeyType(wit.PreimageKey[0]) {
-	case preimage.LocalKeyType:
-		if len(wit.PreimageValue) > 32+8 {
-			return nil, fmt.Errorf("local pre-image exceeds maximum size of 32 bytes with key 0x%x", wit.PreimageKey)
-		}
-		preimagePart := wit.PreimageValue[8:]
-		var tmp [32]byte
-		copy(tmp[:], preimagePart)
-		input, err := preimageAbi.Pack("loadLocalData",
-			new(big.Int).SetBytes(wit.PreimageKey[1:]),
-			localContext,
-			tmp,
-			new(big.Int).SetUint64(uint64(len(preimagePart))),
-			new(big.Int).SetUint64(uint64(wit.PreimageOffset)),
-		)
-		require.NoError(t, err)
-		return input, nil
-	case preimage.Keccak256KeyType:
-		input, err := preimageAbi.Pack(
-			"loadKeccak256PreimagePart",
-			new(big.Int).SetUint64(uint64(wit.PreimageOffset)),
-			wit.PreimageValue[8:])
-		require.NoError(t, err)
-		return input, nil
-	default:
-		return nil, fmt.Errorf("unsupported pre-image type %d, cannot prepare preimage with key %x offset %d for oracle",
-			wit.PreimageKey[0], wit.PreimageKey, wit.PreimageOffset)
-	}
+	return wit.EncodePreimageOracleInput(localContext)
 }
 
 func TestEVM(t *testing.T) {
@@ -1,6 +1,7 @@
 package mipsevm
 
 import (
+	"encoding/binary"
 	"io"
 )
 
@@ -80,3 +81,15 @@ func (m *InstrumentedState) Step(proof bool) (wit *StepWitness, err error) {
 	}
 	return
 }
+
+// LastMemAccess returns the address of the memory word read or written by the last step, along with the
+// value of that word prior to the step. Memory accesses are only tracked when the step generated a proof,
+// so ok is false if proof generation was disabled or the step did not access memory.
+func (m *InstrumentedState) LastMemAccess() (addr uint32, preValue uint32, ok bool) {
+	if !m.memProofEnabled || m.lastMemAccess == ^uint32(0) {
+		return 0, 0, false
+	}
+	// The first node of the memory proof is the 32 byte leaf containing the accessed word.
+	offset := m.lastMemAccess & 0x1c
+	return m.lastMemAccess, binary.BigEndian.Uint32(m.memProof[offset : offset+4]), true
+}
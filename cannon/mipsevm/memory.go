@@ -230,6 +230,25 @@ func (m *Memory) AllocPage(pageIndex uint32) *CachedPage {
 	return p
 }
 
+// Copy returns a deep copy of the memory, including the cached merkle nodes,
+// so that the copy can be modified without affecting the original.
+func (m *Memory) Copy() *Memory {
+	out := NewMemory()
+	for k, v := range m.nodes {
+		if v == nil {
+			out.nodes[k] = nil
+			continue
+		}
+		node := *v
+		out.nodes[k] = &node
+	}
+	for k, p := range m.pages {
+		data := *p.Data
+		out.pages[k] = &CachedPage{Data: &data, Cache: p.Cache, Ok: p.Ok}
+	}
+	return out
+}
+
 type pageEntry struct {
 	Index uint32 `json:"index"`
 	Data  *Page  `json:"data"`
@@ -187,3 +187,22 @@ func TestMemoryJSON(t *testing.T) {
 	require.NoError(t, json.Unmarshal(dat, &res))
 	require.Equal(t, uint32(123), res.GetMemory(8))
 }
+
+func TestMemoryCopy(t *testing.T) {
+	m := NewMemory()
+	m.SetMemory(0x8000, 123)
+	m.SetMemory(0x10000, 456)
+	root := m.MerkleRoot()
+
+	cpy := m.Copy()
+	require.Equal(t, root, cpy.MerkleRoot())
+	require.Equal(t, uint32(123), cpy.GetMemory(0x8000))
+
+	cpy.SetMemory(0x8000, 789)
+	cpy.SetMemory(0x20000, 1)
+	require.Equal(t, uint32(123), m.GetMemory(0x8000), "original must not be modified")
+	require.Equal(t, uint32(0), m.GetMemory(0x20000))
+	require.Equal(t, 2, m.PageCount())
+	require.Equal(t, root, m.MerkleRoot())
+	require.NotEqual(t, root, cpy.MerkleRoot())
+}
@@ -0,0 +1,226 @@
+package mipsevm
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+)
+
+// StateBinaryVersion is the version of the binary state encoding written by State.Serialize.
+// It must be incremented whenever the encoding changes.
+const StateBinaryVersion uint8 = 1
+
+// maxLastHintSize bounds the size of the LastHint field accepted when deserializing.
+const maxLastHintSize = 1 << 30
+
+var ErrUnsupportedStateVersion = errors.New("unsupported binary state version")
+
+// Serialize writes the state in a compact binary encoding.
+//
+// The encoding is:
+//
+//	version          uint8
+//	memory           see Memory.Serialize
+//	preimageKey      [32]byte
+//	preimageOffset   uint32
+//	pc               uint32
+//	nextPC           uint32
+//	lo               uint32
+//	hi               uint32
+//	heap             uint32
+//	exitCode         uint8
+//	exited           uint8 (0 or 1)
+//	step             uint64
+//	registers        [32]uint32
+//	lastHintLen      uint32
+//	lastHint         [lastHintLen]byte
+//
+// All integers are big-endian.
+func (s *State) Serialize(out io.Writer) error {
+	bout := bufio.NewWriter(out)
+	if err := bout.WriteByte(StateBinaryVersion); err != nil {
+		return err
+	}
+	if err := s.Memory.Serialize(bout); err != nil {
+		return err
+	}
+	if _, err := bout.Write(s.PreimageKey[:]); err != nil {
+		return err
+	}
+	fields := []any{
+		s.PreimageOffset,
+		s.PC,
+		s.NextPC,
+		s.LO,
+		s.HI,
+		s.Heap,
+		s.ExitCode,
+		s.Exited,
+		s.Step,
+		s.Registers,
+		uint32(len(s.LastHint)),
+	}
+	for _, field := range fields {
+		if err := binary.Write(bout, binary.BigEndian, field); err != nil {
+			return err
+		}
+	}
+	if _, err := bout.Write(s.LastHint); err != nil {
+		return err
+	}
+	return bout.Flush()
+}
+
+// Deserialize reads a state previously written by Serialize, replacing the current contents of s.
+func (s *State) Deserialize(in io.Reader) error {
+	bin := bufio.NewReader(in)
+	version, err := bin.ReadByte()
+	if err != nil {
+		return fmt.Errorf("failed to read version: %w", err)
+	}
+	if version != StateBinaryVersion {
+		return fmt.Errorf("%w: %v", ErrUnsupportedStateVersion, version)
+	}
+	s.Memory = NewMemory()
+	if err := s.Memory.Deserialize(bin); err != nil {
+		return fmt.Errorf("failed to read memory: %w", err)
+	}
+	if _, err := io.ReadFull(bin, s.PreimageKey[:]); err != nil {
+		return fmt.Errorf("failed to read preimage key: %w", err)
+	}
+	var lastHintLen uint32
+	fields := []any{
+		&s.PreimageOffset,
+		&s.PC,
+		&s.NextPC,
+		&s.LO,
+		&s.HI,
+		&s.Heap,
+		&s.ExitCode,
+		&s.Exited,
+		&s.Step,
+		&s.Registers,
+		&lastHintLen,
+	}
+	for _, field := range fields {
+		if err := binary.Read(bin, binary.BigEndian, field); err != nil {
+			return fmt.Errorf("failed to read state field: %w", err)
+		}
+	}
+	if lastHintLen > maxLastHintSize {
+		return fmt.Errorf("last hint length %v exceeds maximum %v", lastHintLen, maxLastHintSize)
+	}
+	s.LastHint = nil
+	if lastHintLen > 0 {
+		s.LastHint = make([]byte, lastHintLen)
+		if _, err := io.ReadFull(bin, s.LastHint); err != nil {
+			return fmt.Errorf("failed to read last hint: %w", err)
+		}
+	}
+	return nil
+}
+
+// Serialize writes the memory pages as a uint32 page count followed by the index and data of each page,
+// in ascending order of page index.
+func (m *Memory) Serialize(out io.Writer) error {
+	indices := make([]uint32, 0, len(m.pages))
+	for k := range m.pages {
+		indices = append(indices, k)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	if err := binary.Write(out, binary.BigEndian, uint32(len(indices))); err != nil {
+		return err
+	}
+	for _, idx := range indices {
+		if err := binary.Write(out, binary.BigEndian, idx); err != nil {
+			return err
+		}
+		if _, err := out.Write(m.pages[idx].Data[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deserialize reads memory pages previously written by Serialize, replacing the current contents of m.
+func (m *Memory) Deserialize(in io.Reader) error {
+	var count uint32
+	if err := binary.Read(in, binary.BigEndian, &count); err != nil {
+		return err
+	}
+	m.nodes = make(map[uint64]*[32]byte)
+	m.pages = make(map[uint32]*CachedPage)
+	m.lastPageKeys = [2]uint32{^uint32(0), ^uint32(0)}
+	m.lastPage = [2]*CachedPage{nil, nil}
+	for i := uint32(0); i < count; i++ {
+		var idx uint32
+		if err := binary.Read(in, binary.BigEndian, &idx); err != nil {
+			return err
+		}
+		if idx > PageKeyMask {
+			return fmt.Errorf("invalid page index %d", idx)
+		}
+		if _, ok := m.pages[idx]; ok {
+			return fmt.Errorf("cannot load duplicate page, entry %d, page index %d", i, idx)
+		}
+		if _, err := io.ReadFull(in, m.AllocPage(idx).Data[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsBinaryStateFile returns true if the path has a .bin or .bin.gz extension, indicating the state
+// should be stored in the binary encoding rather than JSON.
+func IsBinaryStateFile(path string) bool {
+	return strings.HasSuffix(path, ".bin") || strings.HasSuffix(path, ".bin.gz")
+}
+
+// LoadStateFromFile loads a state from path, using the binary encoding if IsBinaryStateFile is true and
+// JSON otherwise. Files with a .gz extension are decompressed.
+func LoadStateFromFile(path string) (*State, error) {
+	f, err := ioutil.OpenDecompressed(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %q: %w", path, err)
+	}
+	defer f.Close()
+	var state State
+	if IsBinaryStateFile(path) {
+		err = state.Deserialize(f)
+	} else {
+		err = json.NewDecoder(bufio.NewReader(f)).Decode(&state)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode file %q: %w", path, err)
+	}
+	return &state, nil
+}
+
+// WriteStateToFile atomically writes the state to path, using the binary encoding if IsBinaryStateFile is true
+// and JSON otherwise. Files with a .gz extension are compressed.
+func WriteStateToFile(path string, state *State, perm os.FileMode) error {
+	f, err := ioutil.NewAtomicWriterCompressed(path, perm)
+	if err != nil {
+		return fmt.Errorf("failed to open output file: %w", err)
+	}
+	// Ensure we close the stream even if failures occur.
+	defer f.Close()
+	if IsBinaryStateFile(path) {
+		err = state.Serialize(f)
+	} else {
+		err = json.NewEncoder(f).Encode(state)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	// Closing the file causes it to be renamed to the final destination
+	return f.Close()
+}
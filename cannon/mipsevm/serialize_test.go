@@ -0,0 +1,104 @@
+package mipsevm
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func serializeTestState() *State {
+	state := &State{
+		Memory:         NewMemory(),
+		PreimageKey:    common.Hash{0xaa},
+		PreimageOffset: 5,
+		PC:             0x1000,
+		NextPC:         0x1004,
+		LO:             1,
+		HI:             2,
+		Heap:           0x2000_0000,
+		ExitCode:       3,
+		Exited:         true,
+		Step:           123_456_789,
+		LastHint:       []byte{0, 0, 0, 2, 0xab},
+	}
+	for i := range state.Registers {
+		state.Registers[i] = uint32(i * 7)
+	}
+	state.Memory.SetMemory(0x1000, 0xdeadbeef)
+	state.Memory.SetMemory(0x8000_0000, 0xcafebabe)
+	state.Memory.SetMemory(0xffff_fffc, 42)
+	return state
+}
+
+func TestSerializeStateRoundTrip(t *testing.T) {
+	t.Run("Full", func(t *testing.T) {
+		requireRoundTrip(t, serializeTestState())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		requireRoundTrip(t, &State{Memory: NewMemory()})
+	})
+}
+
+func requireRoundTrip(t *testing.T, state *State) {
+	var buf bytes.Buffer
+	require.NoError(t, state.Serialize(&buf))
+	var result State
+	require.NoError(t, result.Deserialize(&buf))
+	requireStatesEqual(t, state, &result)
+}
+
+func requireStatesEqual(t *testing.T, expected *State, actual *State) {
+	require.Equal(t, expected.EncodeWitness(), actual.EncodeWitness())
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err)
+	actualJSON, err := json.Marshal(actual)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expectedJSON), string(actualJSON))
+}
+
+func TestDeserializeStateErrors(t *testing.T) {
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, serializeTestState().Serialize(&buf))
+		data := buf.Bytes()
+		data[0] = StateBinaryVersion + 1
+		var result State
+		require.ErrorIs(t, result.Deserialize(bytes.NewReader(data)), ErrUnsupportedStateVersion)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, serializeTestState().Serialize(&buf))
+		data := buf.Bytes()
+		var result State
+		require.Error(t, result.Deserialize(bytes.NewReader(data[:len(data)-1])))
+		require.Error(t, result.Deserialize(bytes.NewReader(data[:100])))
+	})
+}
+
+func TestStateFileFormats(t *testing.T) {
+	state := serializeTestState()
+	for _, name := range []string{"state.json", "state.json.gz", "state.bin", "state.bin.gz"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			require.NoError(t, WriteStateToFile(path, state, 0644))
+			result, err := LoadStateFromFile(path)
+			require.NoError(t, err)
+			requireStatesEqual(t, state, result)
+		})
+	}
+
+	t.Run("Detect", func(t *testing.T) {
+		require.True(t, IsBinaryStateFile("state.bin"))
+		require.True(t, IsBinaryStateFile("/tmp/123.bin.gz"))
+		require.False(t, IsBinaryStateFile("state.json"))
+		require.False(t, IsBinaryStateFile("state.json.gz"))
+		require.False(t, IsBinaryStateFile("state.binary"))
+	})
+}
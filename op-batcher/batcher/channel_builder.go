@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -62,6 +63,24 @@ type ChannelConfig struct {
 
 	// BatchType indicates whether the channel uses SingularBatch or SpanBatch.
 	BatchType uint
+
+	// UseBlobs indicates that this channel should be sent as a multi-blob
+	// transaction with one blob per frame.
+	UseBlobs bool
+
+	// TargetNumFrames specifies the number of frames (and thus blobs) to
+	// send in a single blob transaction. It is ignored if UseBlobs is false.
+	TargetNumFrames int
+}
+
+// MaxFramesPerTx returns the maximum number of frames that are sent in a
+// single transaction: the target number of frames for blob transactions,
+// and 1 for calldata transactions.
+func (cc *ChannelConfig) MaxFramesPerTx() int {
+	if !cc.UseBlobs {
+		return 1
+	}
+	return cc.TargetNumFrames
 }
 
 // Check validates the [ChannelConfig] parameters.
@@ -91,6 +110,19 @@ func (cc *ChannelConfig) Check() error {
 		return fmt.Errorf("unrecognized batch type: %d", cc.BatchType)
 	}
 
+	if cc.UseBlobs {
+		if cc.TargetNumFrames < 1 {
+			return fmt.Errorf("target number of frames must be at least 1, got %d", cc.TargetNumFrames)
+		}
+		if cc.TargetNumFrames > eth.MaxBlobsPerBlobTx {
+			return fmt.Errorf("target number of frames %d exceeds the maximum number of blobs per tx %d", cc.TargetNumFrames, eth.MaxBlobsPerBlobTx)
+		}
+		// Each frame is prefixed with a version byte when encoded into a blob.
+		if cc.MaxFrameSize > eth.MaxBlobDataSize-1 {
+			return fmt.Errorf("max frame size %d exceeds the max blob data size %d", cc.MaxFrameSize, eth.MaxBlobDataSize-1)
+		}
+	}
+
 	return nil
 }
 
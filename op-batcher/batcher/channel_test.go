@@ -39,8 +39,8 @@ func TestChannelTimeout(t *testing.T) {
 
 	// Manually set a confirmed transactions
 	// To avoid other methods clearing state
-	channel.confirmedTransactions[frameID{frameNumber: 0}] = eth.BlockID{Number: 0}
-	channel.confirmedTransactions[frameID{frameNumber: 1}] = eth.BlockID{Number: 99}
+	channel.confirmedTransactions[txID{frameID{frameNumber: 0}}.String()] = eth.BlockID{Number: 0}
+	channel.confirmedTransactions[txID{frameID{frameNumber: 1}}.String()] = eth.BlockID{Number: 99}
 	channel.confirmedTxUpdated = true
 
 	// Since the ChannelTimeout is 100, the
@@ -50,9 +50,9 @@ func TestChannelTimeout(t *testing.T) {
 
 	// Add a confirmed transaction with a higher number
 	// than the ChannelTimeout
-	channel.confirmedTransactions[frameID{
+	channel.confirmedTransactions[txID{frameID{
 		frameNumber: 2,
-	}] = eth.BlockID{
+	}}.String()] = eth.BlockID{
 		Number: 101,
 	}
 	channel.confirmedTxUpdated = true
@@ -97,12 +97,53 @@ func TestChannelNextTxData(t *testing.T) {
 
 	// Now the nextTxData function should return the frame
 	returnedTxData, err = m.nextTxData(channel)
-	expectedTxData := txData{frame}
+	expectedTxData := singleFrameTxData(frame)
 	expectedChannelID := expectedTxData.ID()
 	require.NoError(t, err)
 	require.Equal(t, expectedTxData, returnedTxData)
 	require.Equal(t, 0, channel.PendingFrames())
-	require.Equal(t, expectedTxData, channel.pendingTransactions[expectedChannelID])
+	require.Equal(t, expectedTxData, channel.pendingTransactions[expectedChannelID.String()])
+}
+
+// TestChannelNextTxDataBlobs checks that blob tx data carries multiple frames
+// and is only returned once enough frames are available.
+func TestChannelNextTxDataBlobs(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{
+		UseBlobs:        true,
+		TargetNumFrames: 2,
+	}, &rollup.Config{})
+	m.Clear()
+
+	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	channel := m.currentChannel
+	require.NotNil(t, channel)
+	channelID := channel.ID()
+
+	frame0 := frameData{data: []byte{0x01}, id: frameID{chID: channelID, frameNumber: 0}}
+	channel.channelBuilder.PushFrame(frame0)
+	// A single frame isn't enough to fill a blob tx of an open channel.
+	require.False(t, channel.HasTxData())
+
+	frame1 := frameData{data: []byte{0x02}, id: frameID{chID: channelID, frameNumber: 1}}
+	channel.channelBuilder.PushFrame(frame1)
+	require.True(t, channel.HasTxData())
+
+	returnedTxData, err := m.nextTxData(channel)
+	require.NoError(t, err)
+	require.True(t, returnedTxData.asBlob)
+	require.Equal(t, []frameData{frame0, frame1}, returnedTxData.Frames())
+	require.Equal(t, 0, channel.PendingFrames())
+
+	id := returnedTxData.ID()
+	require.Equal(t, channelID.String()+":0+1", id.String())
+	blobs, err := returnedTxData.Blobs()
+	require.NoError(t, err)
+	require.Len(t, blobs, 2)
+
+	// A failed blob tx requeues all of its frames.
+	m.TxFailed(id)
+	require.Equal(t, 2, channel.PendingFrames())
 }
 
 // TestChannelTxConfirmed checks the [ChannelManager.TxConfirmed] function.
@@ -131,12 +172,12 @@ func TestChannelTxConfirmed(t *testing.T) {
 	m.currentChannel.channelBuilder.PushFrame(frame)
 	require.Equal(t, 1, m.currentChannel.PendingFrames())
 	returnedTxData, err := m.nextTxData(m.currentChannel)
-	expectedTxData := txData{frame}
+	expectedTxData := singleFrameTxData(frame)
 	expectedChannelID := expectedTxData.ID()
 	require.NoError(t, err)
 	require.Equal(t, expectedTxData, returnedTxData)
 	require.Equal(t, 0, m.currentChannel.PendingFrames())
-	require.Equal(t, expectedTxData, m.currentChannel.pendingTransactions[expectedChannelID])
+	require.Equal(t, expectedTxData, m.currentChannel.pendingTransactions[expectedChannelID.String()])
 	require.Len(t, m.currentChannel.pendingTransactions, 1)
 
 	// An unknown pending transaction should not be marked as confirmed
@@ -144,7 +185,7 @@ func TestChannelTxConfirmed(t *testing.T) {
 	actualChannelID := m.currentChannel.ID()
 	unknownChannelID := derive.ChannelID([derive.ChannelIDLength]byte{0x69})
 	require.NotEqual(t, actualChannelID, unknownChannelID)
-	unknownTxID := frameID{chID: unknownChannelID, frameNumber: 0}
+	unknownTxID := txID{frameID{chID: unknownChannelID, frameNumber: 0}}
 	blockID := eth.BlockID{Number: 0, Hash: common.Hash{0x69}}
 	m.TxConfirmed(unknownTxID, blockID)
 	require.Empty(t, m.currentChannel.confirmedTransactions)
@@ -156,7 +197,7 @@ func TestChannelTxConfirmed(t *testing.T) {
 	m.TxConfirmed(expectedChannelID, blockID)
 	require.Empty(t, m.currentChannel.pendingTransactions)
 	require.Len(t, m.currentChannel.confirmedTransactions, 1)
-	require.Equal(t, blockID, m.currentChannel.confirmedTransactions[expectedChannelID])
+	require.Equal(t, blockID, m.currentChannel.confirmedTransactions[expectedChannelID.String()])
 }
 
 // TestChannelTxFailed checks the [ChannelManager.TxFailed] function.
@@ -180,19 +221,19 @@ func TestChannelTxFailed(t *testing.T) {
 	m.currentChannel.channelBuilder.PushFrame(frame)
 	require.Equal(t, 1, m.currentChannel.PendingFrames())
 	returnedTxData, err := m.nextTxData(m.currentChannel)
-	expectedTxData := txData{frame}
+	expectedTxData := singleFrameTxData(frame)
 	expectedChannelID := expectedTxData.ID()
 	require.NoError(t, err)
 	require.Equal(t, expectedTxData, returnedTxData)
 	require.Equal(t, 0, m.currentChannel.PendingFrames())
-	require.Equal(t, expectedTxData, m.currentChannel.pendingTransactions[expectedChannelID])
+	require.Equal(t, expectedTxData, m.currentChannel.pendingTransactions[expectedChannelID.String()])
 	require.Len(t, m.currentChannel.pendingTransactions, 1)
 
 	// Trying to mark an unknown pending transaction as failed
 	// shouldn't modify state
-	m.TxFailed(frameID{})
+	m.TxFailed(txID{frameID{}})
 	require.Equal(t, 0, m.currentChannel.PendingFrames())
-	require.Equal(t, expectedTxData, m.currentChannel.pendingTransactions[expectedChannelID])
+	require.Equal(t, expectedTxData, m.currentChannel.pendingTransactions[expectedChannelID.String()])
 
 	// Now we still have a pending transaction
 	// Let's mark it as failed
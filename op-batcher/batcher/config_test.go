@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/batcher"
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -25,6 +26,7 @@ func validBatcherConfig() batcher.CLIConfig {
 		MaxL1TxSize:            10,
 		Stopped:                false,
 		BatchType:              0,
+		DataAvailabilityType:   flags.CalldataType,
 		TxMgrConfig:            txmgr.NewCLIConfig("fake", txmgr.DefaultBatcherFlagValues),
 		LogConfig:              log.DefaultCLIConfig(),
 		MetricsConfig:          metrics.DefaultCLIConfig(),
@@ -80,6 +82,19 @@ func TestBatcherConfig(t *testing.T) {
 			override:  func(c *batcher.CLIConfig) { c.BatchType = 100 },
 			errString: "unknown batch type: 100",
 		},
+		{
+			name:      "invalid data availability type",
+			override:  func(c *batcher.CLIConfig) { c.DataAvailabilityType = "foo" },
+			errString: "unknown data availability type: \"foo\"",
+		},
+		{
+			name: "too many frames for blob txs",
+			override: func(c *batcher.CLIConfig) {
+				c.DataAvailabilityType = flags.BlobsType
+				c.CompressorConfig.TargetNumFrames = 7
+			},
+			errString: "too many frames for blob transactions, max 6",
+		},
 	}
 
 	for _, test := range tests {
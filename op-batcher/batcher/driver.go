@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -353,24 +354,56 @@ func (l *BatchSubmitter) publishTxToL1(ctx context.Context, queue *txmgr.Queue[t
 	return nil
 }
 
-// sendTransaction creates & submits a transaction to the batch inbox address with the given `data`.
+// sendTransaction creates & submits a transaction to the batch inbox address with the given `txData`.
 // It currently uses the underlying `txmgr` to handle transaction sending & price management.
 // This is a blocking method. It should not be called concurrently.
 func (l *BatchSubmitter) sendTransaction(txdata txData, queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) {
-	// Do the gas estimation offline. A value of 0 will cause the [txmgr] to estimate the gas limit.
-	data := txdata.Bytes()
-	intrinsicGas, err := core.IntrinsicGas(data, nil, false, true, true, false)
+	var (
+		candidate *txmgr.TxCandidate
+		err       error
+	)
+	if txdata.asBlob {
+		candidate, err = l.blobTxCandidate(txdata)
+	} else {
+		candidate, err = l.calldataTxCandidate(txdata.CallData())
+	}
 	if err != nil {
-		l.Log.Error("Failed to calculate intrinsic gas", "err", err)
+		// Put the frames back into the channel, so they are retried later.
+		l.Log.Error("Failed to create tx candidate", "err", err)
+		l.recordFailedTx(txdata, err)
 		return
 	}
+	queue.Send(txdata, *candidate, receiptsCh)
+}
+
+// blobTxCandidate creates a candidate for a blob transaction, with one blob per frame of the tx data.
+func (l *BatchSubmitter) blobTxCandidate(data txData) (*txmgr.TxCandidate, error) {
+	blobs, err := data.Blobs()
+	if err != nil {
+		return nil, fmt.Errorf("generating blobs for tx data: %w", err)
+	}
+	for _, f := range data.Frames() {
+		l.Metr.RecordBlobUsedBytes(len(f.data))
+	}
+	return &txmgr.TxCandidate{
+		To:       &l.RollupConfig.BatchInboxAddress,
+		Blobs:    blobs,
+		GasLimit: params.TxGas, // a blob tx doesn't carry calldata
+	}, nil
+}
 
-	candidate := txmgr.TxCandidate{
+// calldataTxCandidate creates a candidate for a calldata transaction.
+// The gas estimation is done offline: the intrinsic gas is used as the gas limit.
+func (l *BatchSubmitter) calldataTxCandidate(data []byte) (*txmgr.TxCandidate, error) {
+	intrinsicGas, err := core.IntrinsicGas(data, nil, false, true, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate intrinsic gas: %w", err)
+	}
+	return &txmgr.TxCandidate{
 		To:       &l.RollupConfig.BatchInboxAddress,
 		TxData:   data,
 		GasLimit: intrinsicGas,
-	}
-	queue.Send(txdata, candidate, receiptsCh)
+	}, nil
 }
 
 func (l *BatchSubmitter) handleReceipt(r txmgr.TxReceipt[txData]) {
@@ -417,7 +450,7 @@ func logFields(xs ...any) (fs []any) {
 	for _, x := range xs {
 		switch v := x.(type) {
 		case txData:
-			fs = append(fs, "frame_id", v.ID(), "data_len", v.Len())
+			fs = append(fs, "tx_id", v.ID(), "data_len", v.Len(), "num_frames", len(v.Frames()), "as_blob", v.asBlob)
 		case *types.Receipt:
 			fs = append(fs, "tx", v.TxHash, "block", eth.ReceiptBlockID(v))
 		case error:
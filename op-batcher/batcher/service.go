@@ -15,11 +15,13 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -184,6 +186,23 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 		CompressorConfig:   cfg.CompressorConfig.Config(),
 		BatchType:          cfg.BatchType,
 	}
+
+	switch cfg.DataAvailabilityType {
+	case flags.BlobsType:
+		if !bs.RollupConfig.IsEcotone(uint64(time.Now().Unix())) {
+			bs.Log.Warn("Ecotone upgrade not active yet, but the batcher is configured to post blobs")
+		}
+		// Frames are sized to fit a single frame, prefixed with the version byte, into each blob.
+		// The channel targets the configured number of frames, which are all sent in one blob tx.
+		bs.ChannelConfig.MaxFrameSize = eth.MaxBlobDataSize - 1
+		bs.ChannelConfig.CompressorConfig.TargetFrameSize = eth.MaxBlobDataSize - 1
+		bs.ChannelConfig.UseBlobs = true
+		bs.ChannelConfig.TargetNumFrames = cfg.CompressorConfig.TargetNumFrames
+	case flags.CalldataType:
+	default:
+		return fmt.Errorf("unknown data availability type: %v", cfg.DataAvailabilityType)
+	}
+	bs.Log.Info("Initialized channel config", "da_type", cfg.DataAvailabilityType, "max_frame_size", bs.ChannelConfig.MaxFrameSize, "target_num_frames", bs.ChannelConfig.MaxFramesPerTx())
 	if err := bs.ChannelConfig.Check(); err != nil {
 		return fmt.Errorf("invalid channel configuration: %w", err)
 	}
@@ -2,57 +2,103 @@ package batcher
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 // txData represents the data for a single transaction.
 //
-// Note: The batcher currently sends exactly one frame per transaction. This
-// might change in the future to allow for multiple frames from possibly
-// different channels.
+// Note: The batcher sends exactly one frame per calldata transaction. Blob
+// transactions may carry multiple frames of the same channel, one per blob.
 type txData struct {
-	frame frameData
+	frames []frameData
+	asBlob bool // indicates whether this should be sent as blob
 }
 
-// ID returns the id for this transaction data. It can be used as a map key.
+func singleFrameTxData(frame frameData) txData {
+	return txData{frames: []frameData{frame}}
+}
+
+// ID returns the id for this transaction data. Its String() can be used as a map key.
 func (td *txData) ID() txID {
-	return td.frame.id
+	id := make(txID, 0, len(td.frames))
+	for _, f := range td.frames {
+		id = append(id, f.id)
+	}
+	return id
 }
 
-// Bytes returns the transaction data. It's a version byte (0) followed by the
+// CallData returns the transaction data. It's a version byte (0) followed by the
 // concatenated frames for this transaction.
-func (td *txData) Bytes() []byte {
-	return append([]byte{derive.DerivationVersion0}, td.frame.data...)
+func (td *txData) CallData() []byte {
+	data := make([]byte, 1, 1+td.Len())
+	data[0] = derive.DerivationVersion0
+	for _, f := range td.frames {
+		data = append(data, f.data...)
+	}
+	return data
 }
 
-func (td *txData) Len() int {
-	return 1 + len(td.frame.data)
+// Blobs returns the transaction data encoded as blobs, one blob per frame.
+// Each blob holds a version byte (0) followed by the frame.
+func (td *txData) Blobs() ([]*eth.Blob, error) {
+	blobs := make([]*eth.Blob, 0, len(td.frames))
+	for _, f := range td.frames {
+		var blob eth.Blob
+		if err := blob.FromData(append([]byte{derive.DerivationVersion0}, f.data...)); err != nil {
+			return nil, fmt.Errorf("encoding frame %d of channel %s as blob: %w", f.id.frameNumber, f.id.chID, err)
+		}
+		blobs = append(blobs, &blob)
+	}
+	return blobs, nil
 }
 
-// Frame returns the single frame of this tx data.
-//
-// Note: when the batcher is changed to possibly send multiple frames per tx,
-// this should be changed to a func Frames() []frameData.
-func (td *txData) Frame() frameData {
-	return td.frame
+// Len returns the sum of all the sizes of data in all frames.
+// Len only counts the data itself and doesn't account for the version byte(s).
+func (td *txData) Len() (l int) {
+	for _, f := range td.frames {
+		l += len(f.data)
+	}
+	return l
+}
+
+// Frames returns the frames of this tx data.
+func (td *txData) Frames() []frameData {
+	return td.frames
 }
 
 // txID is an opaque identifier for a transaction.
-// It's internal fields should not be inspected after creation & are subject to change.
-// This ID must be trivially comparable & work as a map key.
-//
-// Note: transactions currently only hold a single frame, so it can be
-// identified by the frame. This needs to be changed once the batcher is changed
-// to send multiple frames per tx.
-type txID = frameID
+// Its internal fields should not be inspected after creation & are subject to change.
+// Its String() can be used for comparisons and works as a map key.
+type txID []frameID
 
 func (id txID) String() string {
-	return fmt.Sprintf("%s:%d", id.chID.String(), id.frameNumber)
+	return id.string(func(id derive.ChannelID) string { return id.String() })
 }
 
 // TerminalString implements log.TerminalStringer, formatting a string for console
 // output during logging.
 func (id txID) TerminalString() string {
-	return fmt.Sprintf("%s:%d", id.chID.TerminalString(), id.frameNumber)
+	return id.string(func(id derive.ChannelID) string { return id.TerminalString() })
+}
+
+func (id txID) string(chIDStringer func(id derive.ChannelID) string) string {
+	var (
+		sb      strings.Builder
+		curChID derive.ChannelID
+	)
+	for _, f := range id {
+		if f.chID == curChID {
+			sb.WriteString(fmt.Sprintf("+%d", f.frameNumber))
+		} else {
+			if curChID != (derive.ChannelID{}) {
+				sb.WriteString("|")
+			}
+			curChID = f.chID
+			sb.WriteString(fmt.Sprintf("%s:%d", chIDStringer(f.chID), f.frameNumber))
+		}
+	}
+	return sb.String()
 }
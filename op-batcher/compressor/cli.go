@@ -24,7 +24,7 @@ func CLIFlags(envPrefix string) []cli.Flag {
 		},
 		&cli.IntFlag{
 			Name:    TargetNumFramesFlagName,
-			Usage:   "The target number of frames to create per channel",
+			Usage:   "The target number of frames to create per channel. Controls the number of blobs per blob tx, if using blob DA.",
 			Value:   1,
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "TARGET_NUM_FRAMES"),
 		},
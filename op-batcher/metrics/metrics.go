@@ -46,6 +46,8 @@ type Metricer interface {
 	RecordBatchTxSuccess()
 	RecordBatchTxFailed()
 
+	RecordBlobUsedBytes(num int)
+
 	Document() []opmetrics.DocumentedMetric
 }
 
@@ -79,6 +81,8 @@ type Metrics struct {
 	channelOutputBytesTotal prometheus.Counter
 
 	batcherTxEvs opmetrics.EventVec
+
+	blobUsedBytes prometheus.Histogram
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -183,6 +187,13 @@ func NewMetrics(procName string) *Metrics {
 		}),
 
 		batcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
+
+		blobUsedBytes: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "blob_used_bytes",
+			Help:      "Blob size in bytes, recorded for every blob of a blob tx.",
+			Buckets:   prometheus.LinearBuckets(0.0, eth.MaxBlobDataSize/13, 14),
+		}),
 	}
 }
 
@@ -304,6 +315,10 @@ func (m *Metrics) RecordBatchTxFailed() {
 	m.batcherTxEvs.Record(TxStageFailed)
 }
 
+func (m *Metrics) RecordBlobUsedBytes(num int) {
+	m.blobUsedBytes.Observe(float64(num))
+}
+
 // estimateBatchSize estimates the size of the batch
 func estimateBatchSize(block *types.Block) uint64 {
 	size := uint64(70) // estimated overhead of batch metadata
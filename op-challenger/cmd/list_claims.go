@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	GameAddressFlag = &cli.StringFlag{
+		Name:    "game-address",
+		Usage:   "Address of the fault dispute game to list claims for.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "GAME_ADDRESS"),
+	}
+)
+
+// claimAgreement reports whether the honest actor agrees with a claim.
+type claimAgreement func(ctx context.Context, claim types.Claim) (string, error)
+
+func ListClaims(ctx *cli.Context) error {
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	gameAddr, err := addressFromCLI(ctx, GameAddressFlag)
+	if err != nil {
+		return err
+	}
+	l1Client, caller, err := dialL1FromCLI(ctx, logger)
+	if err != nil {
+		return err
+	}
+	defer l1Client.Close()
+	contract, err := contracts.NewFaultDisputeGameContract(gameAddr, caller)
+	if err != nil {
+		return fmt.Errorf("failed to create dispute game bindings: %w", err)
+	}
+	splitDepth, err := contract.GetSplitDepth(ctx.Context)
+	if err != nil {
+		return err
+	}
+	agreement, err := newClaimAgreement(ctx.Context, logger, ctx.String(flags.RollupRpcFlag.Name), contract, splitDepth)
+	if err != nil {
+		return err
+	}
+	claims, err := contract.GetAllClaims(ctx.Context)
+	if err != nil {
+		return err
+	}
+	return printClaims(ctx.Context, ctx.App.Writer, claims, splitDepth, agreement)
+}
+
+// newClaimAgreement creates a claimAgreement that compares output root claims against the rollup node.
+// Agreement with claims below the split depth requires executing the fault proof VM so is reported as unknown.
+func newClaimAgreement(ctx context.Context, logger log.Logger, rollupRpc string, contract *contracts.FaultDisputeGameContract, splitDepth uint64) (claimAgreement, error) {
+	if rollupRpc == "" {
+		return func(_ context.Context, _ types.Claim) (string, error) {
+			return "-", nil
+		}, nil
+	}
+	prestateBlock, poststateBlock, err := contract.GetBlockRange(ctx)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := outputs.NewTraceProvider(ctx, logger, rollupRpc, splitDepth, prestateBlock, poststateBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output root trace provider: %w", err)
+	}
+	return func(ctx context.Context, claim types.Claim) (string, error) {
+		if uint64(claim.Depth()) > splitDepth {
+			return "-", nil
+		}
+		expected, err := provider.Get(ctx, claim.Position)
+		if err != nil {
+			return "", fmt.Errorf("failed to load output root for claim %v: %w", claim.ContractIndex, err)
+		}
+		return formatAgreement(expected == claim.Value), nil
+	}, nil
+}
+
+func formatAgreement(agree bool) string {
+	if agree {
+		return "Agree"
+	}
+	return "Disagree"
+}
+
+// moveType describes how the claim relates to its parent.
+func moveType(claim types.Claim, claims []types.Claim) string {
+	if claim.IsRoot() {
+		return "Root"
+	}
+	if claim.ParentContractIndex < 0 || claim.ParentContractIndex >= len(claims) {
+		return "Unknown"
+	}
+	parent := claims[claim.ParentContractIndex]
+	if claim.Position.RightOf(parent.Position) {
+		return "Defend"
+	}
+	return "Attack"
+}
+
+func printClaims(ctx context.Context, out io.Writer, claims []types.Claim, splitDepth uint64, agreement claimAgreement) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Idx\tMove\tParent\tDepth\tIndex\tCountered\tClaim\tAgreement")
+	for _, claim := range claims {
+		parent := "-"
+		if !claim.IsRoot() {
+			parent = fmt.Sprint(claim.ParentContractIndex)
+		}
+		depth := fmt.Sprint(claim.Depth())
+		if uint64(claim.Depth()) == splitDepth {
+			depth += " (split)"
+		}
+		agree, err := agreement(ctx, claim)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+			claim.ContractIndex, moveType(claim, claims), parent, depth, claim.IndexAtDepth(), claim.Countered, claim.Value, agree)
+	}
+	return w.Flush()
+}
+
+func listClaimsFlags() []cli.Flag {
+	return append([]cli.Flag{
+		flags.L1EthRpcFlag,
+		GameAddressFlag,
+		flags.RollupRpcFlag,
+	}, oplog.CLIFlags(flags.EnvVarPrefix)...)
+}
+
+var ListClaimsCommand = &cli.Command{
+	Name:  "list-claims",
+	Usage: "List the claims in a dispute game",
+	Description: "Lists every claim in a dispute game with its position, parent and whether it has been countered. " +
+		"If --" + flags.RollupRpcFlag.Name + " is set, agreement with output root claims is checked against the rollup node.",
+	Action: ListClaims,
+	Flags:  listClaimsFlags(),
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveType(t *testing.T) {
+	root := types.Claim{ClaimData: types.ClaimData{Position: types.NewPositionFromGIndex(big.NewInt(1))}}
+	attack := types.Claim{
+		ClaimData:           types.ClaimData{Position: root.Position.Attack()},
+		ContractIndex:       1,
+		ParentContractIndex: 0,
+	}
+	defend := types.Claim{
+		ClaimData:           types.ClaimData{Position: attack.Position.Attack().Defend()},
+		ContractIndex:       2,
+		ParentContractIndex: 1,
+	}
+	claims := []types.Claim{root, attack, defend}
+	require.Equal(t, "Root", moveType(root, claims))
+	require.Equal(t, "Attack", moveType(attack, claims))
+	require.Equal(t, "Defend", moveType(defend, claims))
+
+	orphan := types.Claim{ClaimData: types.ClaimData{Position: attack.Position}, ContractIndex: 3, ParentContractIndex: 5}
+	require.Equal(t, "Unknown", moveType(orphan, claims))
+}
+
+func TestPrintClaims(t *testing.T) {
+	root := types.Claim{ClaimData: types.ClaimData{Value: common.Hash{0xaa}, Position: types.NewPositionFromGIndex(big.NewInt(1))}, Countered: true}
+	attack := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0xbb}, Position: root.Position.Attack()},
+		ContractIndex:       1,
+		ParentContractIndex: 0,
+	}
+	claims := []types.Claim{root, attack}
+
+	t.Run("Agreement", func(t *testing.T) {
+		agreement := func(_ context.Context, claim types.Claim) (string, error) {
+			return formatAgreement(claim.Value == common.Hash{0xbb}), nil
+		}
+		var out bytes.Buffer
+		require.NoError(t, printClaims(context.Background(), &out, claims, 1, agreement))
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 3)
+		require.Equal(t, []string{"Idx", "Move", "Parent", "Depth", "Index", "Countered", "Claim", "Agreement"}, strings.Fields(lines[0]))
+		require.Equal(t, []string{"0", "Root", "-", "0", "0", "true", common.Hash{0xaa}.String(), "Disagree"}, strings.Fields(lines[1]))
+		require.Equal(t, []string{"1", "Attack", "0", "1", "(split)", "0", "false", common.Hash{0xbb}.String(), "Agree"}, strings.Fields(lines[2]))
+	})
+
+	t.Run("AgreementError", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		agreement := func(_ context.Context, _ types.Claim) (string, error) {
+			return "", expectedErr
+		}
+		err := printClaims(context.Background(), &bytes.Buffer{}, claims, 1, agreement)
+		require.ErrorIs(t, err, expectedErr)
+	})
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+// maxConcurrentGameLoads limits the number of games that have their details loaded in parallel.
+const maxConcurrentGameLoads = 10
+
+type gameInfo struct {
+	types.GameMetadata
+	Index         uint64
+	L2BlockNumber uint64
+	RootClaim     common.Hash
+	Status        types.GameStatus
+	ClaimCount    uint64
+}
+
+func ListGames(ctx *cli.Context) error {
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	factoryAddr, err := addressFromCLI(ctx, flags.FactoryAddressFlag)
+	if err != nil {
+		return err
+	}
+	l1Client, caller, err := dialL1FromCLI(ctx, logger)
+	if err != nil {
+		return err
+	}
+	defer l1Client.Close()
+	blockHash, err := latestBlockHash(ctx.Context, l1Client)
+	if err != nil {
+		return err
+	}
+	factory, err := contracts.NewDisputeGameFactoryContract(factoryAddr, caller)
+	if err != nil {
+		return fmt.Errorf("failed to create dispute game factory bindings: %w", err)
+	}
+	games, err := loadGames(ctx.Context, factory, caller, blockHash)
+	if err != nil {
+		return err
+	}
+	return printGames(ctx.App.Writer, games)
+}
+
+func loadGames(ctx context.Context, factory *contracts.DisputeGameFactoryContract, caller *batching.MultiCaller, blockHash common.Hash) ([]gameInfo, error) {
+	count, err := factory.GetGameCount(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	block := batching.BlockByHash(blockHash)
+	infos := make([]gameInfo, count)
+	group, gCtx := errgroup.WithContext(ctx)
+	group.SetLimit(maxConcurrentGameLoads)
+	for i := uint64(0); i < count; i++ {
+		idx := i
+		group.Go(func() error {
+			game, err := factory.GetGame(gCtx, idx, blockHash)
+			if err != nil {
+				return err
+			}
+			contract, err := contracts.NewFaultDisputeGameContract(game.Proxy, caller)
+			if err != nil {
+				return fmt.Errorf("failed to create dispute game bindings for %v: %w", game.Proxy, err)
+			}
+			l2BlockNumber, rootClaim, status, err := contract.GetGameMetadataAtBlock(gCtx, block)
+			if err != nil {
+				return fmt.Errorf("failed to load metadata for game %v: %w", game.Proxy, err)
+			}
+			claimCount, err := contract.GetClaimCountAtBlock(gCtx, block)
+			if err != nil {
+				return fmt.Errorf("failed to load claim count for game %v: %w", game.Proxy, err)
+			}
+			infos[idx] = gameInfo{
+				GameMetadata:  game,
+				Index:         idx,
+				L2BlockNumber: l2BlockNumber,
+				RootClaim:     rootClaim,
+				Status:        status,
+				ClaimCount:    claimCount,
+			}
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+func printGames(out io.Writer, games []gameInfo) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Idx\tGame\tType\tCreated (UTC)\tL2 Block\tClaims\tStatus\tRoot Claim")
+	for _, game := range games {
+		created := time.Unix(int64(game.Timestamp), 0).UTC().Format(time.DateTime)
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+			game.Index, game.Proxy, game.GameType, created, game.L2BlockNumber, game.ClaimCount, game.Status, game.RootClaim)
+	}
+	return w.Flush()
+}
+
+func listGamesFlags() []cli.Flag {
+	return append([]cli.Flag{
+		flags.L1EthRpcFlag,
+		flags.FactoryAddressFlag,
+	}, oplog.CLIFlags(flags.EnvVarPrefix)...)
+}
+
+var ListGamesCommand = &cli.Command{
+	Name:        "list-games",
+	Usage:       "List the games created by a dispute game factory",
+	Description: "Lists every game created by the dispute game factory with its status and root claim",
+	Action:      ListGames,
+	Flags:       listGamesFlags(),
+}
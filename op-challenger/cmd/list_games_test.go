@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintGames(t *testing.T) {
+	games := []gameInfo{
+		{
+			GameMetadata: types.GameMetadata{
+				GameType:  0,
+				Timestamp: 1_700_000_000,
+				Proxy:     common.Address{0xaa},
+			},
+			Index:         0,
+			L2BlockNumber: 42,
+			RootClaim:     common.Hash{0xbb},
+			Status:        types.GameStatusInProgress,
+			ClaimCount:    3,
+		},
+	}
+	var out bytes.Buffer
+	require.NoError(t, printGames(&out, games))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	require.True(t, strings.HasPrefix(lines[0], "Idx"))
+	require.Contains(t, lines[1], common.Address{0xaa}.String())
+	require.Contains(t, lines[1], "2023-11-14 22:13:20")
+	require.Contains(t, lines[1], types.GameStatusInProgress.String())
+	require.Contains(t, lines[1], common.Hash{0xbb}.String())
+}
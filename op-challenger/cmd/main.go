@@ -41,7 +41,7 @@ func run(ctx context.Context, args []string, action ConfiguredLifecycle) error {
 
 	app := cli.NewApp()
 	app.Version = VersionWithMeta
-	app.Flags = cliapp.ProtectFlags(flags.Flags)
+	app.Flags = cliapp.ProtectFlags(flags.AllFlags())
 	app.Name = "op-challenger"
 	app.Usage = "Challenge outputs"
 	app.Description = "Ensures that on chain outputs are correct."
@@ -58,6 +58,11 @@ func run(ctx context.Context, args []string, action ConfiguredLifecycle) error {
 		}
 		return action(ctx.Context, logger, cfg)
 	})
+	app.Commands = []*cli.Command{
+		ListGamesCommand,
+		ListClaimsCommand,
+		SimulateCommand,
+	}
 	return app.RunContext(ctx, args)
 }
 
@@ -4,15 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/tracetypes"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
@@ -125,6 +129,122 @@ func TestMultipleTraceTypes(t *testing.T) {
 	})
 }
 
+func TestMode(t *testing.T) {
+	t.Run("DefaultsToActor", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.ModeActor, cfg.Mode)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "unknown mode: \"foo\"", addRequiredArgs(config.TraceTypeAlphabet, "--mode=foo"))
+	})
+
+	monitorArgs := func() map[string]string {
+		args := map[string]string{
+			"--mode":                 config.ModeMonitor.String(),
+			"--l1-eth-rpc":           l1EthRpc,
+			"--game-factory-address": gameFactoryAddressValue,
+		}
+		addRequiredOutputArgs(args)
+		return args
+	}
+
+	t.Run("MonitorDoesNotRequireTraceTypeOrDatadir", func(t *testing.T) {
+		cfg := configForArgs(t, toArgList(monitorArgs()))
+		require.Equal(t, config.ModeMonitor, cfg.Mode)
+		require.Empty(t, cfg.TraceTypes)
+		require.Empty(t, cfg.Datadir)
+		require.Equal(t, config.DefaultMonitorExpiryWarning, cfg.MonitorExpiryWarning)
+	})
+
+	t.Run("MonitorRequiresRollupRpc", func(t *testing.T) {
+		args := monitorArgs()
+		delete(args, "--rollup-rpc")
+		verifyArgsInvalid(t, "flag rollup-rpc is required", toArgList(args))
+	})
+
+	t.Run("MonitorExpiryWarning", func(t *testing.T) {
+		cfg := configForArgs(t, append(toArgList(monitorArgs()), "--monitor-expiry-warning=2h"))
+		require.Equal(t, 2*time.Hour, cfg.MonitorExpiryWarning)
+	})
+}
+
+// testVMTraceType is registered by TestRegisteredTraceType to check trace types can add their own flags.
+const testVMTraceType config.TraceType = "test-vm"
+
+var registerTestVM sync.Once
+
+func TestRegisteredTraceType(t *testing.T) {
+	registerTestVM.Do(func() {
+		vmBinFlag := &cli.StringFlag{Name: "test-vm-bin", EnvVars: []string{"OP_CHALLENGER_TEST_VM_BIN"}}
+		tracetypes.Register(tracetypes.Definition{
+			TraceType: testVMTraceType,
+			GameType:  200,
+			Flags:     []cli.Flag{vmBinFlag},
+			CheckFlags: func(ctx *cli.Context) error {
+				if !ctx.IsSet(vmBinFlag.Name) {
+					return fmt.Errorf("flag %s is required", vmBinFlag.Name)
+				}
+				return nil
+			},
+			ReadConfig: func(ctx *cli.Context, cfg *config.Config) error {
+				cfg.TraceTypeOptions = map[config.TraceType]any{testVMTraceType: ctx.String(vmBinFlag.Name)}
+				return nil
+			},
+			New: func(ctx context.Context, deps tracetypes.Deps) (tracetypes.VM, error) {
+				return nil, errors.New("not supported")
+			},
+		})
+	})
+
+	t.Run("RequiresTraceTypeFlags", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag test-vm-bin is required", addRequiredArgs(testVMTraceType))
+	})
+
+	t.Run("RequiresRollupRpc", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag rollup-rpc is required", addRequiredArgsExcept(testVMTraceType, "--rollup-rpc", "--test-vm-bin=./vm"))
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(testVMTraceType, "--test-vm-bin=./vm"))
+		require.Equal(t, []config.TraceType{testVMTraceType}, cfg.TraceTypes)
+		require.Equal(t, "./vm", cfg.TraceTypeOptions[testVMTraceType])
+	})
+}
+
+func TestPolicy(t *testing.T) {
+	t.Run("DefaultsToNoLimits", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.PolicyMaxMovesPerGame)
+		require.Nil(t, cfg.PolicyMaxBond)
+		require.Nil(t, cfg.PolicyMaxGasCost)
+		require.Equal(t, config.DefaultPolicyWindow, cfg.PolicyWindow)
+		require.False(t, cfg.PolicyDisputedRootsOnly)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet,
+			"--policy-max-moves-per-game=20",
+			"--policy-max-bond=1.5",
+			"--policy-max-gas-cost=0.25",
+			"--policy-window=30m",
+			"--policy-disputed-roots-only"))
+		require.Equal(t, uint(20), cfg.PolicyMaxMovesPerGame)
+		require.Equal(t, big.NewInt(1_500_000_000_000_000_000), cfg.PolicyMaxBond)
+		require.Equal(t, big.NewInt(250_000_000_000_000_000), cfg.PolicyMaxGasCost)
+		require.Equal(t, 30*time.Minute, cfg.PolicyWindow)
+		require.True(t, cfg.PolicyDisputedRootsOnly)
+	})
+
+	t.Run("NegativeBond", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid policy-max-bond: -1", addRequiredArgs(config.TraceTypeAlphabet, "--policy-max-bond=-1"))
+	})
+
+	t.Run("NegativeGasCost", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid policy-max-gas-cost: -1", addRequiredArgs(config.TraceTypeAlphabet, "--policy-max-gas-cost=-1"))
+	})
+}
+
 func TestGameFactoryAddress(t *testing.T) {
 	t.Run("Required", func(t *testing.T) {
 		verifyArgsInvalid(t, "flag game-factory-address is required", addRequiredArgsExcept(config.TraceTypeAlphabet, "--game-factory-address"))
@@ -236,6 +356,28 @@ func TestCannonServer(t *testing.T) {
 	})
 }
 
+func TestCannonInProcess(t *testing.T) {
+	t.Run("DefaultsToFalse", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon))
+		require.False(t, cfg.CannonInProcess)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon, "--cannon-in-process"))
+		require.True(t, cfg.CannonInProcess)
+	})
+
+	t.Run("BinAndServerNotRequired", func(t *testing.T) {
+		args := requiredArgs(config.TraceTypeCannon)
+		delete(args, "--cannon-bin")
+		delete(args, "--cannon-server")
+		cfg := configForArgs(t, append(toArgList(args), "--cannon-in-process"))
+		require.True(t, cfg.CannonInProcess)
+		require.Empty(t, cfg.CannonBin)
+		require.Empty(t, cfg.CannonServer)
+	})
+}
+
 func TestCannonAbsolutePrestate(t *testing.T) {
 	t.Run("NotRequiredForAlphabetTrace", func(t *testing.T) {
 		configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, "--cannon-prestate"))
@@ -330,6 +472,18 @@ func TestCannonInfoFreq(t *testing.T) {
 	})
 }
 
+func TestLargePreimages(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.LargePreimages)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--large-preimages"))
+		require.True(t, cfg.LargePreimages)
+	})
+}
+
 func TestGameWindow(t *testing.T) {
 	t.Run("UsesDefault", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
@@ -457,7 +611,7 @@ func requiredArgs(traceType config.TraceType) map[string]string {
 	switch traceType {
 	case config.TraceTypeCannon:
 		addRequiredCannonArgs(args)
-	case config.TraceTypeAlphabet:
+	default:
 		addRequiredOutputArgs(args)
 	}
 	return args
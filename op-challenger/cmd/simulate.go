@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/simulator"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	SimulateTraceTypeFlag = &cli.StringFlag{
+		Name:    "trace-type",
+		Usage:   "The trace type to play the game with. Valid options: " + openum.EnumString(simulator.TraceTypes),
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "SIMULATE_TRACE_TYPE"),
+		Value:   simulator.TraceTypeAlphabet.String(),
+	}
+	SimulateMaxDepthFlag = &cli.Uint64Flag{
+		Name:    "max-depth",
+		Usage:   "Maximum depth of the game.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "SIMULATE_MAX_DEPTH"),
+		Value:   4,
+	}
+	SimulateSplitDepthFlag = &cli.Uint64Flag{
+		Name:    "split-depth",
+		Usage:   "Depth of the output root game leaves. Only used by the output-alphabet trace type.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "SIMULATE_SPLIT_DEPTH"),
+		Value:   2,
+	}
+	SimulateCorrectRootFlag = &cli.BoolFlag{
+		Name:    "correct-root",
+		Usage:   "Propose the root claim from the honest trace instead of the dishonest trace.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "SIMULATE_CORRECT_ROOT"),
+	}
+	SimulateDivergeAtFlag = &cli.Uint64Flag{
+		Name: "diverge-at",
+		Usage: "First trace index where the dishonest trace differs from the honest trace. " +
+			"For output-alphabet games this is an output root trace index. Defaults to the middle of the trace.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "SIMULATE_DIVERGE_AT"),
+	}
+	SimulateActorsFlag = &cli.StringSliceFlag{
+		Name:    "actor",
+		Usage:   "Actors that play the game, in the order they act each round. May be repeated. Valid options: " + openum.EnumString(simulator.ActorTypes),
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "SIMULATE_ACTORS"),
+		Value:   cli.NewStringSlice(simulator.ActorHonest.String(), simulator.ActorDishonest.String()),
+	}
+	SimulateSeedFlag = &cli.Int64Flag{
+		Name:    "seed",
+		Usage:   "Seed for random actors. A time based seed is used if 0.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "SIMULATE_SEED"),
+	}
+	SimulateRandomMovesFlag = &cli.IntFlag{
+		Name:    "random-moves",
+		Usage:   "Number of actions each random actor performs.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "SIMULATE_RANDOM_MOVES"),
+		Value:   10,
+	}
+	SimulateMaxRoundsFlag = &cli.IntFlag{
+		Name:    "max-rounds",
+		Usage:   "Maximum number of rounds to play before resolving the game.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "SIMULATE_MAX_ROUNDS"),
+		Value:   1000,
+	}
+)
+
+var ErrUnexpectedResolution = errors.New("game did not resolve in favour of the honest actor")
+
+func Simulate(ctx *cli.Context) error {
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := simulator.Config{
+		TraceType:   simulator.TraceType(ctx.String(SimulateTraceTypeFlag.Name)),
+		MaxDepth:    ctx.Uint64(SimulateMaxDepthFlag.Name),
+		SplitDepth:  ctx.Uint64(SimulateSplitDepthFlag.Name),
+		CorrectRoot: ctx.Bool(SimulateCorrectRootFlag.Name),
+		DivergeAt:   ctx.Uint64(SimulateDivergeAtFlag.Name),
+		Seed:        ctx.Int64(SimulateSeedFlag.Name),
+		RandomMoves: ctx.Int(SimulateRandomMovesFlag.Name),
+		MaxRounds:   ctx.Int(SimulateMaxRoundsFlag.Name),
+	}
+	for _, actor := range ctx.StringSlice(SimulateActorsFlag.Name) {
+		cfg.Actors = append(cfg.Actors, simulator.ActorType(actor))
+	}
+	if !ctx.IsSet(SimulateDivergeAtFlag.Name) {
+		cfg.DivergeAt = cfg.TraceLength() / 2
+	}
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+	logger.Info("Simulating game", "traceType", cfg.TraceType, "maxDepth", cfg.MaxDepth, "divergeAt", cfg.DivergeAt, "seed", cfg.Seed)
+	result, err := simulator.Simulate(ctx.Context, logger, cfg)
+	if err != nil {
+		return err
+	}
+	splitDepth := uint64(math.MaxUint64)
+	if cfg.TraceType == simulator.TraceTypeOutputAlphabet {
+		splitDepth = cfg.SplitDepth
+	}
+	if err := printSimulation(ctx.App.Writer, result, splitDepth); err != nil {
+		return err
+	}
+	for _, actor := range cfg.Actors {
+		if actor == simulator.ActorHonest && result.Status != result.ExpectedStatus {
+			return ErrUnexpectedResolution
+		}
+	}
+	return nil
+}
+
+func formatAction(action types.Action) string {
+	move := "Defend"
+	if action.IsAttack {
+		move = "Attack"
+	}
+	if action.Type == types.ActionTypeStep {
+		return "Step " + move
+	}
+	return move
+}
+
+func printSimulation(out io.Writer, result *simulator.Result, splitDepth uint64) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Round\tActor\tAction\tParent\tClaim\tResult")
+	for _, e := range result.Events {
+		claim := "-"
+		if e.ClaimIdx >= 0 {
+			claim = fmt.Sprintf("%v %v", e.ClaimIdx, e.Action.Value)
+		}
+		status := "Applied"
+		if e.Err != nil {
+			status = "Rejected: " + e.Err.Error()
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n", e.Round, e.Actor, formatAction(e.Action), e.Action.ParentIdx, claim, status)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	agreement := func(_ context.Context, claim types.Claim) (string, error) {
+		return formatAgreement(result.Agree[claim.ContractIndex]), nil
+	}
+	if err := printClaims(context.Background(), out, result.Claims, splitDepth, agreement); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	if !result.Completed {
+		fmt.Fprintf(out, "Stopped after %v rounds with moves remaining\n", result.Rounds)
+	}
+	fmt.Fprintf(out, "Resolution: %v (expected %v)\n", result.Status, result.ExpectedStatus)
+	violations := result.Violations()
+	if len(violations) == 0 {
+		fmt.Fprintln(out, "Rule violations: none")
+		return nil
+	}
+	fmt.Fprintln(out, "Rule violations:")
+	for _, e := range violations {
+		fmt.Fprintf(out, "  round %v %v %v against claim %v: %v\n", e.Round, e.Actor, formatAction(e.Action), e.Action.ParentIdx,
+			strings.ReplaceAll(e.Violation.Error(), "\n", "; "))
+	}
+	return nil
+}
+
+func simulateFlags() []cli.Flag {
+	return append([]cli.Flag{
+		SimulateTraceTypeFlag,
+		SimulateMaxDepthFlag,
+		SimulateSplitDepthFlag,
+		SimulateCorrectRootFlag,
+		SimulateDivergeAtFlag,
+		SimulateActorsFlag,
+		SimulateSeedFlag,
+		SimulateRandomMovesFlag,
+		SimulateMaxRoundsFlag,
+	}, oplog.CLIFlags(flags.EnvVarPrefix)...)
+}
+
+var SimulateCommand = &cli.Command{
+	Name:  "simulate",
+	Usage: "Play a fault dispute game offline between honest and dishonest actors",
+	Description: "Plays a complete fault dispute game in memory using alphabet or output-alphabet traces, without any chain. " +
+		"Each round every actor calculates and performs its next actions, until no actor makes further progress. " +
+		"The move sequence, the final claims, the resolution and any actions that violate the solver rules are printed.",
+	Action: Simulate,
+	Flags:  simulateFlags(),
+}
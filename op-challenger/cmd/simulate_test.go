@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/simulator"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestPrintSimulation(t *testing.T) {
+	root := types.Claim{ClaimData: types.ClaimData{Value: common.Hash{0xaa}, Position: types.NewPositionFromGIndex(big.NewInt(1))}, Countered: true}
+	attack := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0xbb}, Position: root.Position.Attack()},
+		ContractIndex:       1,
+		ParentContractIndex: 0,
+	}
+	result := &simulator.Result{
+		Events: []simulator.Event{
+			{Round: 1, Actor: "honest-0", Action: types.Action{Type: types.ActionTypeMove, IsAttack: true, Value: attack.Value}, ClaimIdx: 1},
+			{Round: 1, Actor: "random-1", Action: types.Action{Type: types.ActionTypeStep, ParentIdx: 1}, ClaimIdx: -1,
+				Violation: errors.New("parent (1) not at max depth"), Err: simulator.ErrInvalidParent},
+		},
+		Claims:         []types.Claim{root, attack},
+		Agree:          []bool{false, true},
+		Status:         gameTypes.GameStatusChallengerWon,
+		ExpectedStatus: gameTypes.GameStatusChallengerWon,
+		Completed:      true,
+	}
+	var out bytes.Buffer
+	require.NoError(t, printSimulation(&out, result, 1))
+	sections := strings.Split(strings.TrimSpace(out.String()), "\n\n")
+	require.Len(t, sections, 3)
+
+	moves := strings.Split(sections[0], "\n")
+	require.Len(t, moves, 3)
+	require.Equal(t, []string{"1", "honest-0", "Attack", "0", "1", common.Hash{0xbb}.String(), "Applied"}, strings.Fields(moves[1]))
+	require.Equal(t, []string{"1", "random-1", "Step", "Defend", "1", "-", "Rejected:"}, strings.Fields(moves[2])[:7])
+
+	claims := strings.Split(sections[1], "\n")
+	require.Len(t, claims, 3)
+	require.Contains(t, claims[1], "Disagree")
+	require.Contains(t, claims[2], "Agree")
+
+	require.Equal(t, "Resolution: Challenger Won (expected Challenger Won)\n"+
+		"Rule violations:\n"+
+		"  round 1 random-1 Step Defend against claim 1: parent (1) not at max depth", sections[2])
+}
+
+func TestSimulateCommand(t *testing.T) {
+	t.Run("HonestWins", func(t *testing.T) {
+		out, err := runSimulate("--trace-type", "output-alphabet", "--max-depth", "6", "--split-depth", "2", "--correct-root")
+		require.NoError(t, err)
+		require.Contains(t, out, "Resolution: Defender Won (expected Defender Won)")
+		require.Contains(t, out, "Rule violations: none")
+	})
+
+	t.Run("HonestAbsent", func(t *testing.T) {
+		out, err := runSimulate("--actor", "dishonest", "--correct-root")
+		require.NoError(t, err)
+		require.Contains(t, out, "Resolution: Challenger Won (expected Defender Won)")
+	})
+
+	t.Run("InvalidActor", func(t *testing.T) {
+		_, err := runSimulate("--actor", "lazy")
+		require.ErrorIs(t, err, simulator.ErrInvalidActor)
+	})
+}
+
+func runSimulate(args ...string) (string, error) {
+	var out bytes.Buffer
+	app := cli.NewApp()
+	app.Writer = &out
+	app.Commands = []*cli.Command{SimulateCommand}
+	err := app.RunContext(context.Background(), append([]string{"op-challenger", "simulate", "--log.level", "error"}, args...))
+	return out.String(), err
+}
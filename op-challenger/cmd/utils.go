@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+)
+
+// dialL1FromCLI connects to the L1 node specified by the l1-eth-rpc flag.
+func dialL1FromCLI(ctx *cli.Context, logger log.Logger) (*ethclient.Client, *batching.MultiCaller, error) {
+	rpcUrl := ctx.String(flags.L1EthRpcFlag.Name)
+	if rpcUrl == "" {
+		return nil, nil, fmt.Errorf("missing %v", flags.L1EthRpcFlag.Name)
+	}
+	l1Client, err := dial.DialEthClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, logger, rpcUrl)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to dial L1: %w", err)
+	}
+	return l1Client, batching.NewMultiCaller(l1Client.Client(), batching.DefaultBatchSize), nil
+}
+
+// addressFromCLI parses the required address flag.
+func addressFromCLI(ctx *cli.Context, flag *cli.StringFlag) (common.Address, error) {
+	value := ctx.String(flag.Name)
+	if value == "" {
+		return common.Address{}, fmt.Errorf("missing %v", flag.Name)
+	}
+	addr, err := opservice.ParseAddress(value)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid %v: %w", flag.Name, err)
+	}
+	return addr, nil
+}
+
+// latestBlockHash returns the hash of the current L1 head so all data is loaded from a consistent block.
+func latestBlockHash(ctx context.Context, l1Client *ethclient.Client) (common.Hash, error) {
+	head, err := l1Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to retrieve current L1 head: %w", err)
+	}
+	return head.Hash(), nil
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"runtime"
 	"slices"
 	"time"
@@ -33,8 +34,48 @@ var (
 	ErrCannonNetworkAndL2Genesis     = errors.New("only specify one of network or l2 genesis path")
 	ErrCannonNetworkUnknown          = errors.New("unknown cannon network")
 	ErrMissingRollupRpc              = errors.New("missing rollup rpc url")
+	ErrInvalidMode                   = errors.New("invalid mode")
+	ErrMissingPolicyWindow           = errors.New("missing policy window for bond or gas cost limit")
 )
 
+type Mode string
+
+const (
+	// ModeActor plays games by posting claims and steps.
+	ModeActor Mode = "actor"
+	// ModeMonitor evaluates games and reports those resolving incorrectly, without sending any transactions.
+	ModeMonitor Mode = "monitor"
+)
+
+var Modes = []Mode{ModeActor, ModeMonitor}
+
+func (m Mode) String() string {
+	return string(m)
+}
+
+// Set implements the Set method required by the [cli.Generic] interface.
+func (m *Mode) Set(value string) error {
+	if !ValidMode(Mode(value)) {
+		return fmt.Errorf("unknown mode: %q", value)
+	}
+	*m = Mode(value)
+	return nil
+}
+
+func (m *Mode) Clone() any {
+	cpy := *m
+	return &cpy
+}
+
+func ValidMode(value Mode) bool {
+	for _, m := range Modes {
+		if m == value {
+			return true
+		}
+	}
+	return false
+}
+
 type TraceType string
 
 const (
@@ -83,6 +124,25 @@ func ValidTraceType(value TraceType) bool {
 	return false
 }
 
+// traceTypeChecks are the config checks for trace types added by RegisterTraceType.
+var traceTypeChecks = make(map[TraceType]func(c Config) error)
+
+// RegisterTraceType adds a trace type, played with games of gameType, to the set of valid trace types.
+// check is called to validate the config when the trace type is enabled and may be nil.
+// Panics if the trace type is already known, since this indicates a significant programmer error.
+func RegisterTraceType(traceType TraceType, gameType uint8, check func(c Config) error) {
+	if traceType == "" || ValidTraceType(traceType) {
+		panic(fmt.Errorf("invalid or duplicate trace type: %q", traceType))
+	}
+	TraceTypes = append(TraceTypes, traceType)
+	if _, ok := GameIdToString[gameType]; !ok {
+		GameIdToString[gameType] = traceType.String()
+	}
+	if check != nil {
+		traceTypeChecks[traceType] = check
+	}
+}
+
 const (
 	DefaultPollInterval       = time.Second * 12
 	DefaultCannonSnapshotFreq = uint(1_000_000_000)
@@ -92,12 +152,18 @@ const (
 	// The default value is 11 days, which is a 4 day resolution buffer
 	// plus the 7 day game finalization window.
 	DefaultGameWindow = time.Duration(11 * 24 * time.Hour)
+	// DefaultMonitorExpiryWarning is the default time before a game's resolution deadline at which
+	// monitor mode alerts if the game is resolving incorrectly.
+	DefaultMonitorExpiryWarning = time.Duration(24 * time.Hour)
+	// DefaultPolicyWindow is the default period over which the bond and gas cost limits apply.
+	DefaultPolicyWindow = time.Hour
 )
 
 // Config is a well typed config that is parsed from the CLI params.
 // This also contains config options for auxiliary services.
 // It is used to initialize the challenger.
 type Config struct {
+	Mode               Mode             // Whether to play games or only monitor them. Defaults to actor if unset.
 	L1EthRpc           string           // L1 RPC Url
 	GameFactoryAddress common.Address   // Address of the dispute game factory
 	GameAllowlist      []common.Address // Allowlist of fault game addresses
@@ -108,12 +174,25 @@ type Config struct {
 
 	TraceTypes []TraceType // Type of traces supported
 
+	// Limits on the actions taken when playing games
+	PolicyMaxMovesPerGame   uint          // Maximum number of moves to make in a single game. 0 for no limit
+	PolicyMaxBond           *big.Int      // Maximum total bond value in wei to post within PolicyWindow. nil for no limit
+	PolicyMaxGasCost        *big.Int      // Maximum total gas cost in wei to spend within PolicyWindow. nil for no limit
+	PolicyWindow            time.Duration // Period over which the bond and gas cost limits apply
+	PolicyDisputedRootsOnly bool          // Only play games with a root claim the challenger disagrees with
+
+	LargePreimages bool // Upload large preimages via large preimage proposals and challenge invalid proposals
+
+	// Specific to monitor mode
+	MonitorExpiryWarning time.Duration // Time before a game's resolution deadline to alert if it is resolving incorrectly
+
 	// Specific to the output cannon trace type
 	RollupRpc string
 
 	// Specific to the cannon trace provider
 	CannonBin              string // Path to the cannon executable to run when generating trace data
 	CannonServer           string // Path to the op-program executable that provides the pre-image oracle server
+	CannonInProcess        bool   // Execute cannon and the pre-image oracle server in-process instead of as executables
 	CannonAbsolutePreState string // File to load the absolute pre-state for Cannon traces from
 	CannonNetwork          string
 	CannonRollupConfigPath string
@@ -122,6 +201,9 @@ type Config struct {
 	CannonSnapshotFreq     uint   // Frequency of snapshots to create when executing cannon (in VM instructions)
 	CannonInfoFreq         uint   // Frequency of cannon progress log messages (in VM instructions)
 
+	// Specific to trace types added by RegisterTraceType, keyed by trace type
+	TraceTypeOptions map[TraceType]any
+
 	TxMgrConfig   txmgr.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
@@ -134,6 +216,7 @@ func NewConfig(
 	supportedTraceTypes ...TraceType,
 ) Config {
 	return Config{
+		Mode:               ModeActor,
 		L1EthRpc:           l1EthRpc,
 		GameFactoryAddress: gameFactoryAddress,
 		MaxConcurrency:     uint(runtime.NumCPU()),
@@ -150,6 +233,10 @@ func NewConfig(
 		CannonSnapshotFreq: DefaultCannonSnapshotFreq,
 		CannonInfoFreq:     DefaultCannonInfoFreq,
 		GameWindow:         DefaultGameWindow,
+
+		PolicyWindow: DefaultPolicyWindow,
+
+		MonitorExpiryWarning: DefaultMonitorExpiryWarning,
 	}
 }
 
@@ -158,6 +245,9 @@ func (c Config) TraceTypeEnabled(t TraceType) bool {
 }
 
 func (c Config) Check() error {
+	if c.Mode != "" && !ValidMode(c.Mode) {
+		return fmt.Errorf("%w: %q", ErrInvalidMode, c.Mode)
+	}
 	if c.L1EthRpc == "" {
 		return ErrMissingL1EthRPC
 	}
@@ -167,21 +257,36 @@ func (c Config) Check() error {
 	if c.GameFactoryAddress == (common.Address{}) {
 		return ErrMissingGameFactoryAddress
 	}
+	if c.MaxConcurrency == 0 {
+		return ErrMaxConcurrencyZero
+	}
+	if c.Mode == ModeMonitor {
+		// Monitoring doesn't play games, so trace providers and transaction signing aren't required.
+		if c.RollupRpc == "" {
+			return ErrMissingRollupRpc
+		}
+		if err := c.MetricsConfig.Check(); err != nil {
+			return err
+		}
+		return c.PprofConfig.Check()
+	}
 	if len(c.TraceTypes) == 0 {
 		return ErrMissingTraceType
 	}
 	if c.Datadir == "" {
 		return ErrMissingDatadir
 	}
-	if c.MaxConcurrency == 0 {
-		return ErrMaxConcurrencyZero
+	if (c.PolicyMaxBond != nil || c.PolicyMaxGasCost != nil) && c.PolicyWindow <= 0 {
+		return ErrMissingPolicyWindow
 	}
 	if c.TraceTypeEnabled(TraceTypeCannon) {
-		if c.CannonBin == "" {
-			return ErrMissingCannonBin
-		}
-		if c.CannonServer == "" {
-			return ErrMissingCannonServer
+		if !c.CannonInProcess {
+			if c.CannonBin == "" {
+				return ErrMissingCannonBin
+			}
+			if c.CannonServer == "" {
+				return ErrMissingCannonServer
+			}
 		}
 		if c.CannonNetwork == "" {
 			if c.CannonRollupConfigPath == "" {
@@ -214,6 +319,13 @@ func (c Config) Check() error {
 			return ErrMissingCannonInfoFreq
 		}
 	}
+	for _, traceType := range c.TraceTypes {
+		if check, ok := traceTypeChecks[traceType]; ok {
+			if err := check(c); err != nil {
+				return fmt.Errorf("invalid %v config: %w", traceType, err)
+			}
+		}
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"errors"
+	"math/big"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
@@ -84,6 +87,14 @@ func TestCannonServerRequired(t *testing.T) {
 	require.ErrorIs(t, config.Check(), ErrMissingCannonServer)
 }
 
+func TestCannonBinAndServerNotRequiredInProcess(t *testing.T) {
+	config := validConfig(TraceTypeCannon)
+	config.CannonInProcess = true
+	config.CannonBin = ""
+	config.CannonServer = ""
+	require.NoError(t, config.Check())
+}
+
 func TestCannonAbsolutePreStateRequired(t *testing.T) {
 	config := validConfig(TraceTypeCannon)
 	config.CannonAbsolutePreState = ""
@@ -204,3 +215,89 @@ func TestRequireConfigForMultipleTraceTypes(t *testing.T) {
 	cfg.RollupRpc = ""
 	require.ErrorIs(t, cfg.Check(), ErrMissingRollupRpc)
 }
+
+func TestModeMustBeValid(t *testing.T) {
+	cfg := validConfig(TraceTypeAlphabet)
+	require.Equal(t, ModeActor, cfg.Mode)
+	cfg.Mode = "spectator"
+	require.ErrorIs(t, cfg.Check(), ErrInvalidMode)
+}
+
+func TestMonitorMode(t *testing.T) {
+	monitorConfig := func() Config {
+		cfg := NewConfig(validGameFactoryAddress, validL1EthRpc, "")
+		cfg.Mode = ModeMonitor
+		cfg.RollupRpc = validRollupRpc
+		cfg.TxMgrConfig = txmgr.CLIConfig{}
+		return cfg
+	}
+
+	t.Run("TraceTypeDatadirAndTxMgrNotRequired", func(t *testing.T) {
+		require.NoError(t, monitorConfig().Check())
+	})
+
+	t.Run("RollupRpcRequired", func(t *testing.T) {
+		cfg := monitorConfig()
+		cfg.RollupRpc = ""
+		require.ErrorIs(t, cfg.Check(), ErrMissingRollupRpc)
+	})
+
+	t.Run("L1EthRpcRequired", func(t *testing.T) {
+		cfg := monitorConfig()
+		cfg.L1EthRpc = ""
+		require.ErrorIs(t, cfg.Check(), ErrMissingL1EthRPC)
+	})
+
+	t.Run("DefaultExpiryWarning", func(t *testing.T) {
+		require.Equal(t, DefaultMonitorExpiryWarning, monitorConfig().MonitorExpiryWarning)
+	})
+}
+
+func TestRegisterTraceType(t *testing.T) {
+	const traceType TraceType = "test-vm"
+	const gameType = uint8(200)
+	errMissingOptions := errors.New("missing test-vm options")
+	origTraceTypes := TraceTypes
+	t.Cleanup(func() {
+		TraceTypes = origTraceTypes
+		delete(traceTypeChecks, traceType)
+		delete(GameIdToString, gameType)
+	})
+	RegisterTraceType(traceType, gameType, func(c Config) error {
+		if c.TraceTypeOptions[traceType] == nil {
+			return errMissingOptions
+		}
+		return nil
+	})
+
+	require.True(t, ValidTraceType(traceType))
+	require.Equal(t, "test-vm", GameIdToString[gameType])
+
+	cfg := validConfig(traceType)
+	require.ErrorIs(t, cfg.Check(), errMissingOptions)
+	cfg.TraceTypeOptions = map[TraceType]any{traceType: struct{}{}}
+	require.NoError(t, cfg.Check())
+
+	// Checks are only run for enabled trace types
+	cfg = validConfig(TraceTypeAlphabet)
+	require.NoError(t, cfg.Check())
+
+	require.Panics(t, func() { RegisterTraceType(traceType, gameType+1, nil) })
+	require.Panics(t, func() { RegisterTraceType(TraceTypeCannon, gameType+1, nil) })
+}
+
+func TestPolicyWindowRequiredForSpendLimits(t *testing.T) {
+	cfg := validConfig(TraceTypeAlphabet)
+	cfg.PolicyWindow = 0
+	require.NoError(t, cfg.Check(), "window not required without spend limits")
+
+	cfg.PolicyMaxBond = big.NewInt(1)
+	require.ErrorIs(t, cfg.Check(), ErrMissingPolicyWindow)
+	cfg.PolicyMaxBond = nil
+
+	cfg.PolicyMaxGasCost = big.NewInt(1)
+	require.ErrorIs(t, cfg.Check(), ErrMissingPolicyWindow)
+
+	cfg.PolicyWindow = time.Minute
+	require.NoError(t, cfg.Check())
+}
@@ -2,14 +2,18 @@ package flags
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"runtime"
 	"slices"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/tracetypes"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
@@ -20,11 +24,11 @@ import (
 )
 
 const (
-	envVarPrefix = "OP_CHALLENGER"
+	EnvVarPrefix = "OP_CHALLENGER"
 )
 
 func prefixEnvVars(name string) []string {
-	return opservice.PrefixEnvVar(envVarPrefix, name)
+	return opservice.PrefixEnvVar(EnvVarPrefix, name)
 }
 
 var (
@@ -56,6 +60,22 @@ var (
 		EnvVars: prefixEnvVars("DATADIR"),
 	}
 	// Optional Flags
+	ModeFlag = &cli.GenericFlag{
+		Name: "mode",
+		Usage: "Whether to play games as an actor or only monitor them without sending transactions. Valid options: " +
+			openum.EnumString(config.Modes),
+		EnvVars: prefixEnvVars("MODE"),
+		Value: func() *config.Mode {
+			out := config.ModeActor
+			return &out
+		}(),
+	}
+	MonitorExpiryWarningFlag = &cli.DurationFlag{
+		Name:    "monitor-expiry-warning",
+		Usage:   "Time before a game's resolution deadline to alert if it is resolving incorrectly (monitor mode only)",
+		EnvVars: prefixEnvVars("MONITOR_EXPIRY_WARNING"),
+		Value:   config.DefaultMonitorExpiryWarning,
+	}
 	MaxConcurrencyFlag = &cli.UintFlag{
 		Name:    "max-concurrency",
 		Usage:   "Maximum number of threads to use when progressing games",
@@ -101,6 +121,11 @@ var (
 		Usage:   "Path to executable to use as pre-image oracle server when generating trace data (cannon trace type only)",
 		EnvVars: prefixEnvVars("CANNON_SERVER"),
 	}
+	CannonInProcessFlag = &cli.BoolFlag{
+		Name:    "cannon-in-process",
+		Usage:   "Execute cannon and the pre-image oracle server within the challenger process instead of as separate executables (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_IN_PROCESS"),
+	}
 	CannonPreStateFlag = &cli.StringFlag{
 		Name:    "cannon-prestate",
 		Usage:   "Path to absolute prestate to use when generating trace data (cannon trace type only)",
@@ -123,6 +148,37 @@ var (
 		EnvVars: prefixEnvVars("CANNON_INFO_FREQ"),
 		Value:   config.DefaultCannonInfoFreq,
 	}
+	PolicyMaxMovesPerGameFlag = &cli.UintFlag{
+		Name:    "policy-max-moves-per-game",
+		Usage:   "Maximum number of moves to make in a single game. 0 for no limit.",
+		EnvVars: prefixEnvVars("POLICY_MAX_MOVES_PER_GAME"),
+	}
+	PolicyMaxBondFlag = &cli.Float64Flag{
+		Name:    "policy-max-bond",
+		Usage:   "Maximum total value of bonds, in ETH, to post across all games within the policy window. 0 for no limit.",
+		EnvVars: prefixEnvVars("POLICY_MAX_BOND"),
+	}
+	PolicyMaxGasCostFlag = &cli.Float64Flag{
+		Name:    "policy-max-gas-cost",
+		Usage:   "Maximum total gas cost, in ETH, to spend playing games within the policy window. 0 for no limit.",
+		EnvVars: prefixEnvVars("POLICY_MAX_GAS_COST"),
+	}
+	PolicyWindowFlag = &cli.DurationFlag{
+		Name:    "policy-window",
+		Usage:   "Period over which the bond and gas cost limits apply.",
+		EnvVars: prefixEnvVars("POLICY_WINDOW"),
+		Value:   config.DefaultPolicyWindow,
+	}
+	PolicyDisputedRootsOnlyFlag = &cli.BoolFlag{
+		Name:    "policy-disputed-roots-only",
+		Usage:   "Only play games with a root claim the challenger disagrees with.",
+		EnvVars: prefixEnvVars("POLICY_DISPUTED_ROOTS_ONLY"),
+	}
+	LargePreimagesFlag = &cli.BoolFlag{
+		Name:    "large-preimages",
+		Usage:   "Upload large preimages using large preimage proposals and challenge invalid proposals. Requires a PreimageOracle that supports large preimage proposals.",
+		EnvVars: prefixEnvVars("LARGE_PREIMAGES"),
+	}
 	GameWindowFlag = &cli.DurationFlag{
 		Name:    "game-window",
 		Usage:   "The time window which the challenger will look for games to progress.",
@@ -141,6 +197,8 @@ var requiredFlags = []cli.Flag{
 
 // optionalFlags is a list of unchecked cli flags
 var optionalFlags = []cli.Flag{
+	ModeFlag,
+	MonitorExpiryWarningFlag,
 	MaxConcurrencyFlag,
 	HTTPPollInterval,
 	RollupRpcFlag,
@@ -150,18 +208,25 @@ var optionalFlags = []cli.Flag{
 	CannonL2GenesisFlag,
 	CannonBinFlag,
 	CannonServerFlag,
+	CannonInProcessFlag,
 	CannonPreStateFlag,
 	CannonL2Flag,
 	CannonSnapshotFreqFlag,
 	CannonInfoFreqFlag,
 	GameWindowFlag,
+	PolicyMaxMovesPerGameFlag,
+	PolicyMaxBondFlag,
+	PolicyMaxGasCostFlag,
+	PolicyWindowFlag,
+	PolicyDisputedRootsOnlyFlag,
+	LargePreimagesFlag,
 }
 
 func init() {
-	optionalFlags = append(optionalFlags, oplog.CLIFlags(envVarPrefix)...)
-	optionalFlags = append(optionalFlags, txmgr.CLIFlagsWithDefaults(envVarPrefix, txmgr.DefaultChallengerFlagValues)...)
-	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envVarPrefix)...)
-	optionalFlags = append(optionalFlags, oppprof.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, txmgr.CLIFlagsWithDefaults(EnvVarPrefix, txmgr.DefaultChallengerFlagValues)...)
+	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -169,6 +234,11 @@ func init() {
 // Flags contains the list of configuration options available to the binary.
 var Flags []cli.Flag
 
+// AllFlags returns Flags along with the flags of any trace types registered with [tracetypes.Register].
+func AllFlags() []cli.Flag {
+	return append(slices.Clone(Flags), tracetypes.Flags()...)
+}
+
 func CheckCannonFlags(ctx *cli.Context) error {
 	if !ctx.IsSet(CannonNetworkFlag.Name) &&
 		!(ctx.IsSet(CannonRollupConfigFlag.Name) && ctx.IsSet(CannonL2GenesisFlag.Name)) {
@@ -180,11 +250,13 @@ func CheckCannonFlags(ctx *cli.Context) error {
 		return fmt.Errorf("flag %v can not be used with %v and %v",
 			CannonNetworkFlag.Name, CannonRollupConfigFlag.Name, CannonL2GenesisFlag.Name)
 	}
-	if !ctx.IsSet(CannonBinFlag.Name) {
-		return fmt.Errorf("flag %s is required", CannonBinFlag.Name)
-	}
-	if !ctx.IsSet(CannonServerFlag.Name) {
-		return fmt.Errorf("flag %s is required", CannonServerFlag.Name)
+	if !ctx.Bool(CannonInProcessFlag.Name) {
+		if !ctx.IsSet(CannonBinFlag.Name) {
+			return fmt.Errorf("flag %s is required", CannonBinFlag.Name)
+		}
+		if !ctx.IsSet(CannonServerFlag.Name) {
+			return fmt.Errorf("flag %s is required", CannonServerFlag.Name)
+		}
 	}
 	if !ctx.IsSet(CannonPreStateFlag.Name) {
 		return fmt.Errorf("flag %s is required", CannonPreStateFlag.Name)
@@ -195,6 +267,17 @@ func CheckCannonFlags(ctx *cli.Context) error {
 	return nil
 }
 
+// CheckMonitorRequired checks the flags required to monitor games.
+// Only flags needed to load games and check their root claims are required.
+func CheckMonitorRequired(ctx *cli.Context) error {
+	for _, f := range []cli.Flag{L1EthRpcFlag, FactoryAddressFlag, RollupRpcFlag} {
+		if !ctx.IsSet(f.Names()[0]) {
+			return fmt.Errorf("flag %s is required", f.Names()[0])
+		}
+	}
+	return nil
+}
+
 func CheckRequired(ctx *cli.Context, traceTypes []config.TraceType) error {
 	for _, f := range requiredFlags {
 		if !ctx.IsSet(f.Names()[0]) {
@@ -215,7 +298,18 @@ func CheckRequired(ctx *cli.Context, traceTypes []config.TraceType) error {
 				return fmt.Errorf("flag %s is required", RollupRpcFlag.Name)
 			}
 		default:
-			return fmt.Errorf("invalid trace type. must be one of %v", config.TraceTypes)
+			def, ok := tracetypes.Get(traceType)
+			if !ok {
+				return fmt.Errorf("invalid trace type. must be one of %v", config.TraceTypes)
+			}
+			if def.CheckFlags != nil {
+				if err := def.CheckFlags(ctx); err != nil {
+					return err
+				}
+			}
+			if !ctx.IsSet(RollupRpcFlag.Name) {
+				return fmt.Errorf("flag %s is required", RollupRpcFlag.Name)
+			}
 		}
 	}
 	return nil
@@ -235,13 +329,33 @@ func parseTraceTypes(ctx *cli.Context) ([]config.TraceType, error) {
 	return traceTypes, nil
 }
 
+// ethLimit reads a limit specified in ETH from flag, converting it to wei.
+// Returns nil if the limit is 0, indicating there is no limit.
+func ethLimit(ctx *cli.Context, flag *cli.Float64Flag) (*big.Int, error) {
+	value := ctx.Float64(flag.Name)
+	if value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, fmt.Errorf("invalid %v: %v", flag.Name, value)
+	}
+	if value == 0 {
+		return nil, nil
+	}
+	wei, _ := new(big.Float).Mul(big.NewFloat(value), big.NewFloat(params.Ether)).Int(nil)
+	return wei, nil
+}
+
 // NewConfigFromCLI parses the Config from the provided flags or environment variables.
 func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
+	mode := config.Mode(ctx.String(ModeFlag.Name))
 	traceTypes, err := parseTraceTypes(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if err := CheckRequired(ctx, traceTypes); err != nil {
+	if mode == config.ModeMonitor {
+		err = CheckMonitorRequired(ctx)
+	} else {
+		err = CheckRequired(ctx, traceTypes)
+	}
+	if err != nil {
 		return nil, err
 	}
 	gameFactoryAddress, err := opservice.ParseAddress(ctx.String(FactoryAddressFlag.Name))
@@ -267,7 +381,15 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 	if maxConcurrency == 0 {
 		return nil, fmt.Errorf("%v must not be 0", MaxConcurrencyFlag.Name)
 	}
-	return &config.Config{
+	maxBond, err := ethLimit(ctx, PolicyMaxBondFlag)
+	if err != nil {
+		return nil, err
+	}
+	maxGasCost, err := ethLimit(ctx, PolicyMaxGasCostFlag)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &config.Config{
 		// Required Flags
 		L1EthRpc:               ctx.String(L1EthRpcFlag.Name),
 		TraceTypes:             traceTypes,
@@ -282,6 +404,7 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		CannonL2GenesisPath:    ctx.String(CannonL2GenesisFlag.Name),
 		CannonBin:              ctx.String(CannonBinFlag.Name),
 		CannonServer:           ctx.String(CannonServerFlag.Name),
+		CannonInProcess:        ctx.Bool(CannonInProcessFlag.Name),
 		CannonAbsolutePreState: ctx.String(CannonPreStateFlag.Name),
 		Datadir:                ctx.String(DatadirFlag.Name),
 		CannonL2:               ctx.String(CannonL2Flag.Name),
@@ -290,5 +413,23 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		TxMgrConfig:            txMgrConfig,
 		MetricsConfig:          metricsConfig,
 		PprofConfig:            pprofConfig,
-	}, nil
+		Mode:                   mode,
+		MonitorExpiryWarning:   ctx.Duration(MonitorExpiryWarningFlag.Name),
+
+		PolicyMaxMovesPerGame:   ctx.Uint(PolicyMaxMovesPerGameFlag.Name),
+		PolicyMaxBond:           maxBond,
+		PolicyMaxGasCost:        maxGasCost,
+		PolicyWindow:            ctx.Duration(PolicyWindowFlag.Name),
+		PolicyDisputedRootsOnly: ctx.Bool(PolicyDisputedRootsOnlyFlag.Name),
+
+		LargePreimages: ctx.Bool(LargePreimagesFlag.Name),
+	}
+	for _, traceType := range traceTypes {
+		if def, ok := tracetypes.Get(traceType); ok && def.ReadConfig != nil {
+			if err := def.ReadConfig(ctx, cfg); err != nil {
+				return nil, fmt.Errorf("failed to read %v config: %w", traceType, err)
+			}
+		}
+	}
+	return cfg, nil
 }
@@ -24,6 +24,11 @@ type Responder interface {
 	PerformAction(ctx context.Context, action types.Action) error
 }
 
+// ActionPolicy selects which of the actions calculated by the solver are performed, and in what order.
+type ActionPolicy interface {
+	Apply(ctx context.Context, game types.Game, actions []types.Action) []types.Action
+}
+
 type ClaimLoader interface {
 	GetAllClaims(ctx context.Context) ([]types.Claim, error)
 }
@@ -33,16 +38,18 @@ type Agent struct {
 	solver    *solver.GameSolver
 	loader    ClaimLoader
 	responder Responder
+	policy    ActionPolicy
 	maxDepth  int
 	log       log.Logger
 }
 
-func NewAgent(m metrics.Metricer, loader ClaimLoader, maxDepth int, trace types.TraceAccessor, responder Responder, log log.Logger) *Agent {
+func NewAgent(m metrics.Metricer, loader ClaimLoader, maxDepth int, trace types.TraceAccessor, responder Responder, policy ActionPolicy, log log.Logger) *Agent {
 	return &Agent{
 		metrics:   m,
 		solver:    solver.NewGameSolver(maxDepth, trace),
 		loader:    loader,
 		responder: responder,
+		policy:    policy,
 		maxDepth:  maxDepth,
 		log:       log,
 	}
@@ -63,6 +70,7 @@ func (a *Agent) Act(ctx context.Context) error {
 	if err != nil {
 		log.Error("Failed to calculate all required moves", "err", err)
 	}
+	actions = a.policy.Apply(ctx, game, actions)
 
 	// Perform the actions
 	for _, action := range actions {
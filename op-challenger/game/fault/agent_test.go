@@ -70,13 +70,35 @@ func TestLoadClaimsWhenGameNotResolvable(t *testing.T) {
 	require.Zero(t, responder.resolveClaimCount, "should not send resolveClaim")
 }
 
+func TestPerformOnlyActionsAllowedByPolicy(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := 4
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider("abcdefg", uint64(depth)))
+	claimLoader.claims = []types.Claim{
+		claimBuilder.CreateRootClaim(true),
+	}
+	policy := &stubPolicy{}
+	agent.policy = policy
+
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 1, policy.callCount)
+	require.Len(t, policy.proposed, 1, "should propose countering the root")
+	require.Empty(t, responder.actions, "should not perform declined actions")
+
+	policy.allowAll = true
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, policy.proposed, responder.actions)
+}
+
 func setupTestAgent(t *testing.T) (*Agent, *stubClaimLoader, *stubResponder) {
 	logger := testlog.Logger(t, log.LvlInfo)
 	claimLoader := &stubClaimLoader{}
 	depth := 4
 	provider := alphabet.NewTraceProvider("abcd", uint64(depth))
 	responder := &stubResponder{}
-	agent := NewAgent(metrics.NoopMetrics, claimLoader, depth, trace.NewSimpleTraceAccessor(provider), responder, logger)
+	agent := NewAgent(metrics.NoopMetrics, claimLoader, depth, trace.NewSimpleTraceAccessor(provider), responder, &stubPolicy{allowAll: true}, logger)
 	return agent, claimLoader, responder
 }
 
@@ -101,6 +123,8 @@ type stubResponder struct {
 	callResolveClaimCount int
 	callResolveClaimErr   error
 	resolveClaimCount     int
+
+	actions []types.Action
 }
 
 func (s *stubResponder) CallResolve(ctx context.Context) (gameTypes.GameStatus, error) {
@@ -124,5 +148,21 @@ func (s *stubResponder) ResolveClaim(ctx context.Context, clainIdx uint64) error
 }
 
 func (s *stubResponder) PerformAction(ctx context.Context, response types.Action) error {
+	s.actions = append(s.actions, response)
+	return nil
+}
+
+type stubPolicy struct {
+	allowAll  bool
+	callCount int
+	proposed  []types.Action
+}
+
+func (s *stubPolicy) Apply(_ context.Context, _ types.Game, actions []types.Action) []types.Action {
+	s.callCount++
+	s.proposed = actions
+	if s.allowAll {
+		return actions
+	}
 	return nil
 }
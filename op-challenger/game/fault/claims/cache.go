@@ -0,0 +1,331 @@
+package claims
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const cacheFile = "claims.json"
+
+// cacheVersion is the version of the claim cache file format. Caches with a different version are discarded.
+const cacheVersion = 2
+
+var errInconsistentCache = errors.New("cached claims are inconsistent with the contract")
+
+// ClaimContract provides the claims and Move events of a fault dispute game.
+type ClaimContract interface {
+	GetClaimCountAtBlock(ctx context.Context, block batching.Block) (uint64, error)
+	GetClaimsAtBlock(ctx context.Context, block batching.Block, indices []uint64) ([]types.Claim, error)
+	MoveFilter(fromBlock uint64, toBlock uint64) ethereum.FilterQuery
+	DecodeMove(log ethTypes.Log) (contracts.MoveEvent, error)
+}
+
+type L1Source interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethTypes.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error)
+}
+
+// ClaimCache loads the claims of a single game, persisting them in the game's data directory so only claims added
+// since the last load need to be fetched.
+//
+// New claims are found from the game's Move events. The countered flag of cached claims is updated from the parent
+// index of each Move event, and uncountered leaf claims are reloaded each time as they may have been countered by a
+// step, which does not emit an event. All claims are reloaded if the L1 block the cache was built at is no longer
+// canonical, or if the cache can't be updated or the claim count doesn't match the cache.
+type ClaimCache struct {
+	log      log.Logger
+	game     common.Address
+	contract ClaimContract
+	l1       L1Source
+	path     string
+
+	lock   sync.Mutex
+	loaded bool
+	// block is the L1 block the cached claims are up to date with.
+	block  eth.BlockID
+	claims []types.Claim
+}
+
+func NewClaimCache(logger log.Logger, game common.Address, contract ClaimContract, l1 L1Source, dir string) *ClaimCache {
+	return &ClaimCache{
+		log:      logger,
+		game:     game,
+		contract: contract,
+		l1:       l1,
+		path:     filepath.Join(dir, cacheFile),
+	}
+}
+
+// GetAllClaims returns all claims in the game as at the current L1 head.
+func (c *ClaimCache) GetAllClaims(ctx context.Context) ([]types.Claim, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.loaded {
+		c.loadFromDisk()
+		c.loaded = true
+	}
+	headNum, err := c.l1.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load L1 head: %w", err)
+	}
+	head, err := c.blockID(ctx, headNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load L1 head: %w", err)
+	}
+	canonical := false
+	if len(c.claims) > 0 && head.Number >= c.block.Number {
+		canonical, err = c.isCanonical(ctx, head)
+		if err != nil {
+			return nil, err
+		}
+		if !canonical {
+			c.log.Info("Cached claims are not from the canonical L1 chain, reloading all claims", "block", c.block)
+		}
+	}
+	changed := false
+	switch {
+	case !canonical:
+		changed = true
+		err = c.reload(ctx, head)
+	case head.Number > c.block.Number:
+		changed = true
+		if err = c.update(ctx, head); err != nil {
+			// Fall back to reloading all claims, for example if the log range is too large for the L1 node.
+			c.log.Warn("Failed to update cached claims, reloading all claims", "err", err)
+			err = c.reload(ctx, head)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		if err := c.saveToDisk(); err != nil {
+			c.log.Warn("Failed to save claim cache", "path", c.path, "err", err)
+		}
+	}
+	claims := make([]types.Claim, len(c.claims))
+	copy(claims, c.claims)
+	return claims, nil
+}
+
+// isCanonical returns true if the block the cache was built at is part of the canonical chain up to head.
+func (c *ClaimCache) isCanonical(ctx context.Context, head eth.BlockID) (bool, error) {
+	if head.Number == c.block.Number {
+		return head == c.block, nil
+	}
+	block, err := c.blockID(ctx, c.block.Number)
+	if err != nil {
+		return false, fmt.Errorf("failed to load cached L1 block: %w", err)
+	}
+	return block == c.block, nil
+}
+
+func (c *ClaimCache) blockID(ctx context.Context, number uint64) (eth.BlockID, error) {
+	header, err := c.l1.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return eth.BlockID{}, err
+	}
+	return eth.HeaderBlockID(header), nil
+}
+
+func (c *ClaimCache) reload(ctx context.Context, head eth.BlockID) error {
+	block := batching.BlockByHash(head.Hash)
+	count, err := c.contract.GetClaimCountAtBlock(ctx, block)
+	if err != nil {
+		return fmt.Errorf("failed to load claim count: %w", err)
+	}
+	indices := make([]uint64, count)
+	for i := range indices {
+		indices[i] = uint64(i)
+	}
+	claims, err := c.contract.GetClaimsAtBlock(ctx, block, indices)
+	if err != nil {
+		return fmt.Errorf("failed to load claims: %w", err)
+	}
+	c.claims = claims
+	c.block = head
+	return nil
+}
+
+func (c *ClaimCache) update(ctx context.Context, head eth.BlockID) error {
+	logs, err := c.l1.FilterLogs(ctx, c.contract.MoveFilter(c.block.Number+1, head.Number))
+	if err != nil {
+		return fmt.Errorf("failed to load move events: %w", err)
+	}
+	moves := make([]contracts.MoveEvent, 0, len(logs))
+	for _, l := range logs {
+		move, err := c.contract.DecodeMove(l)
+		if err != nil {
+			return fmt.Errorf("failed to decode move event: %w", err)
+		}
+		moves = append(moves, move)
+	}
+
+	block := batching.BlockByHash(head.Hash)
+	count, err := c.contract.GetClaimCountAtBlock(ctx, block)
+	if err != nil {
+		return fmt.Errorf("failed to load claim count: %w", err)
+	}
+	if count != uint64(len(c.claims)+len(moves)) {
+		return fmt.Errorf("%w: expected %v claims but found %v", errInconsistentCache, len(c.claims)+len(moves), count)
+	}
+
+	hasChildren := make([]bool, len(c.claims))
+	for _, claim := range c.claims {
+		if !claim.IsRoot() {
+			hasChildren[claim.ParentContractIndex] = true
+		}
+	}
+	for _, move := range moves {
+		if move.ParentIndex >= uint64(len(c.claims)+len(moves)) {
+			return fmt.Errorf("%w: move parent %v does not exist", errInconsistentCache, move.ParentIndex)
+		}
+		if move.ParentIndex < uint64(len(c.claims)) {
+			hasChildren[move.ParentIndex] = true
+			c.claims[move.ParentIndex].Countered = true
+		}
+	}
+	var indices []uint64
+	for i, claim := range c.claims {
+		// Leaf claims can be countered by a step without emitting an event.
+		if !hasChildren[i] && !claim.Countered {
+			indices = append(indices, uint64(i))
+		}
+	}
+	for i := uint64(len(c.claims)); i < count; i++ {
+		indices = append(indices, i)
+	}
+	if len(indices) == 0 {
+		c.block = head
+		return nil
+	}
+	loaded, err := c.contract.GetClaimsAtBlock(ctx, block, indices)
+	if err != nil {
+		return fmt.Errorf("failed to load claims: %w", err)
+	}
+	existing := len(c.claims)
+	for _, claim := range loaded {
+		if claim.ContractIndex < existing {
+			c.claims[claim.ContractIndex] = claim
+			continue
+		}
+		move := moves[claim.ContractIndex-existing]
+		if claim.Value != move.Claim || uint64(claim.ParentContractIndex) != move.ParentIndex {
+			return fmt.Errorf("%w: claim %v does not match move event", errInconsistentCache, claim.ContractIndex)
+		}
+		c.claims = append(c.claims, claim)
+	}
+	c.block = head
+	return nil
+}
+
+// diskClaim is the persisted form of a claim. Claims are stored in contract index order.
+type diskClaim struct {
+	Value       common.Hash `json:"value"`
+	Position    *big.Int    `json:"position"`
+	Countered   bool        `json:"countered"`
+	Duration    uint64      `json:"duration"`
+	Timestamp   uint64      `json:"timestamp"`
+	ParentIndex int         `json:"parentIndex"`
+}
+
+type diskCache struct {
+	Version   uint64         `json:"version"`
+	Game      common.Address `json:"game"`
+	Block     uint64         `json:"block"`
+	BlockHash common.Hash    `json:"blockHash"`
+	Claims    []diskClaim    `json:"claims"`
+}
+
+func (c *ClaimCache) loadFromDisk() {
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	} else if err != nil {
+		c.log.Warn("Failed to read claim cache", "path", c.path, "err", err)
+		return
+	}
+	var cache diskCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		c.log.Warn("Ignoring invalid claim cache", "path", c.path, "err", err)
+		return
+	}
+	if cache.Version != cacheVersion {
+		c.log.Warn("Ignoring claim cache with unsupported version", "path", c.path, "version", cache.Version, "expected", cacheVersion)
+		return
+	}
+	if cache.Game != c.game {
+		c.log.Warn("Ignoring claim cache for different game", "path", c.path, "cachedGame", cache.Game)
+		return
+	}
+	claims := make([]types.Claim, len(cache.Claims))
+	for i, claim := range cache.Claims {
+		if claim.Position == nil || (i > 0 && (claim.ParentIndex < 0 || claim.ParentIndex >= i)) {
+			c.log.Warn("Ignoring invalid claim cache", "path", c.path, "claimIdx", i)
+			return
+		}
+		claims[i] = types.Claim{
+			ClaimData: types.ClaimData{
+				Value:    claim.Value,
+				Position: types.NewPositionFromGIndex(claim.Position),
+			},
+			Countered:           claim.Countered,
+			Clock:               types.NewClock(time.Duration(claim.Duration)*time.Second, time.Unix(int64(claim.Timestamp), 0)),
+			ContractIndex:       i,
+			ParentContractIndex: claim.ParentIndex,
+		}
+	}
+	c.block = eth.BlockID{Hash: cache.BlockHash, Number: cache.Block}
+	c.claims = claims
+	c.log.Debug("Loaded cached claims", "block", c.block, "claims", len(claims))
+}
+
+func (c *ClaimCache) saveToDisk() error {
+	cache := diskCache{
+		Version:   cacheVersion,
+		Game:      c.game,
+		Block:     c.block.Number,
+		BlockHash: c.block.Hash,
+		Claims:    make([]diskClaim, len(c.claims)),
+	}
+	for i, claim := range c.claims {
+		cache.Claims[i] = diskClaim{
+			Value:       claim.Value,
+			Position:    claim.Position.ToGIndex(),
+			Countered:   claim.Countered,
+			Duration:    uint64(claim.Clock.Duration.Seconds()),
+			Timestamp:   uint64(claim.Clock.Timestamp.Unix()),
+			ParentIndex: claim.ParentContractIndex,
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	out, err := ioutil.NewAtomicWriterCompressed(c.path, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	if err := json.NewEncoder(out).Encode(cache); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("failed to write claims: %w", err)
+	}
+	return out.Close()
+}
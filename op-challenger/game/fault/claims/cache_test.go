@@ -0,0 +1,328 @@
+package claims
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var gameAddr = common.Address{0xaa}
+
+func TestClaimCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("InitialLoad", func(t *testing.T) {
+		contract, l1, cache := setupCacheTest(t, t.TempDir())
+		contract.addClaim(l1, 0)
+		claims, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		requireClaims(t, contract.claims, claims)
+		require.Equal(t, 2, contract.claimsLoaded)
+	})
+
+	t.Run("OnlyLoadNewClaims", func(t *testing.T) {
+		contract, l1, cache := setupCacheTest(t, t.TempDir())
+		_, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, contract.claimsLoaded)
+
+		contract.addClaim(l1, 0)
+		contract.addClaim(l1, 1)
+		claims, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		requireClaims(t, contract.claims, claims)
+		require.True(t, claims[0].Countered)
+		require.Equal(t, 3, contract.claimsLoaded, "should only load the new claims")
+	})
+
+	t.Run("NoNewBlocks", func(t *testing.T) {
+		contract, _, cache := setupCacheTest(t, t.TempDir())
+		_, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		claims, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		requireClaims(t, contract.claims, claims)
+		require.Equal(t, 1, contract.claimsLoaded)
+		require.Equal(t, 1, contract.countsLoaded)
+	})
+
+	t.Run("ReloadUncounteredLeaves", func(t *testing.T) {
+		contract, l1, cache := setupCacheTest(t, t.TempDir())
+		contract.addClaim(l1, 0)
+		_, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+
+		// Step counters the leaf claim without emitting a Move event
+		contract.claims[1].Countered = true
+		l1.head++
+		claims, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		requireClaims(t, contract.claims, claims)
+		require.Equal(t, 3, contract.claimsLoaded)
+
+		// Countered leaves aren't reloaded
+		l1.head++
+		_, err = cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 3, contract.claimsLoaded)
+	})
+
+	t.Run("PersistAcrossRestarts", func(t *testing.T) {
+		dir := t.TempDir()
+		contract, l1, cache := setupCacheTest(t, dir)
+		contract.addClaim(l1, 0)
+		_, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+
+		contract.addClaim(l1, 1)
+		restarted := NewClaimCache(testlog.Logger(t, log.LvlInfo), gameAddr, contract, l1, dir)
+		claims, err := restarted.GetAllClaims(ctx)
+		require.NoError(t, err)
+		requireClaims(t, contract.claims, claims)
+		require.Equal(t, 3, contract.claimsLoaded)
+	})
+
+	t.Run("IgnoreCacheForOtherGame", func(t *testing.T) {
+		dir := t.TempDir()
+		contract, l1, cache := setupCacheTest(t, dir)
+		_, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+
+		other := NewClaimCache(testlog.Logger(t, log.LvlInfo), common.Address{0xbb}, contract, l1, dir)
+		_, err = other.GetAllClaims(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, contract.claimsLoaded)
+	})
+
+	t.Run("IgnoreCacheWithDifferentVersion", func(t *testing.T) {
+		dir := t.TempDir()
+		// Caches written before the version was added have no version field.
+		data := `{"game":"` + gameAddr.Hex() + `","block":10,"claims":[{"value":"` + common.Hash{0x01}.Hex() + `","position":1,"countered":false,"parentIndex":-1}]}`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, cacheFile), []byte(data), 0644))
+		contract, _, cache := setupCacheTest(t, dir)
+		claims, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		requireClaims(t, contract.claims, claims)
+		require.Equal(t, 1, contract.claimsLoaded, "should reload claims")
+	})
+
+	t.Run("IgnoreInvalidCacheFile", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, cacheFile), []byte("{"), 0644))
+		contract, _, cache := setupCacheTest(t, dir)
+		claims, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		requireClaims(t, contract.claims, claims)
+	})
+
+	t.Run("ReloadWhenClaimCountMismatch", func(t *testing.T) {
+		contract, l1, cache := setupCacheTest(t, t.TempDir())
+		_, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+
+		contract.addClaim(l1, 0)
+		// Simulate a reorg that lost the move event
+		l1.logs = nil
+		claims, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		requireClaims(t, contract.claims, claims)
+		require.Equal(t, 3, contract.claimsLoaded)
+	})
+
+	t.Run("ReloadWhenLogsUnavailable", func(t *testing.T) {
+		contract, l1, cache := setupCacheTest(t, t.TempDir())
+		_, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+
+		contract.addClaim(l1, 0)
+		l1.logsErr = errors.New("block range too large")
+		claims, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		requireClaims(t, contract.claims, claims)
+	})
+
+	t.Run("ReloadWhenMoveDoesNotMatchClaim", func(t *testing.T) {
+		contract, l1, cache := setupCacheTest(t, t.TempDir())
+		_, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+
+		contract.addClaim(l1, 0)
+		l1.logs[0].Topics[2] = common.Hash{0xff}
+		claims, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		requireClaims(t, contract.claims, claims)
+	})
+
+	t.Run("ReloadWhenHeadGoesBackwards", func(t *testing.T) {
+		contract, l1, cache := setupCacheTest(t, t.TempDir())
+		_, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+
+		l1.head--
+		_, err = cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, contract.claimsLoaded)
+	})
+
+	t.Run("ReloadWhenCachedBlockNotCanonical", func(t *testing.T) {
+		contract, l1, cache := setupCacheTest(t, t.TempDir())
+		contract.addClaim(l1, 0)
+		_, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, contract.claimsLoaded)
+
+		// Reorg replaces the cached block while the head moves forward
+		l1.fork++
+		l1.head++
+		claims, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		requireClaims(t, contract.claims, claims)
+		require.Equal(t, 4, contract.claimsLoaded, "should reload all claims")
+	})
+
+	t.Run("ReloadWhenHeadReorgedAtSameHeight", func(t *testing.T) {
+		contract, l1, cache := setupCacheTest(t, t.TempDir())
+		_, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+
+		l1.fork++
+		_, err = cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, contract.claimsLoaded)
+	})
+
+	t.Run("ReloadWhenPersistedBlockNotCanonical", func(t *testing.T) {
+		dir := t.TempDir()
+		contract, l1, cache := setupCacheTest(t, dir)
+		_, err := cache.GetAllClaims(ctx)
+		require.NoError(t, err)
+
+		l1.fork++
+		l1.head++
+		restarted := NewClaimCache(testlog.Logger(t, log.LvlInfo), gameAddr, contract, l1, dir)
+		_, err = restarted.GetAllClaims(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, contract.claimsLoaded)
+	})
+}
+
+// requireClaims compares claims by generalized index as positions don't compare equal after a round trip to disk.
+func requireClaims(t *testing.T, expected []types.Claim, actual []types.Claim) {
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		require.Equal(t, expected[i].Position.ToGIndex(), actual[i].Position.ToGIndex(), "claim %v position", i)
+		exp := expected[i]
+		act := actual[i]
+		exp.Position = types.Position{}
+		act.Position = types.Position{}
+		require.Equal(t, exp, act, "claim %v", i)
+	}
+}
+
+func setupCacheTest(t *testing.T, dir string) (*stubClaimContract, *stubL1Source, *ClaimCache) {
+	contract := &stubClaimContract{
+		claims: []types.Claim{{
+			ClaimData:           types.ClaimData{Value: common.Hash{0x01}, Position: types.NewPositionFromGIndex(big.NewInt(1))},
+			Clock:               types.NewClock(0, time.Unix(100, 0)),
+			ParentContractIndex: math.MaxUint32,
+		}},
+	}
+	l1 := &stubL1Source{head: 10}
+	cache := NewClaimCache(testlog.Logger(t, log.LvlInfo), gameAddr, contract, l1, dir)
+	return contract, l1, cache
+}
+
+type stubClaimContract struct {
+	claims       []types.Claim
+	claimsLoaded int
+	countsLoaded int
+}
+
+// addClaim adds a claim attacking parentIdx in a new L1 block, emitting a Move event.
+func (s *stubClaimContract) addClaim(l1 *stubL1Source, parentIdx int) {
+	parent := s.claims[parentIdx]
+	claim := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{byte(len(s.claims) + 1)}, Position: parent.Position.Attack()},
+		Clock:               types.NewClock(time.Duration(len(s.claims))*time.Second, time.Unix(int64(100+len(s.claims)), 0)),
+		ContractIndex:       len(s.claims),
+		ParentContractIndex: parentIdx,
+	}
+	s.claims = append(s.claims, claim)
+	s.claims[parentIdx].Countered = true
+	l1.head++
+	l1.logs = append(l1.logs, ethTypes.Log{
+		Address:     gameAddr,
+		Topics:      []common.Hash{{0x01}, common.BigToHash(big.NewInt(int64(parentIdx))), claim.Value, {}},
+		BlockNumber: l1.head,
+	})
+}
+
+func (s *stubClaimContract) GetClaimCountAtBlock(_ context.Context, _ batching.Block) (uint64, error) {
+	s.countsLoaded++
+	return uint64(len(s.claims)), nil
+}
+
+func (s *stubClaimContract) GetClaimsAtBlock(_ context.Context, _ batching.Block, indices []uint64) ([]types.Claim, error) {
+	var claims []types.Claim
+	for _, idx := range indices {
+		claims = append(claims, s.claims[idx])
+	}
+	s.claimsLoaded += len(indices)
+	return claims, nil
+}
+
+func (s *stubClaimContract) MoveFilter(fromBlock uint64, toBlock uint64) ethereum.FilterQuery {
+	return ethereum.FilterQuery{FromBlock: new(big.Int).SetUint64(fromBlock), ToBlock: new(big.Int).SetUint64(toBlock)}
+}
+
+func (s *stubClaimContract) DecodeMove(log ethTypes.Log) (contracts.MoveEvent, error) {
+	return contracts.MoveEvent{
+		ParentIndex: log.Topics[1].Big().Uint64(),
+		Claim:       log.Topics[2],
+		BlockNumber: log.BlockNumber,
+	}, nil
+}
+
+type stubL1Source struct {
+	head    uint64
+	logs    []ethTypes.Log
+	logsErr error
+	// fork is included in every block hash so changing it simulates a reorg of all blocks.
+	fork byte
+}
+
+func (s *stubL1Source) BlockNumber(_ context.Context) (uint64, error) {
+	return s.head, nil
+}
+
+func (s *stubL1Source) HeaderByNumber(_ context.Context, number *big.Int) (*ethTypes.Header, error) {
+	return &ethTypes.Header{Number: number, Extra: []byte{s.fork}}, nil
+}
+
+func (s *stubL1Source) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error) {
+	if s.logsErr != nil {
+		return nil, s.logsErr
+	}
+	var logs []ethTypes.Log
+	for _, l := range s.logs {
+		if l.BlockNumber >= q.FromBlock.Uint64() && l.BlockNumber <= q.ToBlock.Uint64() {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
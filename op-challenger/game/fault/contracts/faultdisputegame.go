@@ -3,14 +3,19 @@ package contracts
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/big"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 var (
@@ -32,13 +37,27 @@ var (
 	methodGenesisOutputRoot  = "genesisOutputRoot"
 	methodSplitDepth         = "splitDepth"
 	methodL2BlockNumber      = "l2BlockNumber"
+	methodRootClaim          = "rootClaim"
+
+	eventMove = "Move"
 )
 
 type FaultDisputeGameContract struct {
+	addr        common.Address
+	abi         *abi.ABI
 	multiCaller *batching.MultiCaller
 	contract    *batching.BoundContract
 }
 
+// MoveEvent is emitted by the game each time a claim is added by an attack or defend.
+// Claims are appended in the order their Move events are emitted.
+type MoveEvent struct {
+	ParentIndex uint64
+	Claim       common.Hash
+	Claimant    common.Address
+	BlockNumber uint64
+}
+
 type Proposal struct {
 	L2BlockNumber *big.Int
 	OutputRoot    common.Hash
@@ -51,6 +70,8 @@ func NewFaultDisputeGameContract(addr common.Address, caller *batching.MultiCall
 	}
 
 	return &FaultDisputeGameContract{
+		addr:        addr,
+		abi:         contractAbi,
 		multiCaller: caller,
 		contract:    batching.NewBoundContract(contractAbi, addr),
 	}, nil
@@ -75,6 +96,32 @@ func (c *FaultDisputeGameContract) GetBlockRange(ctx context.Context) (prestateB
 	return
 }
 
+// GetGameMetadata returns the L2 block number, root claim and status of the game.
+func (c *FaultDisputeGameContract) GetGameMetadata(ctx context.Context) (uint64, common.Hash, gameTypes.GameStatus, error) {
+	return c.GetGameMetadataAtBlock(ctx, batching.BlockLatest)
+}
+
+// GetGameMetadataAtBlock returns the L2 block number, root claim and status of the game as at the specified block.
+func (c *FaultDisputeGameContract) GetGameMetadataAtBlock(ctx context.Context, block batching.Block) (uint64, common.Hash, gameTypes.GameStatus, error) {
+	results, err := c.multiCaller.Call(ctx, block,
+		c.contract.Call(methodL2BlockNumber),
+		c.contract.Call(methodRootClaim),
+		c.contract.Call(methodStatus))
+	if err != nil {
+		return 0, common.Hash{}, 0, fmt.Errorf("failed to retrieve game metadata: %w", err)
+	}
+	if len(results) != 3 {
+		return 0, common.Hash{}, 0, fmt.Errorf("expected 3 results but got %v", len(results))
+	}
+	l2BlockNumber := results[0].GetBigInt(0).Uint64()
+	rootClaim := results[1].GetHash(0)
+	status, err := gameTypes.GameStatusFromUint8(results[2].GetUint8(0))
+	if err != nil {
+		return 0, common.Hash{}, 0, fmt.Errorf("failed to convert game status: %w", err)
+	}
+	return l2BlockNumber, rootClaim, status, nil
+}
+
 func (c *FaultDisputeGameContract) GetGenesisOutputRoot(ctx context.Context) (common.Hash, error) {
 	genesisOutputRoot, err := c.multiCaller.SingleCall(ctx, batching.BlockLatest, c.contract.Call(methodGenesisOutputRoot))
 	if err != nil {
@@ -109,16 +156,22 @@ func (f *FaultDisputeGameContract) addLocalDataTx(claimIdx uint64, data *types.P
 }
 
 func (f *FaultDisputeGameContract) addGlobalDataTx(ctx context.Context, data *types.PreimageOracleData) (txmgr.TxCandidate, error) {
-	vm, err := f.vm(ctx)
+	oracle, err := f.GetOracle(ctx)
 	if err != nil {
 		return txmgr.TxCandidate{}, err
 	}
-	oracle, err := vm.Oracle(ctx)
+	return oracle.AddGlobalDataTx(data)
+}
+
+// GetOracle returns the preimage oracle used by the game's VM.
+func (f *FaultDisputeGameContract) GetOracle(ctx context.Context) (*PreimageOracleContract, error) {
+	vm, err := f.vm(ctx)
 	if err != nil {
-		return txmgr.TxCandidate{}, err
+		return nil, err
 	}
-	return oracle.AddGlobalDataTx(data)
+	return vm.Oracle(ctx)
 }
+
 func (f *FaultDisputeGameContract) GetGameDuration(ctx context.Context) (uint64, error) {
 	result, err := f.multiCaller.SingleCall(ctx, batching.BlockLatest, f.contract.Call(methodGameDuration))
 	if err != nil {
@@ -160,7 +213,11 @@ func (f *FaultDisputeGameContract) GetStatus(ctx context.Context) (gameTypes.Gam
 }
 
 func (f *FaultDisputeGameContract) GetClaimCount(ctx context.Context) (uint64, error) {
-	result, err := f.multiCaller.SingleCall(ctx, batching.BlockLatest, f.contract.Call(methodClaimCount))
+	return f.GetClaimCountAtBlock(ctx, batching.BlockLatest)
+}
+
+func (f *FaultDisputeGameContract) GetClaimCountAtBlock(ctx context.Context, block batching.Block) (uint64, error) {
+	result, err := f.multiCaller.SingleCall(ctx, block, f.contract.Call(methodClaimCount))
 	if err != nil {
 		return 0, fmt.Errorf("failed to fetch claim count: %w", err)
 	}
@@ -181,23 +238,60 @@ func (f *FaultDisputeGameContract) GetAllClaims(ctx context.Context) ([]types.Cl
 		return nil, fmt.Errorf("failed to load claim count: %w", err)
 	}
 
-	calls := make([]*batching.ContractCall, count)
+	indices := make([]uint64, count)
 	for i := uint64(0); i < count; i++ {
-		calls[i] = f.contract.Call(methodClaim, new(big.Int).SetUint64(i))
+		indices[i] = i
 	}
+	return f.GetClaimsAtBlock(ctx, batching.BlockLatest, indices)
+}
 
-	results, err := f.multiCaller.Call(ctx, batching.BlockLatest, calls...)
+// GetClaimsAtBlock returns the claims with the specified contract indices as at the specified block.
+func (f *FaultDisputeGameContract) GetClaimsAtBlock(ctx context.Context, block batching.Block, indices []uint64) ([]types.Claim, error) {
+	calls := make([]*batching.ContractCall, len(indices))
+	for i, idx := range indices {
+		calls[i] = f.contract.Call(methodClaim, new(big.Int).SetUint64(idx))
+	}
+
+	results, err := f.multiCaller.Call(ctx, block, calls...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch claim data: %w", err)
 	}
 
 	var claims []types.Claim
-	for idx, result := range results {
-		claims = append(claims, f.decodeClaim(result, idx))
+	for i, result := range results {
+		claims = append(claims, f.decodeClaim(result, int(indices[i])))
 	}
 	return claims, nil
 }
 
+// MoveFilter returns a log filter matching the Move events emitted by the game between fromBlock and toBlock inclusive.
+func (f *FaultDisputeGameContract) MoveFilter(fromBlock uint64, toBlock uint64) ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{f.addr},
+		Topics:    [][]common.Hash{{f.abi.Events[eventMove].ID}},
+	}
+}
+
+// DecodeMove decodes a Move event log emitted by the game.
+func (f *FaultDisputeGameContract) DecodeMove(log ethTypes.Log) (MoveEvent, error) {
+	event := f.abi.Events[eventMove]
+	if log.Address != f.addr {
+		return MoveEvent{}, fmt.Errorf("log from unexpected address %v", log.Address)
+	}
+	// All Move event parameters are indexed so are stored in the topics, after the event ID.
+	if len(log.Topics) != 4 || log.Topics[0] != event.ID {
+		return MoveEvent{}, fmt.Errorf("log is not a %v event", eventMove)
+	}
+	return MoveEvent{
+		ParentIndex: new(big.Int).SetBytes(log.Topics[1].Bytes()).Uint64(),
+		Claim:       log.Topics[2],
+		Claimant:    common.BytesToAddress(log.Topics[3].Bytes()),
+		BlockNumber: log.BlockNumber,
+	}, nil
+}
+
 func (f *FaultDisputeGameContract) vm(ctx context.Context) (*VMContract, error) {
 	result, err := f.multiCaller.SingleCall(ctx, batching.BlockLatest, f.contract.Call(methodVM))
 	if err != nil {
@@ -258,6 +352,14 @@ func (f *FaultDisputeGameContract) resolveCall() *batching.ContractCall {
 	return f.contract.Call(methodResolve)
 }
 
+// decodeClock unpacks a claim clock, which holds the duration in seconds in the high 64 bits and the timestamp in the
+// low 64 bits.
+func decodeClock(clock *big.Int) types.Clock {
+	duration := new(big.Int).Rsh(clock, 64).Uint64()
+	timestamp := new(big.Int).And(clock, new(big.Int).SetUint64(math.MaxUint64)).Uint64()
+	return types.NewClock(time.Duration(duration)*time.Second, time.Unix(int64(timestamp), 0))
+}
+
 func (f *FaultDisputeGameContract) decodeClaim(result *batching.CallResult, contractIndex int) types.Claim {
 	parentIndex := result.GetUint32(0)
 	countered := result.GetBool(1)
@@ -270,7 +372,7 @@ func (f *FaultDisputeGameContract) decodeClaim(result *batching.CallResult, cont
 			Position: types.NewPositionFromGIndex(position),
 		},
 		Countered:           countered,
-		Clock:               clock.Uint64(),
+		Clock:               decodeClock(clock),
 		ContractIndex:       contractIndex,
 		ParentContractIndex: int(parentIndex),
 	}
@@ -5,6 +5,7 @@ import (
 	"math"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
@@ -12,6 +13,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
 	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -112,8 +114,8 @@ func TestGetClaim(t *testing.T) {
 	countered := true
 	value := common.Hash{0xab}
 	position := big.NewInt(2)
-	clock := big.NewInt(1234)
-	stubRpc.SetResponse(fdgAddr, methodClaim, batching.BlockLatest, []interface{}{idx}, []interface{}{parentIndex, countered, value, position, clock})
+	clock := faultTypes.NewClock(5*time.Second, time.Unix(1234, 0))
+	stubRpc.SetResponse(fdgAddr, methodClaim, batching.BlockLatest, []interface{}{idx}, []interface{}{parentIndex, countered, value, position, packClock(clock)})
 	status, err := game.GetClaim(context.Background(), idx.Uint64())
 	require.NoError(t, err)
 	require.Equal(t, faultTypes.Claim{
@@ -122,7 +124,7 @@ func TestGetClaim(t *testing.T) {
 			Position: faultTypes.NewPositionFromGIndex(position),
 		},
 		Countered:           true,
-		Clock:               1234,
+		Clock:               clock,
 		ContractIndex:       int(idx.Uint64()),
 		ParentContractIndex: 1,
 	}, status)
@@ -136,7 +138,7 @@ func TestGetAllClaims(t *testing.T) {
 			Position: faultTypes.NewPositionFromGIndex(big.NewInt(1)),
 		},
 		Countered:           true,
-		Clock:               1234,
+		Clock:               faultTypes.NewClock(3*time.Second, time.Unix(1234, 0)),
 		ContractIndex:       0,
 		ParentContractIndex: math.MaxUint32,
 	}
@@ -146,7 +148,7 @@ func TestGetAllClaims(t *testing.T) {
 			Position: faultTypes.NewPositionFromGIndex(big.NewInt(2)),
 		},
 		Countered:           true,
-		Clock:               4455,
+		Clock:               faultTypes.NewClock(4*time.Second, time.Unix(4455, 0)),
 		ContractIndex:       1,
 		ParentContractIndex: 0,
 	}
@@ -156,7 +158,7 @@ func TestGetAllClaims(t *testing.T) {
 			Position: faultTypes.NewPositionFromGIndex(big.NewInt(6)),
 		},
 		Countered:           false,
-		Clock:               7777,
+		Clock:               faultTypes.NewClock(1*time.Second, time.Unix(7777, 0)),
 		ContractIndex:       2,
 		ParentContractIndex: 1,
 	}
@@ -170,6 +172,63 @@ func TestGetAllClaims(t *testing.T) {
 	require.Equal(t, expectedClaims, claims)
 }
 
+func TestGetClaimsAtBlock(t *testing.T) {
+	stubRpc, game := setupFaultDisputeGameTest(t)
+	block := batching.BlockByNumber(55)
+	claim := faultTypes.Claim{
+		ClaimData: faultTypes.ClaimData{
+			Value:    common.Hash{0xbb},
+			Position: faultTypes.NewPositionFromGIndex(big.NewInt(6)),
+		},
+		Clock:               faultTypes.NewClock(1*time.Second, time.Unix(7777, 0)),
+		ContractIndex:       2,
+		ParentContractIndex: 1,
+	}
+	stubRpc.SetResponse(fdgAddr, methodClaimCount, block, nil, []interface{}{big.NewInt(3)})
+	stubRpc.SetResponse(fdgAddr, methodClaim, block, []interface{}{big.NewInt(2)}, []interface{}{
+		uint32(claim.ParentContractIndex), claim.Countered, claim.Value, claim.Position.ToGIndex(), packClock(claim.Clock)})
+
+	count, err := game.GetClaimCountAtBlock(context.Background(), block)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), count)
+	claims, err := game.GetClaimsAtBlock(context.Background(), block, []uint64{2})
+	require.NoError(t, err)
+	require.Equal(t, []faultTypes.Claim{claim}, claims)
+}
+
+func TestMoveEvents(t *testing.T) {
+	_, game := setupFaultDisputeGameTest(t)
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	moveID := fdgAbi.Events[eventMove].ID
+
+	filter := game.MoveFilter(10, 20)
+	require.Equal(t, big.NewInt(10), filter.FromBlock)
+	require.Equal(t, big.NewInt(20), filter.ToBlock)
+	require.Equal(t, []common.Address{fdgAddr}, filter.Addresses)
+	require.Equal(t, [][]common.Hash{{moveID}}, filter.Topics)
+
+	claimant := common.Address{0xcc}
+	log := ethTypes.Log{
+		Address:     fdgAddr,
+		Topics:      []common.Hash{moveID, common.BigToHash(big.NewInt(3)), {0xaa}, common.BytesToHash(claimant.Bytes())},
+		BlockNumber: 15,
+	}
+	event, err := game.DecodeMove(log)
+	require.NoError(t, err)
+	require.Equal(t, MoveEvent{ParentIndex: 3, Claim: common.Hash{0xaa}, Claimant: claimant, BlockNumber: 15}, event)
+
+	wrongAddr := log
+	wrongAddr.Address = vmAddr
+	_, err = game.DecodeMove(wrongAddr)
+	require.Error(t, err)
+
+	wrongEvent := log
+	wrongEvent.Topics = []common.Hash{fdgAbi.Events["Resolved"].ID, {0x01}}
+	_, err = game.DecodeMove(wrongEvent)
+	require.Error(t, err)
+}
+
 func TestCallResolveClaim(t *testing.T) {
 	stubRpc, game := setupFaultDisputeGameTest(t)
 	stubRpc.SetResponse(fdgAddr, methodResolveClaim, batching.BlockLatest, []interface{}{big.NewInt(123)}, nil)
@@ -232,10 +291,16 @@ func expectGetClaim(stubRpc *batchingTest.AbiBasedRpc, claim faultTypes.Claim) {
 			claim.Countered,
 			claim.Value,
 			claim.Position.ToGIndex(),
-			big.NewInt(int64(claim.Clock)),
+			packClock(claim.Clock),
 		})
 }
 
+// packClock encodes clock as the uint128 used by the contract, with the duration in the high 64 bits.
+func packClock(clock faultTypes.Clock) *big.Int {
+	packed := new(big.Int).Lsh(new(big.Int).SetUint64(uint64(clock.Duration.Seconds())), 64)
+	return packed.Or(packed, new(big.Int).SetInt64(clock.Timestamp.Unix()))
+}
+
 func TestGetBlockRange(t *testing.T) {
 	stubRpc, contract := setupFaultDisputeGameTest(t)
 	expectedStart := uint64(65)
@@ -248,6 +313,34 @@ func TestGetBlockRange(t *testing.T) {
 	require.Equal(t, expectedEnd, end)
 }
 
+func TestGetGameMetadata(t *testing.T) {
+	stubRpc, contract := setupFaultDisputeGameTest(t)
+	expectedL2BlockNumber := uint64(123)
+	expectedRootClaim := common.Hash{0x01, 0x02}
+	expectedStatus := types.GameStatusChallengerWon
+	stubRpc.SetResponse(fdgAddr, methodL2BlockNumber, batching.BlockLatest, nil, []interface{}{new(big.Int).SetUint64(expectedL2BlockNumber)})
+	stubRpc.SetResponse(fdgAddr, methodRootClaim, batching.BlockLatest, nil, []interface{}{expectedRootClaim})
+	stubRpc.SetResponse(fdgAddr, methodStatus, batching.BlockLatest, nil, []interface{}{expectedStatus})
+	l2BlockNumber, rootClaim, status, err := contract.GetGameMetadata(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, expectedL2BlockNumber, l2BlockNumber)
+	require.Equal(t, expectedRootClaim, rootClaim)
+	require.Equal(t, expectedStatus, status)
+}
+
+func TestGetGameMetadataAtBlock(t *testing.T) {
+	stubRpc, contract := setupFaultDisputeGameTest(t)
+	block := batching.BlockByHash(common.Hash{0xaa})
+	stubRpc.SetResponse(fdgAddr, methodL2BlockNumber, block, nil, []interface{}{new(big.Int).SetUint64(123)})
+	stubRpc.SetResponse(fdgAddr, methodRootClaim, block, nil, []interface{}{common.Hash{0x01, 0x02}})
+	stubRpc.SetResponse(fdgAddr, methodStatus, block, nil, []interface{}{types.GameStatusInProgress})
+	l2BlockNumber, rootClaim, status, err := contract.GetGameMetadataAtBlock(context.Background(), block)
+	require.NoError(t, err)
+	require.Equal(t, uint64(123), l2BlockNumber)
+	require.Equal(t, common.Hash{0x01, 0x02}, rootClaim)
+	require.Equal(t, types.GameStatusInProgress, status)
+}
+
 func TestGetSplitDepth(t *testing.T) {
 	stubRpc, contract := setupFaultDisputeGameTest(t)
 	expectedSplitDepth := uint64(15)
@@ -307,6 +400,15 @@ func TestFaultDisputeGame_UpdateOracleTx(t *testing.T) {
 	})
 }
 
+func TestGetOracle(t *testing.T) {
+	stubRpc, game := setupFaultDisputeGameTest(t)
+	stubRpc.SetResponse(fdgAddr, methodVM, batching.BlockLatest, nil, []interface{}{vmAddr})
+	stubRpc.SetResponse(vmAddr, methodOracle, batching.BlockLatest, nil, []interface{}{oracleAddr})
+	oracle, err := game.GetOracle(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, oracleAddr, oracle.Addr())
+}
+
 func setupFaultDisputeGameTest(t *testing.T) (*batchingTest.AbiBasedRpc, *FaultDisputeGameContract) {
 	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
 	require.NoError(t, err)
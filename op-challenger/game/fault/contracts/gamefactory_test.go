@@ -51,6 +51,16 @@ func TestDisputeGameFactorySimpleGetters(t *testing.T) {
 	}
 }
 
+func TestGetGameImpl(t *testing.T) {
+	stubRpc, factory := setupDisputeGameFactoryTest(t)
+	gameType := uint8(3)
+	gameImplAddr := common.Address{0xaa}
+	stubRpc.SetResponse(factoryAddr, methodGameImpls, batching.BlockLatest, []interface{}{gameType}, []interface{}{gameImplAddr})
+	actual, err := factory.GetGameImpl(context.Background(), gameType)
+	require.NoError(t, err)
+	require.Equal(t, gameImplAddr, actual)
+}
+
 func TestLoadGame(t *testing.T) {
 	blockHash := common.Hash{0xbb, 0xce}
 	stubRpc, factory := setupDisputeGameFactoryTest(t)
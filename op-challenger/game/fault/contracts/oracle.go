@@ -1,39 +1,271 @@
 package contracts
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 const (
 	methodLoadKeccak256PreimagePart = "loadKeccak256PreimagePart"
+	methodInitLPP                   = "initLPP"
+	methodAddLeavesLPP              = "addLeavesLPP"
+	methodSqueezeLPP                = "squeezeLPP"
+	methodChallengeLPP              = "challengeLPP"
+	methodChallengeFirstLPP         = "challengeFirstLPP"
+	methodProposalCount             = "proposalCount"
+	methodProposals                 = "proposals"
+	methodProposalMetadata          = "proposalMetadata"
+	methodProposalBlocksLen         = "proposalBlocksLen"
+	methodProposalBlocks            = "proposalBlocks"
+	methodChallengePeriod           = "challengePeriod"
 )
 
+var ErrInvalidAddLeavesCall = errors.New("tx is not a valid addLeavesLPP call")
+
 // PreimageOracleContract is a binding that works with contracts implementing the IPreimageOracle interface
 type PreimageOracleContract struct {
+	addr        common.Address
+	abi         *abi.ABI
 	multiCaller *batching.MultiCaller
 	contract    *batching.BoundContract
 }
 
 func NewPreimageOracleContract(addr common.Address, caller *batching.MultiCaller) (*PreimageOracleContract, error) {
-	mipsAbi, err := bindings.PreimageOracleMetaData.GetAbi()
+	oracleAbi, err := bindings.PreimageOracleMetaData.GetAbi()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load preimage oracle ABI: %w", err)
 	}
 
 	return &PreimageOracleContract{
+		addr:        addr,
+		abi:         oracleAbi,
 		multiCaller: caller,
-		contract:    batching.NewBoundContract(mipsAbi, addr),
+		contract:    batching.NewBoundContract(oracleAbi, addr),
 	}, nil
 }
 
-func (c PreimageOracleContract) AddGlobalDataTx(data *types.PreimageOracleData) (txmgr.TxCandidate, error) {
+func (c *PreimageOracleContract) Addr() common.Address {
+	return c.addr
+}
+
+func (c *PreimageOracleContract) AddGlobalDataTx(data *types.PreimageOracleData) (txmgr.TxCandidate, error) {
 	call := c.contract.Call(methodLoadKeccak256PreimagePart, new(big.Int).SetUint64(uint64(data.OracleOffset)), data.GetPreimageWithoutSize())
 	return call.ToTxCandidate()
 }
+
+func (c *PreimageOracleContract) InitLargePreimage(uuid *big.Int, partOffset uint32, claimedSize uint32) (txmgr.TxCandidate, error) {
+	call := c.contract.Call(methodInitLPP, uuid, partOffset, claimedSize)
+	return call.ToTxCandidate()
+}
+
+func (c *PreimageOracleContract) AddLeaves(uuid *big.Int, startingBlockIndex *big.Int, input []byte, commitments []common.Hash, finalize bool) (txmgr.TxCandidate, error) {
+	call := c.contract.Call(methodAddLeavesLPP, uuid, startingBlockIndex, input, commitments, finalize)
+	return call.ToTxCandidate()
+}
+
+func (c *PreimageOracleContract) Squeeze(
+	claimant common.Address,
+	uuid *big.Int,
+	stateMatrix keccakTypes.StateSnapshot,
+	preState keccakTypes.Leaf,
+	preStateProof []common.Hash,
+	postState keccakTypes.Leaf,
+	postStateProof []common.Hash,
+) (txmgr.TxCandidate, error) {
+	call := c.contract.Call(
+		methodSqueezeLPP,
+		claimant,
+		uuid,
+		abiEncodeSnapshot(stateMatrix),
+		toPreimageOracleLeaf(preState),
+		preStateProof,
+		toPreimageOracleLeaf(postState),
+		postStateProof,
+	)
+	return call.ToTxCandidate()
+}
+
+// ChallengeTx creates a transaction to counter an invalid large preimage proposal.
+// If the first leaf is invalid, there is no prestate leaf and challengeFirstLPP is used.
+func (c *PreimageOracleContract) ChallengeTx(ident keccakTypes.LargePreimageIdent, challenge keccakTypes.Challenge) (txmgr.TxCandidate, error) {
+	var call *batching.ContractCall
+	if challenge.Poststate.Index == 0 {
+		call = c.contract.Call(
+			methodChallengeFirstLPP,
+			ident.Claimant,
+			ident.UUID,
+			toPreimageOracleLeaf(challenge.Poststate),
+			challenge.PoststateProof.Hashes(),
+		)
+	} else {
+		call = c.contract.Call(
+			methodChallengeLPP,
+			ident.Claimant,
+			ident.UUID,
+			abiEncodeSnapshot(challenge.StateMatrix),
+			toPreimageOracleLeaf(challenge.Prestate),
+			challenge.PrestateProof.Hashes(),
+			toPreimageOracleLeaf(challenge.Poststate),
+			challenge.PoststateProof.Hashes(),
+		)
+	}
+	return call.ToTxCandidate()
+}
+
+// ChallengePeriod returns the challenge period for large preimage proposals in seconds.
+func (c *PreimageOracleContract) ChallengePeriod(ctx context.Context) (uint64, error) {
+	result, err := c.multiCaller.SingleCall(ctx, batching.BlockLatest, c.contract.Call(methodChallengePeriod))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch challenge period: %w", err)
+	}
+	return result.GetBigInt(0).Uint64(), nil
+}
+
+// GetActivePreimages returns the metadata of all large preimage proposals as of the specified block.
+func (c *PreimageOracleContract) GetActivePreimages(ctx context.Context, blockHash common.Hash) ([]keccakTypes.LargePreimageMetaData, error) {
+	block := batching.BlockByHash(blockHash)
+	result, err := c.multiCaller.SingleCall(ctx, block, c.contract.Call(methodProposalCount))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load number of proposals: %w", err)
+	}
+	count := result.GetBigInt(0).Uint64()
+	if count == 0 {
+		return nil, nil
+	}
+
+	calls := make([]*batching.ContractCall, 0, count)
+	for i := uint64(0); i < count; i++ {
+		calls = append(calls, c.contract.Call(methodProposals, new(big.Int).SetUint64(i)))
+	}
+	results, err := c.multiCaller.Call(ctx, block, calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load proposals: %w", err)
+	}
+	idents := make([]keccakTypes.LargePreimageIdent, 0, len(results))
+	for _, result := range results {
+		idents = append(idents, keccakTypes.LargePreimageIdent{
+			Claimant: result.GetAddress(0),
+			UUID:     result.GetBigInt(1),
+		})
+	}
+	return c.GetProposalMetadata(ctx, block, idents...)
+}
+
+// GetProposalMetadata returns the metadata of the specified large preimage proposals.
+// Proposals that have not been initialized have an empty metadata.
+func (c *PreimageOracleContract) GetProposalMetadata(ctx context.Context, block batching.Block, idents ...keccakTypes.LargePreimageIdent) ([]keccakTypes.LargePreimageMetaData, error) {
+	if len(idents) == 0 {
+		return nil, nil
+	}
+	calls := make([]*batching.ContractCall, 0, len(idents))
+	for _, ident := range idents {
+		calls = append(calls, c.contract.Call(methodProposalMetadata, ident.Claimant, ident.UUID))
+	}
+	results, err := c.multiCaller.Call(ctx, block, calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load proposal metadata: %w", err)
+	}
+	proposals := make([]keccakTypes.LargePreimageMetaData, 0, len(results))
+	for i, result := range results {
+		proposals = append(proposals, decodeProposalMetadata(idents[i], result.GetHash(0)))
+	}
+	return proposals, nil
+}
+
+// GetInputDataBlocks returns the L1 block numbers containing the addLeavesLPP calls for the specified proposal.
+func (c *PreimageOracleContract) GetInputDataBlocks(ctx context.Context, block batching.Block, ident keccakTypes.LargePreimageIdent) ([]uint64, error) {
+	result, err := c.multiCaller.SingleCall(ctx, block, c.contract.Call(methodProposalBlocksLen, ident.Claimant, ident.UUID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load number of proposal blocks: %w", err)
+	}
+	count := result.GetBigInt(0).Uint64()
+	if count == 0 {
+		return nil, nil
+	}
+	calls := make([]*batching.ContractCall, 0, count)
+	for i := uint64(0); i < count; i++ {
+		calls = append(calls, c.contract.Call(methodProposalBlocks, ident.Claimant, ident.UUID, new(big.Int).SetUint64(i)))
+	}
+	results, err := c.multiCaller.Call(ctx, block, calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load proposal blocks: %w", err)
+	}
+	blockNums := make([]uint64, 0, len(results))
+	for _, result := range results {
+		blockNums = append(blockNums, result.GetUint64(0))
+	}
+	return blockNums, nil
+}
+
+// DecodeInputData decodes the transaction data of an addLeavesLPP call.
+// Returns the UUID of the proposal along with the input data.
+func (c *PreimageOracleContract) DecodeInputData(data []byte) (*big.Int, keccakTypes.InputData, error) {
+	method := c.abi.Methods[methodAddLeavesLPP]
+	if len(data) < 4 || !bytes.Equal(data[:4], method.ID) {
+		return nil, keccakTypes.InputData{}, ErrInvalidAddLeavesCall
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, keccakTypes.InputData{}, fmt.Errorf("%w: %w", ErrInvalidAddLeavesCall, err)
+	}
+	uuid := *abi.ConvertType(args[0], new(*big.Int)).(**big.Int)
+	// Skip the input start block, the input data is verified against the leaves the contract recorded.
+	input := *abi.ConvertType(args[2], new([]byte)).(*[]byte)
+	rawCommitments := *abi.ConvertType(args[3], new([][32]byte)).(*[][32]byte)
+	finalize := *abi.ConvertType(args[4], new(bool)).(*bool)
+
+	commitments := make([]common.Hash, 0, len(rawCommitments))
+	for _, c := range rawCommitments {
+		commitments = append(commitments, c)
+	}
+	return uuid, keccakTypes.InputData{
+		Input:       input,
+		Commitments: commitments,
+		Finalize:    finalize,
+	}, nil
+}
+
+// decodeProposalMetadata unpacks the metadata of a large preimage proposal.
+// The metadata is packed into a single bytes32 by the contract:
+//
+//	[0:8]   timestamp the proposal was finalized at, zero if not finalized
+//	[8:12]  part offset
+//	[12:16] claimed size
+//	[16:20] blocks processed, including padding
+//	[20:24] bytes processed, excluding padding
+//	[24:32] countered, non-zero if a challenge succeeded
+func decodeProposalMetadata(ident keccakTypes.LargePreimageIdent, meta common.Hash) keccakTypes.LargePreimageMetaData {
+	return keccakTypes.LargePreimageMetaData{
+		LargePreimageIdent: ident,
+		Timestamp:          binary.BigEndian.Uint64(meta[0:8]),
+		PartOffset:         binary.BigEndian.Uint32(meta[8:12]),
+		ClaimedSize:        binary.BigEndian.Uint32(meta[12:16]),
+		BlocksProcessed:    binary.BigEndian.Uint32(meta[16:20]),
+		BytesProcessed:     binary.BigEndian.Uint32(meta[20:24]),
+		Countered:          binary.BigEndian.Uint64(meta[24:32]) != 0,
+	}
+}
+
+func abiEncodeSnapshot(snapshot keccakTypes.StateSnapshot) bindings.LibKeccakStateMatrix {
+	return bindings.LibKeccakStateMatrix{State: snapshot}
+}
+
+func toPreimageOracleLeaf(l keccakTypes.Leaf) bindings.PreimageOracleLeaf {
+	return bindings.PreimageOracleLeaf{
+		Input:           l.Input[:],
+		Index:           new(big.Int).SetUint64(l.Index),
+		StateCommitment: l.StateCommitment,
+	}
+}
@@ -1,11 +1,14 @@
 package contracts
 
 import (
+	"context"
 	"math/big"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/merkle"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
 	"github.com/ethereum/go-ethereum/common"
@@ -13,12 +16,7 @@ import (
 )
 
 func TestPreimageOracleContract_LoadKeccak256(t *testing.T) {
-	oracleAbi, err := bindings.PreimageOracleMetaData.GetAbi()
-	require.NoError(t, err)
-
-	stubRpc := batchingTest.NewAbiBasedRpc(t, oracleAddr, oracleAbi)
-	oracleContract, err := NewPreimageOracleContract(oracleAddr, batching.NewMultiCaller(stubRpc, batching.DefaultBatchSize))
-	require.NoError(t, err)
+	stubRpc, oracleContract := setupPreimageOracleTest(t)
 
 	data := &types.PreimageOracleData{
 		OracleKey:    common.Hash{0xcc}.Bytes(),
@@ -34,3 +32,263 @@ func TestPreimageOracleContract_LoadKeccak256(t *testing.T) {
 	require.NoError(t, err)
 	stubRpc.VerifyTxCandidate(tx)
 }
+
+func TestPreimageOracleContract_InitLargePreimage(t *testing.T) {
+	stubRpc, oracle := setupPreimageOracleTest(t)
+
+	uuid := big.NewInt(123)
+	partOffset := uint32(1)
+	claimedSize := uint32(2)
+	stubRpc.SetResponse(oracleAddr, methodInitLPP, batching.BlockLatest, []interface{}{
+		uuid,
+		partOffset,
+		claimedSize,
+	}, nil)
+
+	tx, err := oracle.InitLargePreimage(uuid, partOffset, claimedSize)
+	require.NoError(t, err)
+	stubRpc.VerifyTxCandidate(tx)
+}
+
+func TestPreimageOracleContract_AddLeaves(t *testing.T) {
+	stubRpc, oracle := setupPreimageOracleTest(t)
+
+	uuid := big.NewInt(123)
+	startingBlockIndex := big.NewInt(0)
+	input := []byte{0x12}
+	commitments := []common.Hash{{0x34}}
+	finalize := true
+	stubRpc.SetResponse(oracleAddr, methodAddLeavesLPP, batching.BlockLatest, []interface{}{
+		uuid,
+		startingBlockIndex,
+		input,
+		commitments,
+		finalize,
+	}, nil)
+
+	tx, err := oracle.AddLeaves(uuid, startingBlockIndex, input, commitments, finalize)
+	require.NoError(t, err)
+	stubRpc.VerifyTxCandidate(tx)
+}
+
+func TestPreimageOracleContract_Squeeze(t *testing.T) {
+	stubRpc, oracle := setupPreimageOracleTest(t)
+
+	claimant := common.Address{0x12}
+	uuid := big.NewInt(123)
+	stateMatrix := keccakTypes.StateSnapshot{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24}
+	preState := keccakTypes.Leaf{Input: [keccakTypes.BlockSize]byte{0x12}, Index: 123, StateCommitment: common.Hash{0x34}}
+	preStateProof := []common.Hash{{0x34}}
+	postState := keccakTypes.Leaf{Input: [keccakTypes.BlockSize]byte{0x34}, Index: 124, StateCommitment: common.Hash{0x56}}
+	postStateProof := []common.Hash{{0x56}}
+	stubRpc.SetResponse(oracleAddr, methodSqueezeLPP, batching.BlockLatest, []interface{}{
+		claimant,
+		uuid,
+		abiEncodeSnapshot(stateMatrix),
+		toPreimageOracleLeaf(preState),
+		preStateProof,
+		toPreimageOracleLeaf(postState),
+		postStateProof,
+	}, nil)
+
+	tx, err := oracle.Squeeze(claimant, uuid, stateMatrix, preState, preStateProof, postState, postStateProof)
+	require.NoError(t, err)
+	stubRpc.VerifyTxCandidate(tx)
+}
+
+func TestPreimageOracleContract_ChallengePeriod(t *testing.T) {
+	stubRpc, oracle := setupPreimageOracleTest(t)
+	stubRpc.SetResponse(oracleAddr, methodChallengePeriod, batching.BlockLatest, nil, []interface{}{big.NewInt(123)})
+
+	period, err := oracle.ChallengePeriod(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(123), period)
+}
+
+func TestPreimageOracleContract_GetActivePreimages(t *testing.T) {
+	blockHash := common.Hash{0xaa}
+	stubRpc, oracle := setupPreimageOracleTest(t)
+	block := batching.BlockByHash(blockHash)
+	stubRpc.SetResponse(oracleAddr, methodProposalCount, block, nil, []interface{}{big.NewInt(2)})
+
+	preimage1 := keccakTypes.LargePreimageMetaData{
+		LargePreimageIdent: keccakTypes.LargePreimageIdent{
+			Claimant: common.Address{0xaa},
+			UUID:     big.NewInt(1111),
+		},
+		Timestamp:       1234,
+		PartOffset:      1,
+		ClaimedSize:     100,
+		BlocksProcessed: 10,
+		BytesProcessed:  100,
+		Countered:       false,
+	}
+	preimage2 := keccakTypes.LargePreimageMetaData{
+		LargePreimageIdent: keccakTypes.LargePreimageIdent{
+			Claimant: common.Address{0xbb},
+			UUID:     big.NewInt(2222),
+		},
+		Timestamp:       2345,
+		PartOffset:      2,
+		ClaimedSize:     200,
+		BlocksProcessed: 20,
+		BytesProcessed:  200,
+		Countered:       true,
+	}
+	expectGetProposals(stubRpc, block, preimage1, preimage2)
+	preimages, err := oracle.GetActivePreimages(context.Background(), blockHash)
+	require.NoError(t, err)
+	require.Equal(t, []keccakTypes.LargePreimageMetaData{preimage1, preimage2}, preimages)
+}
+
+func TestPreimageOracleContract_GetActivePreimagesNoProposals(t *testing.T) {
+	blockHash := common.Hash{0xaa}
+	stubRpc, oracle := setupPreimageOracleTest(t)
+	stubRpc.SetResponse(oracleAddr, methodProposalCount, batching.BlockByHash(blockHash), nil, []interface{}{big.NewInt(0)})
+
+	preimages, err := oracle.GetActivePreimages(context.Background(), blockHash)
+	require.NoError(t, err)
+	require.Empty(t, preimages)
+}
+
+func TestPreimageOracleContract_GetInputDataBlocks(t *testing.T) {
+	stubRpc, oracle := setupPreimageOracleTest(t)
+	block := batching.BlockByHash(common.Hash{0xaa})
+
+	preimage := keccakTypes.LargePreimageIdent{
+		Claimant: common.Address{0xbb},
+		UUID:     big.NewInt(2222),
+	}
+
+	stubRpc.SetResponse(oracleAddr, methodProposalBlocksLen, block,
+		[]interface{}{preimage.Claimant, preimage.UUID},
+		[]interface{}{big.NewInt(3)})
+	for i, blockNum := range []uint64{100, 150, 200} {
+		stubRpc.SetResponse(oracleAddr, methodProposalBlocks, block,
+			[]interface{}{preimage.Claimant, preimage.UUID, big.NewInt(int64(i))},
+			[]interface{}{blockNum})
+	}
+
+	blocks, err := oracle.GetInputDataBlocks(context.Background(), block, preimage)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{100, 150, 200}, blocks)
+}
+
+func TestPreimageOracleContract_DecodeInputData(t *testing.T) {
+	_, oracle := setupPreimageOracleTest(t)
+	uuid := big.NewInt(1234)
+	expected := keccakTypes.InputData{
+		Input:       []byte{1, 2, 3},
+		Commitments: []common.Hash{{0xaa}, {0xbb}},
+		Finalize:    true,
+	}
+	tx, err := oracle.AddLeaves(uuid, big.NewInt(3), expected.Input, expected.Commitments, expected.Finalize)
+	require.NoError(t, err)
+
+	actualUUID, actual, err := oracle.DecodeInputData(tx.TxData)
+	require.NoError(t, err)
+	require.Equal(t, uuid, actualUUID)
+	require.Equal(t, expected, actual)
+
+	t.Run("WrongMethod", func(t *testing.T) {
+		tx, err := oracle.InitLargePreimage(uuid, 1, 2)
+		require.NoError(t, err)
+		_, _, err = oracle.DecodeInputData(tx.TxData)
+		require.ErrorIs(t, err, ErrInvalidAddLeavesCall)
+	})
+
+	t.Run("TooShort", func(t *testing.T) {
+		_, _, err := oracle.DecodeInputData([]byte{1, 2})
+		require.ErrorIs(t, err, ErrInvalidAddLeavesCall)
+	})
+
+	t.Run("InvalidArgs", func(t *testing.T) {
+		_, _, err := oracle.DecodeInputData(tx.TxData[:40])
+		require.ErrorIs(t, err, ErrInvalidAddLeavesCall)
+	})
+}
+
+func TestPreimageOracleContract_ChallengeTx(t *testing.T) {
+	ident := keccakTypes.LargePreimageIdent{
+		Claimant: common.Address{0xab},
+		UUID:     big.NewInt(4829),
+	}
+	var proof merkle.Proof
+	for i := range proof {
+		proof[i] = common.Hash{byte(i)}
+	}
+
+	t.Run("First", func(t *testing.T) {
+		stubRpc, oracle := setupPreimageOracleTest(t)
+		challenge := keccakTypes.Challenge{
+			Poststate:      keccakTypes.Leaf{Input: [keccakTypes.BlockSize]byte{0x01}, StateCommitment: common.Hash{0xaa}},
+			PoststateProof: proof,
+		}
+		stubRpc.SetResponse(oracleAddr, methodChallengeFirstLPP, batching.BlockLatest, []interface{}{
+			ident.Claimant,
+			ident.UUID,
+			toPreimageOracleLeaf(challenge.Poststate),
+			challenge.PoststateProof.Hashes(),
+		}, nil)
+		tx, err := oracle.ChallengeTx(ident, challenge)
+		require.NoError(t, err)
+		stubRpc.VerifyTxCandidate(tx)
+	})
+
+	t.Run("Subsequent", func(t *testing.T) {
+		stubRpc, oracle := setupPreimageOracleTest(t)
+		challenge := keccakTypes.Challenge{
+			StateMatrix:    keccakTypes.StateSnapshot{1, 2, 3},
+			Prestate:       keccakTypes.Leaf{Input: [keccakTypes.BlockSize]byte{0x01}, Index: 3, StateCommitment: common.Hash{0xaa}},
+			PrestateProof:  proof,
+			Poststate:      keccakTypes.Leaf{Input: [keccakTypes.BlockSize]byte{0x02}, Index: 4, StateCommitment: common.Hash{0xbb}},
+			PoststateProof: proof,
+		}
+		stubRpc.SetResponse(oracleAddr, methodChallengeLPP, batching.BlockLatest, []interface{}{
+			ident.Claimant,
+			ident.UUID,
+			abiEncodeSnapshot(challenge.StateMatrix),
+			toPreimageOracleLeaf(challenge.Prestate),
+			challenge.PrestateProof.Hashes(),
+			toPreimageOracleLeaf(challenge.Poststate),
+			challenge.PoststateProof.Hashes(),
+		}, nil)
+		tx, err := oracle.ChallengeTx(ident, challenge)
+		require.NoError(t, err)
+		stubRpc.VerifyTxCandidate(tx)
+	})
+}
+
+func expectGetProposals(stubRpc *batchingTest.AbiBasedRpc, block batching.Block, proposals ...keccakTypes.LargePreimageMetaData) {
+	for i, proposal := range proposals {
+		stubRpc.SetResponse(oracleAddr, methodProposals, block,
+			[]interface{}{big.NewInt(int64(i))},
+			[]interface{}{proposal.Claimant, proposal.UUID})
+		stubRpc.SetResponse(oracleAddr, methodProposalMetadata, block,
+			[]interface{}{proposal.Claimant, proposal.UUID},
+			[]interface{}{encodeProposalMetadata(proposal)})
+	}
+}
+
+func encodeProposalMetadata(proposal keccakTypes.LargePreimageMetaData) [32]byte {
+	var meta [32]byte
+	big.NewInt(int64(proposal.Timestamp)).FillBytes(meta[0:8])
+	big.NewInt(int64(proposal.PartOffset)).FillBytes(meta[8:12])
+	big.NewInt(int64(proposal.ClaimedSize)).FillBytes(meta[12:16])
+	big.NewInt(int64(proposal.BlocksProcessed)).FillBytes(meta[16:20])
+	big.NewInt(int64(proposal.BytesProcessed)).FillBytes(meta[20:24])
+	if proposal.Countered {
+		meta[31] = 1
+	}
+	return meta
+}
+
+func setupPreimageOracleTest(t *testing.T) (*batchingTest.AbiBasedRpc, *PreimageOracleContract) {
+	oracleAbi, err := bindings.PreimageOracleMetaData.GetAbi()
+	require.NoError(t, err)
+
+	stubRpc := batchingTest.NewAbiBasedRpc(t, oracleAddr, oracleAbi)
+	oracleContract, err := NewPreimageOracleContract(oracleAddr, batching.NewMultiCaller(stubRpc, batching.DefaultBatchSize))
+	require.NoError(t, err)
+	return stubRpc, oracleContract
+}
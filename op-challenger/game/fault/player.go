@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/policy"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -29,25 +34,32 @@ type GamePlayer struct {
 }
 
 type GameContract interface {
+	preimages.PreimageGameContract
 	responder.GameContract
 	GameInfo
 	ClaimLoader
+	claims.ClaimContract
 	GetStatus(ctx context.Context) (gameTypes.GameStatus, error)
 	GetMaxGameDepth(ctx context.Context) (uint64, error)
+	GetOracle(ctx context.Context) (*contracts.PreimageOracleContract, error)
 }
 
 type resourceCreator func(ctx context.Context, logger log.Logger, gameDepth uint64, dir string) (types.TraceAccessor, error)
 
 func NewGamePlayer(
 	ctx context.Context,
+	cl clock.Clock,
 	logger log.Logger,
 	m metrics.Metricer,
 	dir string,
 	addr common.Address,
 	txMgr txmgr.TxManager,
 	loader GameContract,
+	l1Source claims.L1Source,
+	actionPolicy *policy.Policy,
 	validators []Validator,
 	creator resourceCreator,
+	largePreimages bool,
 ) (*GamePlayer, error) {
 	logger = logger.New("game", addr)
 
@@ -80,12 +92,24 @@ func NewGamePlayer(
 		return nil, fmt.Errorf("failed to create trace accessor: %w", err)
 	}
 
-	responder, err := responder.NewFaultResponder(logger, txMgr, loader)
+	var uploader preimages.PreimageUploader = preimages.NewDirectPreimageUploader(logger, txMgr, loader)
+	if largePreimages {
+		oracle, err := loader.GetOracle(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load oracle: %w", err)
+		}
+		large := preimages.NewLargePreimageUploader(logger, cl, txMgr, oracle)
+		uploader = preimages.NewSplitPreimageUploader(uploader, large)
+	}
+
+	gamePolicy := actionPolicy.ForGame(logger, accessor)
+	responder, err := responder.NewFaultResponder(logger, txMgr, loader, uploader, gamePolicy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the responder: %w", err)
 	}
 
-	agent := NewAgent(m, loader, int(gameDepth), accessor, responder, logger)
+	claimCache := claims.NewClaimCache(logger, addr, loader, l1Source, dir)
+	agent := NewAgent(m, claimCache, int(gameDepth), accessor, responder, gamePolicy, logger)
 	return &GamePlayer{
 		act:    agent.Act,
 		loader: loader,
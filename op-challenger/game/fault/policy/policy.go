@@ -0,0 +1,289 @@
+package policy
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrMaxMovesReached   = errors.New("max moves per game reached")
+	ErrBondLimitReached  = errors.New("bond limit for window reached")
+	ErrGasLimitReached   = errors.New("gas cost limit for window reached")
+	ErrValidRootDeclined = errors.New("not countering valid root claim")
+)
+
+// Limits configures the actions the challenger is willing to perform. Zero values disable each limit.
+type Limits struct {
+	// MaxMovesPerGame is the maximum number of moves to make in a single game.
+	MaxMovesPerGame uint
+	// MaxBond is the maximum total value of bonds to post in moves across all games within Window.
+	MaxBond *big.Int
+	// MaxGasCost is the maximum total gas cost, in wei, of transactions sent across all games within Window.
+	MaxGasCost *big.Int
+	// Window is the period over which MaxBond and MaxGasCost apply.
+	Window time.Duration
+	// DisputedRootsOnly restricts the challenger to games with a root claim it disagrees with.
+	DisputedRootsOnly bool
+}
+
+// spend is the value committed by the challenger at a point in time.
+type spend struct {
+	time time.Time
+	bond *big.Int
+	gas  *big.Int
+}
+
+// Policy sits between the solver and the responder, selecting which actions are performed so that the cost of
+// playing games is bounded, for example when games are spammed with claims to drain the challenger's wallet.
+// Bonds are reserved when a move is sent and released if it is not included. Gas costs are counted once the
+// transaction is included. Spending and moves are tracked in memory so are not included after a restart.
+type Policy struct {
+	clock  clock.Clock
+	limits Limits
+
+	lock   sync.Mutex
+	spends []*spend
+}
+
+func NewPolicy(cl clock.Clock, limits Limits) *Policy {
+	return &Policy{
+		clock:  cl,
+		limits: limits,
+	}
+}
+
+// TxManager wraps txMgr to record the gas cost of each transaction sent against the gas cost limit.
+func (p *Policy) TxManager(txMgr txmgr.TxManager) txmgr.TxManager {
+	return &spendTracker{TxManager: txMgr, policy: p}
+}
+
+// ForGame returns a GamePolicy that applies the limits to actions in a single game.
+func (p *Policy) ForGame(logger log.Logger, trace types.TraceAccessor) *GamePolicy {
+	return &GamePolicy{
+		policy: p,
+		log:    logger,
+		trace:  trace,
+	}
+}
+
+// checkGasCost returns an error if the gas cost limit has been reached.
+func (p *Policy) checkGasCost() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	_, gas := p.spentInWindow()
+	return p.checkGasCostLocked(gas)
+}
+
+func (p *Policy) checkGasCostLocked(gas *big.Int) error {
+	if p.limits.MaxGasCost != nil && gas.Cmp(p.limits.MaxGasCost) >= 0 {
+		return fmt.Errorf("%w: spent %v of %v wei", ErrGasLimitReached, gas, p.limits.MaxGasCost)
+	}
+	return nil
+}
+
+// reserveBond records bond as spent if it is within the bond and gas cost limits.
+// The returned spend is nil if nothing was recorded.
+func (p *Policy) reserveBond(bond *big.Int) (*spend, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	bonds, gas := p.spentInWindow()
+	if err := p.checkGasCostLocked(gas); err != nil {
+		return nil, err
+	}
+	if bond == nil || bond.Sign() <= 0 {
+		return nil, nil
+	}
+	if p.limits.MaxBond != nil && new(big.Int).Add(bonds, bond).Cmp(p.limits.MaxBond) > 0 {
+		return nil, fmt.Errorf("%w: bond of %v wei would exceed %v wei with %v wei already posted", ErrBondLimitReached, bond, p.limits.MaxBond, bonds)
+	}
+	return p.record(&spend{bond: bond}), nil
+}
+
+// release removes a bond reservation made by reserveBond.
+func (p *Policy) release(s *spend) {
+	if s == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.spends = slices.DeleteFunc(p.spends, func(other *spend) bool {
+		return other == s
+	})
+}
+
+func (p *Policy) gasSpent(gas *big.Int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.record(&spend{gas: gas})
+}
+
+// record adds s to the spending in the current window and returns it, or nil if spending isn't limited.
+// The caller must hold the lock.
+func (p *Policy) record(s *spend) *spend {
+	if p.limits.Window == 0 {
+		return nil
+	}
+	s.time = p.clock.Now()
+	p.spends = append(p.spends, s)
+	return s
+}
+
+// spentInWindow removes spending outside the current window and returns the total bonds and gas spent within it.
+// The caller must hold the lock.
+func (p *Policy) spentInWindow() (*big.Int, *big.Int) {
+	cutoff := p.clock.Now().Add(-p.limits.Window)
+	p.spends = slices.DeleteFunc(p.spends, func(s *spend) bool {
+		return !s.time.After(cutoff)
+	})
+	bonds := new(big.Int)
+	gas := new(big.Int)
+	for _, s := range p.spends {
+		if s.bond != nil {
+			bonds.Add(bonds, s.bond)
+		}
+		if s.gas != nil {
+			gas.Add(gas, s.gas)
+		}
+	}
+	return bonds, gas
+}
+
+type spendTracker struct {
+	txmgr.TxManager
+	policy *Policy
+}
+
+func (s *spendTracker) Send(ctx context.Context, candidate txmgr.TxCandidate) (*ethtypes.Receipt, error) {
+	receipt, err := s.TxManager.Send(ctx, candidate)
+	if receipt != nil && receipt.EffectiveGasPrice != nil {
+		s.policy.gasSpent(new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice))
+	}
+	return receipt, err
+}
+
+// GamePolicy applies the limits to the actions in a single game.
+// Moves are counted against the per-game limit once they are included.
+type GamePolicy struct {
+	policy *Policy
+	log    log.Logger
+	trace  types.TraceAccessor
+
+	moves uint
+	// validRoot is set once the root claim is known to be valid.
+	validRoot *bool
+}
+
+// Apply returns the actions to perform, declining any that would exceed the limits.
+// Actions are ordered so those responding to the claims closest to their clock expiring are performed first.
+// The bond limit is applied by ReserveMove once the bond required for each move is known.
+func (g *GamePolicy) Apply(ctx context.Context, game types.Game, actions []types.Action) []types.Action {
+	if len(actions) == 0 {
+		return nil
+	}
+	if g.policy.limits.DisputedRootsOnly {
+		valid, err := g.isValidRoot(ctx, game)
+		if err != nil {
+			g.log.Error("Failed to check root claim, declining all actions", "err", err)
+			return nil
+		}
+		if valid {
+			g.log.Debug("Declined actions", "count", len(actions), "reason", ErrValidRootDeclined)
+			return nil
+		}
+	}
+	sorted := slices.Clone(actions)
+	now := g.policy.clock.Now()
+	slices.SortStableFunc(sorted, func(a, b types.Action) int {
+		// Most elapsed time first
+		return cmp.Compare(clockElapsed(game, b, now), clockElapsed(game, a, now))
+	})
+	var allowed []types.Action
+	var moves uint
+	for _, action := range sorted {
+		if err := g.allow(action, moves); err != nil {
+			g.log.Warn("Declined action", "type", action.Type, "parent", action.ParentIdx, "is_attack", action.IsAttack,
+				"elapsed", clockElapsed(game, action, now), "reason", err)
+			continue
+		}
+		if action.Type == types.ActionTypeMove {
+			moves++
+		}
+		allowed = append(allowed, action)
+	}
+	return allowed
+}
+
+// allow checks if action is within the limits, given the moves already allowed but not yet performed.
+func (g *GamePolicy) allow(action types.Action, pendingMoves uint) error {
+	if action.Type == types.ActionTypeMove && g.policy.limits.MaxMovesPerGame != 0 && g.moves+pendingMoves >= g.policy.limits.MaxMovesPerGame {
+		return fmt.Errorf("%w: %v", ErrMaxMovesReached, g.moves)
+	}
+	return g.policy.checkGasCost()
+}
+
+// ReserveMove reserves bond against the bond limit before a move is sent.
+// The returned function records the move against the per-game limit if it was included and releases the bond
+// reservation if it wasn't.
+func (g *GamePolicy) ReserveMove(bond *big.Int) (func(included bool), error) {
+	reserved, err := g.policy.reserveBond(bond)
+	if err != nil {
+		return nil, err
+	}
+	return func(included bool) {
+		if !included {
+			g.policy.release(reserved)
+			return
+		}
+		g.moves++
+	}, nil
+}
+
+func (g *GamePolicy) isValidRoot(ctx context.Context, game types.Game) (bool, error) {
+	if g.validRoot != nil {
+		return *g.validRoot, nil
+	}
+	root := game.Claims()[0]
+	expected, err := g.trace.Get(ctx, game, root, root.Position)
+	if err != nil {
+		return false, err
+	}
+	valid := expected == root.Value
+	g.validRoot = &valid
+	if valid {
+		g.log.Info("Not countering valid root claim")
+	}
+	return valid, nil
+}
+
+// clockElapsed returns the time that would be on the clock of the claim created by action if it were performed now.
+// Each team's clock includes the time taken to respond to all of the opposing team's claims so the claim with the most
+// elapsed time is closest to being unable to be countered.
+func clockElapsed(game types.Game, action types.Action, now time.Time) time.Duration {
+	claims := game.Claims()
+	if action.ParentIdx < 0 || action.ParentIdx >= len(claims) {
+		return 0
+	}
+	parent := claims[action.ParentIdx]
+	var elapsed time.Duration
+	if !parent.IsRoot() {
+		if grandparent, err := game.GetParent(parent); err == nil {
+			elapsed = grandparent.Clock.Duration
+		}
+	}
+	if !parent.Clock.Timestamp.IsZero() {
+		elapsed += now.Sub(parent.Clock.Timestamp)
+	}
+	return elapsed
+}
@@ -0,0 +1,212 @@
+package policy
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+const maxDepth = 4
+
+var (
+	bond  = big.NewInt(100)
+	start = time.Unix(10_000, 0)
+)
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("NoLimits", func(t *testing.T) {
+		policy, _, game := setupPolicyTest(t, Limits{})
+		actions := []types.Action{attack(0), step(0)}
+		require.Equal(t, actions, policy.Apply(ctx, game, actions))
+	})
+
+	t.Run("NoActions", func(t *testing.T) {
+		policy, _, game := setupPolicyTest(t, Limits{MaxMovesPerGame: 1})
+		require.Empty(t, policy.Apply(ctx, game, nil))
+	})
+
+	t.Run("MaxMovesPerGame", func(t *testing.T) {
+		policy, _, game := setupPolicyTest(t, Limits{MaxMovesPerGame: 2})
+		require.Len(t, policy.Apply(ctx, game, []types.Action{attack(0), attack(0), attack(0)}), 2)
+		performMove(t, policy, true)
+		// Steps aren't counted as moves
+		require.Len(t, policy.Apply(ctx, game, []types.Action{attack(0), attack(0), step(0)}), 2)
+		performMove(t, policy, true)
+		require.Empty(t, policy.Apply(ctx, game, []types.Action{attack(0)}))
+		require.Len(t, policy.Apply(ctx, game, []types.Action{step(0)}), 1)
+	})
+
+	t.Run("MovesNotIncludedAreNotCounted", func(t *testing.T) {
+		policy, _, game := setupPolicyTest(t, Limits{MaxMovesPerGame: 1})
+		require.Len(t, policy.Apply(ctx, game, []types.Action{attack(0)}), 1)
+		performMove(t, policy, false)
+		require.Len(t, policy.Apply(ctx, game, []types.Action{attack(0)}), 1)
+	})
+
+	t.Run("MaxMovesIsPerGame", func(t *testing.T) {
+		policy, _, game := setupPolicyTest(t, Limits{MaxMovesPerGame: 1})
+		performMove(t, policy, true)
+		require.Empty(t, policy.Apply(ctx, game, []types.Action{attack(0)}))
+		other := policy.policy.ForGame(testlog.Logger(t, log.LvlInfo), policy.trace)
+		require.Len(t, other.Apply(ctx, game, []types.Action{attack(0)}), 1)
+	})
+
+	t.Run("MaxGasCost", func(t *testing.T) {
+		policy, cl, game := setupPolicyTest(t, Limits{MaxGasCost: big.NewInt(1000), Window: time.Hour})
+		txMgr := &stubTxMgr{receipt: &ethtypes.Receipt{GasUsed: 100, EffectiveGasPrice: big.NewInt(10)}}
+		gameTxMgr := policy.policy.TxManager(txMgr)
+		require.Len(t, policy.Apply(ctx, game, []types.Action{attack(0), step(0)}), 2)
+
+		_, err := gameTxMgr.Send(ctx, txmgr.TxCandidate{})
+		require.NoError(t, err)
+		require.Equal(t, 1, txMgr.sent)
+		require.Empty(t, policy.Apply(ctx, game, []types.Action{attack(0), step(0)}))
+
+		cl.AdvanceTime(time.Hour)
+		require.Len(t, policy.Apply(ctx, game, []types.Action{attack(0), step(0)}), 2)
+	})
+
+	t.Run("DisputedRootsOnly", func(t *testing.T) {
+		policy, _, _ := setupPolicyTest(t, Limits{DisputedRootsOnly: true})
+		builder := test.NewClaimBuilder(t, maxDepth, alphabet.NewTraceProvider("abcdefgh", maxDepth))
+		validRootGame := builder.GameBuilder(true).Game
+		require.Empty(t, policy.Apply(ctx, validRootGame, []types.Action{attack(0)}))
+
+		policy, _, _ = setupPolicyTest(t, Limits{DisputedRootsOnly: true})
+		invalidRootGame := builder.GameBuilder(false).Game
+		require.Len(t, policy.Apply(ctx, invalidRootGame, []types.Action{attack(0)}), 1)
+	})
+
+	t.Run("PrioritizeClaimsClosestToExpiry", func(t *testing.T) {
+		policy, cl, _ := setupPolicyTest(t, Limits{MaxMovesPerGame: 1})
+		root := types.Claim{
+			ClaimData:           types.ClaimData{Position: types.NewPositionFromGIndex(big.NewInt(1))},
+			Clock:               types.NewClock(0, start),
+			ParentContractIndex: -1,
+		}
+		child := types.Claim{
+			ClaimData:     types.ClaimData{Value: common.Hash{0x01}, Position: root.Position.Attack()},
+			Clock:         types.NewClock(5*time.Minute, start.Add(5*time.Minute)),
+			ContractIndex: 1,
+		}
+		grandchild := types.Claim{
+			ClaimData:           types.ClaimData{Value: common.Hash{0x02}, Position: child.Position.Attack()},
+			Clock:               types.NewClock(10*time.Minute, start.Add(10*time.Minute)),
+			ContractIndex:       2,
+			ParentContractIndex: 1,
+		}
+		game := types.NewGameState([]types.Claim{root, child, grandchild}, maxDepth)
+		cl.AdvanceTime(20 * time.Minute)
+
+		// Countering root: 20 minutes since root was posted.
+		// Countering child: 15 minutes since child was posted plus no time from the root's clock.
+		// Countering grandchild: 10 minutes since grandchild was posted plus 5 minutes from the child's clock.
+		actions := []types.Action{attack(2), attack(1), attack(0)}
+		require.Equal(t, []types.Action{attack(0)}, policy.Apply(ctx, game, actions))
+	})
+}
+
+func TestReserveMove(t *testing.T) {
+	t.Run("MaxBond", func(t *testing.T) {
+		policy, cl, _ := setupPolicyTest(t, Limits{MaxBond: big.NewInt(250), Window: time.Hour})
+		for i := 0; i < 2; i++ {
+			done, err := policy.ReserveMove(bond)
+			require.NoError(t, err)
+			done(true)
+		}
+		_, err := policy.ReserveMove(bond)
+		require.ErrorIs(t, err, ErrBondLimitReached)
+		// Moves without a bond are still allowed
+		_, err = policy.ReserveMove(new(big.Int))
+		require.NoError(t, err)
+		_, err = policy.ReserveMove(nil)
+		require.NoError(t, err)
+
+		// Bonds posted outside the window are not counted
+		cl.AdvanceTime(time.Hour)
+		_, err = policy.ReserveMove(bond)
+		require.NoError(t, err)
+	})
+
+	t.Run("ReleaseBondIfNotIncluded", func(t *testing.T) {
+		policy, _, _ := setupPolicyTest(t, Limits{MaxBond: big.NewInt(150), Window: time.Hour})
+		done, err := policy.ReserveMove(bond)
+		require.NoError(t, err)
+		// Reserved bonds count towards the limit until the move completes
+		_, err = policy.ReserveMove(bond)
+		require.ErrorIs(t, err, ErrBondLimitReached)
+
+		done(false)
+		done, err = policy.ReserveMove(bond)
+		require.NoError(t, err)
+		done(true)
+		_, err = policy.ReserveMove(bond)
+		require.ErrorIs(t, err, ErrBondLimitReached)
+	})
+
+	t.Run("MaxGasCost", func(t *testing.T) {
+		policy, _, _ := setupPolicyTest(t, Limits{MaxGasCost: big.NewInt(1000), Window: time.Hour})
+		txMgr := &stubTxMgr{receipt: &ethtypes.Receipt{GasUsed: 100, EffectiveGasPrice: big.NewInt(10)}}
+		_, err := policy.policy.TxManager(txMgr).Send(context.Background(), txmgr.TxCandidate{})
+		require.NoError(t, err)
+		_, err = policy.ReserveMove(bond)
+		require.ErrorIs(t, err, ErrGasLimitReached)
+	})
+}
+
+func setupPolicyTest(t *testing.T, limits Limits) (*GamePolicy, *clock.DeterministicClock, types.Game) {
+	cl := clock.NewDeterministicClock(start)
+	builder := test.NewClaimBuilder(t, maxDepth, alphabet.NewTraceProvider("abcdefgh", maxDepth))
+	game := builder.GameBuilder(false).Game
+	accessor := trace.NewSimpleTraceAccessor(alphabet.NewTraceProvider("abcdefgh", maxDepth))
+	policy := NewPolicy(cl, limits).ForGame(testlog.Logger(t, log.LvlInfo), accessor)
+	return policy, cl, game
+}
+
+// performMove reserves and completes a move as the responder would.
+func performMove(t *testing.T, policy *GamePolicy, included bool) {
+	done, err := policy.ReserveMove(bond)
+	require.NoError(t, err)
+	done(included)
+}
+
+func attack(parentIdx int) types.Action {
+	return types.Action{
+		Type:      types.ActionTypeMove,
+		ParentIdx: parentIdx,
+		IsAttack:  true,
+	}
+}
+
+func step(parentIdx int) types.Action {
+	return types.Action{
+		Type:      types.ActionTypeStep,
+		ParentIdx: parentIdx,
+	}
+}
+
+type stubTxMgr struct {
+	txmgr.TxManager
+	receipt *ethtypes.Receipt
+	sent    int
+}
+
+func (s *stubTxMgr) Send(_ context.Context, _ txmgr.TxCandidate) (*ethtypes.Receipt, error) {
+	s.sent++
+	return s.receipt, nil
+}
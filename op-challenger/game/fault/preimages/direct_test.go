@@ -0,0 +1,112 @@
+package preimages
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	mockUpdateOracleTxError = errors.New("mock update oracle tx error")
+	mockTxMgrSendError      = errors.New("mock tx mgr send error")
+)
+
+func TestDirectPreimageUploader_UploadPreimage(t *testing.T) {
+	t.Run("UpdateOracleTxFails", func(t *testing.T) {
+		oracle, txMgr, contract := newTestDirectPreimageUploader(t)
+		contract.updateFails = true
+		err := oracle.UploadPreimage(context.Background(), 0, &types.PreimageOracleData{})
+		require.ErrorIs(t, err, mockUpdateOracleTxError)
+		require.Equal(t, 1, contract.updates)
+		require.Equal(t, 0, txMgr.sends) // verify that the tx was not sent
+	})
+
+	t.Run("SendFails", func(t *testing.T) {
+		oracle, txMgr, contract := newTestDirectPreimageUploader(t)
+		txMgr.sendFails = true
+		err := oracle.UploadPreimage(context.Background(), 0, &types.PreimageOracleData{})
+		require.ErrorIs(t, err, mockTxMgrSendError)
+		require.Equal(t, 1, contract.updates)
+		require.Equal(t, 1, txMgr.sends)
+	})
+
+	t.Run("NilPreimageData", func(t *testing.T) {
+		oracle, _, _ := newTestDirectPreimageUploader(t)
+		err := oracle.UploadPreimage(context.Background(), 0, nil)
+		require.ErrorIs(t, err, ErrNilPreimageData)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		oracle, txMgr, contract := newTestDirectPreimageUploader(t)
+		data := &types.PreimageOracleData{OracleKey: common.Hash{0xaa}.Bytes()}
+		err := oracle.UploadPreimage(context.Background(), 3, data)
+		require.NoError(t, err)
+		require.Equal(t, 1, contract.updates)
+		require.Equal(t, uint64(3), contract.claimIdx)
+		require.Equal(t, data, contract.data)
+		require.Equal(t, 1, txMgr.sends)
+	})
+}
+
+func newTestDirectPreimageUploader(t *testing.T) (*DirectPreimageUploader, *mockTxMgr, *mockPreimageGameContract) {
+	logger := testlog.Logger(t, log.LvlError)
+	txMgr := &mockTxMgr{}
+	contract := &mockPreimageGameContract{}
+	return NewDirectPreimageUploader(logger, txMgr, contract), txMgr, contract
+}
+
+type mockPreimageGameContract struct {
+	updates     int
+	updateFails bool
+	claimIdx    uint64
+	data        *types.PreimageOracleData
+}
+
+func (s *mockPreimageGameContract) UpdateOracleTx(_ context.Context, claimIdx uint64, data *types.PreimageOracleData) (txmgr.TxCandidate, error) {
+	s.updates++
+	if s.updateFails {
+		return txmgr.TxCandidate{}, mockUpdateOracleTxError
+	}
+	s.claimIdx = claimIdx
+	s.data = data
+	return txmgr.TxCandidate{}, nil
+}
+
+type mockTxMgr struct {
+	from      common.Address
+	sends     int
+	sent      []txmgr.TxCandidate
+	sendFails bool
+	reverts   bool
+	// onSend is called for each successful, non-reverted tx
+	onSend func(candidate txmgr.TxCandidate) error
+}
+
+func (s *mockTxMgr) Send(_ context.Context, candidate txmgr.TxCandidate) (*ethtypes.Receipt, error) {
+	s.sends++
+	if s.sendFails {
+		return nil, mockTxMgrSendError
+	}
+	if s.reverts {
+		return &ethtypes.Receipt{Status: ethtypes.ReceiptStatusFailed}, nil
+	}
+	if s.onSend != nil {
+		if err := s.onSend(candidate); err != nil {
+			return nil, err
+		}
+	}
+	s.sent = append(s.sent, candidate)
+	return &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful}, nil
+}
+
+func (s *mockTxMgr) BlockNumber(_ context.Context) (uint64, error) { return 0, nil }
+func (s *mockTxMgr) From() common.Address                          { return s.from }
+func (s *mockTxMgr) Close()                                        {}
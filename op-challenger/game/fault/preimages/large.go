@@ -0,0 +1,216 @@
+package preimages
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/merkle"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var _ PreimageUploader = (*LargePreimageUploader)(nil)
+
+// MaxBlocksPerChunk is the maximum number of keccak blocks to include in each addLeavesLPP call.
+const MaxBlocksPerChunk = 300
+
+// MaxChunkSize is the maximum size of the preimage data to include in each addLeavesLPP call.
+const MaxChunkSize = MaxBlocksPerChunk * keccakTypes.BlockSize
+
+var (
+	// ErrChallengePeriodNotOver is returned when the proposal has been fully uploaded but can't be squeezed yet.
+	ErrChallengePeriodNotOver = errors.New("challenge period not over")
+	// ErrProposalCountered is returned when the proposal was successfully challenged and can never be squeezed.
+	ErrProposalCountered = errors.New("large preimage proposal was countered")
+)
+
+// LargePreimageUploader handles uploading large preimages by chunking the preimage data into multiple transactions.
+// Each call to UploadPreimage resumes from the on-chain state of the proposal, so an upload that is interrupted or
+// is waiting for the challenge period to end can be completed by retrying.
+type LargePreimageUploader struct {
+	log log.Logger
+
+	clock    clock.Clock
+	txMgr    txmgr.TxManager
+	contract PreimageOracleContract
+}
+
+func NewLargePreimageUploader(logger log.Logger, cl clock.Clock, txMgr txmgr.TxManager, contract PreimageOracleContract) *LargePreimageUploader {
+	return &LargePreimageUploader{logger, cl, txMgr, contract}
+}
+
+func (p *LargePreimageUploader) UploadPreimage(ctx context.Context, parent uint64, data *types.PreimageOracleData) error {
+	if data == nil {
+		return ErrNilPreimageData
+	}
+	ident := keccakTypes.LargePreimageIdent{
+		Claimant: p.txMgr.From(),
+		UUID:     p.newUUID(data),
+	}
+	logger := p.log.New("uuid", ident.UUID, "key", common.Bytes2Hex(data.OracleKey))
+	metadata, err := p.proposalMetadata(ctx, ident)
+	if err != nil {
+		return err
+	}
+	if metadata.Countered {
+		return fmt.Errorf("%w: %v", ErrProposalCountered, ident.UUID)
+	}
+
+	preimage := data.GetPreimageWithoutSize()
+	if metadata.ClaimedSize == 0 {
+		logger.Info("Initializing large preimage proposal", "size", len(preimage))
+		if err := p.initLargePreimage(ctx, ident.UUID, data.OracleOffset, uint32(len(preimage))); err != nil {
+			return fmt.Errorf("failed to initialize large preimage with uuid %v: %w", ident.UUID, err)
+		}
+	}
+
+	if metadata.Timestamp == 0 {
+		logger.Info("Adding leaves to large preimage proposal", "bytesProcessed", metadata.BytesProcessed, "size", len(preimage))
+		if err := p.addLargePreimageData(ctx, ident.UUID, preimage, metadata.BytesProcessed); err != nil {
+			return fmt.Errorf("failed to add leaves to large preimage with uuid %v: %w", ident.UUID, err)
+		}
+		// Reload to get the finalization timestamp.
+		metadata, err = p.proposalMetadata(ctx, ident)
+		if err != nil {
+			return err
+		}
+		if metadata.Timestamp == 0 {
+			return fmt.Errorf("large preimage with uuid %v was not finalized", ident.UUID)
+		}
+	}
+
+	period, err := p.contract.ChallengePeriod(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load challenge period: %w", err)
+	}
+	challengeEnd := time.Unix(int64(metadata.Timestamp), 0).Add(time.Duration(period) * time.Second)
+	if !p.clock.Now().After(challengeEnd) {
+		logger.Info("Waiting for large preimage challenge period to end", "end", challengeEnd)
+		return fmt.Errorf("%w: proposal %v can be squeezed after %v", ErrChallengePeriodNotOver, ident.UUID, challengeEnd)
+	}
+
+	logger.Info("Squeezing large preimage proposal")
+	if err := p.squeeze(ctx, ident, preimage); err != nil {
+		return fmt.Errorf("failed to squeeze large preimage with uuid %v: %w", ident.UUID, err)
+	}
+	return nil
+}
+
+// newUUID generates a unique identifier for the proposal by hashing the sender address, preimage key and offset,
+// so retrying the same upload resumes the existing proposal.
+func (p *LargePreimageUploader) newUUID(data *types.PreimageOracleData) *big.Int {
+	sender := p.txMgr.From()
+	offset := make([]byte, 4)
+	binary.BigEndian.PutUint32(offset, data.OracleOffset)
+	return new(big.Int).SetBytes(crypto.Keccak256(sender.Bytes(), data.OracleKey, offset))
+}
+
+func (p *LargePreimageUploader) proposalMetadata(ctx context.Context, ident keccakTypes.LargePreimageIdent) (keccakTypes.LargePreimageMetaData, error) {
+	metadata, err := p.contract.GetProposalMetadata(ctx, batching.BlockLatest, ident)
+	if err != nil {
+		return keccakTypes.LargePreimageMetaData{}, fmt.Errorf("failed to load metadata for large preimage with uuid %v: %w", ident.UUID, err)
+	}
+	if len(metadata) != 1 {
+		return keccakTypes.LargePreimageMetaData{}, fmt.Errorf("expected one metadata for large preimage with uuid %v but got %v", ident.UUID, len(metadata))
+	}
+	return metadata[0], nil
+}
+
+func (p *LargePreimageUploader) initLargePreimage(ctx context.Context, uuid *big.Int, partOffset uint32, claimedSize uint32) error {
+	candidate, err := p.contract.InitLargePreimage(uuid, partOffset, claimedSize)
+	if err != nil {
+		return fmt.Errorf("failed to create pre-image oracle tx: %w", err)
+	}
+	return p.sendTxAndWait(ctx, candidate)
+}
+
+// addLargePreimageData adds the preimage data in chunks, skipping any chunks that were already processed.
+// Chunks are always split at the same offsets so a partially uploaded proposal can be resumed.
+func (p *LargePreimageUploader) addLargePreimageData(ctx context.Context, uuid *big.Int, preimage []byte, bytesProcessed uint32) error {
+	stateMatrix := matrix.NewStateMatrix()
+	in := bytes.NewReader(preimage)
+	blocksProcessed := 0
+	processed := 0
+	for {
+		inputData, err := stateMatrix.AbsorbUpTo(in, MaxChunkSize)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to absorb preimage data: %w", err)
+		}
+		startBlock := blocksProcessed
+		blocksProcessed += len(inputData.Commitments)
+		processed += len(inputData.Input)
+		// The final chunk may not contain any new bytes but must still be sent to finalize the proposal.
+		if processed > int(bytesProcessed) || inputData.Finalize {
+			candidate, err := p.contract.AddLeaves(uuid, big.NewInt(int64(startBlock)), inputData.Input, inputData.Commitments, inputData.Finalize)
+			if err != nil {
+				return fmt.Errorf("failed to create add leaves tx: %w", err)
+			}
+			if err := p.sendTxAndWait(ctx, candidate); err != nil {
+				return err
+			}
+		}
+		if inputData.Finalize {
+			return nil
+		}
+	}
+}
+
+func (p *LargePreimageUploader) squeeze(ctx context.Context, ident keccakTypes.LargePreimageIdent, preimage []byte) error {
+	leaves, stateMatrix, err := matrix.ProposalLeaves(bytes.NewReader(preimage))
+	if err != nil {
+		return fmt.Errorf("failed to compute proposal leaves: %w", err)
+	}
+	if len(leaves) < 2 {
+		return fmt.Errorf("preimage of %v bytes is too small to squeeze", len(preimage))
+	}
+	tree := merkle.NewBinaryMerkleTree()
+	for _, leaf := range leaves {
+		if err := tree.AddLeaf(leaf.Hash()); err != nil {
+			return fmt.Errorf("failed to add leaf %v to merkle tree: %w", leaf.Index, err)
+		}
+	}
+	preState := leaves[len(leaves)-2]
+	postState := leaves[len(leaves)-1]
+	preStateProof, err := tree.ProofAtIndex(preState.Index)
+	if err != nil {
+		return err
+	}
+	postStateProof, err := tree.ProofAtIndex(postState.Index)
+	if err != nil {
+		return err
+	}
+	candidate, err := p.contract.Squeeze(ident.Claimant, ident.UUID, stateMatrix, preState, preStateProof.Hashes(), postState, postStateProof.Hashes())
+	if err != nil {
+		return fmt.Errorf("failed to create squeeze tx: %w", err)
+	}
+	return p.sendTxAndWait(ctx, candidate)
+}
+
+// sendTxAndWait sends a transaction through the [txmgr] and waits for a receipt.
+// Unlike single transaction uploads, each step depends on the previous one so a reverted tx is an error.
+func (p *LargePreimageUploader) sendTxAndWait(ctx context.Context, candidate txmgr.TxCandidate) error {
+	receipt, err := p.txMgr.Send(ctx, candidate)
+	if err != nil {
+		return err
+	}
+	if receipt.Status == ethtypes.ReceiptStatusFailed {
+		p.log.Error("LargePreimageUploader tx successfully published but reverted", "tx_hash", receipt.TxHash)
+		return fmt.Errorf("tx %v reverted", receipt.TxHash)
+	}
+	p.log.Debug("LargePreimageUploader tx successfully published", "tx_hash", receipt.TxHash)
+	return nil
+}
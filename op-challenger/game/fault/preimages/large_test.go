@@ -0,0 +1,250 @@
+package preimages
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/merkle"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+const challengePeriod = 3600
+
+var mockMetadataError = errors.New("mock metadata error")
+
+func largePreimageData(size int) *types.PreimageOracleData {
+	key := preimage.Keccak256Key(common.Hash{0xaa}).PreimageKey()
+	data := make([]byte, 8+size)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	return types.NewPreimageOracleData(key[:], data, 0)
+}
+
+func TestLargePreimageUploader_UploadPreimage(t *testing.T) {
+	t.Run("MetadataFails", func(t *testing.T) {
+		oracle, _, txMgr, contract := newTestLargePreimageUploader(t)
+		contract.metadataFails = true
+		err := oracle.UploadPreimage(context.Background(), 0, largePreimageData(MaxChunkSize))
+		require.ErrorIs(t, err, mockMetadataError)
+		require.Equal(t, 0, txMgr.sends)
+	})
+
+	t.Run("InitFails", func(t *testing.T) {
+		oracle, _, txMgr, contract := newTestLargePreimageUploader(t)
+		txMgr.sendFails = true
+		err := oracle.UploadPreimage(context.Background(), 0, largePreimageData(MaxChunkSize))
+		require.ErrorIs(t, err, mockTxMgrSendError)
+		require.Equal(t, 0, contract.initCalls)
+		require.Equal(t, 0, contract.addCalls)
+	})
+
+	t.Run("Reverted", func(t *testing.T) {
+		oracle, _, txMgr, _ := newTestLargePreimageUploader(t)
+		txMgr.reverts = true
+		err := oracle.UploadPreimage(context.Background(), 0, largePreimageData(MaxChunkSize))
+		require.ErrorContains(t, err, "reverted")
+		require.Equal(t, 1, txMgr.sends)
+	})
+
+	t.Run("WaitsForChallengePeriod", func(t *testing.T) {
+		oracle, cl, txMgr, contract := newTestLargePreimageUploader(t)
+		data := largePreimageData(MaxChunkSize*2 + 100)
+		err := oracle.UploadPreimage(context.Background(), 0, data)
+		require.ErrorIs(t, err, ErrChallengePeriodNotOver)
+		require.Equal(t, 1, contract.initCalls)
+		require.Equal(t, 3, contract.addCalls)
+		require.Equal(t, 0, contract.squeezeCalls)
+		require.Equal(t, 4, txMgr.sends)
+
+		// Retrying before the challenge period ends doesn't send any txs
+		cl.AdvanceTime(challengePeriod * time.Second)
+		err = oracle.UploadPreimage(context.Background(), 0, data)
+		require.ErrorIs(t, err, ErrChallengePeriodNotOver)
+		require.Equal(t, 4, txMgr.sends)
+
+		cl.AdvanceTime(time.Second)
+		err = oracle.UploadPreimage(context.Background(), 0, data)
+		require.NoError(t, err)
+		require.Equal(t, 1, contract.squeezeCalls)
+		require.Equal(t, 5, txMgr.sends)
+		contract.verifySqueeze(t, data.GetPreimageWithoutSize())
+	})
+
+	t.Run("ExactMultipleOfChunkSize", func(t *testing.T) {
+		oracle, cl, _, contract := newTestLargePreimageUploader(t)
+		data := largePreimageData(MaxChunkSize * 2)
+		err := oracle.UploadPreimage(context.Background(), 0, data)
+		require.ErrorIs(t, err, ErrChallengePeriodNotOver)
+		// The final call only contains the padding block
+		require.Equal(t, 3, contract.addCalls)
+		require.Empty(t, contract.lastInput)
+
+		cl.AdvanceTime((challengePeriod + 1) * time.Second)
+		require.NoError(t, oracle.UploadPreimage(context.Background(), 0, data))
+		contract.verifySqueeze(t, data.GetPreimageWithoutSize())
+	})
+
+	t.Run("ResumesPartialUpload", func(t *testing.T) {
+		oracle, _, txMgr, contract := newTestLargePreimageUploader(t)
+		data := largePreimageData(MaxChunkSize*2 + 100)
+		contract.addFailsAfter = 1
+		err := oracle.UploadPreimage(context.Background(), 0, data)
+		require.ErrorIs(t, err, mockTxMgrSendError)
+		require.Equal(t, 1, contract.addCalls)
+
+		contract.addFailsAfter = 0
+		err = oracle.UploadPreimage(context.Background(), 0, data)
+		require.ErrorIs(t, err, ErrChallengePeriodNotOver)
+		require.Equal(t, 1, contract.initCalls)
+		// Only the remaining two chunks are added
+		require.Equal(t, 3, contract.addCalls)
+		require.Equal(t, uint32(len(data.GetPreimageWithoutSize())), contract.metadata.BytesProcessed)
+		require.Len(t, txMgr.sent, 4)
+	})
+
+	t.Run("Countered", func(t *testing.T) {
+		oracle, _, txMgr, contract := newTestLargePreimageUploader(t)
+		contract.metadata.ClaimedSize = 1
+		contract.metadata.Countered = true
+		err := oracle.UploadPreimage(context.Background(), 0, largePreimageData(MaxChunkSize))
+		require.ErrorIs(t, err, ErrProposalCountered)
+		require.Equal(t, 0, txMgr.sends)
+	})
+
+	t.Run("NilPreimageData", func(t *testing.T) {
+		oracle, _, _, _ := newTestLargePreimageUploader(t)
+		err := oracle.UploadPreimage(context.Background(), 0, nil)
+		require.ErrorIs(t, err, ErrNilPreimageData)
+	})
+}
+
+func newTestLargePreimageUploader(t *testing.T) (*LargePreimageUploader, *clock.DeterministicClock, *mockTxMgr, *mockPreimageOracleContract) {
+	logger := testlog.Logger(t, log.LvlError)
+	cl := clock.NewDeterministicClock(time.Unix(1_000_000, 0))
+	txMgr := &mockTxMgr{from: common.Address{0xcc}}
+	contract := &mockPreimageOracleContract{clock: cl}
+	txMgr.onSend = contract.onSend
+	return NewLargePreimageUploader(logger, cl, txMgr, contract), cl, txMgr, contract
+}
+
+// mockPreimageOracleContract tracks the state of a single proposal, applying each tx once it is sent.
+type mockPreimageOracleContract struct {
+	clock   *clock.DeterministicClock
+	pending []func() error
+
+	metadataFails bool
+	addFailsAfter int
+	metadata      keccakTypes.LargePreimageMetaData
+	commitments   []common.Hash
+	lastInput     []byte
+
+	initCalls    int
+	addCalls     int
+	squeezeCalls int
+
+	squeezeStateMatrix keccakTypes.StateSnapshot
+	squeezePreState    keccakTypes.Leaf
+	squeezePreProof    []common.Hash
+	squeezePostState   keccakTypes.Leaf
+	squeezePostProof   []common.Hash
+}
+
+// candidate returns a tx candidate that applies the update once the mock tx manager sends it.
+func (s *mockPreimageOracleContract) candidate(apply func() error) txmgr.TxCandidate {
+	s.pending = append(s.pending, apply)
+	return txmgr.TxCandidate{TxData: []byte{byte(len(s.pending) - 1)}}
+}
+
+func (s *mockPreimageOracleContract) onSend(candidate txmgr.TxCandidate) error {
+	return s.pending[candidate.TxData[0]]()
+}
+
+func (s *mockPreimageOracleContract) InitLargePreimage(_ *big.Int, partOffset uint32, claimedSize uint32) (txmgr.TxCandidate, error) {
+	return s.candidate(func() error {
+		s.initCalls++
+		s.metadata.PartOffset = partOffset
+		s.metadata.ClaimedSize = claimedSize
+		return nil
+	}), nil
+}
+
+func (s *mockPreimageOracleContract) AddLeaves(_ *big.Int, startingBlockIndex *big.Int, input []byte, commitments []common.Hash, finalize bool) (txmgr.TxCandidate, error) {
+	return s.candidate(func() error {
+		if s.addFailsAfter > 0 && s.addCalls >= s.addFailsAfter {
+			return mockTxMgrSendError
+		}
+		if startingBlockIndex.Uint64() != uint64(s.metadata.BlocksProcessed) {
+			return errors.New("unexpected starting block index")
+		}
+		s.addCalls++
+		s.lastInput = input
+		s.commitments = append(s.commitments, commitments...)
+		s.metadata.BlocksProcessed += uint32(len(commitments))
+		s.metadata.BytesProcessed += uint32(len(input))
+		if finalize {
+			s.metadata.Timestamp = uint64(s.clock.Now().Unix())
+		}
+		return nil
+	}), nil
+}
+
+func (s *mockPreimageOracleContract) Squeeze(_ common.Address, _ *big.Int, stateMatrix keccakTypes.StateSnapshot, preState keccakTypes.Leaf, preStateProof []common.Hash, postState keccakTypes.Leaf, postStateProof []common.Hash) (txmgr.TxCandidate, error) {
+	return s.candidate(func() error {
+		s.squeezeCalls++
+		s.squeezeStateMatrix = stateMatrix
+		s.squeezePreState = preState
+		s.squeezePreProof = preStateProof
+		s.squeezePostState = postState
+		s.squeezePostProof = postStateProof
+		return nil
+	}), nil
+}
+
+func (s *mockPreimageOracleContract) GetProposalMetadata(_ context.Context, _ batching.Block, idents ...keccakTypes.LargePreimageIdent) ([]keccakTypes.LargePreimageMetaData, error) {
+	if s.metadataFails {
+		return nil, mockMetadataError
+	}
+	metadata := s.metadata
+	metadata.LargePreimageIdent = idents[0]
+	return []keccakTypes.LargePreimageMetaData{metadata}, nil
+}
+
+func (s *mockPreimageOracleContract) ChallengePeriod(_ context.Context) (uint64, error) {
+	return challengePeriod, nil
+}
+
+func (s *mockPreimageOracleContract) verifySqueeze(t *testing.T, data []byte) {
+	require.Equal(t, 1, s.squeezeCalls)
+	leaves, _, err := matrix.ProposalLeaves(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, s.commitments, len(leaves))
+	tree := merkle.NewBinaryMerkleTree()
+	for i, leaf := range leaves {
+		require.Equal(t, s.commitments[i], leaf.StateCommitment)
+		require.NoError(t, tree.AddLeaf(leaf.Hash()))
+	}
+	root := tree.RootHash()
+
+	require.Equal(t, leaves[len(leaves)-2], s.squeezePreState)
+	require.Equal(t, leaves[len(leaves)-1], s.squeezePostState)
+	require.True(t, merkle.VerifyProof(root, s.squeezePreState.Hash(), s.squeezePreState.Index, merkle.Proof(s.squeezePreProof)))
+	require.True(t, merkle.VerifyProof(root, s.squeezePostState.Hash(), s.squeezePostState.Index, merkle.Proof(s.squeezePostProof)))
+
+	// The state matrix must be the state committed to by the prestate leaf
+	require.Equal(t, s.squeezePreState.StateCommitment, s.squeezeStateMatrix.Commitment())
+}
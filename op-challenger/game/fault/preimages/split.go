@@ -0,0 +1,37 @@
+package preimages
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+var _ PreimageUploader = (*SplitPreimageUploader)(nil)
+
+// LargePreimageSizeThreshold is the size in bytes at which keccak256 preimages are uploaded with the
+// large preimage proposal flow, since anything larger may not fit in a single transaction.
+const LargePreimageSizeThreshold = 120_000
+
+// SplitPreimageUploader routes preimage uploads to the direct or large preimage uploader based on their size.
+type SplitPreimageUploader struct {
+	largePreimageSizeThreshold uint64
+	directUploader             PreimageUploader
+	largeUploader              PreimageUploader
+}
+
+func NewSplitPreimageUploader(directUploader PreimageUploader, largeUploader PreimageUploader) *SplitPreimageUploader {
+	return &SplitPreimageUploader{LargePreimageSizeThreshold, directUploader, largeUploader}
+}
+
+func (s *SplitPreimageUploader) UploadPreimage(ctx context.Context, parent uint64, data *types.PreimageOracleData) error {
+	if data == nil {
+		return ErrNilPreimageData
+	}
+	// Only global keccak256 preimages can be uploaded as large preimage proposals.
+	if data.IsLocal || len(data.OracleKey) == 0 || data.OracleKey[0] != byte(preimage.Keccak256KeyType) ||
+		uint64(len(data.GetPreimageWithoutSize())) < s.largePreimageSizeThreshold {
+		return s.directUploader.UploadPreimage(ctx, parent, data)
+	}
+	return s.largeUploader.UploadPreimage(ctx, parent, data)
+}
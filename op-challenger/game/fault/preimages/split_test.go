@@ -0,0 +1,62 @@
+package preimages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitPreimageUploader(t *testing.T) {
+	keccakKey := preimage.Keccak256Key(common.Hash{0xaa}).PreimageKey()
+	largeData := make([]byte, 8+LargePreimageSizeThreshold)
+
+	t.Run("DirectUploadSucceeds", func(t *testing.T) {
+		oracle, direct, large := newTestSplitPreimageUploader(t)
+		err := oracle.UploadPreimage(context.Background(), 0, &types.PreimageOracleData{OracleKey: keccakKey[:], OracleData: make([]byte, 8+100)})
+		require.NoError(t, err)
+		require.Equal(t, 1, direct.updates)
+		require.Equal(t, 0, large.updates)
+	})
+
+	t.Run("LocalDataUploadsDirectly", func(t *testing.T) {
+		oracle, direct, large := newTestSplitPreimageUploader(t)
+		localKey := preimage.LocalIndexKey(4).PreimageKey()
+		err := oracle.UploadPreimage(context.Background(), 0, types.NewPreimageOracleData(localKey[:], largeData, 0))
+		require.NoError(t, err)
+		require.Equal(t, 1, direct.updates)
+		require.Equal(t, 0, large.updates)
+	})
+
+	t.Run("LargeUploadSucceeds", func(t *testing.T) {
+		oracle, direct, large := newTestSplitPreimageUploader(t)
+		err := oracle.UploadPreimage(context.Background(), 0, types.NewPreimageOracleData(keccakKey[:], largeData, 0))
+		require.NoError(t, err)
+		require.Equal(t, 1, large.updates)
+		require.Equal(t, 0, direct.updates)
+	})
+
+	t.Run("NilPreimageOracleData", func(t *testing.T) {
+		oracle, _, _ := newTestSplitPreimageUploader(t)
+		err := oracle.UploadPreimage(context.Background(), 0, nil)
+		require.ErrorIs(t, err, ErrNilPreimageData)
+	})
+}
+
+type mockPreimageUploader struct {
+	updates int
+}
+
+func (s *mockPreimageUploader) UploadPreimage(_ context.Context, _ uint64, _ *types.PreimageOracleData) error {
+	s.updates++
+	return nil
+}
+
+func newTestSplitPreimageUploader(t *testing.T) (*SplitPreimageUploader, *mockPreimageUploader, *mockPreimageUploader) {
+	direct := &mockPreimageUploader{}
+	large := &mockPreimageUploader{}
+	return NewSplitPreimageUploader(direct, large), direct, large
+}
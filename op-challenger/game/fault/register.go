@@ -5,107 +5,116 @@ import (
 	"fmt"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
-	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/policy"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/tracetypes"
 	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
-var (
-	cannonGameType   = uint8(0)
-	alphabetGameType = uint8(255)
-)
-
 type CloseFunc func()
 
 type Registry interface {
 	RegisterGameType(gameType uint8, creator scheduler.PlayerCreator)
 }
 
+type OracleRegistry interface {
+	RegisterOracle(oracle keccakTypes.LargePreimageOracle)
+}
+
 func RegisterGameTypes(
 	registry Registry,
+	oracles OracleRegistry,
 	ctx context.Context,
+	cl clock.Clock,
 	logger log.Logger,
 	m metrics.Metricer,
 	cfg *config.Config,
 	rollupClient outputs.OutputRollupClient,
 	txMgr txmgr.TxManager,
+	gameFactory *contracts.DisputeGameFactoryContract,
 	caller *batching.MultiCaller,
+	l1Source claims.L1Source,
+	actionPolicy *policy.Policy,
 ) (CloseFunc, error) {
-	var closer CloseFunc
-	var l2Client *ethclient.Client
-	if cfg.TraceTypeEnabled(config.TraceTypeCannon) {
-		l2, err := ethclient.DialContext(ctx, cfg.CannonL2)
-		if err != nil {
-			return nil, fmt.Errorf("dial l2 client %v: %w", cfg.CannonL2, err)
+	var vms []tracetypes.VM
+	closer := func() {
+		for _, vm := range vms {
+			vm.Close()
 		}
-		l2Client = l2
-		closer = l2Client.Close
-	}
-	if cfg.TraceTypeEnabled(config.TraceTypeCannon) {
-		registerCannon(registry, ctx, logger, m, cfg, rollupClient, txMgr, caller, l2Client)
 	}
-	if cfg.TraceTypeEnabled(config.TraceTypeAlphabet) {
-		registerAlphabet(registry, ctx, logger, m, rollupClient, txMgr, caller)
-	}
-	return closer, nil
-}
-
-func registerAlphabet(
-	registry Registry,
-	ctx context.Context,
-	logger log.Logger,
-	m metrics.Metricer,
-	rollupClient outputs.OutputRollupClient,
-	txMgr txmgr.TxManager,
-	caller *batching.MultiCaller,
-) {
-	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
-		contract, err := contracts.NewFaultDisputeGameContract(game.Proxy, caller)
-		if err != nil {
-			return nil, err
+	deps := tracetypes.Deps{Logger: logger, Metrics: m, Config: cfg}
+	for _, def := range tracetypes.Definitions() {
+		if !cfg.TraceTypeEnabled(def.TraceType) {
+			continue
 		}
-		prestateBlock, poststateBlock, err := contract.GetBlockRange(ctx)
-		if err != nil {
-			return nil, err
-		}
-		prestateProvider := outputs.NewPrestateProvider(ctx, logger, rollupClient, prestateBlock)
-		splitDepth, err := contract.GetSplitDepth(ctx)
+		vm, err := def.New(ctx, deps)
 		if err != nil {
-			return nil, err
+			closer()
+			return nil, fmt.Errorf("failed to start %v trace type: %w", def.TraceType, err)
 		}
-		creator := func(ctx context.Context, logger log.Logger, gameDepth uint64, dir string) (faultTypes.TraceAccessor, error) {
-			accessor, err := outputs.NewOutputAlphabetTraceAccessor(logger, m, prestateProvider, rollupClient, splitDepth, prestateBlock, poststateBlock)
-			if err != nil {
+		vms = append(vms, vm)
+		if def.MonitorOracle && cfg.LargePreimages {
+			if err := registerOracle(ctx, oracles, gameFactory, caller, def.GameType); err != nil {
+				closer()
 				return nil, err
 			}
-			return accessor, nil
 		}
-		prestateValidator := NewPrestateValidator(contract.GetAbsolutePrestateHash, prestateProvider)
-		genesisValidator := NewPrestateValidator(contract.GetGenesisOutputRoot, prestateProvider)
-		return NewGamePlayer(ctx, logger, m, dir, game.Proxy, txMgr, contract, []Validator{prestateValidator, genesisValidator}, creator)
+		registerOutputGame(registry, ctx, cl, logger, m, def, vm, rollupClient, txMgr, caller, l1Source, actionPolicy, cfg.LargePreimages)
 	}
-	registry.RegisterGameType(alphabetGameType, playerCreator)
+	return closer, nil
 }
 
-func registerCannon(
+// registerOracle adds the PreimageOracle used by the current implementation of gameType to oracles so its large
+// preimage proposals are monitored. No oracle is registered if the factory has no implementation for gameType.
+func registerOracle(ctx context.Context, oracles OracleRegistry, gameFactory *contracts.DisputeGameFactoryContract, caller *batching.MultiCaller, gameType uint8) error {
+	implAddr, err := gameFactory.GetGameImpl(ctx, gameType)
+	if err != nil {
+		return fmt.Errorf("failed to load implementation for game type %v: %w", gameType, err)
+	}
+	if implAddr == (common.Address{}) {
+		return nil
+	}
+	contract, err := contracts.NewFaultDisputeGameContract(implAddr, caller)
+	if err != nil {
+		return fmt.Errorf("failed to create fault dispute game contract bindings for %v: %w", implAddr, err)
+	}
+	oracle, err := contract.GetOracle(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load oracle address: %w", err)
+	}
+	oracles.RegisterOracle(oracle)
+	return nil
+}
+
+// registerOutputGame registers the player creator for games of the trace type's game type.
+// Output roots are used above the split depth and traces from vm below it.
+func registerOutputGame(
 	registry Registry,
 	ctx context.Context,
+	cl clock.Clock,
 	logger log.Logger,
 	m metrics.Metricer,
-	cfg *config.Config,
+	def tracetypes.Definition,
+	vm tracetypes.VM,
 	rollupClient outputs.OutputRollupClient,
 	txMgr txmgr.TxManager,
 	caller *batching.MultiCaller,
-	l2Client cannon.L2HeaderSource,
+	l1Source claims.L1Source,
+	actionPolicy *policy.Policy,
+	largePreimages bool,
 ) {
+	metricsLabel := fmt.Sprintf("output_%v_provider", def.TraceType)
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
 		contract, err := contracts.NewFaultDisputeGameContract(game.Proxy, caller)
 		if err != nil {
@@ -121,15 +130,12 @@ func registerCannon(
 			if err != nil {
 				return nil, fmt.Errorf("failed to load split depth: %w", err)
 			}
-			accessor, err := outputs.NewOutputCannonTraceAccessor(logger, m, cfg, l2Client, contract, prestateProvider, rollupClient, dir, splitDepth, prestateBlock, poststateBlock)
-			if err != nil {
-				return nil, err
-			}
-			return accessor, nil
+			providerCreator := outputs.WithAbsolutePrestate(vm.PrestateProvider(), vm.ProviderCreator(logger, contract, dir))
+			return outputs.NewOutputTraceAccessor(logger, m, metricsLabel, prestateProvider, rollupClient, splitDepth, prestateBlock, poststateBlock, providerCreator), nil
 		}
 		prestateValidator := NewPrestateValidator(contract.GetAbsolutePrestateHash, prestateProvider)
 		genesisValidator := NewPrestateValidator(contract.GetGenesisOutputRoot, prestateProvider)
-		return NewGamePlayer(ctx, logger, m, dir, game.Proxy, txMgr, contract, []Validator{prestateValidator, genesisValidator}, creator)
+		return NewGamePlayer(ctx, cl, logger, m, dir, game.Proxy, txMgr, contract, l1Source, actionPolicy, []Validator{prestateValidator, genesisValidator}, creator, largePreimages)
 	}
-	registry.RegisterGameType(cannonGameType, playerCreator)
+	registry.RegisterGameType(def.GameType, playerCreator)
 }
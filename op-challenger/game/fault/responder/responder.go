@@ -3,7 +3,9 @@ package responder
 import (
 	"context"
 	"fmt"
+	"math/big"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -21,7 +23,13 @@ type GameContract interface {
 	AttackTx(parentContractIndex uint64, pivot common.Hash) (txmgr.TxCandidate, error)
 	DefendTx(parentContractIndex uint64, pivot common.Hash) (txmgr.TxCandidate, error)
 	StepTx(claimIdx uint64, isAttack bool, stateData []byte, proof []byte) (txmgr.TxCandidate, error)
-	UpdateOracleTx(ctx context.Context, claimIdx uint64, data *types.PreimageOracleData) (txmgr.TxCandidate, error)
+}
+
+// MoveLimiter limits the bonds posted and moves made.
+type MoveLimiter interface {
+	// ReserveMove reserves bond for a move before it is sent, returning an error if the move would exceed the limits.
+	// The returned function must be called with whether the move was included once it completes.
+	ReserveMove(bond *big.Int) (func(included bool), error)
 }
 
 // FaultResponder implements the [Responder] interface to send onchain transactions.
@@ -30,14 +38,18 @@ type FaultResponder struct {
 
 	txMgr    txmgr.TxManager
 	contract GameContract
+	uploader preimages.PreimageUploader
+	limiter  MoveLimiter
 }
 
 // NewFaultResponder returns a new [FaultResponder].
-func NewFaultResponder(logger log.Logger, txMgr txmgr.TxManager, contract GameContract) (*FaultResponder, error) {
+func NewFaultResponder(logger log.Logger, txMgr txmgr.TxManager, contract GameContract, uploader preimages.PreimageUploader, limiter MoveLimiter) (*FaultResponder, error) {
 	return &FaultResponder{
 		log:      logger,
 		txMgr:    txMgr,
 		contract: contract,
+		uploader: uploader,
+		limiter:  limiter,
 	}, nil
 }
 
@@ -74,17 +86,13 @@ func (r *FaultResponder) ResolveClaim(ctx context.Context, claimIdx uint64) erro
 
 func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action) error {
 	if action.OracleData != nil {
-		r.log.Info("Updating oracle data", "key", action.OracleData.OracleKey)
-		candidate, err := r.contract.UpdateOracleTx(ctx, uint64(action.ParentIdx), action.OracleData)
-		if err != nil {
-			return fmt.Errorf("failed to create pre-image oracle tx: %w", err)
-		}
-		if err := r.sendTxAndWait(ctx, candidate); err != nil {
-			return fmt.Errorf("failed to populate pre-image oracle: %w", err)
+		if err := r.uploader.UploadPreimage(ctx, uint64(action.ParentIdx), action.OracleData); err != nil {
+			return fmt.Errorf("failed to upload preimage: %w", err)
 		}
 	}
 	var candidate txmgr.TxCandidate
 	var err error
+	moveDone := func(included bool) {}
 	switch action.Type {
 	case types.ActionTypeMove:
 		if action.IsAttack {
@@ -92,26 +100,42 @@ func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action)
 		} else {
 			candidate, err = r.contract.DefendTx(uint64(action.ParentIdx), action.Value)
 		}
+		if err != nil {
+			return err
+		}
+		moveDone, err = r.limiter.ReserveMove(candidate.Value)
+		if err != nil {
+			return fmt.Errorf("move declined: %w", err)
+		}
 	case types.ActionTypeStep:
 		candidate, err = r.contract.StepTx(uint64(action.ParentIdx), action.IsAttack, action.PreState, action.ProofData)
 	}
 	if err != nil {
 		return err
 	}
-	return r.sendTxAndWait(ctx, candidate)
+	included, err := r.sendTx(ctx, candidate)
+	moveDone(included)
+	return err
 }
 
 // sendTxAndWait sends a transaction through the [txmgr] and waits for a receipt.
 // This sets the tx GasLimit to 0, performing gas estimation online through the [txmgr].
 func (r *FaultResponder) sendTxAndWait(ctx context.Context, candidate txmgr.TxCandidate) error {
+	_, err := r.sendTx(ctx, candidate)
+	return err
+}
+
+// sendTx sends a transaction through the [txmgr] and waits for a receipt.
+// Returns true if the transaction was included without reverting.
+func (r *FaultResponder) sendTx(ctx context.Context, candidate txmgr.TxCandidate) (bool, error) {
 	receipt, err := r.txMgr.Send(ctx, candidate)
 	if err != nil {
-		return err
+		return false, err
 	}
 	if receipt.Status == ethtypes.ReceiptStatusFailed {
 		r.log.Error("Responder tx successfully published but reverted", "tx_hash", receipt.TxHash)
-	} else {
-		r.log.Debug("Responder tx successfully published", "tx_hash", receipt.TxHash)
+		return false, nil
 	}
-	return nil
+	r.log.Debug("Responder tx successfully published", "tx_hash", receipt.TxHash)
+	return true, nil
 }
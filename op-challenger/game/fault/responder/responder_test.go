@@ -3,6 +3,7 @@ package responder
 import (
 	"context"
 	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
@@ -18,14 +19,16 @@ import (
 )
 
 var (
-	mockSendError = errors.New("mock send error")
-	mockCallError = errors.New("mock call error")
+	mockSendError   = errors.New("mock send error")
+	mockCallError   = errors.New("mock call error")
+	mockUploadError = errors.New("mock upload error")
+	mockLimitError  = errors.New("mock limit error")
 )
 
 // TestCallResolve tests the [Responder.CallResolve].
 func TestCallResolve(t *testing.T) {
 	t.Run("SendFails", func(t *testing.T) {
-		responder, _, contract := newTestFaultResponder(t)
+		responder, _, contract, _ := newTestFaultResponder(t)
 		contract.callFails = true
 		status, err := responder.CallResolve(context.Background())
 		require.ErrorIs(t, err, mockCallError)
@@ -34,7 +37,7 @@ func TestCallResolve(t *testing.T) {
 	})
 
 	t.Run("Success", func(t *testing.T) {
-		responder, _, contract := newTestFaultResponder(t)
+		responder, _, contract, _ := newTestFaultResponder(t)
 		status, err := responder.CallResolve(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, gameTypes.GameStatusInProgress, status)
@@ -45,7 +48,7 @@ func TestCallResolve(t *testing.T) {
 // TestResolve tests the [Responder.Resolve] method.
 func TestResolve(t *testing.T) {
 	t.Run("SendFails", func(t *testing.T) {
-		responder, mockTxMgr, _ := newTestFaultResponder(t)
+		responder, mockTxMgr, _, _ := newTestFaultResponder(t)
 		mockTxMgr.sendFails = true
 		err := responder.Resolve(context.Background())
 		require.ErrorIs(t, err, mockSendError)
@@ -53,7 +56,7 @@ func TestResolve(t *testing.T) {
 	})
 
 	t.Run("Success", func(t *testing.T) {
-		responder, mockTxMgr, _ := newTestFaultResponder(t)
+		responder, mockTxMgr, _, _ := newTestFaultResponder(t)
 		err := responder.Resolve(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, 1, mockTxMgr.sends)
@@ -62,7 +65,7 @@ func TestResolve(t *testing.T) {
 
 func TestCallResolveClaim(t *testing.T) {
 	t.Run("SendFails", func(t *testing.T) {
-		responder, _, contract := newTestFaultResponder(t)
+		responder, _, contract, _ := newTestFaultResponder(t)
 		contract.callFails = true
 		err := responder.CallResolveClaim(context.Background(), 0)
 		require.ErrorIs(t, err, mockCallError)
@@ -70,7 +73,7 @@ func TestCallResolveClaim(t *testing.T) {
 	})
 
 	t.Run("Success", func(t *testing.T) {
-		responder, _, contract := newTestFaultResponder(t)
+		responder, _, contract, _ := newTestFaultResponder(t)
 		err := responder.CallResolveClaim(context.Background(), 0)
 		require.NoError(t, err)
 		require.Equal(t, 1, contract.calls)
@@ -79,7 +82,7 @@ func TestCallResolveClaim(t *testing.T) {
 
 func TestResolveClaim(t *testing.T) {
 	t.Run("SendFails", func(t *testing.T) {
-		responder, mockTxMgr, _ := newTestFaultResponder(t)
+		responder, mockTxMgr, _, _ := newTestFaultResponder(t)
 		mockTxMgr.sendFails = true
 		err := responder.ResolveClaim(context.Background(), 0)
 		require.ErrorIs(t, err, mockSendError)
@@ -87,7 +90,7 @@ func TestResolveClaim(t *testing.T) {
 	})
 
 	t.Run("Success", func(t *testing.T) {
-		responder, mockTxMgr, _ := newTestFaultResponder(t)
+		responder, mockTxMgr, _, _ := newTestFaultResponder(t)
 		err := responder.ResolveClaim(context.Background(), 0)
 		require.NoError(t, err)
 		require.Equal(t, 1, mockTxMgr.sends)
@@ -97,7 +100,7 @@ func TestResolveClaim(t *testing.T) {
 // TestRespond tests the [Responder.Respond] method.
 func TestPerformAction(t *testing.T) {
 	t.Run("send fails", func(t *testing.T) {
-		responder, mockTxMgr, _ := newTestFaultResponder(t)
+		responder, mockTxMgr, _, _ := newTestFaultResponder(t)
 		mockTxMgr.sendFails = true
 		err := responder.PerformAction(context.Background(), types.Action{
 			Type:      types.ActionTypeMove,
@@ -110,7 +113,7 @@ func TestPerformAction(t *testing.T) {
 	})
 
 	t.Run("sends response", func(t *testing.T) {
-		responder, mockTxMgr, _ := newTestFaultResponder(t)
+		responder, mockTxMgr, _, _ := newTestFaultResponder(t)
 		err := responder.PerformAction(context.Background(), types.Action{
 			Type:      types.ActionTypeMove,
 			ParentIdx: 123,
@@ -122,7 +125,7 @@ func TestPerformAction(t *testing.T) {
 	})
 
 	t.Run("attack", func(t *testing.T) {
-		responder, mockTxMgr, contract := newTestFaultResponder(t)
+		responder, mockTxMgr, contract, _ := newTestFaultResponder(t)
 		action := types.Action{
 			Type:      types.ActionTypeMove,
 			ParentIdx: 123,
@@ -138,7 +141,7 @@ func TestPerformAction(t *testing.T) {
 	})
 
 	t.Run("defend", func(t *testing.T) {
-		responder, mockTxMgr, contract := newTestFaultResponder(t)
+		responder, mockTxMgr, contract, _ := newTestFaultResponder(t)
 		action := types.Action{
 			Type:      types.ActionTypeMove,
 			ParentIdx: 123,
@@ -153,8 +156,64 @@ func TestPerformAction(t *testing.T) {
 		require.Equal(t, ([]byte)("defend"), mockTxMgr.sent[0].TxData)
 	})
 
+	t.Run("moveReservedAgainstLimits", func(t *testing.T) {
+		responder, _, _, _ := newTestFaultResponder(t)
+		limiter := responder.limiter.(*mockMoveLimiter)
+		err := responder.PerformAction(context.Background(), types.Action{
+			Type:      types.ActionTypeMove,
+			ParentIdx: 123,
+			IsAttack:  true,
+			Value:     common.Hash{0xaa},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []*big.Int{nil}, limiter.reserved)
+		require.Equal(t, []bool{true}, limiter.completed)
+	})
+
+	t.Run("moveDeclinedByLimits", func(t *testing.T) {
+		responder, mockTxMgr, _, _ := newTestFaultResponder(t)
+		limiter := responder.limiter.(*mockMoveLimiter)
+		limiter.declines = true
+		err := responder.PerformAction(context.Background(), types.Action{
+			Type:      types.ActionTypeMove,
+			ParentIdx: 123,
+			IsAttack:  true,
+			Value:     common.Hash{0xaa},
+		})
+		require.ErrorIs(t, err, mockLimitError)
+		require.Empty(t, mockTxMgr.sent)
+	})
+
+	t.Run("revertedMoveReleasesReservation", func(t *testing.T) {
+		responder, mockTxMgr, _, _ := newTestFaultResponder(t)
+		mockTxMgr.reverts = true
+		limiter := responder.limiter.(*mockMoveLimiter)
+		err := responder.PerformAction(context.Background(), types.Action{
+			Type:      types.ActionTypeMove,
+			ParentIdx: 123,
+			IsAttack:  true,
+			Value:     common.Hash{0xaa},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []bool{false}, limiter.completed)
+	})
+
+	t.Run("failedMoveReleasesReservation", func(t *testing.T) {
+		responder, mockTxMgr, _, _ := newTestFaultResponder(t)
+		mockTxMgr.sendFails = true
+		limiter := responder.limiter.(*mockMoveLimiter)
+		err := responder.PerformAction(context.Background(), types.Action{
+			Type:      types.ActionTypeMove,
+			ParentIdx: 123,
+			IsAttack:  true,
+			Value:     common.Hash{0xaa},
+		})
+		require.ErrorIs(t, err, mockSendError)
+		require.Equal(t, []bool{false}, limiter.completed)
+	})
+
 	t.Run("step", func(t *testing.T) {
-		responder, mockTxMgr, contract := newTestFaultResponder(t)
+		responder, mockTxMgr, contract, _ := newTestFaultResponder(t)
 		action := types.Action{
 			Type:      types.ActionTypeStep,
 			ParentIdx: 123,
@@ -171,7 +230,7 @@ func TestPerformAction(t *testing.T) {
 	})
 
 	t.Run("stepWithOracleData", func(t *testing.T) {
-		responder, mockTxMgr, contract := newTestFaultResponder(t)
+		responder, mockTxMgr, contract, uploader := newTestFaultResponder(t)
 		action := types.Action{
 			Type:      types.ActionTypeStep,
 			ParentIdx: 123,
@@ -185,23 +244,78 @@ func TestPerformAction(t *testing.T) {
 		err := responder.PerformAction(context.Background(), action)
 		require.NoError(t, err)
 
-		require.Len(t, mockTxMgr.sent, 2)
-		require.EqualValues(t, action.OracleData, contract.updateOracleArgs)
-		require.EqualValues(t, action.ParentIdx, contract.updateOracleClaimIdx)
-		require.EqualValues(t, []interface{}{uint64(action.ParentIdx), action.IsAttack, action.PreState, action.ProofData}, contract.stepArgs)
+		require.Len(t, mockTxMgr.sent, 1)
+		require.Equal(t, 1, uploader.updates)
+		require.EqualValues(t, action.OracleData, uploader.lastData)
+		require.EqualValues(t, action.ParentIdx, uploader.lastClaimIdx)
 		// Important that the oracle is updated first
-		require.Equal(t, ([]byte)("updateOracle"), mockTxMgr.sent[0].TxData)
-		require.Equal(t, ([]byte)("step"), mockTxMgr.sent[1].TxData)
+		require.Equal(t, 0, uploader.sendsBeforeUpload)
+		require.EqualValues(t, []interface{}{uint64(action.ParentIdx), action.IsAttack, action.PreState, action.ProofData}, contract.stepArgs)
+		require.Equal(t, ([]byte)("step"), mockTxMgr.sent[0].TxData)
+	})
+
+	t.Run("stepWithOracleDataUploadFails", func(t *testing.T) {
+		responder, mockTxMgr, _, uploader := newTestFaultResponder(t)
+		uploader.uploadFails = true
+		action := types.Action{
+			Type:       types.ActionTypeStep,
+			ParentIdx:  123,
+			IsAttack:   true,
+			PreState:   []byte{1, 2, 3},
+			ProofData:  []byte{4, 5, 6},
+			OracleData: &types.PreimageOracleData{},
+		}
+		err := responder.PerformAction(context.Background(), action)
+		require.ErrorIs(t, err, mockUploadError)
+		// The step isn't sent without the preimage
+		require.Empty(t, mockTxMgr.sent)
 	})
 }
 
-func newTestFaultResponder(t *testing.T) (*FaultResponder, *mockTxManager, *mockContract) {
+func newTestFaultResponder(t *testing.T) (*FaultResponder, *mockTxManager, *mockContract, *mockPreimageUploader) {
 	log := testlog.Logger(t, log.LvlError)
 	mockTxMgr := &mockTxManager{}
 	contract := &mockContract{}
-	responder, err := NewFaultResponder(log, mockTxMgr, contract)
+	uploader := &mockPreimageUploader{txMgr: mockTxMgr}
+	responder, err := NewFaultResponder(log, mockTxMgr, contract, uploader, &mockMoveLimiter{})
 	require.NoError(t, err)
-	return responder, mockTxMgr, contract
+	return responder, mockTxMgr, contract, uploader
+}
+
+type mockMoveLimiter struct {
+	declines  bool
+	reserved  []*big.Int
+	completed []bool
+}
+
+func (m *mockMoveLimiter) ReserveMove(bond *big.Int) (func(included bool), error) {
+	if m.declines {
+		return nil, mockLimitError
+	}
+	m.reserved = append(m.reserved, bond)
+	return func(included bool) {
+		m.completed = append(m.completed, included)
+	}, nil
+}
+
+type mockPreimageUploader struct {
+	txMgr             *mockTxManager
+	updates           int
+	uploadFails       bool
+	lastClaimIdx      uint64
+	lastData          *types.PreimageOracleData
+	sendsBeforeUpload int
+}
+
+func (m *mockPreimageUploader) UploadPreimage(_ context.Context, claimIdx uint64, data *types.PreimageOracleData) error {
+	if m.uploadFails {
+		return mockUploadError
+	}
+	m.updates++
+	m.lastClaimIdx = claimIdx
+	m.lastData = data
+	m.sendsBeforeUpload = m.txMgr.sends
+	return nil
 }
 
 type mockTxManager struct {
@@ -209,6 +323,7 @@ type mockTxManager struct {
 	sends     int
 	sent      []txmgr.TxCandidate
 	sendFails bool
+	reverts   bool
 }
 
 func (m *mockTxManager) Send(_ context.Context, candidate txmgr.TxCandidate) (*ethtypes.Receipt, error) {
@@ -219,7 +334,7 @@ func (m *mockTxManager) Send(_ context.Context, candidate txmgr.TxCandidate) (*e
 	m.sent = append(m.sent, candidate)
 	return ethtypes.NewReceipt(
 		[]byte{},
-		false,
+		m.reverts,
 		0,
 	), nil
 }
@@ -236,13 +351,11 @@ func (m *mockTxManager) Close() {
 }
 
 type mockContract struct {
-	calls                int
-	callFails            bool
-	attackArgs           []interface{}
-	defendArgs           []interface{}
-	stepArgs             []interface{}
-	updateOracleClaimIdx uint64
-	updateOracleArgs     *types.PreimageOracleData
+	calls      int
+	callFails  bool
+	attackArgs []interface{}
+	defendArgs []interface{}
+	stepArgs   []interface{}
 }
 
 func (m *mockContract) CallResolve(_ context.Context) (gameTypes.GameStatus, error) {
@@ -283,9 +396,3 @@ func (m *mockContract) StepTx(claimIdx uint64, isAttack bool, stateData []byte,
 	m.stepArgs = []interface{}{claimIdx, isAttack, stateData, proofData}
 	return txmgr.TxCandidate{TxData: ([]byte)("step")}, nil
 }
-
-func (m *mockContract) UpdateOracleTx(_ context.Context, claimIdx uint64, data *types.PreimageOracleData) (txmgr.TxCandidate, error) {
-	m.updateOracleClaimIdx = claimIdx
-	m.updateOracleArgs = data
-	return txmgr.TxCandidate{TxData: ([]byte)("updateOracle")}, nil
-}
@@ -0,0 +1,121 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type ActorType string
+
+const (
+	// ActorHonest plays the game with the honest trace.
+	ActorHonest ActorType = "honest"
+	// ActorDishonest plays the game consistently, but with a trace that diverges from the honest trace.
+	ActorDishonest ActorType = "dishonest"
+	// ActorRandom makes moves with random values against random claims, and steps with random state data.
+	ActorRandom ActorType = "random"
+)
+
+var ActorTypes = []ActorType{ActorHonest, ActorDishonest, ActorRandom}
+
+func (a ActorType) String() string {
+	return string(a)
+}
+
+func ValidActorType(value ActorType) bool {
+	for _, a := range ActorTypes {
+		if a == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Actor decides the actions to perform in a game.
+type Actor interface {
+	Name() string
+	Act(ctx context.Context, game types.Game) ([]types.Action, error)
+}
+
+// solverActor uses a [solver.GameSolver] to choose its actions, as the challenger agent does.
+type solverActor struct {
+	name   string
+	logger log.Logger
+	solver *solver.GameSolver
+}
+
+func newSolverActor(name string, logger log.Logger, maxDepth uint64, accessor types.TraceAccessor) *solverActor {
+	return &solverActor{
+		name:   name,
+		logger: logger.New("actor", name),
+		solver: solver.NewGameSolver(int(maxDepth), accessor),
+	}
+}
+
+func (a *solverActor) Name() string {
+	return a.name
+}
+
+func (a *solverActor) Act(ctx context.Context, game types.Game) ([]types.Action, error) {
+	actions, err := a.solver.CalculateNextActions(ctx, game)
+	if err != nil {
+		// Like the agent, still perform the actions that could be calculated.
+		a.logger.Warn("Failed to calculate all required moves", "err", err)
+	}
+	return actions, nil
+}
+
+// randomActor performs a single random action each time it acts, until it has used all of its moves.
+type randomActor struct {
+	name     string
+	rng      *rand.Rand
+	maxDepth uint64
+	moves    int
+}
+
+func newRandomActor(name string, rng *rand.Rand, maxDepth uint64, moves int) *randomActor {
+	return &randomActor{
+		name:     name,
+		rng:      rng,
+		maxDepth: maxDepth,
+		moves:    moves,
+	}
+}
+
+func (a *randomActor) Name() string {
+	return a.name
+}
+
+func (a *randomActor) Act(_ context.Context, game types.Game) ([]types.Action, error) {
+	if a.moves <= 0 {
+		return nil, nil
+	}
+	a.moves--
+	claims := game.Claims()
+	parent := claims[a.rng.Intn(len(claims))]
+	action := types.Action{
+		ParentIdx: parent.ContractIndex,
+		IsAttack:  parent.IsRoot() || a.rng.Intn(2) == 0,
+	}
+	if uint64(parent.Depth()) == a.maxDepth {
+		action.Type = types.ActionTypeStep
+		action.PreState = make([]byte, 64)
+		if _, err := a.rng.Read(action.PreState); err != nil {
+			return nil, fmt.Errorf("failed to generate state data: %w", err)
+		}
+	} else {
+		action.Type = types.ActionTypeMove
+		if _, err := a.rng.Read(action.Value[:]); err != nil {
+			return nil, fmt.Errorf("failed to generate claim: %w", err)
+		}
+	}
+	return []types.Action{action}, nil
+}
+
+var _ Actor = (*solverActor)(nil)
+var _ Actor = (*randomActor)(nil)
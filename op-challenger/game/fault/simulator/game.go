@@ -0,0 +1,230 @@
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Errors returned when an action is rejected. They mirror the reverts of the FaultDisputeGame contract.
+var (
+	ErrParentNotFound     = errors.New("parent claim does not exist")
+	ErrCannotDefendRoot   = errors.New("cannot defend root claim")
+	ErrGameDepthExceeded  = errors.New("game depth exceeded")
+	ErrClaimAlreadyExists = errors.New("claim already exists")
+	ErrInvalidParent      = errors.New("step parent is not at max depth")
+	ErrInvalidPrestate    = errors.New("state data does not match prestate claim")
+	ErrValidStep          = errors.New("step does not counter parent claim")
+	ErrAncestorNotFound   = errors.New("trace ancestor not found")
+)
+
+// rootParentIndex is the parent index of the root claim, matching the value used by the contract.
+const rootParentIndex = math.MaxUint32
+
+// StepVM returns the state commitment that results from executing a single instruction from stateData.
+// The parent is the max depth claim being stepped against.
+type StepVM func(ctx context.Context, game types.Game, parent types.Claim, stateData []byte) (common.Hash, error)
+
+type claimKey struct {
+	value  common.Hash
+	gindex string
+	parent int
+}
+
+// Game is an in-memory dispute game that applies moves and steps with the same validation as the
+// FaultDisputeGame contract. Chess clocks, bonds and the VM status checks on execution trace subgame
+// root claims are not simulated.
+type Game struct {
+	maxDepth uint64
+	// splitDepth is the depth of the output root game leaves, or -1 if the game has no output root game.
+	splitDepth       int
+	absolutePrestate common.Hash
+	vm               StepVM
+
+	claims []types.Claim
+	exists map[claimKey]bool
+}
+
+func NewGame(rootClaim common.Hash, maxDepth uint64, splitDepth int, absolutePrestate common.Hash, vm StepVM) *Game {
+	root := types.Claim{
+		ClaimData:           types.ClaimData{Value: rootClaim, Position: types.NewPositionFromGIndex(big.NewInt(1))},
+		ParentContractIndex: rootParentIndex,
+	}
+	return &Game{
+		maxDepth:         maxDepth,
+		splitDepth:       splitDepth,
+		absolutePrestate: absolutePrestate,
+		vm:               vm,
+		claims:           []types.Claim{root},
+		exists:           map[claimKey]bool{keyOf(root): true},
+	}
+}
+
+func keyOf(claim types.Claim) claimKey {
+	return claimKey{value: claim.Value, gindex: claim.Position.ToGIndex().String(), parent: claim.ParentContractIndex}
+}
+
+// State returns a snapshot of the current claims in the game.
+func (g *Game) State() types.Game {
+	claims := make([]types.Claim, len(g.claims))
+	copy(claims, g.claims)
+	return types.NewGameState(claims, g.maxDepth)
+}
+
+// Apply performs the action, returning the index of the new claim for moves or -1 for steps.
+func (g *Game) Apply(ctx context.Context, action types.Action) (int, error) {
+	switch action.Type {
+	case types.ActionTypeMove:
+		return g.Move(action.ParentIdx, action.Value, action.IsAttack)
+	case types.ActionTypeStep:
+		return -1, g.Step(ctx, action.ParentIdx, action.IsAttack, action.PreState)
+	default:
+		return -1, fmt.Errorf("unknown action type: %v", action.Type)
+	}
+}
+
+// Move adds a claim that attacks or defends the claim at parentIdx and counters the parent.
+func (g *Game) Move(parentIdx int, value common.Hash, isAttack bool) (int, error) {
+	if parentIdx < 0 || parentIdx >= len(g.claims) {
+		return -1, fmt.Errorf("%w: %v", ErrParentNotFound, parentIdx)
+	}
+	parent := g.claims[parentIdx]
+	pos := movePosition(parent.Position, isAttack)
+	if (parentIdx == 0 || pos.Depth() == g.splitDepth+2) && !isAttack {
+		return -1, ErrCannotDefendRoot
+	}
+	if uint64(pos.Depth()) > g.maxDepth {
+		return -1, fmt.Errorf("%w: depth %v", ErrGameDepthExceeded, pos.Depth())
+	}
+	claim := types.Claim{
+		ClaimData:           types.ClaimData{Value: value, Position: pos},
+		ContractIndex:       len(g.claims),
+		ParentContractIndex: parentIdx,
+	}
+	key := keyOf(claim)
+	if g.exists[key] {
+		return -1, ErrClaimAlreadyExists
+	}
+	g.exists[key] = true
+	g.claims = append(g.claims, claim)
+	g.claims[parentIdx].Countered = true
+	return claim.ContractIndex, nil
+}
+
+// Step executes a single instruction against the max depth claim at parentIdx, countering it if the
+// resulting state shows the claim is invalid.
+func (g *Game) Step(ctx context.Context, parentIdx int, isAttack bool, stateData []byte) error {
+	if parentIdx < 0 || parentIdx >= len(g.claims) {
+		return fmt.Errorf("%w: %v", ErrParentNotFound, parentIdx)
+	}
+	parent := g.claims[parentIdx]
+	if uint64(parent.Depth()) != g.maxDepth {
+		return ErrInvalidParent
+	}
+	parentGIndex := parent.Position.ToGIndex()
+	var preStateClaim common.Hash
+	var postState types.Claim
+	if isAttack {
+		stepIndex := movePosition(parent.Position, true).IndexAtDepth()
+		subgameLeaves := new(big.Int).Lsh(big.NewInt(1), uint(int(g.maxDepth)-g.splitDepth))
+		if new(big.Int).Mod(stepIndex, subgameLeaves).Sign() == 0 {
+			preStateClaim = g.absolutePrestate
+		} else {
+			preState, err := g.findTraceAncestor(new(big.Int).Sub(parentGIndex, big.NewInt(1)), parent.ParentContractIndex)
+			if err != nil {
+				return err
+			}
+			preStateClaim = preState.Value
+		}
+		postState = parent
+	} else {
+		preStateClaim = parent.Value
+		var err error
+		postState, err = g.findTraceAncestor(new(big.Int).Add(parentGIndex, big.NewInt(1)), parent.ParentContractIndex)
+		if err != nil {
+			return err
+		}
+	}
+	// The highest order byte is the VM status and is not part of the state hash.
+	stateHash := crypto.Keccak256Hash(stateData)
+	if !bytes.Equal(stateHash[1:], preStateClaim[1:]) {
+		return ErrInvalidPrestate
+	}
+	result, err := g.vm(ctx, g.State(), parent, stateData)
+	if err != nil {
+		return fmt.Errorf("vm step failed: %w", err)
+	}
+	validStep := result == postState.Value
+	parentPostAgree := (parent.Depth()-postState.Depth())%2 == 0
+	if parentPostAgree == validStep {
+		return ErrValidStep
+	}
+	g.claims[parentIdx].Countered = true
+	return nil
+}
+
+// findTraceAncestor walks up from the claim at start to find the claim that commits to the same trace index as gindex.
+func (g *Game) findTraceAncestor(gindex *big.Int, start int) (types.Claim, error) {
+	ancestor := traceAncestor(gindex)
+	if g.splitDepth >= 0 {
+		if gindex.BitLen()-1 <= g.splitDepth {
+			return types.Claim{}, fmt.Errorf("%w: position %v is above the split depth", ErrAncestorNotFound, gindex)
+		}
+		// Keep the ancestor within the execution trace subgame. Only positions that commit to the final
+		// leaf of a subgame have an ancestor above the split depth.
+		if depth := ancestor.BitLen() - 1; depth <= g.splitDepth {
+			ancestor.Add(ancestor, big.NewInt(1))
+			ancestor.Lsh(ancestor, uint(g.splitDepth+1-depth))
+			ancestor.Sub(ancestor, big.NewInt(1))
+		}
+	}
+	idx := start
+	for idx >= 0 && idx < len(g.claims) {
+		claim := g.claims[idx]
+		if claim.Position.ToGIndex().Cmp(ancestor) == 0 {
+			return claim, nil
+		}
+		idx = claim.ParentContractIndex
+	}
+	return types.Claim{}, fmt.Errorf("%w: gindex %v", ErrAncestorNotFound, ancestor)
+}
+
+// traceAncestor returns the highest ancestor of gindex that commits to the same trace index.
+func traceAncestor(gindex *big.Int) *big.Int {
+	ancestor := new(big.Int).Set(gindex)
+	for ancestor.Bit(0) == 1 {
+		ancestor.Rsh(ancestor, 1)
+	}
+	if ancestor.Sign() == 0 {
+		ancestor.SetUint64(1)
+	}
+	return ancestor
+}
+
+// movePosition returns the position of a move against a claim at pos, which is the left child of pos
+// for an attack and the left child of pos+1 for a defense.
+func movePosition(pos types.Position, isAttack bool) types.Position {
+	gindex := pos.ToGIndex()
+	if !isAttack {
+		gindex.SetBit(gindex, 0, 1)
+	}
+	return types.NewPositionFromGIndex(gindex.Lsh(gindex, 1))
+}
+
+// Resolve returns the outcome of the game and its claims with countered updated as the contract would
+// after resolving every subgame once all clocks have expired.
+func (g *Game) Resolve() (gameTypes.GameStatus, []types.Claim) {
+	claims := types.ResolveClaims(g.claims)
+	if claims[0].Countered {
+		return gameTypes.GameStatusChallengerWon, claims
+	}
+	return gameTypes.GameStatusDefenderWon, claims
+}
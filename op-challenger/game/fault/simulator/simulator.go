@@ -0,0 +1,219 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrNoActors          = errors.New("no actors specified")
+	ErrInvalidActor      = errors.New("invalid actor type")
+	ErrInvalidTraceType  = errors.New("invalid trace type")
+	ErrInvalidMaxDepth   = errors.New("max depth must be between 1 and 16")
+	ErrInvalidSplitDepth = errors.New("split depth must be less than max depth - 1")
+	ErrInvalidDivergeAt  = errors.New("diverge index must be within the trace")
+)
+
+type TraceType string
+
+const (
+	// TraceTypeAlphabet plays a game over a single alphabet trace.
+	TraceTypeAlphabet TraceType = "alphabet"
+	// TraceTypeOutputAlphabet plays an output root game above the split depth and alphabet traces below it.
+	TraceTypeOutputAlphabet TraceType = "output-alphabet"
+)
+
+var TraceTypes = []TraceType{TraceTypeAlphabet, TraceTypeOutputAlphabet}
+
+func (t TraceType) String() string {
+	return string(t)
+}
+
+func ValidTraceType(value TraceType) bool {
+	for _, t := range TraceTypes {
+		if t == value {
+			return true
+		}
+	}
+	return false
+}
+
+type Config struct {
+	TraceType TraceType
+	MaxDepth  uint64
+	// SplitDepth is the depth of the output root game leaves. Only used by output-alphabet games.
+	SplitDepth uint64
+	// CorrectRoot is true if the root claim is from the honest trace, otherwise it is from the dishonest trace.
+	CorrectRoot bool
+	// DivergeAt is the first trace index where the dishonest trace differs from the honest trace.
+	// For output-alphabet games this is the output root trace index.
+	DivergeAt uint64
+	Actors    []ActorType
+	// Seed is used by random actors.
+	Seed int64
+	// RandomMoves is the number of actions each random actor performs.
+	RandomMoves int
+	// MaxRounds limits the length of the game if the actors never stop making moves.
+	MaxRounds int
+}
+
+// TraceLength returns the number of indices in the trace that DivergeAt applies to.
+func (c Config) TraceLength() uint64 {
+	if c.TraceType == TraceTypeOutputAlphabet {
+		return uint64(1) << c.SplitDepth
+	}
+	return uint64(1) << c.MaxDepth
+}
+
+func (c Config) Check() error {
+	if !ValidTraceType(c.TraceType) {
+		return fmt.Errorf("%w: %v", ErrInvalidTraceType, c.TraceType)
+	}
+	if c.MaxDepth == 0 || c.MaxDepth > 16 {
+		return ErrInvalidMaxDepth
+	}
+	if c.TraceType == TraceTypeOutputAlphabet && c.SplitDepth+1 >= c.MaxDepth {
+		return ErrInvalidSplitDepth
+	}
+	if c.DivergeAt >= c.TraceLength() {
+		return fmt.Errorf("%w: %v not less than trace length %v", ErrInvalidDivergeAt, c.DivergeAt, c.TraceLength())
+	}
+	if len(c.Actors) == 0 {
+		return ErrNoActors
+	}
+	for _, a := range c.Actors {
+		if !ValidActorType(a) {
+			return fmt.Errorf("%w: %v", ErrInvalidActor, a)
+		}
+	}
+	return nil
+}
+
+// Event is an action performed by an actor during the game.
+type Event struct {
+	Round  int
+	Actor  string
+	Action types.Action
+	// ClaimIdx is the index of the claim added by a move, or -1 if no claim was added.
+	ClaimIdx int
+	// Violation describes the rules in the solver package that the action broke, if any.
+	Violation error
+	// Err is set if the game rejected the action.
+	Err error
+}
+
+type Result struct {
+	Events []Event
+	// Claims are the final claims in the game, with countered set by resolution.
+	Claims []types.Claim
+	// Agree reports whether the honest trace agrees with each claim.
+	Agree  []bool
+	Status gameTypes.GameStatus
+	// ExpectedStatus is the status the game should resolve to if an honest actor participates.
+	ExpectedStatus gameTypes.GameStatus
+	Rounds         int
+	// Completed is false if the game was stopped by the round limit rather than running out of moves.
+	Completed bool
+}
+
+func (r *Result) Violations() []Event {
+	var violations []Event
+	for _, e := range r.Events {
+		if e.Violation != nil {
+			violations = append(violations, e)
+		}
+	}
+	return violations
+}
+
+// Simulate plays a game between the configured actors until no actor has any more actions to perform,
+// then resolves it.
+func Simulate(ctx context.Context, logger log.Logger, cfg Config) (*Result, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	var t *traces
+	switch cfg.TraceType {
+	case TraceTypeAlphabet:
+		t = newAlphabetTraces(cfg.MaxDepth, cfg.DivergeAt)
+	case TraceTypeOutputAlphabet:
+		t = newOutputAlphabetTraces(ctx, logger, cfg.MaxDepth, cfg.SplitDepth, cfg.DivergeAt)
+	}
+	rootAccessor := t.dishonest
+	if cfg.CorrectRoot {
+		rootAccessor = t.honest
+	}
+	rootClaim, err := rootAccessor.Get(ctx, nil, types.Claim{}, types.NewPositionFromGIndex(common.Big1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate root claim: %w", err)
+	}
+	prestate, err := absolutePrestate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate absolute prestate: %w", err)
+	}
+	game := NewGame(rootClaim, cfg.MaxDepth, t.splitDepth, prestate, t.step)
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	actors := make([]Actor, len(cfg.Actors))
+	for i, actorType := range cfg.Actors {
+		name := fmt.Sprintf("%v-%d", actorType, i)
+		switch actorType {
+		case ActorHonest:
+			actors[i] = newSolverActor(name, logger, cfg.MaxDepth, t.honest)
+		case ActorDishonest:
+			actors[i] = newSolverActor(name, logger, cfg.MaxDepth, t.dishonest)
+		case ActorRandom:
+			actors[i] = newRandomActor(name, rng, cfg.MaxDepth, cfg.RandomMoves)
+		}
+	}
+
+	result := &Result{ExpectedStatus: gameTypes.GameStatusChallengerWon}
+	if cfg.CorrectRoot {
+		result.ExpectedStatus = gameTypes.GameStatusDefenderWon
+	}
+	for round := 1; round <= cfg.MaxRounds && !result.Completed; round++ {
+		result.Rounds = round
+		progress := false
+		for _, actor := range actors {
+			state := game.State()
+			actions, err := actor.Act(ctx, state)
+			if err != nil {
+				return nil, fmt.Errorf("actor %v failed to act: %w", actor.Name(), err)
+			}
+			for _, action := range actions {
+				idx, err := game.Apply(ctx, action)
+				result.Events = append(result.Events, Event{
+					Round:     round,
+					Actor:     actor.Name(),
+					Action:    action,
+					ClaimIdx:  idx,
+					Violation: solver.CheckRules(state, action),
+					Err:       err,
+				})
+				if err == nil {
+					progress = true
+				}
+			}
+		}
+		result.Completed = !progress
+	}
+
+	result.Status, result.Claims = game.Resolve()
+	state := game.State()
+	for _, claim := range result.Claims {
+		expected, err := t.honest.Get(ctx, state, claim, claim.Position)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check agreement with claim %v: %w", claim.ContractIndex, err)
+		}
+		result.Agree = append(result.Agree, expected == claim.Value)
+	}
+	return result, nil
+}
@@ -0,0 +1,170 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	actorSets := [][]ActorType{
+		{ActorHonest},
+		{ActorHonest, ActorDishonest},
+		{ActorDishonest, ActorHonest},
+		{ActorHonest, ActorRandom},
+		{ActorHonest, ActorDishonest, ActorRandom},
+	}
+	configs := []Config{
+		{TraceType: TraceTypeAlphabet, MaxDepth: 4, DivergeAt: 0},
+		{TraceType: TraceTypeAlphabet, MaxDepth: 4, DivergeAt: 7},
+		{TraceType: TraceTypeAlphabet, MaxDepth: 4, DivergeAt: 15},
+		{TraceType: TraceTypeOutputAlphabet, MaxDepth: 6, SplitDepth: 2, DivergeAt: 0},
+		{TraceType: TraceTypeOutputAlphabet, MaxDepth: 6, SplitDepth: 2, DivergeAt: 2},
+		{TraceType: TraceTypeOutputAlphabet, MaxDepth: 7, SplitDepth: 3, DivergeAt: 7},
+	}
+	for _, cfg := range configs {
+		for _, actors := range actorSets {
+			for _, correctRoot := range []bool{true, false} {
+				cfg := cfg
+				cfg.Actors = actors
+				cfg.CorrectRoot = correctRoot
+				cfg.RandomMoves = 10
+				cfg.MaxRounds = 100
+				name := fmt.Sprintf("%v-depth%v-diverge%v-%v-correctRoot%v", cfg.TraceType, cfg.MaxDepth, cfg.DivergeAt, actors, correctRoot)
+				t.Run(name, func(t *testing.T) {
+					result, err := Simulate(context.Background(), testlog.Logger(t, log.LvlError), cfg)
+					require.NoError(t, err)
+					require.True(t, result.Completed)
+					require.Equal(t, result.ExpectedStatus, result.Status)
+					for _, e := range result.Events {
+						if strings.HasPrefix(e.Actor, string(ActorRandom)) {
+							continue
+						}
+						require.NoErrorf(t, e.Violation, "actor %v violated rules", e.Actor)
+						if strings.HasPrefix(e.Actor, string(ActorHonest)) {
+							require.NoErrorf(t, e.Err, "honest action rejected: %+v", e.Action)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestSimulateDishonestOnly(t *testing.T) {
+	cfg := Config{
+		TraceType:   TraceTypeAlphabet,
+		MaxDepth:    4,
+		DivergeAt:   5,
+		Actors:      []ActorType{ActorDishonest},
+		CorrectRoot: true,
+		MaxRounds:   100,
+	}
+	result, err := Simulate(context.Background(), testlog.Logger(t, log.LvlError), cfg)
+	require.NoError(t, err)
+	require.True(t, result.Completed)
+	// Without an honest actor to defend it, the correct root claim is countered.
+	require.Equal(t, gameTypes.GameStatusChallengerWon, result.Status)
+	require.Equal(t, gameTypes.GameStatusDefenderWon, result.ExpectedStatus)
+	require.True(t, result.Agree[0])
+	require.Len(t, result.Agree, len(result.Claims))
+}
+
+func TestSimulateMaxRounds(t *testing.T) {
+	cfg := Config{
+		TraceType: TraceTypeAlphabet,
+		MaxDepth:  4,
+		Actors:    []ActorType{ActorHonest, ActorDishonest},
+		MaxRounds: 2,
+	}
+	result, err := Simulate(context.Background(), testlog.Logger(t, log.LvlError), cfg)
+	require.NoError(t, err)
+	require.False(t, result.Completed)
+	require.Equal(t, 2, result.Rounds)
+}
+
+func TestConfigCheck(t *testing.T) {
+	valid := Config{TraceType: TraceTypeOutputAlphabet, MaxDepth: 6, SplitDepth: 2, Actors: []ActorType{ActorHonest}}
+	require.NoError(t, valid.Check())
+
+	tests := []struct {
+		name     string
+		modifier func(cfg *Config)
+		expected error
+	}{
+		{"TraceType", func(cfg *Config) { cfg.TraceType = "cannon" }, ErrInvalidTraceType},
+		{"ZeroMaxDepth", func(cfg *Config) { cfg.MaxDepth = 0 }, ErrInvalidMaxDepth},
+		{"LargeMaxDepth", func(cfg *Config) { cfg.MaxDepth = 17 }, ErrInvalidMaxDepth},
+		{"SplitDepth", func(cfg *Config) { cfg.SplitDepth = 5 }, ErrInvalidSplitDepth},
+		{"DivergeAt", func(cfg *Config) { cfg.DivergeAt = 4 }, ErrInvalidDivergeAt},
+		{"NoActors", func(cfg *Config) { cfg.Actors = nil }, ErrNoActors},
+		{"Actor", func(cfg *Config) { cfg.Actors = []ActorType{"lazy"} }, ErrInvalidActor},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := valid
+			test.modifier(&cfg)
+			require.ErrorIs(t, cfg.Check(), test.expected)
+		})
+	}
+}
+
+func TestGameRejectsInvalidActions(t *testing.T) {
+	ctx := context.Background()
+	vm := func(_ context.Context, _ types.Game, _ types.Claim, _ []byte) (common.Hash, error) {
+		return common.Hash{}, nil
+	}
+	game := NewGame(common.Hash{0xaa}, 2, -1, common.Hash{}, vm)
+
+	_, err := game.Move(0, common.Hash{0xbb}, false)
+	require.ErrorIs(t, err, ErrCannotDefendRoot)
+	_, err = game.Move(1, common.Hash{0xbb}, true)
+	require.ErrorIs(t, err, ErrParentNotFound)
+
+	idx, err := game.Move(0, common.Hash{0xbb}, true)
+	require.NoError(t, err)
+	require.Equal(t, 1, idx)
+	require.True(t, game.State().Claims()[0].Countered)
+	_, err = game.Move(0, common.Hash{0xbb}, true)
+	require.ErrorIs(t, err, ErrClaimAlreadyExists)
+
+	require.ErrorIs(t, game.Step(ctx, 1, true, nil), ErrInvalidParent)
+	leaf, err := game.Move(idx, common.Hash{0xcc}, true)
+	require.NoError(t, err)
+	_, err = game.Move(leaf, common.Hash{0xdd}, true)
+	require.ErrorIs(t, err, ErrGameDepthExceeded)
+	require.ErrorIs(t, game.Step(ctx, leaf, true, []byte{1}), ErrInvalidPrestate)
+
+	status, claims := game.Resolve()
+	require.Equal(t, gameTypes.GameStatusDefenderWon, status)
+	require.False(t, claims[leaf].Countered)
+	require.True(t, claims[idx].Countered)
+	require.False(t, claims[0].Countered)
+}
+
+func TestTraceAncestor(t *testing.T) {
+	tests := []struct {
+		gindex   int64
+		expected int64
+	}{
+		{1, 1},
+		{2, 2},
+		{3, 1},
+		{0b1011, 0b10},
+		{0b1111, 1},
+		{0b10110, 0b10110},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, traceAncestor(big.NewInt(test.gindex)).Int64(), "gindex %b", test.gindex)
+	}
+}
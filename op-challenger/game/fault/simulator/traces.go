@@ -0,0 +1,141 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/split"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// prestateBlock is the L2 block of the agreed starting output root in output-alphabet games.
+const prestateBlock = 1000
+
+// traces holds the honest and dishonest views of a game's trace.
+type traces struct {
+	maxDepth   uint64
+	splitDepth int
+
+	honestSelector trace.ProviderSelector
+	honest         types.TraceAccessor
+	dishonest      types.TraceAccessor
+}
+
+// alphabetTrace returns the honest alphabet trace with a letter for every leaf of a game with the given depth.
+func alphabetTrace(depth uint64) string {
+	letters := make([]byte, 1<<depth)
+	for i := range letters {
+		letters[i] = 'a' + byte(i%26)
+	}
+	return string(letters)
+}
+
+// divergeTrace returns a copy of the alphabet trace with the case of every letter from index onwards flipped.
+func divergeTrace(trace string, index uint64) string {
+	letters := []byte(trace)
+	for i := index; i < uint64(len(letters)); i++ {
+		letters[i] ^= 0x20
+	}
+	return string(letters)
+}
+
+func newAlphabetTraces(maxDepth uint64, divergeAt uint64) *traces {
+	honestTrace := alphabetTrace(maxDepth)
+	honestProvider := alphabet.NewTraceProvider(honestTrace, maxDepth)
+	dishonestProvider := alphabet.NewTraceProvider(divergeTrace(honestTrace, divergeAt), maxDepth)
+	return &traces{
+		maxDepth:   maxDepth,
+		splitDepth: -1,
+		honestSelector: func(_ context.Context, _ types.Game, _ types.Claim, _ types.Position) (types.TraceProvider, error) {
+			return honestProvider, nil
+		},
+		honest:    trace.NewSimpleTraceAccessor(honestProvider),
+		dishonest: trace.NewSimpleTraceAccessor(dishonestProvider),
+	}
+}
+
+// outputSource is an [outputs.OutputRollupClient] with deterministic output roots.
+// Output roots from divergeBlock onwards differ from the honest output roots.
+type outputSource struct {
+	divergeBlock uint64
+	dishonest    bool
+}
+
+func (o *outputSource) OutputAtBlock(_ context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	honest := !o.dishonest || blockNum < o.divergeBlock
+	root := crypto.Keccak256Hash(new(big.Int).SetUint64(blockNum).Bytes(), []byte{boolToByte(honest)})
+	return &eth.OutputResponse{OutputRoot: eth.Bytes32(root)}, nil
+}
+
+func boolToByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func newOutputAlphabetSelector(ctx context.Context, logger log.Logger, source outputs.OutputRollupClient, maxDepth, splitDepth uint64, bottomTrace func(localContext common.Hash) string) trace.ProviderSelector {
+	poststateBlock := prestateBlock + uint64(1)<<splitDepth
+	prestateProvider := outputs.NewPrestateProvider(ctx, logger, source, prestateBlock)
+	outputProvider := outputs.NewTraceProviderFromInputs(logger, prestateProvider, source, splitDepth, prestateBlock, poststateBlock)
+	creator := func(ctx context.Context, localContext common.Hash, depth uint64, agreed contracts.Proposal, claimed contracts.Proposal) (types.TraceProvider, error) {
+		return alphabet.NewTraceProvider(bottomTrace(localContext), depth), nil
+	}
+	return split.NewSplitProviderSelector(outputProvider, int(splitDepth), outputs.OutputRootSplitAdapter(outputProvider, creator))
+}
+
+func newOutputAlphabetTraces(ctx context.Context, logger log.Logger, maxDepth, splitDepth uint64, divergeAt uint64) *traces {
+	bottomDepth := maxDepth - splitDepth - 1
+	bottomDivergeAt := min(divergeAt, uint64(1)<<bottomDepth-1)
+	honestSelector := newOutputAlphabetSelector(ctx, logger, &outputSource{}, maxDepth, splitDepth, func(localContext common.Hash) string {
+		return localContext.Hex()
+	})
+	dishonestSource := &outputSource{divergeBlock: prestateBlock + 1 + divergeAt, dishonest: true}
+	dishonestSelector := newOutputAlphabetSelector(ctx, logger, dishonestSource, maxDepth, splitDepth, func(localContext common.Hash) string {
+		return divergeTrace(localContext.Hex(), bottomDivergeAt)
+	})
+	return &traces{
+		maxDepth:       maxDepth,
+		splitDepth:     int(splitDepth),
+		honestSelector: honestSelector,
+		honest:         trace.NewAccessor(honestSelector),
+		dishonest:      trace.NewAccessor(dishonestSelector),
+	}
+}
+
+// step is the [StepVM] for the game. Executing an instruction from the honest trace state at index i
+// results in the honest trace state at index i+1, so only the honest actor's claims are valid.
+func (t *traces) step(ctx context.Context, game types.Game, parent types.Claim, stateData []byte) (common.Hash, error) {
+	provider, err := t.honestSelector(ctx, game, parent, parent.Position)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	depth := t.maxDepth
+	if translated, ok := provider.(*trace.TranslatingProvider); ok {
+		provider = translated.Original()
+		depth = t.maxDepth - uint64(t.splitDepth) - 1
+	}
+	// Alphabet states are the 32 byte trace index followed by the letter, except for the absolute prestate.
+	next := new(big.Int)
+	if len(stateData) == 64 {
+		next.SetBytes(stateData[:32]).Add(next, big.NewInt(1))
+	}
+	if next.Cmp(new(big.Int).Lsh(big.NewInt(1), uint(depth))) >= 0 {
+		return common.Hash{}, fmt.Errorf("no state after trace index %v", new(big.Int).Sub(next, big.NewInt(1)))
+	}
+	return provider.Get(ctx, types.NewPosition(int(depth), next))
+}
+
+// absolutePrestate returns the prestate commitment of the execution trace.
+func absolutePrestate(ctx context.Context) (common.Hash, error) {
+	return alphabet.NewTraceProvider("", 0).AbsolutePreStateCommitment(ctx)
+}
@@ -17,7 +17,8 @@ var rules = []actionRule{
 	doNotDefendRootClaim,
 }
 
-func checkRules(game types.Game, action types.Action) error {
+// CheckRules returns an error describing every rule the action violates, or nil if it is valid for the game.
+func CheckRules(game types.Game, action types.Action) error {
 	var errs []error
 	for _, rule := range rules {
 		errs = append(errs, rule(game, action))
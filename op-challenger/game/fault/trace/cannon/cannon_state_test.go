@@ -47,4 +47,15 @@ func TestLoadState(t *testing.T) {
 		require.NoError(t, json.Unmarshal(testState, &expected))
 		require.Equal(t, &expected, state)
 	})
+	t.Run("Binary", func(t *testing.T) {
+		var expected mipsevm.State
+		require.NoError(t, json.Unmarshal(testState, &expected))
+		dir := t.TempDir()
+		path := filepath.Join(dir, "state.bin.gz")
+		require.NoError(t, mipsevm.WriteStateToFile(path, &expected, 0644))
+
+		state, err := parseState(path)
+		require.NoError(t, err)
+		require.Equal(t, expected.EncodeWitness(), state.EncodeWitness())
+	})
 }
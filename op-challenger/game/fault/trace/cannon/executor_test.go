@@ -12,6 +12,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -68,6 +69,22 @@ func TestGenerateProof(t *testing.T) {
 		return binary, subcommand, args
 	}
 
+	t.Run("MigratesDiskKVPreimages", func(t *testing.T) {
+		diskKVDir := filepath.Join(dir, diskKVPreimagesDir)
+		require.NoError(t, os.MkdirAll(diskKVDir, 0755))
+		require.NoError(t, kvstore.NewDiskKV(diskKVDir).Put(common.Hash{0xaa}, []byte("preimage")))
+		_, _, args := captureExec(t, cfg, 150_000_000)
+		require.NoDirExists(t, diskKVDir)
+		require.Equal(t, filepath.Join(dir, preimagesDir), args["--datadir"])
+
+		kv, err := kvstore.NewPebbleKV(args["--datadir"])
+		require.NoError(t, err)
+		defer kv.Close()
+		actual, err := kv.Get(common.Hash{0xaa})
+		require.NoError(t, err)
+		require.Equal(t, []byte("preimage"), actual)
+	})
+
 	t.Run("Network", func(t *testing.T) {
 		cfg.CannonNetwork = "mainnet"
 		cfg.CannonRollupConfigPath = ""
@@ -93,8 +110,9 @@ func TestGenerateProof(t *testing.T) {
 		require.Equal(t, cfg.L1EthRpc, args["--l1"])
 		require.Equal(t, cfg.CannonL2, args["--l2"])
 		require.Equal(t, filepath.Join(dir, preimagesDir), args["--datadir"])
+		require.Equal(t, "pebble", args["--data.format"])
 		require.Equal(t, filepath.Join(dir, proofsDir, "%d.json.gz"), args["--proof-fmt"])
-		require.Equal(t, filepath.Join(dir, snapsDir, "%d.json.gz"), args["--snapshot-fmt"])
+		require.Equal(t, filepath.Join(dir, snapsDir, "%d.bin.gz"), args["--snapshot-fmt"])
 		require.Equal(t, cfg.CannonNetwork, args["--network"])
 		require.NotContains(t, args, "--rollup.config")
 		require.NotContains(t, args, "--l2.genesis")
@@ -198,6 +216,22 @@ func TestFindStartingSnapshot(t *testing.T) {
 		require.Equal(t, filepath.Join(dir, "250.json.gz"), snapshot)
 	})
 
+	t.Run("UseBinarySnapshots", func(t *testing.T) {
+		dir := withSnapshots(t, "100.json.gz", "123.bin.gz", "250.bin.gz")
+
+		snapshot, err := findStartingSnapshot(logger, dir, execTestCannonPrestate, 101)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "100.json.gz"), snapshot)
+
+		snapshot, err = findStartingSnapshot(logger, dir, execTestCannonPrestate, 124)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "123.bin.gz"), snapshot)
+
+		snapshot, err = findStartingSnapshot(logger, dir, execTestCannonPrestate, 256)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "250.bin.gz"), snapshot)
+	})
+
 	t.Run("IgnoreDirectories", func(t *testing.T) {
 		dir := withSnapshots(t, "100.json.gz")
 		require.NoError(t, os.Mkdir(filepath.Join(dir, "120.json.gz"), 0o777))
@@ -207,7 +241,7 @@ func TestFindStartingSnapshot(t *testing.T) {
 	})
 
 	t.Run("IgnoreUnexpectedFiles", func(t *testing.T) {
-		dir := withSnapshots(t, ".file", "100.json.gz", "foo", "bar.json.gz")
+		dir := withSnapshots(t, ".file", "100.json.gz", "foo", "bar.json.gz", "120.bin", "130.json")
 		snapshot, err := findStartingSnapshot(logger, dir, execTestCannonPrestate, 150)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(dir, "100.json.gz"), snapshot)
@@ -0,0 +1,388 @@
+package cannon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/host"
+	hostconfig "github.com/ethereum-optimism/optimism/op-program/host/config"
+	oppio "github.com/ethereum-optimism/optimism/op-program/io"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// maxInMemorySnapshots is the maximum number of VM states kept in memory for each game.
+// Each snapshot is a full copy of the VM memory so this is kept small.
+const maxInMemorySnapshots = 3
+
+type preimageServer func(ctx context.Context, logger log.Logger, cfg *hostconfig.Config, preimageChannel oppio.FileChannel, hintChannel oppio.FileChannel) error
+
+// InProcessExecutor generates cannon proofs by running the MIPS VM and the op-program pre-image server
+// within the challenger process, avoiding the cost of starting new processes and loading snapshots from disk.
+// Snapshots are kept in memory so subsequent proofs for the same game can resume from the closest prior state.
+// Proofs are still written to the proofs directory so they can be reused by the CannonTraceProvider.
+type InProcessExecutor struct {
+	logger           log.Logger
+	metrics          CannonMetricer
+	l1               string
+	l2               string
+	inputs           LocalGameInputs
+	network          string
+	rollupConfig     string
+	l2Genesis        string
+	absolutePreState string
+	snapshotFreq     uint64
+	infoFreq         uint64
+	preimageServer   preimageServer
+
+	lock      sync.Mutex
+	prestate  *mipsevm.State
+	snapshots *stateCache
+}
+
+func NewInProcessExecutor(logger log.Logger, m CannonMetricer, cfg *config.Config, inputs LocalGameInputs) *InProcessExecutor {
+	return &InProcessExecutor{
+		logger:           logger,
+		metrics:          m,
+		l1:               cfg.L1EthRpc,
+		l2:               cfg.CannonL2,
+		inputs:           inputs,
+		network:          cfg.CannonNetwork,
+		rollupConfig:     cfg.CannonRollupConfigPath,
+		l2Genesis:        cfg.CannonL2GenesisPath,
+		absolutePreState: cfg.CannonAbsolutePreState,
+		snapshotFreq:     uint64(cfg.CannonSnapshotFreq),
+		infoFreq:         uint64(cfg.CannonInfoFreq),
+		preimageServer:   host.PreimageServer,
+		snapshots:        newStateCache(maxInMemorySnapshots),
+	}
+}
+
+func (e *InProcessExecutor) GenerateProof(ctx context.Context, dir string, i uint64) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	proofDir := filepath.Join(dir, proofsDir)
+	dataDir, err := preparePreimagesDir(e.logger, dir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(proofDir, 0755); err != nil {
+		return fmt.Errorf("could not create proofs directory %v: %w", proofDir, err)
+	}
+	serverCfg, err := e.serverConfig(dataDir)
+	if err != nil {
+		return err
+	}
+	state, err := e.startingState(i)
+	if err != nil {
+		return fmt.Errorf("find starting state: %w", err)
+	}
+	e.logger.Info("Generating trace in-process", "proof", i, "start", state.Step)
+	execStart := time.Now()
+	err = e.execute(ctx, e.logger.New("proof", i), serverCfg, dir, state, i)
+	e.metrics.RecordCannonExecutionTime(time.Since(execStart).Seconds())
+	return err
+}
+
+// startingState returns a copy of the closest known state at or before step i.
+func (e *InProcessExecutor) startingState(i uint64) (*mipsevm.State, error) {
+	if state := e.snapshots.Closest(i); state != nil {
+		return copyState(state), nil
+	}
+	if e.prestate == nil {
+		prestate, err := parseState(e.absolutePreState)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load absolute pre-state: %w", err)
+		}
+		e.prestate = prestate
+	}
+	return copyState(e.prestate), nil
+}
+
+// execute runs the VM from state until the proof for step i has been written or the program exits.
+func (e *InProcessExecutor) execute(ctx context.Context, logger log.Logger, serverCfg *hostconfig.Config, dir string, state *mipsevm.State, i uint64) error {
+	pClientRW, pHostRW, err := oppio.CreateBidirectionalChannel()
+	if err != nil {
+		return fmt.Errorf("failed to create preimage pipe: %w", err)
+	}
+	hClientRW, hHostRW, err := oppio.CreateBidirectionalChannel()
+	if err != nil {
+		_ = pClientRW.Close()
+		_ = pHostRW.Close()
+		return fmt.Errorf("failed to create hints pipe: %w", err)
+	}
+	serverCtx, cancel := context.WithCancel(ctx)
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- e.preimageServer(serverCtx, logger, serverCfg, pHostRW, hHostRW)
+	}()
+	defer func() {
+		// Closing the client side of the channels stops the pre-image server.
+		_ = pClientRW.Close()
+		_ = hClientRW.Close()
+		cancel()
+		if err := <-serverErr; err != nil {
+			logger.Error("Pre-image server failed", "err", err)
+		}
+	}()
+
+	oracle := &inProcessOracle{
+		pCl: preimage.NewOracleClient(pClientRW),
+		hCl: preimage.NewHintWriter(hClientRW),
+	}
+	outLog := &mipsevm.LoggingWriter{Name: "program std-out", Log: logger}
+	errLog := &mipsevm.LoggingWriter{Name: "program std-err", Log: logger}
+	vm := mipsevm.NewInstrumentedState(state, oracle, outLog, errLog)
+
+	start := time.Now()
+	startStep := state.Step
+	for !state.Exited {
+		step := state.Step
+		if step%100 == 0 { // don't do the ctx err check (includes lock) too often
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		if e.infoFreq > 0 && step%e.infoFreq == 0 {
+			delta := time.Since(start)
+			logger.Info("processing",
+				"step", step,
+				"pc", mipsevm.HexU32(state.PC),
+				"ips", float64(step-startStep)/(float64(delta)/float64(time.Second)),
+				"pages", state.Memory.PageCount(),
+				"mem", state.Memory.Usage(),
+			)
+		}
+		if i < math.MaxUint64 && step == i+1 {
+			break
+		}
+		if e.snapshotFreq > 0 && step%e.snapshotFreq == 0 && step != startStep {
+			e.snapshots.Add(copyState(state))
+		}
+		if step == i {
+			proof, err := e.proveStep(vm, state)
+			if err != nil {
+				return err
+			}
+			path := filepath.Join(dir, proofsDir, fmt.Sprintf("%d.json.gz", step))
+			if err := ioutil.WriteCompressedJson(path, proof); err != nil {
+				return fmt.Errorf("failed to write proof data: %w", err)
+			}
+		} else if _, err := stepSafely(vm, false); err != nil {
+			return fmt.Errorf("failed at step %d (PC: %08x): %w", step, state.PC, err)
+		}
+	}
+	// The state is not modified after this point, so it can be cached without copying.
+	e.snapshots.Add(state)
+	if state.Exited && state.Step <= i {
+		// The requested proof is beyond the end of the trace, store the final state so the trace can be extended.
+		if err := mipsevm.WriteStateToFile(filepath.Join(dir, finalState), state, 0644); err != nil {
+			return fmt.Errorf("failed to write final state: %w", err)
+		}
+	}
+	return nil
+}
+
+func (e *InProcessExecutor) proveStep(vm *mipsevm.InstrumentedState, state *mipsevm.State) (*proofData, error) {
+	step := state.Step
+	witness, err := stepSafely(vm, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed at proof-gen step %d (PC: %08x): %w", step, state.PC, err)
+	}
+	postStateHash, err := state.EncodeWitness().StateHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash poststate witness: %w", err)
+	}
+	proof := &proofData{
+		ClaimValue: postStateHash,
+		StateData:  witness.State,
+		ProofData:  witness.MemProof,
+	}
+	if witness.HasPreimage() {
+		proof.OracleKey = witness.PreimageKey[:]
+		proof.OracleValue = witness.PreimageValue
+		proof.OracleOffset = witness.PreimageOffset
+	}
+	return proof, nil
+}
+
+// stepSafely executes a single VM step, converting any panic into an error.
+// The pre-image oracle client panics if the in-process pre-image server fails, which must not crash the challenger.
+func stepSafely(vm *mipsevm.InstrumentedState, proof bool) (wit *mipsevm.StepWitness, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("vm panicked: %v", r)
+		}
+	}()
+	return vm.Step(proof)
+}
+
+func (e *InProcessExecutor) serverConfig(dataDir string) (*hostconfig.Config, error) {
+	rollupCfg, err := e.loadRollupConfig()
+	if err != nil {
+		return nil, err
+	}
+	l2ChainCfg, err := e.loadL2ChainConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg := hostconfig.NewConfig(rollupCfg, l2ChainCfg, e.inputs.L1Head, e.inputs.L2Head, e.inputs.L2OutputRoot, e.inputs.L2Claim, e.inputs.L2BlockNumber.Uint64())
+	cfg.DataDir = dataDir
+	cfg.DataFormat = hostconfig.DataFormatPebble
+	cfg.L1URL = e.l1
+	cfg.L2URL = e.l2
+	cfg.ServerMode = true
+	if err := cfg.Check(); err != nil {
+		return nil, fmt.Errorf("invalid pre-image server config: %w", err)
+	}
+	return cfg, nil
+}
+
+func (e *InProcessExecutor) loadRollupConfig() (*rollup.Config, error) {
+	if e.network != "" {
+		return chaincfg.GetRollupConfig(e.network)
+	}
+	var rollupCfg rollup.Config
+	if err := loadJSON(e.rollupConfig, &rollupCfg); err != nil {
+		return nil, fmt.Errorf("failed to load rollup config: %w", err)
+	}
+	return &rollupCfg, nil
+}
+
+func (e *InProcessExecutor) loadL2ChainConfig() (*params.ChainConfig, error) {
+	if e.network != "" {
+		ch := chaincfg.ChainByName(e.network)
+		if ch == nil {
+			return nil, fmt.Errorf("%w: %v", config.ErrCannonNetworkUnknown, e.network)
+		}
+		return params.LoadOPStackChainConfig(ch.ChainID)
+	}
+	var genesis core.Genesis
+	if err := loadJSON(e.l2Genesis, &genesis); err != nil {
+		return nil, fmt.Errorf("failed to load l2 genesis: %w", err)
+	}
+	if genesis.Config == nil {
+		return nil, errors.New("l2 genesis has no chain config")
+	}
+	return genesis.Config, nil
+}
+
+func loadJSON(path string, out any) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return json.NewDecoder(file).Decode(out)
+}
+
+type rawHint string
+
+func (rh rawHint) Hint() string {
+	return string(rh)
+}
+
+type rawKey [32]byte
+
+func (rk rawKey) PreimageKey() [32]byte {
+	return rk
+}
+
+// inProcessOracle adapts the pre-image and hint channels to the oracle interface used by the VM.
+type inProcessOracle struct {
+	pCl *preimage.OracleClient
+	hCl *preimage.HintWriter
+}
+
+func (o *inProcessOracle) Hint(v []byte) {
+	o.hCl.Hint(rawHint(v))
+}
+
+func (o *inProcessOracle) GetPreimage(k [32]byte) []byte {
+	return o.pCl.Get(rawKey(k))
+}
+
+var _ mipsevm.PreimageOracle = (*inProcessOracle)(nil)
+
+// copyState creates a deep copy of the VM state.
+func copyState(state *mipsevm.State) *mipsevm.State {
+	cpy := *state
+	cpy.Memory = state.Memory.Copy()
+	cpy.LastHint = common.CopyBytes(state.LastHint)
+	return &cpy
+}
+
+// stateCache keeps a bounded number of VM states, evicting the least recently used state when full.
+// States in the cache must not be modified.
+type stateCache struct {
+	limit  int
+	clock  uint64
+	states map[uint64]*cachedState
+}
+
+type cachedState struct {
+	state    *mipsevm.State
+	lastUsed uint64
+}
+
+func newStateCache(limit int) *stateCache {
+	return &stateCache{
+		limit:  limit,
+		states: make(map[uint64]*cachedState),
+	}
+}
+
+func (c *stateCache) Add(state *mipsevm.State) {
+	c.clock++
+	if existing, ok := c.states[state.Step]; ok {
+		existing.lastUsed = c.clock
+		return
+	}
+	if len(c.states) >= c.limit {
+		c.evict()
+	}
+	c.states[state.Step] = &cachedState{state: state, lastUsed: c.clock}
+}
+
+// Closest returns the state with the highest step that is at or before step, or nil if there is none.
+func (c *stateCache) Closest(step uint64) *mipsevm.State {
+	var best *cachedState
+	for s, entry := range c.states {
+		if s <= step && (best == nil || s > best.state.Step) {
+			best = entry
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	c.clock++
+	best.lastUsed = c.clock
+	return best.state
+}
+
+func (c *stateCache) evict() {
+	var oldest *cachedState
+	for _, entry := range c.states {
+		if oldest == nil || entry.lastUsed < oldest.lastUsed {
+			oldest = entry
+		}
+	}
+	if oldest != nil {
+		delete(c.states, oldest.state.Step)
+	}
+}
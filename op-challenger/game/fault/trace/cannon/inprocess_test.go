@@ -0,0 +1,220 @@
+package cannon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/host"
+	hostconfig "github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	oppio "github.com/ethereum-optimism/optimism/op-program/io"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// testProgramLength is the number of instructions executed by the program created by testProgramState.
+const testProgramLength = 13
+
+// testProgramState creates a VM state for a small program that increments a register 10 times and then exits.
+func testProgramState() *mipsevm.State {
+	state := &mipsevm.State{Memory: mipsevm.NewMemory(), NextPC: 4}
+	addr := uint32(0)
+	for i := 0; i < 10; i++ {
+		state.Memory.SetMemory(addr, 0x25080001) // addiu $t0, $t0, 1
+		addr += 4
+	}
+	state.Memory.SetMemory(addr, 0x24021096)   // addiu $v0, $zero, 4246 (exit_group)
+	state.Memory.SetMemory(addr+4, 0x24040000) // addiu $a0, $zero, 0
+	state.Memory.SetMemory(addr+8, 0x0000000c) // syscall
+	return state
+}
+
+// expectedPostState returns the state hash after executing step i of the test program.
+func expectedPostState(t *testing.T, i uint64) common.Hash {
+	state := testProgramState()
+	vm := mipsevm.NewInstrumentedState(state, nil, nil, nil)
+	for state.Step <= i && !state.Exited {
+		_, err := vm.Step(false)
+		require.NoError(t, err)
+	}
+	hash, err := state.EncodeWitness().StateHash()
+	require.NoError(t, err)
+	return hash
+}
+
+func TestInProcessGenerateProof(t *testing.T) {
+	t.Run("GeneratesProof", func(t *testing.T) {
+		executor, dir, m := setupInProcessExecutor(t)
+		require.NoError(t, executor.GenerateProof(context.Background(), dir, 5))
+		require.Equal(t, 1, m.executionTimeRecordCount)
+
+		proof := readProof(t, dir, 5)
+		require.Equal(t, expectedPostState(t, 5), proof.ClaimValue)
+		require.NotEmpty(t, proof.StateData)
+		require.NotEmpty(t, proof.ProofData)
+		require.NoFileExists(t, filepath.Join(dir, finalState))
+	})
+
+	t.Run("ResumesFromMemorySnapshot", func(t *testing.T) {
+		executor, dir, _ := setupInProcessExecutor(t)
+		require.NoError(t, executor.GenerateProof(context.Background(), dir, 3))
+		// Remove the prestate to ensure later executions resume from in-memory state
+		require.NoError(t, os.Remove(executor.absolutePreState))
+		executor.prestate = nil
+
+		require.NoError(t, executor.GenerateProof(context.Background(), dir, 8))
+		require.Equal(t, expectedPostState(t, 8), readProof(t, dir, 8).ClaimValue)
+		// Cached states must not be modified by later executions
+		require.Equal(t, expectedPostState(t, 3), readProof(t, dir, 3).ClaimValue)
+		require.NoError(t, executor.GenerateProof(context.Background(), dir, 4))
+		require.Equal(t, expectedPostState(t, 4), readProof(t, dir, 4).ClaimValue)
+	})
+
+	t.Run("TakesSnapshotsAtFrequency", func(t *testing.T) {
+		executor, dir, _ := setupInProcessExecutor(t)
+		executor.snapshotFreq = 2
+		require.NoError(t, executor.GenerateProof(context.Background(), dir, 6))
+		state := executor.snapshots.Closest(5)
+		require.NotNil(t, state)
+		require.Equal(t, uint64(4), state.Step)
+	})
+
+	t.Run("WritesFinalStateWhenExited", func(t *testing.T) {
+		executor, dir, _ := setupInProcessExecutor(t)
+		require.NoError(t, executor.GenerateProof(context.Background(), dir, math.MaxUint64))
+		require.FileExists(t, filepath.Join(dir, finalState))
+		state, err := parseState(filepath.Join(dir, finalState))
+		require.NoError(t, err)
+		require.True(t, state.Exited)
+		require.Equal(t, uint64(testProgramLength), state.Step)
+	})
+
+	t.Run("TraceProvider", func(t *testing.T) {
+		executor, dir, _ := setupInProcessExecutor(t)
+		provider := &CannonTraceProvider{
+			logger:    testlog.Logger(t, log.LvlInfo),
+			dir:       dir,
+			prestate:  executor.absolutePreState,
+			generator: executor,
+			gameDepth: 63,
+		}
+		value, err := provider.Get(context.Background(), types.NewPosition(63, big.NewInt(7)))
+		require.NoError(t, err)
+		require.Equal(t, expectedPostState(t, 7), value)
+
+		// Beyond the end of the trace the final state is repeated
+		value, err = provider.Get(context.Background(), types.NewPosition(63, big.NewInt(1000)))
+		require.NoError(t, err)
+		require.Equal(t, expectedPostState(t, testProgramLength), value)
+		require.Equal(t, uint64(testProgramLength-1), provider.lastStep)
+	})
+
+	t.Run("ContextCancelled", func(t *testing.T) {
+		executor, dir, _ := setupInProcessExecutor(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.ErrorIs(t, executor.GenerateProof(ctx, dir, 5), context.Canceled)
+	})
+}
+
+func TestInProcessOracle(t *testing.T) {
+	dataDir := t.TempDir()
+	value := []byte("hello world")
+	key := preimage.Keccak256Key(crypto.Keccak256Hash(value)).PreimageKey()
+	kv, err := kvstore.NewPebbleKV(dataDir)
+	require.NoError(t, err)
+	require.NoError(t, kv.Put(key, value))
+	require.NoError(t, kv.Close())
+	pClientRW, pHostRW, err := oppio.CreateBidirectionalChannel()
+	require.NoError(t, err)
+	hClientRW, hHostRW, err := oppio.CreateBidirectionalChannel()
+	require.NoError(t, err)
+	cfg := &hostconfig.Config{DataDir: dataDir, DataFormat: hostconfig.DataFormatPebble}
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- host.PreimageServer(context.Background(), testlog.Logger(t, log.LvlInfo), cfg, pHostRW, hHostRW)
+	}()
+
+	oracle := &inProcessOracle{
+		pCl: preimage.NewOracleClient(pClientRW),
+		hCl: preimage.NewHintWriter(hClientRW),
+	}
+	oracle.Hint([]byte("ignored hint"))
+	require.Equal(t, value, oracle.GetPreimage(key))
+
+	require.NoError(t, pClientRW.Close())
+	require.NoError(t, hClientRW.Close())
+	require.NoError(t, <-serverErr)
+}
+
+func TestStateCache(t *testing.T) {
+	cache := newStateCache(2)
+	require.Nil(t, cache.Closest(100))
+
+	cache.Add(&mipsevm.State{Step: 10})
+	cache.Add(&mipsevm.State{Step: 20})
+	require.Nil(t, cache.Closest(5))
+	require.Equal(t, uint64(10), cache.Closest(15).Step)
+	require.Equal(t, uint64(20), cache.Closest(20).Step)
+
+	// Step 10 was used least recently so is evicted
+	cache.Closest(25)
+	cache.Closest(15)
+	cache.Add(&mipsevm.State{Step: 30})
+	require.Equal(t, uint64(10), cache.Closest(15).Step)
+	require.Equal(t, uint64(10), cache.Closest(25).Step)
+	require.Equal(t, uint64(30), cache.Closest(35).Step)
+}
+
+func setupInProcessExecutor(t *testing.T) (*InProcessExecutor, string, *cannonDurationMetrics) {
+	tempDir := t.TempDir()
+	prestate := filepath.Join(tempDir, "prestate.json.gz")
+	require.NoError(t, ioutil.WriteCompressedJson(prestate, testProgramState()))
+	cfg := config.NewConfig(common.Address{0xbb}, "http://localhost:8888", tempDir, config.TraceTypeCannon)
+	cfg.CannonInProcess = true
+	cfg.CannonAbsolutePreState = prestate
+	cfg.CannonNetwork = "op-mainnet"
+	cfg.CannonL2 = "http://localhost:9999"
+	cfg.CannonSnapshotFreq = 1000
+	cfg.CannonInfoFreq = 1000
+	inputs := LocalGameInputs{
+		L1Head:        common.Hash{0x11},
+		L2Head:        common.Hash{0x22},
+		L2OutputRoot:  common.Hash{0x33},
+		L2Claim:       common.Hash{0x44},
+		L2BlockNumber: big.NewInt(3333),
+	}
+	m := &cannonDurationMetrics{}
+	executor := NewInProcessExecutor(testlog.Logger(t, log.LvlInfo), m, &cfg, inputs)
+	// The test program doesn't use the pre-image oracle, so just serve until the channels are closed.
+	executor.preimageServer = func(_ context.Context, _ log.Logger, _ *hostconfig.Config, preimageChannel oppio.FileChannel, hintChannel oppio.FileChannel) error {
+		defer preimageChannel.Close()
+		defer hintChannel.Close()
+		_, _ = preimageChannel.Read(make([]byte, 1))
+		return nil
+	}
+	return executor, filepath.Join(tempDir, "gameDir"), m
+}
+
+func readProof(t *testing.T, dir string, i uint64) *proofData {
+	file, err := ioutil.OpenDecompressed(filepath.Join(dir, proofsDir, fmt.Sprintf("%d.json.gz", i)))
+	require.NoError(t, err)
+	defer file.Close()
+	var proof proofData
+	require.NoError(t, json.NewDecoder(file).Decode(&proof))
+	return &proof
+}
@@ -0,0 +1,57 @@
+package cannon
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// preimagesDir is the pebble database of pre-images used by the op-program server.
+	preimagesDir = "preimages-pebble"
+	// diskKVPreimagesDir is the directory of pre-images stored one file each by earlier versions.
+	diskKVPreimagesDir = "preimages"
+)
+
+// preparePreimagesDir creates the pebble pre-image directory in dir and returns its path.
+// Any pre-images stored in the DiskKV format by earlier versions are first copied into the pebble database and the
+// old directory is removed. If migration is interrupted, the old directory is kept and migrated again next time.
+func preparePreimagesDir(logger log.Logger, dir string) (string, error) {
+	dataDir := filepath.Join(dir, preimagesDir)
+	diskKVDir := filepath.Join(dir, diskKVPreimagesDir)
+	if _, err := os.Stat(diskKVDir); err == nil {
+		if err := migratePreimages(logger, diskKVDir, dataDir); err != nil {
+			return "", err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("could not check for DiskKV pre-images in %v: %w", diskKVDir, err)
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return "", fmt.Errorf("could not create preimage cache directory %v: %w", dataDir, err)
+	}
+	return dataDir, nil
+}
+
+func migratePreimages(logger log.Logger, diskKVDir string, dataDir string) error {
+	kv, err := kvstore.NewPebbleKV(dataDir)
+	if err != nil {
+		return err
+	}
+	copied, err := kvstore.MigrateDiskKV(diskKVDir, kv, nil)
+	if err != nil {
+		_ = kv.Close()
+		return fmt.Errorf("could not migrate DiskKV pre-images from %v: %w", diskKVDir, err)
+	}
+	if err := kv.Close(); err != nil {
+		return fmt.Errorf("could not close pre-image database %v: %w", dataDir, err)
+	}
+	if err := os.RemoveAll(diskKVDir); err != nil {
+		return fmt.Errorf("could not remove DiskKV pre-images %v: %w", diskKVDir, err)
+	}
+	logger.Info("Migrated DiskKV pre-images", "from", diskKVDir, "to", dataDir, "count", copied)
+	return nil
+}
@@ -0,0 +1,80 @@
+package cannon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreparePreimagesDir(t *testing.T) {
+	t.Run("CreatesDir", func(t *testing.T) {
+		dir := t.TempDir()
+		dataDir, err := preparePreimagesDir(testlog.Logger(t, log.LvlInfo), dir)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, preimagesDir), dataDir)
+		require.DirExists(t, dataDir)
+		require.NoDirExists(t, filepath.Join(dir, diskKVPreimagesDir))
+	})
+
+	t.Run("MigratesDiskKV", func(t *testing.T) {
+		dir := t.TempDir()
+		diskKVDir := filepath.Join(dir, diskKVPreimagesDir)
+		require.NoError(t, os.MkdirAll(diskKVDir, 0755))
+		diskKV := kvstore.NewDiskKV(diskKVDir)
+		preimages := map[common.Hash][]byte{
+			{0x01}: []byte("first"),
+			{0x02}: []byte("second"),
+			{0x03}: {},
+		}
+		for k, v := range preimages {
+			require.NoError(t, diskKV.Put(k, v))
+		}
+		// Incomplete writes are not migrated
+		require.NoError(t, os.WriteFile(filepath.Join(diskKVDir, common.Hash{0x04}.Hex()+".txt.123"), []byte("00"), 0644))
+
+		dataDir, err := preparePreimagesDir(testlog.Logger(t, log.LvlInfo), dir)
+		require.NoError(t, err)
+		require.NoDirExists(t, diskKVDir)
+
+		kv, err := kvstore.NewPebbleKV(dataDir)
+		require.NoError(t, err)
+		defer kv.Close()
+		for k, v := range preimages {
+			actual, err := kv.Get(k)
+			require.NoError(t, err)
+			require.Equal(t, v, actual)
+		}
+		_, err = kv.Get(common.Hash{0x04})
+		require.ErrorIs(t, err, kvstore.ErrNotFound)
+	})
+
+	t.Run("KeepsExistingPebbleData", func(t *testing.T) {
+		dir := t.TempDir()
+		existing, err := kvstore.NewPebbleKV(filepath.Join(dir, preimagesDir))
+		require.NoError(t, err)
+		require.NoError(t, existing.Put(common.Hash{0xaa}, []byte("existing")))
+		require.NoError(t, existing.Close())
+		diskKVDir := filepath.Join(dir, diskKVPreimagesDir)
+		require.NoError(t, os.MkdirAll(diskKVDir, 0755))
+		require.NoError(t, kvstore.NewDiskKV(diskKVDir).Put(common.Hash{0xbb}, []byte("migrated")))
+
+		dataDir, err := preparePreimagesDir(testlog.Logger(t, log.LvlInfo), dir)
+		require.NoError(t, err)
+
+		kv, err := kvstore.NewPebbleKV(dataDir)
+		require.NoError(t, err)
+		defer kv.Close()
+		actual, err := kv.Get(common.Hash{0xaa})
+		require.NoError(t, err)
+		require.Equal(t, []byte("existing"), actual)
+		actual, err = kv.Get(common.Hash{0xbb})
+		require.NoError(t, err)
+		require.Equal(t, []byte("migrated"), actual)
+	})
+}
@@ -55,11 +55,17 @@ type CannonTraceProvider struct {
 }
 
 func NewTraceProvider(logger log.Logger, m CannonMetricer, cfg *config.Config, localContext common.Hash, localInputs LocalGameInputs, dir string, gameDepth uint64) *CannonTraceProvider {
+	var generator ProofGenerator
+	if cfg.CannonInProcess {
+		generator = NewInProcessExecutor(logger, m, cfg, localInputs)
+	} else {
+		generator = NewExecutor(logger, m, cfg, localInputs)
+	}
 	return &CannonTraceProvider{
 		logger:       logger,
 		dir:          dir,
 		prestate:     cfg.CannonAbsolutePreState,
-		generator:    NewExecutor(logger, m, cfg, localInputs),
+		generator:    generator,
 		gameDepth:    gameDepth,
 		localContext: localContext,
 	}
@@ -253,11 +253,7 @@ func (e *stubGenerator) GenerateProof(ctx context.Context, dir string, i uint64)
 	e.generated = append(e.generated, int(i))
 	if e.finalState != nil && e.finalState.Step <= i {
 		// Requesting a trace index past the end of the trace
-		data, err := json.Marshal(e.finalState)
-		if err != nil {
-			return err
-		}
-		return writeGzip(filepath.Join(dir, finalState), data)
+		return mipsevm.WriteStateToFile(filepath.Join(dir, finalState), e.finalState, 0o644)
 	}
 	if e.proof != nil {
 		proofFile := filepath.Join(dir, proofsDir, fmt.Sprintf("%d.json.gz", i))
@@ -0,0 +1,29 @@
+package outputs
+
+import (
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/split"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// NewOutputTraceAccessor creates a trace accessor for output root games.
+// Output roots are used as the trace down to the split depth and the trace providers created by creator are used
+// below it. Created providers are cached by local context and reported in metrics under metricsLabel.
+func NewOutputTraceAccessor(
+	logger log.Logger,
+	m metrics.Metricer,
+	metricsLabel string,
+	prestateProvider types.PrestateProvider,
+	rollupClient OutputRollupClient,
+	splitDepth uint64,
+	prestateBlock uint64,
+	poststateBlock uint64,
+	creator ProposalTraceProviderCreator,
+) *trace.Accessor {
+	outputProvider := NewTraceProviderFromInputs(logger, prestateProvider, rollupClient, splitDepth, prestateBlock, poststateBlock)
+	cache := NewProviderCache(m, metricsLabel, creator)
+	selector := split.NewSplitProviderSelector(outputProvider, int(splitDepth), OutputRootSplitAdapter(outputProvider, cache.GetOrCreate))
+	return trace.NewAccessor(selector)
+}
@@ -51,6 +51,27 @@ func OutputRootSplitAdapter(topProvider *OutputTraceProvider, creator ProposalTr
 	}
 }
 
+// WithAbsolutePrestate wraps creator so the trace providers it creates use prestate as their absolute prestate.
+// This allows the VM used below the split depth to supply its absolute prestate separately to its trace providers.
+func WithAbsolutePrestate(prestate types.PrestateProvider, creator ProposalTraceProviderCreator) ProposalTraceProviderCreator {
+	return func(ctx context.Context, localContext common.Hash, depth uint64, agreed contracts.Proposal, claimed contracts.Proposal) (types.TraceProvider, error) {
+		provider, err := creator(ctx, localContext, depth, agreed, claimed)
+		if err != nil {
+			return nil, err
+		}
+		return &prestateTraceProvider{TraceProvider: provider, prestate: prestate}, nil
+	}
+}
+
+type prestateTraceProvider struct {
+	types.TraceProvider
+	prestate types.PrestateProvider
+}
+
+func (p *prestateTraceProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	return p.prestate.AbsolutePreStateCommitment(ctx)
+}
+
 func createLocalContext(pre types.Claim, post types.Claim) common.Hash {
 	return crypto.Keccak256Hash(localContextPreimage(pre, post))
 }
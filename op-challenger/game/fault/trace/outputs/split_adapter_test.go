@@ -152,6 +152,45 @@ func (c *capturingCreator) Create(_ context.Context, localContext common.Hash, _
 	return nil, creatorError
 }
 
+func TestWithAbsolutePrestate(t *testing.T) {
+	vmPrestate := &stubPrestateProvider{absolutePrestate: common.Hash{0xaa}}
+	localContext := common.Hash{0xbb}
+	agreed := contracts.Proposal{L2BlockNumber: big.NewInt(5), OutputRoot: common.Hash{0x05}}
+	claimed := contracts.Proposal{L2BlockNumber: big.NewInt(6), OutputRoot: common.Hash{0x06}}
+
+	t.Run("UsesSuppliedPrestate", func(t *testing.T) {
+		creator := WithAbsolutePrestate(vmPrestate, NewAlphabetProviderCreator())
+		provider, err := creator(context.Background(), localContext, 4, agreed, claimed)
+		require.NoError(t, err)
+		prestate, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, vmPrestate.absolutePrestate, prestate)
+
+		// Other methods are served by the wrapped provider
+		expected, err := NewAlphabetProviderCreator()(context.Background(), localContext, 4, agreed, claimed)
+		require.NoError(t, err)
+		expectedClaim, err := expected.Get(context.Background(), types.NewPosition(4, big.NewInt(3)))
+		require.NoError(t, err)
+		actualClaim, err := provider.Get(context.Background(), types.NewPosition(4, big.NewInt(3)))
+		require.NoError(t, err)
+		require.Equal(t, expectedClaim, actualClaim)
+	})
+
+	t.Run("PrestateError", func(t *testing.T) {
+		creator := WithAbsolutePrestate(&stubPrestateProvider{errorsOnAbsolutePrestateFetch: true}, NewAlphabetProviderCreator())
+		provider, err := creator(context.Background(), localContext, 4, agreed, claimed)
+		require.NoError(t, err)
+		_, err = provider.AbsolutePreStateCommitment(context.Background())
+		require.ErrorIs(t, err, errNoOutputAtBlock)
+	})
+
+	t.Run("CreatorError", func(t *testing.T) {
+		creator := WithAbsolutePrestate(vmPrestate, (&capturingCreator{}).Create)
+		_, err := creator(context.Background(), localContext, 4, agreed, claimed)
+		require.ErrorIs(t, err, creatorError)
+	})
+}
+
 func TestCreateLocalContext(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -0,0 +1,78 @@
+package tracetypes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// The built-in trace types use the core challenger flags and config checks so don't define their own.
+func init() {
+	registerBuiltin(Definition{
+		TraceType:     config.TraceTypeCannon,
+		GameType:      config.CannonFaultGameID,
+		MonitorOracle: true,
+		New:           newCannonVM,
+	})
+	registerBuiltin(Definition{
+		TraceType: config.TraceTypeAlphabet,
+		GameType:  config.AlphabetFaultGameID,
+		New:       newAlphabetVM,
+	})
+}
+
+type cannonVM struct {
+	m        metrics.Metricer
+	cfg      *config.Config
+	l2Client *ethclient.Client
+	prestate *cannon.CannonPrestateProvider
+}
+
+func newCannonVM(ctx context.Context, deps Deps) (VM, error) {
+	l2Client, err := ethclient.DialContext(ctx, deps.Config.CannonL2)
+	if err != nil {
+		return nil, fmt.Errorf("dial l2 client %v: %w", deps.Config.CannonL2, err)
+	}
+	return &cannonVM{
+		m:        deps.Metrics,
+		cfg:      deps.Config,
+		l2Client: l2Client,
+		prestate: cannon.NewPrestateProvider(deps.Config.CannonAbsolutePreState),
+	}, nil
+}
+
+func (v *cannonVM) PrestateProvider() types.PrestateProvider {
+	return v.prestate
+}
+
+func (v *cannonVM) ProviderCreator(logger log.Logger, game GameContract, dir string) outputs.ProposalTraceProviderCreator {
+	return outputs.NewCannonProviderCreator(logger, v.m, v.cfg, v.l2Client, game, dir)
+}
+
+func (v *cannonVM) Close() {
+	v.l2Client.Close()
+}
+
+type alphabetVM struct{}
+
+func newAlphabetVM(_ context.Context, _ Deps) (VM, error) {
+	return alphabetVM{}, nil
+}
+
+func (alphabetVM) PrestateProvider() types.PrestateProvider {
+	return &alphabet.AlphabetPrestateProvider{}
+}
+
+func (alphabetVM) ProviderCreator(_ log.Logger, _ GameContract, _ string) outputs.ProposalTraceProviderCreator {
+	return outputs.NewAlphabetProviderCreator()
+}
+
+func (alphabetVM) Close() {}
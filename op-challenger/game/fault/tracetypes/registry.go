@@ -0,0 +1,125 @@
+package tracetypes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+)
+
+// GameContract provides the details of the game being played that a VM may need to create trace providers.
+type GameContract interface {
+	GetL1Head(ctx context.Context) (common.Hash, error)
+}
+
+// Deps are the services shared by all games, available to a trace type when it is started.
+type Deps struct {
+	Logger  log.Logger
+	Metrics metrics.Metricer
+	Config  *config.Config
+}
+
+// VM provides the trace used below the split depth of output root games.
+// Output roots are used above the split depth for all trace types.
+type VM interface {
+	// PrestateProvider provides the absolute prestate of the VM, used as the absolute prestate of the trace
+	// providers below the split depth.
+	PrestateProvider() types.PrestateProvider
+
+	// ProviderCreator returns the function used to create trace providers for the execution of the block between an
+	// agreed and claimed output root in game. Any data generated for the game should be stored under dir.
+	ProviderCreator(logger log.Logger, game GameContract, dir string) outputs.ProposalTraceProviderCreator
+
+	// Close releases any resources held by the VM once it is no longer used.
+	Close()
+}
+
+// Definition describes a trace type and how to create its VM.
+type Definition struct {
+	// TraceType is the name used to enable the trace type with the --trace-type flag.
+	TraceType config.TraceType
+
+	// GameType is the dispute game factory game type that is played using this trace type.
+	GameType uint8
+
+	// Flags are additional CLI flags used to configure the trace type.
+	// Flag names and env vars must be unique across all trace types.
+	Flags []cli.Flag
+
+	// CheckFlags verifies any required flags are set when the trace type is enabled. Optional.
+	CheckFlags func(ctx *cli.Context) error
+
+	// ReadConfig reads settings from the CLI flags into cfg, typically into cfg.TraceTypeOptions.
+	// Only called when the trace type is enabled. Optional.
+	ReadConfig func(ctx *cli.Context, cfg *config.Config) error
+
+	// CheckConfig validates cfg when the trace type is enabled. Optional.
+	CheckConfig func(cfg config.Config) error
+
+	// MonitorOracle enables monitoring of large preimage proposals to the game's PreimageOracle.
+	MonitorOracle bool
+
+	// New creates the VM when the challenger starts with the trace type enabled.
+	New func(ctx context.Context, deps Deps) (VM, error)
+}
+
+var definitions []Definition
+
+// Register adds a trace type so it can be enabled and used to play games of its game type.
+// Register must be called before the challenger's flags are parsed, typically from an init function.
+// Panics if the trace type or game type is already registered, since this indicates a significant programmer error.
+func Register(def Definition) {
+	validate(def)
+	config.RegisterTraceType(def.TraceType, def.GameType, def.CheckConfig)
+	definitions = append(definitions, def)
+}
+
+// registerBuiltin adds a trace type that is already known to the config package.
+func registerBuiltin(def Definition) {
+	validate(def)
+	definitions = append(definitions, def)
+}
+
+func validate(def Definition) {
+	if def.New == nil {
+		panic(fmt.Errorf("no VM constructor for trace type: %v", def.TraceType))
+	}
+	for _, existing := range definitions {
+		if existing.TraceType == def.TraceType {
+			panic(fmt.Errorf("duplicate trace type registered: %v", def.TraceType))
+		}
+		if existing.GameType == def.GameType {
+			panic(fmt.Errorf("duplicate trace type registered for game type %v: %v and %v", def.GameType, existing.TraceType, def.TraceType))
+		}
+	}
+}
+
+// Get returns the definition of the specified trace type.
+func Get(traceType config.TraceType) (Definition, bool) {
+	for _, def := range definitions {
+		if def.TraceType == traceType {
+			return def, true
+		}
+	}
+	return Definition{}, false
+}
+
+// Definitions returns all registered trace types in the order they were registered.
+func Definitions() []Definition {
+	return append([]Definition(nil), definitions...)
+}
+
+// Flags returns the additional CLI flags of all registered trace types.
+func Flags() []cli.Flag {
+	var flags []cli.Flag
+	for _, def := range definitions {
+		flags = append(flags, def.Flags...)
+	}
+	return flags
+}
@@ -0,0 +1,78 @@
+package tracetypes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestBuiltinTraceTypes(t *testing.T) {
+	defs := Definitions()
+	require.Len(t, defs, 2)
+	require.Equal(t, config.TraceTypeCannon, defs[0].TraceType)
+	require.Equal(t, uint8(config.CannonFaultGameID), defs[0].GameType)
+	require.True(t, defs[0].MonitorOracle)
+	require.Equal(t, config.TraceTypeAlphabet, defs[1].TraceType)
+	require.Equal(t, uint8(config.AlphabetFaultGameID), defs[1].GameType)
+	require.False(t, defs[1].MonitorOracle)
+}
+
+func TestRegister(t *testing.T) {
+	origDefinitions := definitions
+	origTraceTypes := config.TraceTypes
+	t.Cleanup(func() {
+		definitions = origDefinitions
+		config.TraceTypes = origTraceTypes
+		delete(config.GameIdToString, 200)
+	})
+	flag := &cli.StringFlag{Name: "test-vm-bin"}
+	def := Definition{
+		TraceType: "test-vm",
+		GameType:  200,
+		Flags:     []cli.Flag{flag},
+		New: func(_ context.Context, _ Deps) (VM, error) {
+			return alphabetVM{}, nil
+		},
+	}
+	Register(def)
+
+	actual, ok := Get("test-vm")
+	require.True(t, ok)
+	require.Equal(t, def.GameType, actual.GameType)
+	require.Len(t, Definitions(), 3)
+	require.Equal(t, []cli.Flag{flag}, Flags())
+	require.True(t, config.ValidTraceType("test-vm"))
+	require.Equal(t, "test-vm", config.GameIdToString[200])
+
+	t.Run("DuplicateTraceType", func(t *testing.T) {
+		dup := def
+		dup.GameType = 201
+		require.Panics(t, func() { Register(dup) })
+	})
+
+	t.Run("DuplicateGameType", func(t *testing.T) {
+		dup := def
+		dup.TraceType = "other-vm"
+		require.Panics(t, func() { Register(dup) })
+		require.False(t, config.ValidTraceType("other-vm"))
+	})
+
+	t.Run("BuiltinTraceType", func(t *testing.T) {
+		dup := def
+		dup.TraceType = config.TraceTypeCannon
+		dup.GameType = 201
+		require.Panics(t, func() { Register(dup) })
+	})
+
+	t.Run("MissingConstructor", func(t *testing.T) {
+		require.Panics(t, func() { Register(Definition{TraceType: "no-vm", GameType: 201}) })
+	})
+}
+
+func TestGetUnknownTraceType(t *testing.T) {
+	_, ok := Get("unknown")
+	require.False(t, ok)
+}
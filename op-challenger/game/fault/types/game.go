@@ -119,3 +119,28 @@ func (g *gameState) getParent(claim Claim) *Claim {
 	parent := g.claims[claim.ParentContractIndex]
 	return &parent
 }
+
+// ResolveClaims returns a copy of claims with Countered updated as the contract would after resolving every
+// subgame once all clocks have expired. A claim with children is countered if any of its children are
+// uncountered, while leaf claims keep their current countered state. Claims must be in contract order.
+func ResolveClaims(claims []Claim) []Claim {
+	resolved := make([]Claim, len(claims))
+	copy(resolved, claims)
+	hasChildren := make([]bool, len(resolved))
+	uncounteredChild := make([]bool, len(resolved))
+	// Children are always added after their parent so resolve from the last claim back to the root.
+	for i := len(resolved) - 1; i >= 0; i-- {
+		if hasChildren[i] {
+			resolved[i].Countered = uncounteredChild[i]
+		}
+		if resolved[i].IsRoot() {
+			continue
+		}
+		parent := resolved[i].ParentContractIndex
+		hasChildren[parent] = true
+		if !resolved[i].Countered {
+			uncounteredChild[parent] = true
+		}
+	}
+	return resolved
+}
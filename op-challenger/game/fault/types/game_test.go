@@ -147,3 +147,45 @@ func buildGameWithClaim(claimGIndex *big.Int, parentGIndex *big.Int) *gameState
 	}
 	return NewGameState([]Claim{parentClaim, claim}, testMaxDepth)
 }
+
+func TestResolveClaims(t *testing.T) {
+	root, top, middle, bottom := createTestClaims()
+	t.Run("RootOnly", func(t *testing.T) {
+		resolved := ResolveClaims([]Claim{root})
+		require.False(t, resolved[0].Countered)
+	})
+
+	t.Run("UncounteredLeaf", func(t *testing.T) {
+		claims := []Claim{root, top, middle, bottom}
+		for i := 0; i < 3; i++ {
+			claims[i].Countered = true
+		}
+		resolved := ResolveClaims(claims)
+		require.False(t, resolved[3].Countered)
+		require.True(t, resolved[2].Countered)
+		require.False(t, resolved[1].Countered)
+		require.True(t, resolved[0].Countered)
+		// The original claims are not modified
+		require.True(t, claims[1].Countered)
+	})
+
+	t.Run("CounteredLeaf", func(t *testing.T) {
+		claims := []Claim{root, top, middle, bottom}
+		claims[3].Countered = true
+		resolved := ResolveClaims(claims)
+		require.True(t, resolved[3].Countered)
+		require.False(t, resolved[2].Countered)
+		require.True(t, resolved[1].Countered)
+		require.False(t, resolved[0].Countered)
+	})
+
+	t.Run("AnyUncounteredChild", func(t *testing.T) {
+		countered := top
+		countered.Countered = true
+		uncountered := top
+		uncountered.Value = common.Hash{0xaa}
+		uncountered.ContractIndex = 2
+		resolved := ResolveClaims([]Claim{root, countered, uncountered})
+		require.True(t, resolved[0].Countered)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -110,13 +111,28 @@ type Claim struct {
 	//       When caching is implemented for the Challenger, this will need
 	//       to be changed/removed to avoid invalid/stale contract state.
 	Countered bool
-	Clock     uint64
+	Clock     Clock
 	// Location of the claim & it's parent inside the contract. Does not exist
 	// for claims that have not made it to the contract.
 	ContractIndex       int
 	ParentContractIndex int
 }
 
+// Clock is the chess clock of a claim.
+type Clock struct {
+	// Duration is the time accumulated on the clock of the team that posted the claim, up to when it was posted.
+	Duration time.Duration
+	// Timestamp is the time the claim was posted.
+	Timestamp time.Time
+}
+
+func NewClock(duration time.Duration, timestamp time.Time) Clock {
+	return Clock{
+		Duration:  duration,
+		Timestamp: timestamp,
+	}
+}
+
 // IsRoot returns true if this claim is the root claim.
 func (c *Claim) IsRoot() bool {
 	return c.Position.IsRootPosition()
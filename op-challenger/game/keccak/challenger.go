@@ -0,0 +1,114 @@
+package keccak
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type Verifier interface {
+	CreateChallenge(ctx context.Context, blockHash common.Hash, oracle keccakTypes.LargePreimageOracle, preimage keccakTypes.LargePreimageMetaData) (keccakTypes.Challenge, error)
+}
+
+type ChallengeMetrics interface {
+	RecordPreimageChallenged()
+	RecordPreimageChallengeFailed()
+}
+
+// PreimageChallenger counters invalid large preimage proposals.
+type PreimageChallenger struct {
+	log      log.Logger
+	metrics  ChallengeMetrics
+	clock    clock.Clock
+	verifier Verifier
+	txMgr    txmgr.TxManager
+}
+
+func NewPreimageChallenger(logger log.Logger, metrics ChallengeMetrics, cl clock.Clock, verifier Verifier, txMgr txmgr.TxManager) *PreimageChallenger {
+	return &PreimageChallenger{
+		log:      logger,
+		metrics:  metrics,
+		clock:    cl,
+		verifier: verifier,
+		txMgr:    txMgr,
+	}
+}
+
+// Challenge verifies each finalized proposal that is still within its challenge period and sends a challenge
+// for any that are invalid. Proposals are verified in parallel since loading their input data may be slow.
+func (c *PreimageChallenger) Challenge(ctx context.Context, blockHash common.Hash, oracle keccakTypes.LargePreimageOracle, preimages []keccakTypes.LargePreimageMetaData) error {
+	period, err := oracle.ChallengePeriod(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load challenge period: %w", err)
+	}
+	challengePeriod := time.Duration(period) * time.Second
+	now := c.clock.Now()
+
+	var txLock sync.Mutex
+	var wg sync.WaitGroup
+	var txs []txmgr.TxCandidate
+	for _, preimage := range preimages {
+		preimage := preimage
+		if !preimage.ShouldVerify(now, challengePeriod) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger := c.log.New("oracle", oracle.Addr(), "claimant", preimage.Claimant, "uuid", preimage.UUID)
+			challenge, err := c.verifier.CreateChallenge(ctx, blockHash, oracle, preimage)
+			if errors.Is(err, matrix.ErrValid) {
+				logger.Debug("Preimage is valid")
+				return
+			} else if err != nil {
+				logger.Error("Failed to verify large preimage", "err", err)
+				return
+			}
+			logger.Info("Challenging preimage", "block", challenge.Poststate.Index)
+			tx, err := oracle.ChallengeTx(preimage.LargePreimageIdent, challenge)
+			if err != nil {
+				logger.Error("Failed to create challenge transaction", "err", err)
+				return
+			}
+			txLock.Lock()
+			defer txLock.Unlock()
+			txs = append(txs, tx)
+		}()
+	}
+	wg.Wait()
+	c.log.Debug("Sending challenges", "count", len(txs))
+	return c.sendChallenges(ctx, txs)
+}
+
+func (c *PreimageChallenger) sendChallenges(ctx context.Context, txs []txmgr.TxCandidate) error {
+	var errs []error
+	for _, tx := range txs {
+		receipt, err := c.txMgr.Send(ctx, tx)
+		if err != nil {
+			c.metrics.RecordPreimageChallengeFailed()
+			errs = append(errs, err)
+			continue
+		}
+		if receipt.Status == ethtypes.ReceiptStatusFailed {
+			c.metrics.RecordPreimageChallengeFailed()
+			c.log.Error("Preimage challenge tx successfully published but reverted", "tx_hash", receipt.TxHash)
+			continue
+		}
+		c.metrics.RecordPreimageChallenged()
+		c.log.Info("Preimage challenge tx successfully published", "tx_hash", receipt.TxHash)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to send %v of %v challenges: %w", len(errs), len(txs), errors.Join(errs...))
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package keccak
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+const testChallengePeriod = 3600
+
+var now = time.Unix(1_000_000, 0)
+
+func TestChallenge(t *testing.T) {
+	blockHash := common.Hash{0xff}
+	newPreimage := func(uuid int64, timestamp uint64, countered bool) keccakTypes.LargePreimageMetaData {
+		return keccakTypes.LargePreimageMetaData{
+			LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0xab}, UUID: big.NewInt(uuid)},
+			Timestamp:          timestamp,
+			Countered:          countered,
+		}
+	}
+	active := uint64(now.Unix()) - 10
+	preimages := []keccakTypes.LargePreimageMetaData{
+		newPreimage(1, active, false),
+		newPreimage(2, active, false),
+		newPreimage(3, active, false),
+	}
+
+	t.Run("SendChallenges", func(t *testing.T) {
+		verifier, txMgr, challenger, metrics := setupChallengerTest(t)
+		verifier.challenges[preimages[1].UUID.Uint64()] = keccakTypes.Challenge{StateMatrix: keccakTypes.StateSnapshot{0x01}}
+		verifier.challenges[preimages[2].UUID.Uint64()] = keccakTypes.Challenge{StateMatrix: keccakTypes.StateSnapshot{0x02}}
+		oracle := &stubOracle{}
+		err := challenger.Challenge(context.Background(), blockHash, oracle, preimages)
+		require.NoError(t, err)
+
+		require.Len(t, oracle.challenged, 2)
+		require.ElementsMatch(t, []uint64{2, 3}, oracle.challengedUUIDs())
+		require.Len(t, txMgr.sent, 2)
+		require.Equal(t, 2, metrics.challenged)
+		require.Equal(t, 0, metrics.failed)
+	})
+
+	t.Run("SkipsInactivePreimages", func(t *testing.T) {
+		verifier, txMgr, challenger, _ := setupChallengerTest(t)
+		inactive := []keccakTypes.LargePreimageMetaData{
+			newPreimage(1, 0, false),                                      // not finalized
+			newPreimage(2, active, true),                                  // already countered
+			newPreimage(3, uint64(now.Unix())-testChallengePeriod, false), // challenge period over
+		}
+		for _, p := range inactive {
+			verifier.challenges[p.UUID.Uint64()] = keccakTypes.Challenge{}
+		}
+		oracle := &stubOracle{}
+		err := challenger.Challenge(context.Background(), blockHash, oracle, inactive)
+		require.NoError(t, err)
+		require.Empty(t, verifier.verified)
+		require.Empty(t, txMgr.sent)
+	})
+
+	t.Run("LogErrorWhenCreateTxFails", func(t *testing.T) {
+		verifier, txMgr, challenger, _ := setupChallengerTest(t)
+		verifier.challenges[preimages[1].UUID.Uint64()] = keccakTypes.Challenge{StateMatrix: keccakTypes.StateSnapshot{0x01}}
+		oracle := &stubOracle{err: errors.New("boom")}
+		err := challenger.Challenge(context.Background(), blockHash, oracle, preimages)
+		require.NoError(t, err)
+		require.Empty(t, txMgr.sent)
+	})
+
+	t.Run("LogErrorWhenVerifierFails", func(t *testing.T) {
+		verifier, txMgr, challenger, _ := setupChallengerTest(t)
+		verifier.challenges[preimages[1].UUID.Uint64()] = keccakTypes.Challenge{StateMatrix: keccakTypes.StateSnapshot{0x01}}
+		verifier.err = errors.New("boom")
+		err := challenger.Challenge(context.Background(), blockHash, &stubOracle{}, preimages)
+		require.NoError(t, err)
+		require.Empty(t, txMgr.sent)
+	})
+
+	t.Run("ReturnErrorWhenSendFails", func(t *testing.T) {
+		verifier, txMgr, challenger, metrics := setupChallengerTest(t)
+		verifier.challenges[preimages[1].UUID.Uint64()] = keccakTypes.Challenge{StateMatrix: keccakTypes.StateSnapshot{0x01}}
+		txMgr.sendErr = errors.New("boom")
+		err := challenger.Challenge(context.Background(), blockHash, &stubOracle{}, preimages)
+		require.ErrorIs(t, err, txMgr.sendErr)
+		require.Equal(t, 1, metrics.failed)
+	})
+
+	t.Run("RecordFailureWhenReverted", func(t *testing.T) {
+		verifier, txMgr, challenger, metrics := setupChallengerTest(t)
+		verifier.challenges[preimages[1].UUID.Uint64()] = keccakTypes.Challenge{StateMatrix: keccakTypes.StateSnapshot{0x01}}
+		txMgr.reverts = true
+		err := challenger.Challenge(context.Background(), blockHash, &stubOracle{}, preimages)
+		require.NoError(t, err)
+		require.Equal(t, 0, metrics.challenged)
+		require.Equal(t, 1, metrics.failed)
+	})
+}
+
+func setupChallengerTest(t *testing.T) (*stubVerifier, *stubTxMgr, *PreimageChallenger, *stubChallengerMetrics) {
+	verifier := &stubVerifier{
+		challenges: make(map[uint64]keccakTypes.Challenge),
+	}
+	txMgr := &stubTxMgr{}
+	metrics := &stubChallengerMetrics{}
+	cl := clock.NewDeterministicClock(now)
+	challenger := NewPreimageChallenger(testlog.Logger(t, log.LvlInfo), metrics, cl, verifier, txMgr)
+	return verifier, txMgr, challenger, metrics
+}
+
+type stubVerifier struct {
+	m          sync.Mutex
+	challenges map[uint64]keccakTypes.Challenge
+	verified   []uint64
+	err        error
+}
+
+func (s *stubVerifier) CreateChallenge(_ context.Context, _ common.Hash, _ keccakTypes.LargePreimageOracle, preimage keccakTypes.LargePreimageMetaData) (keccakTypes.Challenge, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.verified = append(s.verified, preimage.UUID.Uint64())
+	if s.err != nil {
+		return keccakTypes.Challenge{}, s.err
+	}
+	challenge, ok := s.challenges[preimage.UUID.Uint64()]
+	if !ok {
+		return keccakTypes.Challenge{}, matrix.ErrValid
+	}
+	return challenge, nil
+}
+
+type stubTxMgr struct {
+	sent    []txmgr.TxCandidate
+	sendErr error
+	reverts bool
+}
+
+func (s *stubTxMgr) Send(_ context.Context, candidate txmgr.TxCandidate) (*ethtypes.Receipt, error) {
+	if s.sendErr != nil {
+		return nil, s.sendErr
+	}
+	s.sent = append(s.sent, candidate)
+	status := ethtypes.ReceiptStatusSuccessful
+	if s.reverts {
+		status = ethtypes.ReceiptStatusFailed
+	}
+	return &ethtypes.Receipt{Status: status}, nil
+}
+
+func (s *stubTxMgr) BlockNumber(_ context.Context) (uint64, error) { return 0, nil }
+func (s *stubTxMgr) From() common.Address                          { return common.Address{} }
+func (s *stubTxMgr) Close()                                        {}
+
+type stubChallengerMetrics struct {
+	challenged int
+	failed     int
+}
+
+func (s *stubChallengerMetrics) RecordPreimageChallenged() {
+	s.challenged++
+}
+
+func (s *stubChallengerMetrics) RecordPreimageChallengeFailed() {
+	s.failed++
+}
+
+type stubOracle struct {
+	m          sync.Mutex
+	addr       common.Address
+	err        error
+	preimages  []keccakTypes.LargePreimageMetaData
+	challenged []keccakTypes.LargePreimageIdent
+}
+
+func (s *stubOracle) challengedUUIDs() []uint64 {
+	var uuids []uint64
+	for _, ident := range s.challenged {
+		uuids = append(uuids, ident.UUID.Uint64())
+	}
+	return uuids
+}
+
+func (s *stubOracle) Addr() common.Address {
+	return s.addr
+}
+
+func (s *stubOracle) GetActivePreimages(_ context.Context, _ common.Hash) ([]keccakTypes.LargePreimageMetaData, error) {
+	return s.preimages, nil
+}
+
+func (s *stubOracle) GetInputDataBlocks(_ context.Context, _ batching.Block, _ keccakTypes.LargePreimageIdent) ([]uint64, error) {
+	panic("not supported")
+}
+
+func (s *stubOracle) DecodeInputData(_ []byte) (*big.Int, keccakTypes.InputData, error) {
+	panic("not supported")
+}
+
+func (s *stubOracle) ChallengeTx(ident keccakTypes.LargePreimageIdent, _ keccakTypes.Challenge) (txmgr.TxCandidate, error) {
+	if s.err != nil {
+		return txmgr.TxCandidate{}, s.err
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.challenged = append(s.challenged, ident)
+	return txmgr.TxCandidate{TxData: ident.UUID.Bytes()}, nil
+}
+
+func (s *stubOracle) ChallengePeriod(_ context.Context) (uint64, error) {
+	return testChallengePeriod, nil
+}
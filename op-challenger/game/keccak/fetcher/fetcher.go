@@ -0,0 +1,103 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrNoLeavesFound = errors.New("no leaves found in block")
+
+// L1Source is the subset of the L1 RPC used to load the input data of large preimage proposals.
+type L1Source interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// InputFetcher loads the leaves added to a large preimage proposal from the addLeavesLPP transactions on L1.
+type InputFetcher struct {
+	log    log.Logger
+	source L1Source
+}
+
+func NewPreimageFetcher(logger log.Logger, source L1Source) *InputFetcher {
+	return &InputFetcher{
+		log:    logger,
+		source: source,
+	}
+}
+
+// FetchInputs returns the input data of every successful addLeavesLPP call for the proposal, in the order they were
+// added, as of the specified L1 block.
+func (f *InputFetcher) FetchInputs(ctx context.Context, blockHash common.Hash, oracle keccakTypes.LargePreimageOracle, ident keccakTypes.LargePreimageIdent) ([]keccakTypes.InputData, error) {
+	blockNums, err := oracle.GetInputDataBlocks(ctx, batching.BlockByHash(blockHash), ident)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve leaf block nums: %w", err)
+	}
+	var inputs []keccakTypes.InputData
+	for _, blockNum := range blockNums {
+		foundRelevantTx := false
+		block, err := f.source.BlockByNumber(ctx, new(big.Int).SetUint64(blockNum))
+		if err != nil {
+			return nil, fmt.Errorf("failed getting tx for block %v: %w", blockNum, err)
+		}
+		for _, tx := range block.Transactions() {
+			inputData, err := f.extractRelevantLeavesFromTx(ctx, oracle, tx, ident)
+			if err != nil {
+				return nil, err
+			}
+			if inputData != nil {
+				foundRelevantTx = true
+				inputs = append(inputs, *inputData)
+			}
+		}
+		if !foundRelevantTx {
+			// The contract said there was a relevant transaction in this block that we failed to find.
+			// There was either a reorg or the extraction logic is broken.
+			// Either way, abort this attempt to validate the preimage.
+			return nil, fmt.Errorf("%w %v", ErrNoLeavesFound, blockNum)
+		}
+	}
+	return inputs, nil
+}
+
+func (f *InputFetcher) extractRelevantLeavesFromTx(ctx context.Context, oracle keccakTypes.LargePreimageOracle, tx *types.Transaction, ident keccakTypes.LargePreimageIdent) (*keccakTypes.InputData, error) {
+	if tx.To() == nil || *tx.To() != oracle.Addr() {
+		f.log.Trace("Skip tx with incorrect to addr", "tx", tx.Hash(), "expected", oracle.Addr(), "actual", tx.To())
+		return nil, nil
+	}
+	uuid, inputData, err := oracle.DecodeInputData(tx.Data())
+	if err != nil {
+		f.log.Debug("Skip tx with invalid call data", "tx", tx.Hash(), "err", err)
+		return nil, nil
+	}
+	if uuid.Cmp(ident.UUID) != 0 {
+		f.log.Trace("Skip tx with incorrect UUID", "tx", tx.Hash(), "expected", ident.UUID, "actual", uuid)
+		return nil, nil
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		f.log.Debug("Skip tx with invalid sender", "tx", tx.Hash(), "err", err)
+		return nil, nil
+	}
+	if sender != ident.Claimant {
+		f.log.Trace("Skip tx with incorrect sender", "tx", tx.Hash(), "expected", ident.Claimant, "actual", sender)
+		return nil, nil
+	}
+	rcpt, err := f.source.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve receipt for tx %v: %w", tx.Hash(), err)
+	}
+	if rcpt.Status != types.ReceiptStatusSuccessful {
+		f.log.Trace("Skip failed transaction", "tx", tx.Hash())
+		return nil, nil
+	}
+	return &inputData, nil
+}
@@ -0,0 +1,270 @@
+package fetcher
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	oracleAddr = common.Address{0x99, 0x98}
+	privKey, _ = crypto.GenerateKey()
+	ident      = keccakTypes.LargePreimageIdent{
+		Claimant: crypto.PubkeyToAddress(privKey.PublicKey),
+		UUID:     big.NewInt(888),
+	}
+	chainID     = big.NewInt(123)
+	blockHash   = common.Hash{0xdd}
+	input1      = keccakTypes.InputData{Input: []byte{0xbb, 0x11}, Commitments: []common.Hash{{0xcc, 0x11}}}
+	input2      = keccakTypes.InputData{Input: []byte{0xbb, 0x22}, Commitments: []common.Hash{{0xcc, 0x22}}}
+	input3      = keccakTypes.InputData{Input: []byte{0xbb, 0x33}, Commitments: []common.Hash{{0xcc, 0x33}}, Finalize: true}
+	errNotFound = errors.New("not found")
+)
+
+func TestFetchLeaves_NoBlocks(t *testing.T) {
+	fetcher, oracle, _ := setupFetcherTest(t)
+	oracle.leafBlocks = []uint64{}
+	leaves, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	require.NoError(t, err)
+	require.Empty(t, leaves)
+}
+
+func TestFetchLeaves_SingleTx(t *testing.T) {
+	fetcher, oracle, l1Source := setupFetcherTest(t)
+	blockNum := uint64(7)
+	oracle.leafBlocks = []uint64{blockNum}
+	l1Source.txs[blockNum] = types.Transactions{oracle.txForInput(ValidTx, input1)}
+	inputs, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	require.NoError(t, err)
+	require.Equal(t, []keccakTypes.InputData{input1}, inputs)
+}
+
+func TestFetchLeaves_MultipleBlocksAndTxs(t *testing.T) {
+	fetcher, oracle, l1Source := setupFetcherTest(t)
+	oracle.leafBlocks = []uint64{5, 9}
+	l1Source.txs[5] = types.Transactions{oracle.txForInput(ValidTx, input1), oracle.txForInput(ValidTx, input2)}
+	l1Source.txs[9] = types.Transactions{oracle.txForInput(ValidTx, input3)}
+	inputs, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	require.NoError(t, err)
+	require.Equal(t, []keccakTypes.InputData{input1, input2, input3}, inputs)
+}
+
+func TestFetchLeaves_SkipIrrelevantTxs(t *testing.T) {
+	fetcher, oracle, l1Source := setupFetcherTest(t)
+	blockNum := uint64(7)
+	oracle.leafBlocks = []uint64{blockNum}
+	failedTx := oracle.txForInput(ValidTx, input2)
+	l1Source.rcptStatus[failedTx.Hash()] = types.ReceiptStatusFailed
+	l1Source.txs[blockNum] = types.Transactions{
+		oracle.txForInput(WithToAddr(common.Address{0x88}), input2),
+		oracle.txForInput(WithUUID(big.NewInt(1)), input2),
+		oracle.txForInput(WithSender(otherKey()), input2),
+		oracle.txForInput(WithoutToAddr(), input2),
+		oracle.txForInput(WithInvalidData(), input2),
+		failedTx,
+		oracle.txForInput(ValidTx, input1),
+	}
+	inputs, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	require.NoError(t, err)
+	require.Equal(t, []keccakTypes.InputData{input1}, inputs)
+}
+
+func TestFetchLeaves_ErrorsWhenNoValidLeavesInBlock(t *testing.T) {
+	fetcher, oracle, l1Source := setupFetcherTest(t)
+	blockNum := uint64(7)
+	oracle.leafBlocks = []uint64{blockNum}
+	l1Source.txs[blockNum] = types.Transactions{oracle.txForInput(WithUUID(big.NewInt(1)), input1)}
+	_, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	require.ErrorIs(t, err, ErrNoLeavesFound)
+}
+
+func TestFetchLeaves_ErrorsOnMissingReceipt(t *testing.T) {
+	fetcher, oracle, l1Source := setupFetcherTest(t)
+	blockNum := uint64(7)
+	oracle.leafBlocks = []uint64{blockNum}
+	tx := oracle.txForInput(ValidTx, input1)
+	l1Source.txs[blockNum] = types.Transactions{tx}
+	l1Source.missingRcpts[tx.Hash()] = true
+	_, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	require.ErrorIs(t, err, errNotFound)
+}
+
+func TestFetchLeaves_ErrorsOnMissingBlock(t *testing.T) {
+	fetcher, oracle, _ := setupFetcherTest(t)
+	oracle.leafBlocks = []uint64{7}
+	_, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	require.ErrorIs(t, err, errNotFound)
+}
+
+func setupFetcherTest(t *testing.T) (*InputFetcher, *stubOracle, *stubL1Source) {
+	oracle := &stubOracle{
+		txInputs: make(map[byte]keccakTypes.InputData),
+	}
+	l1Source := &stubL1Source{
+		txs:          make(map[uint64]types.Transactions),
+		rcptStatus:   make(map[common.Hash]uint64),
+		missingRcpts: make(map[common.Hash]bool),
+	}
+	fetcher := NewPreimageFetcher(testlog.Logger(t, log.LvlTrace), l1Source)
+	return fetcher, oracle, l1Source
+}
+
+type txOpts struct {
+	to      *common.Address
+	uuid    *big.Int
+	privKey *ecdsa.PrivateKey
+	invalid bool
+}
+
+type TxModifier func(tx *txOpts)
+
+var ValidTx TxModifier = func(_ *txOpts) {}
+
+func WithToAddr(addr common.Address) TxModifier {
+	return func(tx *txOpts) {
+		tx.to = &addr
+	}
+}
+
+func WithoutToAddr() TxModifier {
+	return func(tx *txOpts) {
+		tx.to = nil
+	}
+}
+
+func WithUUID(uuid *big.Int) TxModifier {
+	return func(tx *txOpts) {
+		tx.uuid = uuid
+	}
+}
+
+func WithSender(key *ecdsa.PrivateKey) TxModifier {
+	return func(tx *txOpts) {
+		tx.privKey = key
+	}
+}
+
+func WithInvalidData() TxModifier {
+	return func(tx *txOpts) {
+		tx.invalid = true
+	}
+}
+
+func otherKey() *ecdsa.PrivateKey {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+type stubOracle struct {
+	nextTxId   byte
+	leafBlocks []uint64
+	txInputs   map[byte]keccakTypes.InputData
+	txUUIDs    map[byte]*big.Int
+}
+
+// txForInput creates a signed tx whose data is an id the stub oracle decodes to the input.
+func (o *stubOracle) txForInput(modifier TxModifier, input keccakTypes.InputData) *types.Transaction {
+	opts := &txOpts{
+		to:      &oracleAddr,
+		uuid:    ident.UUID,
+		privKey: privKey,
+	}
+	modifier(opts)
+
+	id := o.nextTxId
+	o.nextTxId++
+	o.txInputs[id] = input
+	if o.txUUIDs == nil {
+		o.txUUIDs = make(map[byte]*big.Int)
+	}
+	o.txUUIDs[id] = opts.uuid
+	data := []byte{id}
+	if opts.invalid {
+		data = []byte{}
+	}
+	tx := types.MustSignNewTx(opts.privKey, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID: chainID,
+		Nonce:   uint64(id),
+		To:      opts.to,
+		Data:    data,
+	})
+	return tx
+}
+
+func (o *stubOracle) Addr() common.Address {
+	return oracleAddr
+}
+
+func (o *stubOracle) GetInputDataBlocks(_ context.Context, block batching.Block, requestedIdent keccakTypes.LargePreimageIdent) ([]uint64, error) {
+	if !reflect.DeepEqual(block, batching.BlockByHash(blockHash)) {
+		panic("Unexpected block")
+	}
+	if requestedIdent != ident {
+		panic("Unexpected ident")
+	}
+	return o.leafBlocks, nil
+}
+
+func (o *stubOracle) DecodeInputData(data []byte) (*big.Int, keccakTypes.InputData, error) {
+	if len(data) == 0 {
+		return nil, keccakTypes.InputData{}, errors.New("invalid tx data")
+	}
+	input, ok := o.txInputs[data[0]]
+	if !ok {
+		return nil, keccakTypes.InputData{}, errors.New("unknown tx")
+	}
+	return o.txUUIDs[data[0]], input, nil
+}
+
+func (o *stubOracle) GetActivePreimages(_ context.Context, _ common.Hash) ([]keccakTypes.LargePreimageMetaData, error) {
+	panic("not supported")
+}
+
+func (o *stubOracle) ChallengeTx(_ keccakTypes.LargePreimageIdent, _ keccakTypes.Challenge) (txmgr.TxCandidate, error) {
+	panic("not supported")
+}
+
+func (o *stubOracle) ChallengePeriod(_ context.Context) (uint64, error) {
+	panic("not supported")
+}
+
+type stubL1Source struct {
+	txs          map[uint64]types.Transactions
+	rcptStatus   map[common.Hash]uint64
+	missingRcpts map[common.Hash]bool
+}
+
+func (s *stubL1Source) BlockByNumber(_ context.Context, number *big.Int) (*types.Block, error) {
+	txs, ok := s.txs[number.Uint64()]
+	if !ok {
+		return nil, errNotFound
+	}
+	return (&types.Block{}).WithBody(txs, nil), nil
+}
+
+func (s *stubL1Source) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if s.missingRcpts[txHash] {
+		return nil, errNotFound
+	}
+	status, ok := s.rcptStatus[txHash]
+	if !ok {
+		status = types.ReceiptStatusSuccessful
+	}
+	return &types.Receipt{Status: status}, nil
+}
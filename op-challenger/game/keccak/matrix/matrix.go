@@ -0,0 +1,201 @@
+package matrix
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/merkle"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrValid is returned by Challenge when every leaf of the proposal is valid.
+	ErrValid = errors.New("state commitments are valid")
+
+	ErrInvalidMaxLen = errors.New("max length must be a multiple of the block size")
+)
+
+// StateMatrix implements a stateful keccak sponge that exposes the state matrix and state commitments
+// after absorbing each block, as required for large preimage proposals.
+type StateMatrix struct {
+	state types.StateSnapshot
+}
+
+// NewStateMatrix creates a new state matrix initialized with the initial, zero keccak block.
+func NewStateMatrix() *StateMatrix {
+	return &StateMatrix{}
+}
+
+// StateSnapshot returns a copy of the current state matrix.
+func (d *StateMatrix) StateSnapshot() types.StateSnapshot {
+	return d.state
+}
+
+// PackState packs the state matrix in the ABI encoding used by the PreimageOracle contract.
+func (d *StateMatrix) PackState() []byte {
+	return d.state.Pack()
+}
+
+// StateCommitment returns the commitment to the current state matrix.
+func (d *StateMatrix) StateCommitment() common.Hash {
+	return d.state.Commitment()
+}
+
+// Hash returns the keccak256 hash of the absorbed data.
+// It is only meaningful once the final, padded block has been absorbed.
+func (d *StateMatrix) Hash() common.Hash {
+	var out common.Hash
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(out[i*8:], d.state[i])
+	}
+	return out
+}
+
+// absorbLeafInput absorbs a single full block of input into the state.
+func (d *StateMatrix) absorbLeafInput(block [types.BlockSize]byte) {
+	for i := 0; i < types.BlockSize/8; i++ {
+		d.state[i] ^= binary.LittleEndian.Uint64(block[i*8:])
+	}
+	a := [25]uint64(d.state)
+	keccakF1600(&a)
+	d.state = a
+}
+
+// absorbNextLeaf reads the next block from in and absorbs it, padding the block if it is the last one.
+// Returns the raw bytes read, the padded block and whether it was the final block.
+func (d *StateMatrix) absorbNextLeaf(in io.Reader) ([]byte, [types.BlockSize]byte, bool, error) {
+	var block [types.BlockSize]byte
+	n, err := io.ReadFull(in, block[:])
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		raw := common.CopyBytes(block[:n])
+		pad(&block, n)
+		d.absorbLeafInput(block)
+		return raw, block, true, nil
+	} else if err != nil {
+		return nil, block, false, err
+	}
+	d.absorbLeafInput(block)
+	return block[:], block, false, nil
+}
+
+// pad applies the keccak256 multi-rate padding to a partial block of length n.
+func pad(block *[types.BlockSize]byte, n int) {
+	block[n] = 0x01
+	block[types.BlockSize-1] |= 0x80
+}
+
+// AbsorbUpTo absorbs up to maxLen bytes from in, returning the raw input along with the state commitment after
+// each block. maxLen must be a multiple of the block size.
+// Returns io.EOF along with the final input data once the end of in is reached and the final block has been padded.
+func (d *StateMatrix) AbsorbUpTo(in io.Reader, maxLen int) (types.InputData, error) {
+	if maxLen < types.BlockSize || maxLen%types.BlockSize != 0 {
+		return types.InputData{}, ErrInvalidMaxLen
+	}
+	input := make([]byte, 0, maxLen)
+	var commitments []common.Hash
+	for len(input)+types.BlockSize <= maxLen {
+		raw, _, final, err := d.absorbNextLeaf(in)
+		if err != nil {
+			return types.InputData{}, err
+		}
+		input = append(input, raw...)
+		commitments = append(commitments, d.StateCommitment())
+		if final {
+			return types.InputData{
+				Input:       input,
+				Commitments: commitments,
+				Finalize:    true,
+			}, io.EOF
+		}
+	}
+	return types.InputData{
+		Input:       input,
+		Commitments: commitments,
+	}, nil
+}
+
+// ProposalLeaves absorbs all data from in and returns the resulting leaves of a valid proposal, along with the
+// state matrix before the final leaf was absorbed, as required to squeeze the proposal.
+func ProposalLeaves(in io.Reader) ([]types.Leaf, types.StateSnapshot, error) {
+	s := NewStateMatrix()
+	var leaves []types.Leaf
+	for i := uint64(0); ; i++ {
+		snapshot := s.StateSnapshot()
+		_, block, final, err := s.absorbNextLeaf(in)
+		if err != nil {
+			return nil, types.StateSnapshot{}, fmt.Errorf("failed to absorb leaf %v: %w", i, err)
+		}
+		leaves = append(leaves, types.Leaf{
+			Input:           block,
+			Index:           i,
+			StateCommitment: s.StateCommitment(),
+		})
+		if final {
+			return leaves, snapshot, nil
+		}
+	}
+}
+
+// Challenge creates a challenge to the first invalid state commitment for the data read from in.
+// Returns ErrValid if all commitments are valid.
+func Challenge(in io.Reader, commitments []common.Hash) (types.Challenge, error) {
+	s := NewStateMatrix()
+	tree := merkle.NewBinaryMerkleTree()
+	var leaves []types.Leaf
+	var prestateMatrix types.StateSnapshot
+	invalidIdx := -1
+	for i := 0; ; i++ {
+		if i >= len(commitments) {
+			return types.Challenge{}, fmt.Errorf("insufficient commitments, only %v provided", len(commitments))
+		}
+		snapshot := s.StateSnapshot()
+		_, block, final, err := s.absorbNextLeaf(in)
+		if err != nil {
+			return types.Challenge{}, fmt.Errorf("failed to absorb leaf %v: %w", i, err)
+		}
+		leaf := types.Leaf{
+			Input:           block,
+			Index:           uint64(i),
+			StateCommitment: commitments[i],
+		}
+		leaves = append(leaves, leaf)
+		if err := tree.AddLeaf(leaf.Hash()); err != nil {
+			return types.Challenge{}, fmt.Errorf("failed to add leaf %v: %w", i, err)
+		}
+		if invalidIdx < 0 && s.StateCommitment() != commitments[i] {
+			invalidIdx = i
+			prestateMatrix = snapshot
+		}
+		if final {
+			break
+		}
+	}
+	if len(commitments) != len(leaves) {
+		return types.Challenge{}, fmt.Errorf("expected %v commitments but got %v", len(leaves), len(commitments))
+	}
+	if invalidIdx < 0 {
+		return types.Challenge{}, ErrValid
+	}
+
+	challenge := types.Challenge{
+		StateMatrix: prestateMatrix,
+		Poststate:   leaves[invalidIdx],
+	}
+	proof, err := tree.ProofAtIndex(uint64(invalidIdx))
+	if err != nil {
+		return types.Challenge{}, err
+	}
+	challenge.PoststateProof = proof
+	if invalidIdx > 0 {
+		challenge.Prestate = leaves[invalidIdx-1]
+		proof, err := tree.ProofAtIndex(uint64(invalidIdx - 1))
+		if err != nil {
+			return types.Challenge{}, err
+		}
+		challenge.PrestateProof = proof
+	}
+	return challenge, nil
+}
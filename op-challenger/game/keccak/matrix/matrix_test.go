@@ -0,0 +1,177 @@
+package matrix
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/merkle"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var testLengths = []int{0, 1, 135, 136, 137, 271, 272, 273, 1000, 5000}
+
+func randomData(t *testing.T, size int) []byte {
+	data := make([]byte, size)
+	_, err := rand.New(rand.NewSource(int64(size))).Read(data)
+	require.NoError(t, err)
+	return data
+}
+
+func absorbAll(t *testing.T, data []byte, maxLen int) (*StateMatrix, []types.InputData) {
+	s := NewStateMatrix()
+	in := bytes.NewReader(data)
+	var calls []types.InputData
+	for {
+		call, err := s.AbsorbUpTo(in, maxLen)
+		calls = append(calls, call)
+		if err == io.EOF {
+			return s, calls
+		}
+		require.NoError(t, err)
+	}
+}
+
+func TestStateMatrix_Hash(t *testing.T) {
+	for _, size := range testLengths {
+		size := size
+		t.Run(fmt.Sprintf("Size-%v", size), func(t *testing.T) {
+			data := randomData(t, size)
+			s, _ := absorbAll(t, data, types.BlockSize*3)
+			require.Equal(t, crypto.Keccak256Hash(data), s.Hash())
+		})
+	}
+}
+
+func TestStateMatrix_AbsorbUpTo(t *testing.T) {
+	for _, size := range testLengths {
+		size := size
+		t.Run(fmt.Sprintf("Size-%v", size), func(t *testing.T) {
+			data := randomData(t, size)
+			_, calls := absorbAll(t, data, types.BlockSize*2)
+
+			input := []byte{}
+			var commitments []common.Hash
+			for i, call := range calls {
+				input = append(input, call.Input...)
+				commitments = append(commitments, call.Commitments...)
+				require.Equal(t, i == len(calls)-1, call.Finalize)
+				if !call.Finalize {
+					require.Len(t, call.Input, types.BlockSize*2)
+					require.Len(t, call.Commitments, 2)
+				}
+			}
+			require.Equal(t, data, input)
+			require.Len(t, commitments, size/types.BlockSize+1)
+		})
+	}
+}
+
+func TestStateMatrix_AbsorbUpToInvalidMaxLen(t *testing.T) {
+	s := NewStateMatrix()
+	_, err := s.AbsorbUpTo(bytes.NewReader(nil), types.BlockSize+1)
+	require.ErrorIs(t, err, ErrInvalidMaxLen)
+	_, err = s.AbsorbUpTo(bytes.NewReader(nil), 0)
+	require.ErrorIs(t, err, ErrInvalidMaxLen)
+}
+
+func commitmentsFor(t *testing.T, data []byte) []common.Hash {
+	_, calls := absorbAll(t, data, types.BlockSize)
+	var commitments []common.Hash
+	for _, call := range calls {
+		commitments = append(commitments, call.Commitments...)
+	}
+	return commitments
+}
+
+func TestChallenge_Valid(t *testing.T) {
+	for _, size := range testLengths {
+		data := randomData(t, size)
+		_, err := Challenge(bytes.NewReader(data), commitmentsFor(t, data))
+		require.ErrorIs(t, err, ErrValid)
+	}
+}
+
+func TestChallenge_Invalid(t *testing.T) {
+	data := randomData(t, 1000)
+	valid := commitmentsFor(t, data)
+	for invalidIdx := range valid {
+		invalidIdx := invalidIdx
+		t.Run(fmt.Sprintf("Leaf-%v", invalidIdx), func(t *testing.T) {
+			commitments := append([]common.Hash{}, valid...)
+			commitments[invalidIdx] = common.Hash{0xba, 0xd0}
+			// later commitments are irrelevant once one is invalid
+			if invalidIdx+1 < len(commitments) {
+				commitments[invalidIdx+1] = common.Hash{0xba, 0xd1}
+			}
+
+			challenge, err := Challenge(bytes.NewReader(data), commitments)
+			require.NoError(t, err)
+			require.Equal(t, uint64(invalidIdx), challenge.Poststate.Index)
+			require.Equal(t, commitments[invalidIdx], challenge.Poststate.StateCommitment)
+
+			root := rootHash(t, data, commitments)
+			require.True(t, merkle.VerifyProof(root, challenge.Poststate.Hash(), challenge.Poststate.Index, challenge.PoststateProof))
+
+			// absorbing the invalid leaf into the prestate matrix must produce a different commitment
+			s := &StateMatrix{state: challenge.StateMatrix}
+			s.absorbLeafInput(challenge.Poststate.Input)
+			require.NotEqual(t, challenge.Poststate.StateCommitment, s.StateCommitment())
+
+			if invalidIdx == 0 {
+				require.Equal(t, types.StateSnapshot{}, challenge.StateMatrix)
+				require.Equal(t, types.Leaf{}, challenge.Prestate)
+			} else {
+				require.Equal(t, uint64(invalidIdx-1), challenge.Prestate.Index)
+				require.Equal(t, challenge.StateMatrix.Commitment(), challenge.Prestate.StateCommitment)
+				require.True(t, merkle.VerifyProof(root, challenge.Prestate.Hash(), challenge.Prestate.Index, challenge.PrestateProof))
+			}
+		})
+	}
+}
+
+func TestChallenge_InsufficientCommitments(t *testing.T) {
+	data := randomData(t, 1000)
+	commitments := commitmentsFor(t, data)
+	_, err := Challenge(bytes.NewReader(data), commitments[:len(commitments)-1])
+	require.ErrorContains(t, err, "insufficient commitments")
+}
+
+func rootHash(t *testing.T, data []byte, commitments []common.Hash) common.Hash {
+	tree := merkle.NewBinaryMerkleTree()
+	padded := append(common.CopyBytes(data), make([]byte, types.BlockSize-len(data)%types.BlockSize)...)
+	padded[len(data)] = 0x01
+	padded[len(padded)-1] |= 0x80
+	for i, commitment := range commitments {
+		leaf := types.Leaf{Index: uint64(i), StateCommitment: commitment}
+		copy(leaf.Input[:], padded[i*types.BlockSize:])
+		require.NoError(t, tree.AddLeaf(leaf.Hash()))
+	}
+	return tree.RootHash()
+}
+
+func TestProposalLeaves(t *testing.T) {
+	for _, size := range testLengths {
+		data := randomData(t, size)
+		leaves, finalPrestate, err := ProposalLeaves(bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Len(t, leaves, size/types.BlockSize+1)
+
+		commitments := commitmentsFor(t, data)
+		s := NewStateMatrix()
+		for i, leaf := range leaves {
+			require.Equal(t, uint64(i), leaf.Index)
+			require.Equal(t, commitments[i], leaf.StateCommitment)
+			if i == len(leaves)-1 {
+				require.Equal(t, s.StateSnapshot(), finalPrestate)
+			}
+			s.absorbLeafInput(leaf.Input)
+		}
+		require.Equal(t, crypto.Keccak256Hash(data), s.Hash())
+	}
+}
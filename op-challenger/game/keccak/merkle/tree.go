@@ -0,0 +1,120 @@
+package merkle
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BinaryMerkleTreeDepth is the depth of the merkle tree the PreimageOracle contract builds from large preimage leaves.
+const BinaryMerkleTreeDepth = 16
+
+// MaxLeafCount is the maximum number of leaves that fit in the tree.
+const MaxLeafCount = 1<<BinaryMerkleTreeDepth - 1
+
+var (
+	ErrTreeFull     = errors.New("merkle tree is full")
+	ErrInvalidIndex = errors.New("leaf index out of range")
+)
+
+// Proof is the list of sibling hashes from a leaf to the root of the tree.
+type Proof [BinaryMerkleTreeDepth]common.Hash
+
+// Hashes returns the proof as a slice, which is the form the contract expects.
+func (p Proof) Hashes() []common.Hash {
+	return p[:]
+}
+
+// zeroHashes are the roots of empty subtrees of increasing height.
+var zeroHashes = func() [BinaryMerkleTreeDepth]common.Hash {
+	var hashes [BinaryMerkleTreeDepth]common.Hash
+	for i := 1; i < BinaryMerkleTreeDepth; i++ {
+		hashes[i] = crypto.Keccak256Hash(hashes[i-1][:], hashes[i-1][:])
+	}
+	return hashes
+}()
+
+// BinaryMerkleTree is an append-only binary merkle tree of fixed depth matching the one built by the PreimageOracle
+// contract. Empty leaves are the zero hash.
+type BinaryMerkleTree struct {
+	leaves []common.Hash
+}
+
+func NewBinaryMerkleTree() *BinaryMerkleTree {
+	return &BinaryMerkleTree{}
+}
+
+// LeafCount returns the number of leaves added to the tree.
+func (m *BinaryMerkleTree) LeafCount() uint64 {
+	return uint64(len(m.leaves))
+}
+
+// AddLeaf appends the hash of a leaf to the tree.
+func (m *BinaryMerkleTree) AddLeaf(leafHash common.Hash) error {
+	if len(m.leaves) >= MaxLeafCount {
+		return ErrTreeFull
+	}
+	m.leaves = append(m.leaves, leafHash)
+	return nil
+}
+
+// RootHash returns the root of the tree.
+func (m *BinaryMerkleTree) RootHash() common.Hash {
+	level := m.leaves
+	for height := 0; height < BinaryMerkleTreeDepth; height++ {
+		level = nextLevel(level, zeroHashes[height])
+	}
+	if len(level) == 0 {
+		return crypto.Keccak256Hash(zeroHashes[BinaryMerkleTreeDepth-1][:], zeroHashes[BinaryMerkleTreeDepth-1][:])
+	}
+	return level[0]
+}
+
+// ProofAtIndex returns the merkle proof for the leaf at the given index.
+func (m *BinaryMerkleTree) ProofAtIndex(index uint64) (Proof, error) {
+	var proof Proof
+	if index >= uint64(len(m.leaves)) {
+		return proof, fmt.Errorf("%w: %v", ErrInvalidIndex, index)
+	}
+	level := m.leaves
+	for height := 0; height < BinaryMerkleTreeDepth; height++ {
+		sibling := index ^ 1
+		if sibling < uint64(len(level)) {
+			proof[height] = level[sibling]
+		} else {
+			proof[height] = zeroHashes[height]
+		}
+		level = nextLevel(level, zeroHashes[height])
+		index /= 2
+	}
+	return proof, nil
+}
+
+// nextLevel hashes pairs of nodes to build the level above, padding an odd node with the empty subtree root.
+func nextLevel(level []common.Hash, zero common.Hash) []common.Hash {
+	next := make([]common.Hash, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		right := zero
+		if i+1 < len(level) {
+			right = level[i+1]
+		}
+		next = append(next, crypto.Keccak256Hash(level[i][:], right[:]))
+	}
+	return next
+}
+
+// VerifyProof returns true if the proof shows the leaf at index is included in a tree with the given root.
+func VerifyProof(root common.Hash, leafHash common.Hash, index uint64, proof Proof) bool {
+	node := leafHash
+	for height := 0; height < BinaryMerkleTreeDepth; height++ {
+		if index&1 == 0 {
+			node = crypto.Keccak256Hash(node[:], proof[height][:])
+		} else {
+			node = crypto.Keccak256Hash(proof[height][:], node[:])
+		}
+		index /= 2
+	}
+	return node == root
+}
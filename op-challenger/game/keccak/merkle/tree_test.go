@@ -0,0 +1,54 @@
+package merkle
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryMerkleTree_EmptyRoot(t *testing.T) {
+	tree := NewBinaryMerkleTree()
+	expected := common.Hash{}
+	for i := 0; i < BinaryMerkleTreeDepth; i++ {
+		expected = crypto.Keccak256Hash(expected[:], expected[:])
+	}
+	require.Equal(t, expected, tree.RootHash())
+}
+
+func TestBinaryMerkleTree_SingleLeaf(t *testing.T) {
+	tree := NewBinaryMerkleTree()
+	leaf := common.Hash{0xaa}
+	require.NoError(t, tree.AddLeaf(leaf))
+
+	expected := leaf
+	zero := common.Hash{}
+	for i := 0; i < BinaryMerkleTreeDepth; i++ {
+		expected = crypto.Keccak256Hash(expected[:], zero[:])
+		zero = crypto.Keccak256Hash(zero[:], zero[:])
+	}
+	require.Equal(t, expected, tree.RootHash())
+}
+
+func TestBinaryMerkleTree_Proofs(t *testing.T) {
+	tree := NewBinaryMerkleTree()
+	for i := 0; i < 13; i++ {
+		require.NoError(t, tree.AddLeaf(common.Hash{byte(i + 1)}))
+		root := tree.RootHash()
+		for j := uint64(0); j <= uint64(i); j++ {
+			proof, err := tree.ProofAtIndex(j)
+			require.NoError(t, err)
+			require.Truef(t, VerifyProof(root, common.Hash{byte(j + 1)}, j, proof), "invalid proof for leaf %v of %v", j, i+1)
+			require.False(t, VerifyProof(root, common.Hash{0xff}, j, proof))
+		}
+	}
+	require.Equal(t, uint64(13), tree.LeafCount())
+}
+
+func TestBinaryMerkleTree_InvalidIndex(t *testing.T) {
+	tree := NewBinaryMerkleTree()
+	require.NoError(t, tree.AddLeaf(common.Hash{0x01}))
+	_, err := tree.ProofAtIndex(1)
+	require.ErrorIs(t, err, ErrInvalidIndex)
+}
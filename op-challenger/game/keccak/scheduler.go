@@ -0,0 +1,91 @@
+package keccak
+
+import (
+	"context"
+	"sync"
+
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type Challenger interface {
+	Challenge(ctx context.Context, blockHash common.Hash, oracle keccakTypes.LargePreimageOracle, preimages []keccakTypes.LargePreimageMetaData) error
+}
+
+type OracleSource interface {
+	Oracles() []keccakTypes.LargePreimageOracle
+}
+
+// LargePreimageScheduler checks the large preimage proposals of every known oracle for each new L1 block.
+// Blocks that arrive while the previous block is still being processed are skipped.
+type LargePreimageScheduler struct {
+	log        log.Logger
+	ch         chan common.Hash
+	oracles    OracleSource
+	challenger Challenger
+	cancel     func()
+	wg         sync.WaitGroup
+}
+
+func NewLargePreimageScheduler(logger log.Logger, oracleSource OracleSource, challenger Challenger) *LargePreimageScheduler {
+	return &LargePreimageScheduler{
+		log:        logger,
+		ch:         make(chan common.Hash, 1),
+		oracles:    oracleSource,
+		challenger: challenger,
+	}
+}
+
+func (s *LargePreimageScheduler) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+func (s *LargePreimageScheduler) Close() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+	return nil
+}
+
+func (s *LargePreimageScheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case blockHash := <-s.ch:
+			s.verifyPreimages(ctx, blockHash)
+		}
+	}
+}
+
+// Schedule queues the block to be checked, without blocking if a block is already queued.
+func (s *LargePreimageScheduler) Schedule(blockHash common.Hash, _ uint64) error {
+	select {
+	case s.ch <- blockHash:
+	default:
+		s.log.Trace("Skipping preimage check while already processing")
+	}
+	return nil
+}
+
+func (s *LargePreimageScheduler) verifyPreimages(ctx context.Context, blockHash common.Hash) {
+	for _, oracle := range s.oracles.Oracles() {
+		if err := s.verifyOraclePreimages(ctx, oracle, blockHash); err != nil {
+			s.log.Error("Failed to verify preimages in oracle", "oracle", oracle.Addr(), "blockHash", blockHash, "err", err)
+		}
+	}
+}
+
+func (s *LargePreimageScheduler) verifyOraclePreimages(ctx context.Context, oracle keccakTypes.LargePreimageOracle, blockHash common.Hash) error {
+	preimages, err := oracle.GetActivePreimages(ctx, blockHash)
+	if err != nil {
+		return err
+	}
+	return s.challenger.Challenge(ctx, blockHash, oracle, preimages)
+}
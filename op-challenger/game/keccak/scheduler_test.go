@@ -0,0 +1,67 @@
+package keccak
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleNextCheck(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LvlInfo)
+	preimage1 := keccakTypes.LargePreimageMetaData{LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0xab}, UUID: big.NewInt(111)}}
+	preimage2 := keccakTypes.LargePreimageMetaData{LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0xcd}, UUID: big.NewInt(222)}}
+	oracle := &stubOracle{
+		addr:      common.Address{0x99},
+		preimages: []keccakTypes.LargePreimageMetaData{preimage1, preimage2},
+	}
+	challenger := &stubChallenger{}
+	scheduler := NewLargePreimageScheduler(logger, &stubOracleSource{oracles: []keccakTypes.LargePreimageOracle{oracle}}, challenger)
+	scheduler.Start(ctx)
+	defer scheduler.Close()
+	err := scheduler.Schedule(common.Hash{0xaa}, 3)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return challenger.Checked() != nil
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Equal(t, []keccakTypes.LargePreimageMetaData{preimage1, preimage2}, challenger.Checked())
+}
+
+type stubOracleSource struct {
+	oracles []keccakTypes.LargePreimageOracle
+}
+
+func (s *stubOracleSource) Oracles() []keccakTypes.LargePreimageOracle {
+	return s.oracles
+}
+
+type stubChallenger struct {
+	m       sync.Mutex
+	checked []keccakTypes.LargePreimageMetaData
+}
+
+func (s *stubChallenger) Challenge(_ context.Context, _ common.Hash, _ keccakTypes.LargePreimageOracle, preimages []keccakTypes.LargePreimageMetaData) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.checked = preimages
+	return nil
+}
+
+func (s *stubChallenger) Checked() []keccakTypes.LargePreimageMetaData {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.checked == nil {
+		return nil
+	}
+	v := make([]keccakTypes.LargePreimageMetaData, len(s.checked))
+	copy(v, s.checked)
+	return v
+}
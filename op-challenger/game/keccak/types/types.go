@@ -0,0 +1,109 @@
+package types
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/merkle"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BlockSize is the size in bytes of the keccak256 rate, and so the size of each leaf of a large preimage.
+const BlockSize = 136
+
+// StateSnapshot is a snapshot of the 25 lanes of the keccak state matrix.
+type StateSnapshot [25]uint64
+
+// Pack encodes the state matrix in the ABI encoding used by the PreimageOracle contract.
+func (s StateSnapshot) Pack() []byte {
+	buf := make([]byte, 0, len(s)*32)
+	for _, v := range s {
+		buf = append(buf, common.BigToHash(new(big.Int).SetUint64(v)).Bytes()...)
+	}
+	return buf
+}
+
+// Commitment returns the commitment to the state matrix stored in the leaves of a large preimage proposal.
+func (s StateSnapshot) Commitment() common.Hash {
+	return crypto.Keccak256Hash(s.Pack())
+}
+
+// Leaf is a single block of a large preimage along with the commitment to the keccak state after absorbing it.
+type Leaf struct {
+	// Input is the data absorbed for the block, exactly 136 bytes, including any padding on the final block.
+	Input [BlockSize]byte
+	// Index of the block in the absorption process
+	Index uint64
+	// StateCommitment is the hash of the internal state after absorbing the input.
+	StateCommitment common.Hash
+}
+
+// Hash returns the hash of the leaf as stored in the proposal merkle tree.
+func (l Leaf) Hash() common.Hash {
+	index := common.BigToHash(new(big.Int).SetUint64(l.Index))
+	return crypto.Keccak256Hash(l.Input[:], index[:], l.StateCommitment[:])
+}
+
+// InputData is the data submitted in a single addLeavesLPP call.
+type InputData struct {
+	// Input is the unpadded data, a multiple of the block size unless this is the final call.
+	Input []byte
+	// Commitments are the state commitments after absorbing each block of Input.
+	Commitments []common.Hash
+	// Finalize is true if Input contains the final bytes of the preimage and the contract should apply padding.
+	Finalize bool
+}
+
+// LargePreimageIdent identifies a large preimage proposal.
+type LargePreimageIdent struct {
+	Claimant common.Address
+	UUID     *big.Int
+}
+
+// LargePreimageMetaData is the current state of a large preimage proposal.
+type LargePreimageMetaData struct {
+	LargePreimageIdent
+
+	// Timestamp is the time at which the proposal was finalized, or 0 if it has not been finalized.
+	Timestamp       uint64
+	PartOffset      uint32
+	ClaimedSize     uint32
+	BlocksProcessed uint32
+	BytesProcessed  uint32
+	Countered       bool
+}
+
+// ShouldVerify returns true if the preimage upload is complete, has not yet been countered, and the
+// challenge period has not yet elapsed.
+func (m LargePreimageMetaData) ShouldVerify(now time.Time, challengePeriod time.Duration) bool {
+	return m.Timestamp > 0 && !m.Countered && time.Unix(int64(m.Timestamp), 0).Add(challengePeriod).After(now)
+}
+
+// Challenge is the data required to prove a leaf of a large preimage proposal is invalid.
+type Challenge struct {
+	// StateMatrix is the keccak state matrix before absorbing the invalid leaf.
+	StateMatrix StateSnapshot
+
+	// Prestate is the valid leaf immediately before the invalid one.
+	// It is not set when the first leaf is invalid.
+	Prestate      Leaf
+	PrestateProof merkle.Proof
+
+	// Poststate is the first invalid leaf.
+	Poststate      Leaf
+	PoststateProof merkle.Proof
+}
+
+// LargePreimageOracle is the subset of the PreimageOracle contract used to find and challenge large preimage proposals.
+type LargePreimageOracle interface {
+	Addr() common.Address
+	GetActivePreimages(ctx context.Context, blockHash common.Hash) ([]LargePreimageMetaData, error)
+	GetInputDataBlocks(ctx context.Context, block batching.Block, ident LargePreimageIdent) ([]uint64, error)
+	DecodeInputData(data []byte) (*big.Int, InputData, error)
+	ChallengeTx(ident LargePreimageIdent, challenge Challenge) (txmgr.TxCandidate, error)
+	ChallengePeriod(ctx context.Context) (uint64, error)
+}
@@ -0,0 +1,49 @@
+package keccak
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type Fetcher interface {
+	FetchInputs(ctx context.Context, blockHash common.Hash, oracle keccakTypes.LargePreimageOracle, ident keccakTypes.LargePreimageIdent) ([]keccakTypes.InputData, error)
+}
+
+// PreimageVerifier checks the state commitments of large preimage proposals against the data that was submitted.
+type PreimageVerifier struct {
+	log     log.Logger
+	fetcher Fetcher
+}
+
+func NewPreimageVerifier(logger log.Logger, fetcher Fetcher) *PreimageVerifier {
+	return &PreimageVerifier{
+		log:     logger,
+		fetcher: fetcher,
+	}
+}
+
+// CreateChallenge returns the challenge for the first invalid leaf of the proposal.
+// Returns matrix.ErrValid if the proposal is valid.
+func (v *PreimageVerifier) CreateChallenge(ctx context.Context, blockHash common.Hash, oracle keccakTypes.LargePreimageOracle, preimage keccakTypes.LargePreimageMetaData) (keccakTypes.Challenge, error) {
+	inputs, err := v.fetcher.FetchInputs(ctx, blockHash, oracle, preimage.LargePreimageIdent)
+	if err != nil {
+		return keccakTypes.Challenge{}, fmt.Errorf("failed to fetch leaves: %w", err)
+	}
+	var data []byte
+	var commitments []common.Hash
+	for _, input := range inputs {
+		data = append(data, input.Input...)
+		commitments = append(commitments, input.Commitments...)
+	}
+	challenge, err := matrix.Challenge(bytes.NewReader(data), commitments)
+	if err != nil {
+		return keccakTypes.Challenge{}, fmt.Errorf("failed to create challenge: %w", err)
+	}
+	return challenge, nil
+}
@@ -0,0 +1,110 @@
+package keccak
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		inputs   func() []keccakTypes.InputData
+		expected func(t *testing.T, challenge keccakTypes.Challenge)
+	}{
+		{
+			name:   "Valid-SingleInput",
+			inputs: func() []keccakTypes.InputData { return validInputs(t, 1) },
+		},
+		{
+			name:   "Valid-MultipleInputs",
+			inputs: func() []keccakTypes.InputData { return validInputs(t, 3) },
+		},
+		{
+			name: "Invalid-FirstCommitment",
+			inputs: func() []keccakTypes.InputData {
+				inputs := validInputs(t, 3)
+				inputs[0].Commitments[0] = common.Hash{0xaa}
+				return inputs
+			},
+			expected: func(t *testing.T, challenge keccakTypes.Challenge) {
+				require.Equal(t, uint64(0), challenge.Poststate.Index)
+			},
+		},
+		{
+			name: "Invalid-LaterCommitment",
+			inputs: func() []keccakTypes.InputData {
+				inputs := validInputs(t, 3)
+				inputs[1].Commitments[1] = common.Hash{0xaa}
+				return inputs
+			},
+			expected: func(t *testing.T, challenge keccakTypes.Challenge) {
+				require.Equal(t, uint64(3), challenge.Poststate.Index)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			fetcher := &stubFetcher{inputs: test.inputs()}
+			verifier := NewPreimageVerifier(testlog.Logger(t, log.LvlInfo), fetcher)
+			preimage := keccakTypes.LargePreimageMetaData{
+				LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0x11}, UUID: big.NewInt(1)},
+			}
+			challenge, err := verifier.CreateChallenge(context.Background(), common.Hash{0xff}, &stubOracle{}, preimage)
+			if test.expected == nil {
+				require.ErrorIs(t, err, matrix.ErrValid)
+			} else {
+				require.NoError(t, err)
+				test.expected(t, challenge)
+			}
+		})
+	}
+}
+
+func TestVerify_FetchFails(t *testing.T) {
+	fetchErr := errors.New("boom")
+	verifier := NewPreimageVerifier(testlog.Logger(t, log.LvlInfo), &stubFetcher{err: fetchErr})
+	_, err := verifier.CreateChallenge(context.Background(), common.Hash{0xff}, &stubOracle{}, keccakTypes.LargePreimageMetaData{})
+	require.ErrorIs(t, err, fetchErr)
+}
+
+// validInputs creates valid input data split into the specified number of calls, with two leaves per call.
+func validInputs(t *testing.T, inputCount int) []keccakTypes.InputData {
+	data := make([]byte, keccakTypes.BlockSize*2*inputCount-10)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	s := matrix.NewStateMatrix()
+	in := bytes.NewReader(data)
+	var inputs []keccakTypes.InputData
+	for {
+		input, err := s.AbsorbUpTo(in, keccakTypes.BlockSize*2)
+		inputs = append(inputs, input)
+		if errors.Is(err, io.EOF) {
+			require.Len(t, inputs, inputCount, fmt.Sprintf("unexpected number of inputs: %v", len(inputs)))
+			return inputs
+		}
+		require.NoError(t, err)
+	}
+}
+
+type stubFetcher struct {
+	inputs []keccakTypes.InputData
+	err    error
+}
+
+func (s *stubFetcher) FetchInputs(_ context.Context, _ common.Hash, _ keccakTypes.LargePreimageOracle, _ keccakTypes.LargePreimageIdent) ([]keccakTypes.InputData, error) {
+	return s.inputs, s.err
+}
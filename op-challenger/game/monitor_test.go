@@ -18,6 +18,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 func TestMonitorMinGameTimestamp(t *testing.T) {
@@ -166,6 +167,18 @@ func TestMonitorOnlyScheduleSpecifiedGame(t *testing.T) {
 	require.Equal(t, []common.Address{addr2}, sched.Scheduled()[0])
 }
 
+func TestMonitorWithoutPreimages(t *testing.T) {
+	monitor, source, sched, _ := setupMonitorTest(t, []common.Address{})
+	monitor.preimages = nil
+	addr1 := common.Address{0xaa}
+	source.games = []types.GameMetadata{newFDG(addr1, 9999)}
+
+	monitor.onNewL1Head(context.Background(), eth.L1BlockRef{Hash: common.Hash{0x01}, Number: 1})
+
+	require.Len(t, sched.Scheduled(), 1)
+	require.Equal(t, []common.Address{addr1}, sched.Scheduled()[0])
+}
+
 func newFDG(proxy common.Address, timestamp uint64) types.GameMetadata {
 	return types.GameMetadata{
 		Proxy:     proxy,
@@ -185,12 +198,14 @@ func setupMonitorTest(
 		return i, nil
 	}
 	sched := &stubScheduler{}
+	preimages := &stubPreimageScheduler{}
 	mockHeadSource := &mockNewHeadSource{}
 	monitor := newGameMonitor(
 		logger,
 		clock.SystemClock,
 		source,
 		sched,
+		preimages,
 		time.Duration(0),
 		fetchBlockNum,
 		allowedGames,
@@ -281,3 +296,15 @@ func (s *stubScheduler) Schedule(games []types.GameMetadata, blockNumber uint64)
 	s.scheduled = append(s.scheduled, addrs)
 	return nil
 }
+
+type stubPreimageScheduler struct {
+	sync.Mutex
+	scheduleCount int
+}
+
+func (s *stubPreimageScheduler) Schedule(_ common.Hash, _ uint64) error {
+	s.Lock()
+	defer s.Unlock()
+	s.scheduleCount++
+	return nil
+}
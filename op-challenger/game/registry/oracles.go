@@ -0,0 +1,34 @@
+package registry
+
+import (
+	"sync"
+
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/maps"
+)
+
+// OracleRegistry tracks the PreimageOracle contracts used by the supported game types.
+type OracleRegistry struct {
+	l       sync.Mutex
+	oracles map[common.Address]keccakTypes.LargePreimageOracle
+}
+
+func NewOracleRegistry() *OracleRegistry {
+	return &OracleRegistry{
+		oracles: make(map[common.Address]keccakTypes.LargePreimageOracle),
+	}
+}
+
+// RegisterOracle adds an oracle to the registry. Registering the same oracle address multiple times has no effect.
+func (r *OracleRegistry) RegisterOracle(oracle keccakTypes.LargePreimageOracle) {
+	r.l.Lock()
+	defer r.l.Unlock()
+	r.oracles[oracle.Addr()] = oracle
+}
+
+func (r *OracleRegistry) Oracles() []keccakTypes.LargePreimageOracle {
+	r.l.Lock()
+	defer r.l.Unlock()
+	return maps.Values(r.oracles)
+}
@@ -0,0 +1,26 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeduplicateOracles(t *testing.T) {
+	registry := NewOracleRegistry()
+	caller := batching.NewMultiCaller(nil, batching.DefaultBatchSize)
+	oracleA, err := contracts.NewPreimageOracleContract(common.Address{0xaa}, caller)
+	require.NoError(t, err)
+	oracleB, err := contracts.NewPreimageOracleContract(common.Address{0xbb}, caller)
+	require.NoError(t, err)
+	registry.RegisterOracle(oracleA)
+	registry.RegisterOracle(oracleB)
+	registry.RegisterOracle(oracleB)
+	oracles := registry.Oracles()
+	require.Len(t, oracles, 2)
+	require.ElementsMatch(t, []keccakTypes.LargePreimageOracle{oracleA, oracleB}, oracles)
+}
@@ -14,9 +14,14 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/policy"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak"
+	keccakFetcher "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/fetcher"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/loader"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/registry"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/watcher"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-challenger/version"
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -35,11 +40,16 @@ type Service struct {
 	monitor *gameMonitor
 	sched   *scheduler.Scheduler
 
+	preimages *keccak.LargePreimageScheduler
+
+	watcher *watcher.GameWatcher
+
 	faultGamesCloser fault.CloseFunc
 
 	txMgr *txmgr.SimpleTxManager
 
-	loader *loader.GameLoader
+	factoryContract *contracts.DisputeGameFactoryContract
+	loader          *loader.GameLoader
 
 	rollupClient *sources.RollupClient
 
@@ -70,8 +80,10 @@ func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Se
 }
 
 func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error {
-	if err := s.initTxManager(cfg); err != nil {
-		return err
+	if cfg.Mode != config.ModeMonitor {
+		if err := s.initTxManager(cfg); err != nil {
+			return err
+		}
 	}
 	if err := s.initL1Client(ctx, cfg); err != nil {
 		return err
@@ -91,7 +103,9 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	if err := s.initGameLoader(cfg); err != nil {
 		return err
 	}
-	if err := s.initScheduler(ctx, cfg); err != nil {
+	if cfg.Mode == config.ModeMonitor {
+		s.initWatcher(cfg)
+	} else if err := s.initScheduler(ctx, cfg); err != nil {
 		return err
 	}
 
@@ -158,7 +172,9 @@ func (s *Service) initMetricsServer(cfg *opmetrics.CLIConfig) error {
 	}
 	s.logger.Info("started metrics server", "addr", metricsSrv.Addr())
 	s.metricsSrv = metricsSrv
-	s.balanceMetricer = s.metrics.StartBalanceMetrics(s.logger, s.l1Client, s.txMgr.From())
+	if s.txMgr != nil {
+		s.balanceMetricer = s.metrics.StartBalanceMetrics(s.logger, s.l1Client, s.txMgr.From())
+	}
 	return nil
 }
 
@@ -168,6 +184,7 @@ func (s *Service) initGameLoader(cfg *config.Config) error {
 	if err != nil {
 		return fmt.Errorf("failed to bind the fault dispute game factory contract: %w", err)
 	}
+	s.factoryContract = factoryContract
 	s.loader = loader.NewGameLoader(factoryContract)
 	return nil
 }
@@ -186,8 +203,18 @@ func (s *Service) initRollupClient(ctx context.Context, cfg *config.Config) erro
 
 func (s *Service) initScheduler(ctx context.Context, cfg *config.Config) error {
 	gameTypeRegistry := registry.NewGameTypeRegistry()
+	oracles := registry.NewOracleRegistry()
 	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
-	closer, err := fault.RegisterGameTypes(gameTypeRegistry, ctx, s.logger, s.metrics, cfg, s.rollupClient, s.txMgr, caller)
+	actionPolicy := policy.NewPolicy(clock.SystemClock, policy.Limits{
+		MaxMovesPerGame:   cfg.PolicyMaxMovesPerGame,
+		MaxBond:           cfg.PolicyMaxBond,
+		MaxGasCost:        cfg.PolicyMaxGasCost,
+		Window:            cfg.PolicyWindow,
+		DisputedRootsOnly: cfg.PolicyDisputedRootsOnly,
+	})
+	// Only transactions sent while playing games count towards the gas cost limit.
+	gameTxMgr := actionPolicy.TxManager(s.txMgr)
+	closer, err := fault.RegisterGameTypes(gameTypeRegistry, oracles, ctx, clock.SystemClock, s.logger, s.metrics, cfg, s.rollupClient, gameTxMgr, s.factoryContract, caller, s.l1Client, actionPolicy)
 	if err != nil {
 		return err
 	}
@@ -195,17 +222,55 @@ func (s *Service) initScheduler(ctx context.Context, cfg *config.Config) error {
 
 	disk := newDiskManager(cfg.Datadir)
 	s.sched = scheduler.NewScheduler(s.logger, s.metrics, disk, cfg.MaxConcurrency, gameTypeRegistry.CreatePlayer)
+
+	if cfg.LargePreimages {
+		s.initLargePreimages(oracles)
+	}
 	return nil
 }
 
+func (s *Service) initLargePreimages(oracles keccak.OracleSource) {
+	fetcher := keccakFetcher.NewPreimageFetcher(s.logger, s.l1Client)
+	verifier := keccak.NewPreimageVerifier(s.logger, fetcher)
+	challenger := keccak.NewPreimageChallenger(s.logger, s.metrics, clock.SystemClock, verifier, s.txMgr)
+	s.preimages = keccak.NewLargePreimageScheduler(s.logger, oracles, challenger)
+}
+
+// initWatcher creates the game watcher used in monitor mode in place of the scheduler.
+// No games are played or preimages challenged so no transaction manager is required.
+func (s *Service) initWatcher(cfg *config.Config) {
+	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
+	creator := func(game types.GameMetadata) (watcher.GameContract, error) {
+		return contracts.NewFaultDisputeGameContract(game.Proxy, caller)
+	}
+	s.watcher = watcher.NewGameWatcher(s.logger, s.metrics, clock.SystemClock, creator, s.rollupClient, cfg.MaxConcurrency, cfg.MonitorExpiryWarning)
+}
+
 func (s *Service) initMonitor(cfg *config.Config) {
 	cl := clock.SystemClock
-	s.monitor = newGameMonitor(s.logger, cl, s.loader, s.sched, cfg.GameWindow, s.l1Client.BlockNumber, cfg.GameAllowlist, s.pollClient)
+	if s.watcher != nil {
+		s.monitor = newGameMonitor(s.logger, cl, s.loader, s.watcher, nil, cfg.GameWindow, s.l1Client.BlockNumber, cfg.GameAllowlist, s.pollClient)
+		return
+	}
+	var preimages preimageScheduler
+	if s.preimages != nil {
+		preimages = s.preimages
+	}
+	s.monitor = newGameMonitor(s.logger, cl, s.loader, s.sched, preimages, cfg.GameWindow, s.l1Client.BlockNumber, cfg.GameAllowlist, s.pollClient)
 }
 
 func (s *Service) Start(ctx context.Context) error {
-	s.logger.Info("starting scheduler")
-	s.sched.Start(ctx)
+	if s.watcher != nil {
+		s.logger.Info("starting game watcher")
+		s.watcher.Start(ctx)
+	} else {
+		s.logger.Info("starting scheduler")
+		s.sched.Start(ctx)
+		if s.preimages != nil {
+			s.logger.Info("starting large preimage verification")
+			s.preimages.Start(ctx)
+		}
+	}
 	s.logger.Info("starting monitoring")
 	s.monitor.StartMonitoring()
 	s.logger.Info("challenger game service start completed")
@@ -228,6 +293,16 @@ func (s *Service) Stop(ctx context.Context) error {
 	if s.monitor != nil {
 		s.monitor.StopMonitoring()
 	}
+	if s.watcher != nil {
+		if err := s.watcher.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close game watcher: %w", err))
+		}
+	}
+	if s.preimages != nil {
+		if err := s.preimages.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close preimage verifier: %w", err))
+		}
+	}
 	if s.faultGamesCloser != nil {
 		s.faultGamesCloser()
 	}
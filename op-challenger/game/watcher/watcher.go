@@ -0,0 +1,278 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/errgroup"
+)
+
+type Health string
+
+const (
+	// HealthCorrect games are resolved, or currently resolving, as an honest actor would expect.
+	HealthCorrect Health = "correct"
+	// HealthIncorrect games are in progress and would resolve incorrectly if all clocks expired now.
+	HealthIncorrect Health = "incorrect"
+	// HealthExpiring games are resolving incorrectly and are within the expiry warning of their deadline.
+	HealthExpiring Health = "expiring"
+	// HealthResolvedIncorrectly games have already resolved with the wrong outcome.
+	HealthResolvedIncorrectly Health = "resolved_incorrectly"
+	// HealthUnknown games could not be evaluated.
+	HealthUnknown Health = "unknown"
+)
+
+type Metrics interface {
+	RecordGameHealth(correct, incorrect, expiring, resolvedIncorrectly, unknown int)
+}
+
+// GameContract provides the state of a fault dispute game.
+type GameContract interface {
+	GetGameMetadata(ctx context.Context) (uint64, common.Hash, gameTypes.GameStatus, error)
+	GetGameDuration(ctx context.Context) (uint64, error)
+	GetAllClaims(ctx context.Context) ([]faultTypes.Claim, error)
+}
+
+type GameContractCreator func(game gameTypes.GameMetadata) (GameContract, error)
+
+type OutputRollupClient interface {
+	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
+}
+
+// GameReport is the result of evaluating a single game.
+type GameReport struct {
+	Game          gameTypes.GameMetadata
+	L2BlockNumber uint64
+	RootClaim     common.Hash
+	// Status is the on-chain status of the game.
+	Status gameTypes.GameStatus
+	// Expected is the status the game should resolve to, based on the output root from the rollup node.
+	Expected gameTypes.GameStatus
+	// Provisional is the status the game would resolve to if all clocks expired now.
+	// Only set for games that are in progress.
+	Provisional gameTypes.GameStatus
+	// Deadline is the earliest time the clock of an uncountered claim expires, after which the claim can no longer be
+	// countered and its subgame can be resolved. Only set for games that are in progress.
+	Deadline time.Time
+	Health   Health
+}
+
+// GameWatcher evaluates every game in the background each time new games are scheduled, reporting games that are
+// resolving incorrectly without sending any transactions.
+// Updates that arrive while the previous evaluation is still being processed are skipped.
+type GameWatcher struct {
+	log            log.Logger
+	metrics        Metrics
+	clock          clock.Clock
+	creator        GameContractCreator
+	rollupClient   OutputRollupClient
+	maxConcurrency int
+	expiryWarning  time.Duration
+
+	ch     chan []gameTypes.GameMetadata
+	cancel func()
+	wg     sync.WaitGroup
+
+	// resolved caches the reports of resolved games, which can't change. Only accessed from the run goroutine.
+	resolved map[common.Address]GameReport
+}
+
+func NewGameWatcher(
+	logger log.Logger,
+	m Metrics,
+	cl clock.Clock,
+	creator GameContractCreator,
+	rollupClient OutputRollupClient,
+	maxConcurrency uint,
+	expiryWarning time.Duration,
+) *GameWatcher {
+	return &GameWatcher{
+		log:            logger,
+		metrics:        m,
+		clock:          cl,
+		creator:        creator,
+		rollupClient:   rollupClient,
+		maxConcurrency: int(maxConcurrency),
+		expiryWarning:  expiryWarning,
+		ch:             make(chan []gameTypes.GameMetadata, 1),
+		resolved:       make(map[common.Address]GameReport),
+	}
+}
+
+func (w *GameWatcher) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	w.cancel = cancel
+	w.wg.Add(1)
+	go w.run(ctx)
+}
+
+func (w *GameWatcher) Close() error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+	return nil
+}
+
+func (w *GameWatcher) run(ctx context.Context) {
+	defer w.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case games := <-w.ch:
+			w.CheckGames(ctx, games)
+		}
+	}
+}
+
+// Schedule queues the games to be evaluated, without blocking if an update is already queued.
+func (w *GameWatcher) Schedule(games []gameTypes.GameMetadata, _ uint64) error {
+	select {
+	case w.ch <- games:
+	default:
+		w.log.Trace("Skipping game evaluation while already processing")
+	}
+	return nil
+}
+
+// CheckGames evaluates each game, logging and recording metrics for any that are not resolving correctly.
+func (w *GameWatcher) CheckGames(ctx context.Context, games []gameTypes.GameMetadata) []GameReport {
+	reports := make([]GameReport, len(games))
+	group, gCtx := errgroup.WithContext(ctx)
+	group.SetLimit(w.maxConcurrency)
+	for i, game := range games {
+		i, game := i, game
+		if report, ok := w.resolved[game.Proxy]; ok {
+			reports[i] = report
+			continue
+		}
+		group.Go(func() error {
+			report, err := w.checkGame(gCtx, game)
+			if err != nil {
+				w.log.Error("Failed to evaluate game", "game", game.Proxy, "err", err)
+				report = GameReport{Game: game, Health: HealthUnknown}
+			}
+			reports[i] = report
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	resolved := make(map[common.Address]GameReport)
+	counts := make(map[Health]int)
+	for _, report := range reports {
+		counts[report.Health]++
+		if report.Status != gameTypes.GameStatusInProgress && report.Health != HealthUnknown {
+			if _, ok := w.resolved[report.Game.Proxy]; !ok {
+				w.logReport(report)
+			}
+			resolved[report.Game.Proxy] = report
+		} else {
+			w.logReport(report)
+		}
+	}
+	// Only keep resolved games that are still being monitored so games outside the game window are released.
+	w.resolved = resolved
+	w.metrics.RecordGameHealth(counts[HealthCorrect], counts[HealthIncorrect], counts[HealthExpiring],
+		counts[HealthResolvedIncorrectly], counts[HealthUnknown])
+	w.log.Info("Evaluated games", "total", len(games), "correct", counts[HealthCorrect], "incorrect", counts[HealthIncorrect],
+		"expiring", counts[HealthExpiring], "resolvedIncorrectly", counts[HealthResolvedIncorrectly], "unknown", counts[HealthUnknown])
+	return reports
+}
+
+func (w *GameWatcher) checkGame(ctx context.Context, game gameTypes.GameMetadata) (GameReport, error) {
+	report := GameReport{Game: game}
+	contract, err := w.creator(game)
+	if err != nil {
+		return GameReport{}, fmt.Errorf("failed to create contract: %w", err)
+	}
+	report.L2BlockNumber, report.RootClaim, report.Status, err = contract.GetGameMetadata(ctx)
+	if err != nil {
+		return GameReport{}, fmt.Errorf("failed to load game metadata: %w", err)
+	}
+	output, err := w.rollupClient.OutputAtBlock(ctx, report.L2BlockNumber)
+	if err != nil {
+		return GameReport{}, fmt.Errorf("failed to load output root at block %v: %w", report.L2BlockNumber, err)
+	}
+	report.Expected = gameTypes.GameStatusChallengerWon
+	if common.Hash(output.OutputRoot) == report.RootClaim {
+		report.Expected = gameTypes.GameStatusDefenderWon
+	}
+
+	if report.Status != gameTypes.GameStatusInProgress {
+		report.Health = HealthCorrect
+		if report.Status != report.Expected {
+			report.Health = HealthResolvedIncorrectly
+		}
+		return report, nil
+	}
+
+	duration, err := contract.GetGameDuration(ctx)
+	if err != nil {
+		return GameReport{}, fmt.Errorf("failed to load game duration: %w", err)
+	}
+	claims, err := contract.GetAllClaims(ctx)
+	if err != nil {
+		return GameReport{}, fmt.Errorf("failed to load claims: %w", err)
+	}
+	if len(claims) == 0 {
+		return GameReport{}, fmt.Errorf("game has no claims")
+	}
+	resolved := faultTypes.ResolveClaims(claims)
+	report.Deadline = counterDeadline(resolved, duration)
+	report.Provisional = gameTypes.GameStatusDefenderWon
+	if resolved[0].Countered {
+		report.Provisional = gameTypes.GameStatusChallengerWon
+	}
+	switch {
+	case report.Provisional == report.Expected:
+		report.Health = HealthCorrect
+	case report.Deadline.Sub(w.clock.Now()) <= w.expiryWarning:
+		report.Health = HealthExpiring
+	default:
+		report.Health = HealthIncorrect
+	}
+	return report, nil
+}
+
+// counterDeadline returns the earliest time the clock of an uncountered claim expires. Each team has half the game
+// duration on its clock, and a claim's clock records the time its team had used when it was posted.
+func counterDeadline(claims []faultTypes.Claim, duration uint64) time.Time {
+	clockDuration := time.Duration(duration/2) * time.Second
+	var deadline time.Time
+	for _, claim := range claims {
+		if claim.Countered {
+			continue
+		}
+		expiry := claim.Clock.Timestamp.Add(clockDuration - claim.Clock.Duration)
+		if deadline.IsZero() || expiry.Before(deadline) {
+			deadline = expiry
+		}
+	}
+	return deadline
+}
+
+func (w *GameWatcher) logReport(report GameReport) {
+	logger := w.log.New("game", report.Game.Proxy, "l2BlockNumber", report.L2BlockNumber, "rootClaim", report.RootClaim,
+		"expected", report.Expected)
+	switch report.Health {
+	case HealthCorrect:
+		logger.Debug("Game resolving correctly", "status", report.Status, "provisional", report.Provisional)
+	case HealthIncorrect:
+		logger.Warn("Game resolving incorrectly", "provisional", report.Provisional, "deadline", report.Deadline)
+	case HealthExpiring:
+		logger.Error("Game resolving incorrectly close to its deadline", "provisional", report.Provisional,
+			"deadline", report.Deadline, "remaining", report.Deadline.Sub(w.clock.Now()))
+	case HealthResolvedIncorrectly:
+		logger.Error("Game resolved incorrectly", "status", report.Status)
+	}
+}
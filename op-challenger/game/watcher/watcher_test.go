@@ -0,0 +1,223 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	gameDuration  = uint64(7 * 24 * 60 * 60)
+	expiryWarning = 24 * time.Hour
+)
+
+var (
+	correctRoot = common.Hash{0xaa}
+	invalidRoot = common.Hash{0xbb}
+	gameStart   = time.Unix(1_000_000, 0)
+)
+
+func TestCheckGames(t *testing.T) {
+	rootOnly := func(root common.Hash) []faultTypes.Claim {
+		return []faultTypes.Claim{rootClaim(root)}
+	}
+	countered := func(root common.Hash) []faultTypes.Claim {
+		r := rootClaim(root)
+		r.Countered = true
+		return []faultTypes.Claim{r, {
+			ClaimData:     faultTypes.ClaimData{Value: common.Hash{0xcc}, Position: r.Position.Attack()},
+			Clock:         faultTypes.NewClock(0, gameStart),
+			ContractIndex: 1,
+		}}
+	}
+	tests := []struct {
+		name     string
+		game     *stubGameContract
+		elapsed  time.Duration
+		expected Health
+	}{
+		{"UnchallengedValidRoot", &stubGameContract{rootClaim: correctRoot, claims: rootOnly(correctRoot)}, 0, HealthCorrect},
+		{"ChallengedInvalidRoot", &stubGameContract{rootClaim: invalidRoot, claims: countered(invalidRoot)}, 0, HealthCorrect},
+		{"UnchallengedInvalidRoot", &stubGameContract{rootClaim: invalidRoot, claims: rootOnly(invalidRoot)}, 0, HealthIncorrect},
+		{"ChallengedValidRoot", &stubGameContract{rootClaim: correctRoot, claims: countered(correctRoot)}, 0, HealthIncorrect},
+		{"InvalidRootBeforeExpiryWarning", &stubGameContract{rootClaim: invalidRoot, claims: rootOnly(invalidRoot)}, 60*time.Hour - time.Second, HealthIncorrect},
+		{"ExpiringInvalidRoot", &stubGameContract{rootClaim: invalidRoot, claims: rootOnly(invalidRoot)}, 60 * time.Hour, HealthExpiring},
+		{"ExpiredInvalidRoot", &stubGameContract{rootClaim: invalidRoot, claims: rootOnly(invalidRoot)}, 4 * 24 * time.Hour, HealthExpiring},
+		{"ExpiringValidRoot", &stubGameContract{rootClaim: correctRoot, claims: rootOnly(correctRoot)}, 4 * 24 * time.Hour, HealthCorrect},
+		{"DefenderWonValidRoot", &stubGameContract{rootClaim: correctRoot, status: gameTypes.GameStatusDefenderWon}, 0, HealthCorrect},
+		{"ChallengerWonInvalidRoot", &stubGameContract{rootClaim: invalidRoot, status: gameTypes.GameStatusChallengerWon}, 0, HealthCorrect},
+		{"DefenderWonInvalidRoot", &stubGameContract{rootClaim: invalidRoot, status: gameTypes.GameStatusDefenderWon}, 0, HealthResolvedIncorrectly},
+		{"ChallengerWonValidRoot", &stubGameContract{rootClaim: correctRoot, status: gameTypes.GameStatusChallengerWon}, 0, HealthResolvedIncorrectly},
+		{"MetadataError", &stubGameContract{metadataErr: errors.New("boom")}, 0, HealthUnknown},
+		{"NoClaims", &stubGameContract{rootClaim: correctRoot}, 0, HealthUnknown},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			game := gameTypes.GameMetadata{Proxy: common.Address{0x01}, Timestamp: uint64(gameStart.Unix())}
+			watcher, cl, m, _ := setupWatcherTest(t, map[common.Address]*stubGameContract{game.Proxy: test.game})
+			cl.AdvanceTime(test.elapsed)
+			reports := watcher.CheckGames(context.Background(), []gameTypes.GameMetadata{game})
+			require.Len(t, reports, 1)
+			require.Equal(t, test.expected, reports[0].Health)
+			require.Equal(t, 1, m.counts[test.expected])
+		})
+	}
+}
+
+func TestCheckGamesReportsDeadline(t *testing.T) {
+	game := gameTypes.GameMetadata{Proxy: common.Address{0x01}, Timestamp: uint64(gameStart.Unix())}
+	contract := &stubGameContract{rootClaim: invalidRoot, claims: []faultTypes.Claim{rootClaim(invalidRoot)}}
+	watcher, _, _, _ := setupWatcherTest(t, map[common.Address]*stubGameContract{game.Proxy: contract})
+	reports := watcher.CheckGames(context.Background(), []gameTypes.GameMetadata{game})
+	require.Len(t, reports, 1)
+	report := reports[0]
+	// The unchallenged root can be resolved once the challenger's half of the game duration has elapsed
+	require.Equal(t, gameStart.Add(time.Duration(gameDuration/2)*time.Second), report.Deadline)
+	require.Equal(t, uint64(1234), report.L2BlockNumber)
+	require.Equal(t, invalidRoot, report.RootClaim)
+	require.Equal(t, gameTypes.GameStatusChallengerWon, report.Expected)
+	require.Equal(t, gameTypes.GameStatusDefenderWon, report.Provisional)
+}
+
+func TestCheckGamesDeadlineFromClaimClocks(t *testing.T) {
+	game := gameTypes.GameMetadata{Proxy: common.Address{0x01}, Timestamp: uint64(gameStart.Unix())}
+	root := rootClaim(correctRoot)
+	root.Countered = true
+	// The counter claim was posted 3 hours into the game, after its team had used 2 hours of their clock
+	counter := faultTypes.Claim{
+		ClaimData:     faultTypes.ClaimData{Value: common.Hash{0xcc}, Position: root.Position.Attack()},
+		Clock:         faultTypes.NewClock(2*time.Hour, gameStart.Add(3*time.Hour)),
+		ContractIndex: 1,
+	}
+	contract := &stubGameContract{rootClaim: correctRoot, claims: []faultTypes.Claim{root, counter}}
+	watcher, _, _, _ := setupWatcherTest(t, map[common.Address]*stubGameContract{game.Proxy: contract})
+	reports := watcher.CheckGames(context.Background(), []gameTypes.GameMetadata{game})
+	require.Len(t, reports, 1)
+	require.Equal(t, HealthIncorrect, reports[0].Health)
+	require.Equal(t, gameStart.Add(time.Duration(gameDuration/2)*time.Second+time.Hour), reports[0].Deadline)
+}
+
+func TestCheckGamesCachesResolvedGames(t *testing.T) {
+	resolvedGame := gameTypes.GameMetadata{Proxy: common.Address{0x01}}
+	activeGame := gameTypes.GameMetadata{Proxy: common.Address{0x02}, Timestamp: uint64(gameStart.Unix())}
+	contracts := map[common.Address]*stubGameContract{
+		resolvedGame.Proxy: {rootClaim: invalidRoot, status: gameTypes.GameStatusDefenderWon},
+		activeGame.Proxy:   {rootClaim: correctRoot, claims: []faultTypes.Claim{rootClaim(correctRoot)}},
+	}
+	watcher, _, m, rollup := setupWatcherTest(t, contracts)
+	games := []gameTypes.GameMetadata{resolvedGame, activeGame}
+
+	watcher.CheckGames(context.Background(), games)
+	require.Equal(t, 2, rollup.requests)
+	watcher.CheckGames(context.Background(), games)
+	require.Equal(t, 3, rollup.requests)
+	require.Equal(t, 1, m.counts[HealthResolvedIncorrectly])
+	require.Equal(t, 1, m.counts[HealthCorrect])
+
+	// Games that are no longer monitored are removed from the cache
+	watcher.CheckGames(context.Background(), []gameTypes.GameMetadata{activeGame})
+	require.Empty(t, watcher.resolved)
+}
+
+func TestWatcherSchedule(t *testing.T) {
+	game := gameTypes.GameMetadata{Proxy: common.Address{0x01}, Timestamp: uint64(gameStart.Unix())}
+	contract := &stubGameContract{rootClaim: invalidRoot, claims: []faultTypes.Claim{rootClaim(invalidRoot)}}
+	watcher, _, m, _ := setupWatcherTest(t, map[common.Address]*stubGameContract{game.Proxy: contract})
+	watcher.Start(context.Background())
+	defer watcher.Close()
+
+	require.NoError(t, watcher.Schedule([]gameTypes.GameMetadata{game}, 1))
+	require.Eventually(t, func() bool {
+		return m.Count(HealthIncorrect) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func setupWatcherTest(t *testing.T, contracts map[common.Address]*stubGameContract) (*GameWatcher, *clock.DeterministicClock, *stubMetrics, *stubRollupClient) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cl := clock.NewDeterministicClock(gameStart)
+	m := &stubMetrics{}
+	rollup := &stubRollupClient{outputRoot: correctRoot}
+	creator := func(game gameTypes.GameMetadata) (GameContract, error) {
+		contract, ok := contracts[game.Proxy]
+		if !ok {
+			return nil, errors.New("unknown game")
+		}
+		return contract, nil
+	}
+	return NewGameWatcher(logger, m, cl, creator, rollup, 2, expiryWarning), cl, m, rollup
+}
+
+func rootClaim(value common.Hash) faultTypes.Claim {
+	return faultTypes.Claim{
+		ClaimData:           faultTypes.ClaimData{Value: value, Position: faultTypes.NewPositionFromGIndex(common.Big1)},
+		Clock:               faultTypes.NewClock(0, gameStart),
+		ParentContractIndex: -1,
+	}
+}
+
+type stubGameContract struct {
+	rootClaim   common.Hash
+	status      gameTypes.GameStatus
+	claims      []faultTypes.Claim
+	metadataErr error
+}
+
+func (s *stubGameContract) GetGameMetadata(_ context.Context) (uint64, common.Hash, gameTypes.GameStatus, error) {
+	return 1234, s.rootClaim, s.status, s.metadataErr
+}
+
+func (s *stubGameContract) GetGameDuration(_ context.Context) (uint64, error) {
+	return gameDuration, nil
+}
+
+func (s *stubGameContract) GetAllClaims(_ context.Context) ([]faultTypes.Claim, error) {
+	return s.claims, nil
+}
+
+type stubRollupClient struct {
+	m          sync.Mutex
+	outputRoot common.Hash
+	requests   int
+}
+
+func (s *stubRollupClient) OutputAtBlock(_ context.Context, _ uint64) (*eth.OutputResponse, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.requests++
+	return &eth.OutputResponse{OutputRoot: eth.Bytes32(s.outputRoot)}, nil
+}
+
+type stubMetrics struct {
+	m      sync.Mutex
+	counts map[Health]int
+}
+
+func (s *stubMetrics) RecordGameHealth(correct, incorrect, expiring, resolvedIncorrectly, unknown int) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.counts = map[Health]int{
+		HealthCorrect:             correct,
+		HealthIncorrect:           incorrect,
+		HealthExpiring:            expiring,
+		HealthResolvedIncorrectly: resolvedIncorrectly,
+		HealthUnknown:             unknown,
+	}
+}
+
+func (s *stubMetrics) Count(health Health) int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.counts[health]
+}
@@ -34,8 +34,13 @@ type Metricer interface {
 	RecordGameMove()
 	RecordCannonExecutionTime(t float64)
 
+	RecordPreimageChallenged()
+	RecordPreimageChallengeFailed()
+
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 
+	RecordGameHealth(correct, incorrect, expiring, resolvedIncorrectly, unknown int)
+
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
 
@@ -64,10 +69,15 @@ type Metrics struct {
 	moves prometheus.Counter
 	steps prometheus.Counter
 
+	preimageChallenged      prometheus.Counter
+	preimageChallengeFailed prometheus.Counter
+
 	cannonExecutionTime prometheus.Histogram
 
 	trackedGames  prometheus.GaugeVec
 	inflightGames prometheus.Gauge
+
+	monitoredGames prometheus.GaugeVec
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -114,6 +124,16 @@ func NewMetrics() *Metrics {
 			Name:      "steps",
 			Help:      "Number of game steps made by the challenge agent",
 		}),
+		preimageChallenged: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "preimage_challenged",
+			Help:      "Number of preimages challenged by the challenger",
+		}),
+		preimageChallengeFailed: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "preimage_challenge_failed",
+			Help:      "Number of preimage challenges that failed",
+		}),
 		cannonExecutionTime: factory.NewHistogram(prometheus.HistogramOpts{
 			Namespace: Namespace,
 			Name:      "cannon_execution_time",
@@ -139,6 +159,13 @@ func NewMetrics() *Metrics {
 			Name:      "inflight_games",
 			Help:      "Number of games being tracked by the challenger",
 		}),
+		monitoredGames: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "monitored_games",
+			Help:      "Number of games evaluated in monitor mode, by whether they are resolving correctly",
+		}, []string{
+			"health",
+		}),
 	}
 }
 
@@ -178,6 +205,14 @@ func (m *Metrics) RecordGameStep() {
 	m.steps.Add(1)
 }
 
+func (m *Metrics) RecordPreimageChallenged() {
+	m.preimageChallenged.Add(1)
+}
+
+func (m *Metrics) RecordPreimageChallengeFailed() {
+	m.preimageChallengeFailed.Add(1)
+}
+
 func (m *Metrics) RecordCannonExecutionTime(t float64) {
 	m.cannonExecutionTime.Observe(t)
 }
@@ -204,6 +239,14 @@ func (m *Metrics) RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 	m.trackedGames.WithLabelValues("challenger_won").Set(float64(challengerWon))
 }
 
+func (m *Metrics) RecordGameHealth(correct, incorrect, expiring, resolvedIncorrectly, unknown int) {
+	m.monitoredGames.WithLabelValues("correct").Set(float64(correct))
+	m.monitoredGames.WithLabelValues("incorrect").Set(float64(incorrect))
+	m.monitoredGames.WithLabelValues("expiring").Set(float64(expiring))
+	m.monitoredGames.WithLabelValues("resolved_incorrectly").Set(float64(resolvedIncorrectly))
+	m.monitoredGames.WithLabelValues("unknown").Set(float64(unknown))
+}
+
 func (m *Metrics) RecordActedL1Block(n uint64) {
 	m.highestActedL1Block.Set(float64(n))
 }
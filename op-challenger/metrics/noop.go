@@ -26,12 +26,17 @@ func (*NoopMetricsImpl) RecordUp()                 {}
 func (*NoopMetricsImpl) RecordGameMove() {}
 func (*NoopMetricsImpl) RecordGameStep() {}
 
+func (*NoopMetricsImpl) RecordPreimageChallenged()      {}
+func (*NoopMetricsImpl) RecordPreimageChallengeFailed() {}
+
 func (*NoopMetricsImpl) RecordActedL1Block(_ uint64) {}
 
 func (*NoopMetricsImpl) RecordCannonExecutionTime(t float64) {}
 
 func (*NoopMetricsImpl) RecordGamesStatus(inProgress, defenderWon, challengerWon int) {}
 
+func (*NoopMetricsImpl) RecordGameHealth(_, _, _, _, _ int) {}
+
 func (*NoopMetricsImpl) RecordGameUpdateScheduled() {}
 func (*NoopMetricsImpl) RecordGameUpdateCompleted() {}
 
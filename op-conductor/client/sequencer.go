@@ -10,9 +10,12 @@ import (
 )
 
 // SequencerControl defines the interface for controlling the sequencer.
+//
+//go:generate mockery --name SequencerControl --output mocks/
 type SequencerControl interface {
 	StartSequencer(ctx context.Context, hash common.Hash) error
 	StopSequencer(ctx context.Context) (common.Hash, error)
+	SequencerActive(ctx context.Context) (bool, error)
 	LatestUnsafeBlock(ctx context.Context) (eth.BlockInfo, error)
 }
 
@@ -45,3 +48,8 @@ func (s *sequencerController) StartSequencer(ctx context.Context, hash common.Ha
 func (s *sequencerController) StopSequencer(ctx context.Context) (common.Hash, error) {
 	return s.node.StopSequencer(ctx)
 }
+
+// SequencerActive implements SequencerControl.
+func (s *sequencerController) SequencerActive(ctx context.Context) (bool, error) {
+	return s.node.SequencerActive(ctx)
+}
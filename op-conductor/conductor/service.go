@@ -3,29 +3,78 @@ package conductor
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/client"
 	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-conductor/health"
+	conductorrpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	opclient "github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
+var (
+	// ErrResumeTimeout is returned when the control loop doesn't resume in time.
+	ErrResumeTimeout = errors.New("timeout to resume conductor")
+	// ErrPauseTimeout is returned when the control loop doesn't pause in time.
+	ErrPauseTimeout = errors.New("timeout to pause conductor")
+	// ErrUnsafeHeadMismatch is returned when the unsafe head of the sequencer doesn't match the one in the FSM.
+	ErrUnsafeHeadMismatch = errors.New("unsafe head mismatch")
+)
+
+// actionRetryBackoff is the delay before a failed control loop action is retried.
+const actionRetryBackoff = 2 * time.Second
+
 // New creates a new OpConductor instance.
 func New(ctx context.Context, cfg *Config, log log.Logger, version string) (*OpConductor, error) {
+	return NewOpConductor(ctx, cfg, log, version, nil, nil, nil)
+}
+
+// NewOpConductor creates a new OpConductor instance, using the given sequencer control,
+// consensus and health monitor if they are not nil.
+func NewOpConductor(
+	ctx context.Context,
+	cfg *Config,
+	log log.Logger,
+	version string,
+	ctrl client.SequencerControl,
+	cons consensus.Consensus,
+	hmon health.HealthMonitor,
+) (*OpConductor, error) {
 	if err := cfg.Check(); err != nil {
 		return nil, errors.Wrap(err, "invalid config")
 	}
 
 	oc := &OpConductor{
-		log:     log,
-		version: version,
-		cfg:     cfg,
+		log:          log,
+		version:      version,
+		cfg:          cfg,
+		pauseCh:      make(chan struct{}),
+		pauseDoneCh:  make(chan struct{}),
+		resumeCh:     make(chan struct{}),
+		resumeDoneCh: make(chan struct{}),
+		actionCh:     make(chan struct{}, 1),
+		ctrl:         ctrl,
+		cons:         cons,
+		hmon:         hmon,
 	}
+	oc.actionFn = oc.action
+	oc.shutdownCtx, oc.shutdownCancel = context.WithCancel(context.Background())
 
 	err := oc.init(ctx)
 	if err != nil {
@@ -34,6 +83,7 @@ func New(ctx context.Context, cfg *Config, log log.Logger, version string) (*OpC
 		if closeErr := oc.Stop(ctx); closeErr != nil {
 			return nil, multierror.Append(err, closeErr)
 		}
+		return nil, err
 	}
 
 	return oc, nil
@@ -41,12 +91,34 @@ func New(ctx context.Context, cfg *Config, log log.Logger, version string) (*OpC
 
 func (c *OpConductor) init(ctx context.Context) error {
 	c.log.Info("initializing OpConductor", "version", c.version)
-	if err := c.initSequencerControl(ctx); err != nil {
-		return errors.Wrap(err, "failed to initialize sequencer control")
+	if c.ctrl == nil {
+		if err := c.initSequencerControl(ctx); err != nil {
+			return errors.Wrap(err, "failed to initialize sequencer control")
+		}
+	}
+	if c.cons == nil {
+		if err := c.initConsensus(ctx); err != nil {
+			return errors.Wrap(err, "failed to initialize consensus")
+		}
+	}
+	if c.hmon == nil {
+		if err := c.initHealthMonitor(ctx); err != nil {
+			return errors.Wrap(err, "failed to initialize health monitor")
+		}
 	}
-	if err := c.initConsensus(ctx); err != nil {
-		return errors.Wrap(err, "failed to initialize consensus")
+	c.initRPCServer()
+
+	active, err := c.ctrl.SequencerActive(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get sequencer active status")
 	}
+	c.seqActive.Store(active)
+	c.leader.Store(c.cons.Leader())
+	// assume the sequencer is healthy until the health monitor reports otherwise.
+	c.healthy.Store(true)
+	c.paused.Store(c.cfg.Paused)
+	c.leaderUpdateCh = c.cons.LeaderCh()
+	c.healthUpdateCh = c.hmon.Subscribe()
 	return nil
 }
 
@@ -81,6 +153,59 @@ func (c *OpConductor) initConsensus(ctx context.Context) error {
 	return nil
 }
 
+func (c *OpConductor) initRPCServer() {
+	server := oprpc.NewServer(
+		c.cfg.RPC.ListenAddr,
+		c.cfg.RPC.ListenPort,
+		c.version,
+		oprpc.WithLogger(c.log),
+	)
+	c.log.Info("registering RPC API", "namespace", conductorrpc.RPCNamespace)
+	server.AddAPI(rpc.API{
+		Namespace: conductorrpc.RPCNamespace,
+		Service:   conductorrpc.NewAPIBackend(c.log, c),
+	})
+	c.rpcServer = server
+}
+
+func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
+	nc, err := opclient.NewRPC(ctx, c.log, c.cfg.NodeRPC)
+	if err != nil {
+		return errors.Wrap(err, "failed to create node rpc client")
+	}
+	node := sources.NewRollupClient(nc)
+
+	pc, err := rpc.DialContext(ctx, c.cfg.NodeRPC)
+	if err != nil {
+		return errors.Wrap(err, "failed to create p2p rpc client")
+	}
+	p2pClient := p2p.NewClient(pc)
+
+	ec, err := rpc.DialContext(ctx, c.cfg.ExecutionRPC)
+	if err != nil {
+		return errors.Wrap(err, "failed to create execution rpc client")
+	}
+	el := ethclient.NewClient(ec)
+
+	c.hmon = health.NewSequencerHealthMonitor(
+		c.log,
+		clock.SystemClock,
+		health.Config{
+			Interval:           c.cfg.HealthCheck.Interval,
+			SafeInterval:       c.cfg.HealthCheck.SafeInterval,
+			MinPeerCount:       c.cfg.HealthCheck.MinPeerCount,
+			L1MaxStaleness:     c.cfg.HealthCheck.L1MaxStaleness,
+			UnhealthyThreshold: c.cfg.HealthCheck.UnhealthyThreshold,
+			HealthyThreshold:   c.cfg.HealthCheck.HealthyThreshold,
+		},
+		&c.cfg.RollupCfg,
+		node,
+		el,
+		p2pClient,
+	)
+	return nil
+}
+
 // OpConductor represents a full conductor instance and its resources, it does:
 //  1. performs health checks on sequencer
 //  2. participate in consensus protocol for leader election
@@ -98,21 +223,354 @@ type OpConductor struct {
 
 	ctrl client.SequencerControl
 	cons consensus.Consensus
+	hmon health.HealthMonitor
+
+	rpcServer *oprpc.Server
+
+	leader    atomic.Bool
+	healthy   atomic.Bool
+	seqActive atomic.Bool
+
+	healthUpdateCh <-chan health.HealthResult
+	leaderUpdateCh <-chan bool
+
+	// actionFn brings the sequencer to the desired state based on the current leadership,
+	// health and sequencing status. It can be overridden in tests.
+	actionFn func()
+
+	wg           sync.WaitGroup
+	pauseCh      chan struct{}
+	pauseDoneCh  chan struct{}
+	resumeCh     chan struct{}
+	resumeDoneCh chan struct{}
+	actionCh     chan struct{}
+	paused       atomic.Bool
+	stopped      atomic.Bool
+
+	// retryCh fires when a failed action should be retried. It is only accessed by the control loop.
+	retryCh <-chan time.Time
+
+	shutdownCtx    context.Context
+	shutdownCancel context.CancelFunc
 }
 
 var _ cliapp.Lifecycle = (*OpConductor)(nil)
 
 // Start implements cliapp.Lifecycle.
-func (*OpConductor) Start(ctx context.Context) error {
-	panic("unimplemented")
+func (oc *OpConductor) Start(ctx context.Context) error {
+	oc.log.Info("starting OpConductor")
+
+	if err := oc.hmon.Start(); err != nil {
+		return errors.Wrap(err, "failed to start health monitor")
+	}
+
+	oc.log.Info("starting JSON-RPC server")
+	if err := oc.rpcServer.Start(); err != nil {
+		return errors.Wrap(err, "failed to start JSON-RPC server")
+	}
+
+	oc.wg.Add(1)
+	go oc.loop()
+
+	// make sure the sequencer is brought to the desired state on startup.
+	oc.queueAction()
+
+	oc.log.Info("OpConductor started")
+	return nil
 }
 
 // Stop implements cliapp.Lifecycle.
-func (*OpConductor) Stop(ctx context.Context) error {
-	panic("unimplemented")
+func (oc *OpConductor) Stop(ctx context.Context) error {
+	oc.log.Info("stopping OpConductor")
+
+	var result *multierror.Error
+
+	// close control loop
+	oc.shutdownCancel()
+	oc.wg.Wait()
+
+	if oc.rpcServer != nil {
+		if err := oc.rpcServer.Stop(); err != nil {
+			result = multierror.Append(result, errors.Wrap(err, "failed to stop rpc server"))
+		}
+	}
+
+	if oc.hmon != nil {
+		if err := oc.hmon.Stop(); err != nil {
+			result = multierror.Append(result, errors.Wrap(err, "failed to stop health monitor"))
+		}
+	}
+
+	if oc.cons != nil {
+		if err := oc.cons.Shutdown(); err != nil {
+			result = multierror.Append(result, errors.Wrap(err, "failed to shutdown consensus"))
+		}
+	}
+
+	if err := result.ErrorOrNil(); err != nil {
+		oc.log.Error("failed to stop OpConductor", "err", err)
+		return err
+	}
+
+	oc.stopped.Store(true)
+	oc.log.Info("OpConductor stopped")
+	return nil
 }
 
 // Stopped implements cliapp.Lifecycle.
-func (*OpConductor) Stopped() bool {
-	panic("unimplemented")
+func (oc *OpConductor) Stopped() bool {
+	return oc.stopped.Load()
+}
+
+// Pause pauses the control loop of OpConductor, but still allows it to participate in leader election.
+func (oc *OpConductor) Pause(ctx context.Context) error {
+	select {
+	case oc.pauseCh <- struct{}{}:
+		<-oc.pauseDoneCh
+		return nil
+	case <-ctx.Done():
+		return ErrPauseTimeout
+	}
+}
+
+// Resume resumes the control loop of OpConductor.
+func (oc *OpConductor) Resume(ctx context.Context) error {
+	select {
+	case oc.resumeCh <- struct{}{}:
+		<-oc.resumeDoneCh
+		return nil
+	case <-ctx.Done():
+		return ErrResumeTimeout
+	}
+}
+
+// Paused returns true if OpConductor is paused.
+func (oc *OpConductor) Paused() bool {
+	return oc.paused.Load()
+}
+
+// SequencerHealthy returns true if the sequencer is healthy.
+func (oc *OpConductor) SequencerHealthy(_ context.Context) bool {
+	return oc.healthy.Load()
+}
+
+// Leader returns true if OpConductor is the leader.
+func (oc *OpConductor) Leader(_ context.Context) bool {
+	return oc.cons.Leader()
+}
+
+// LeaderWithID returns the current leader's server ID and address.
+func (oc *OpConductor) LeaderWithID(_ context.Context) *consensus.ServerInfo {
+	return oc.cons.LeaderWithID()
+}
+
+// AddVoter adds a voting member into the cluster.
+func (oc *OpConductor) AddVoter(_ context.Context, id string, addr string) error {
+	return oc.cons.AddVoter(id, addr)
+}
+
+// AddNonVoter adds a non-voting member into the cluster.
+func (oc *OpConductor) AddNonVoter(_ context.Context, id string, addr string) error {
+	return oc.cons.AddNonVoter(id, addr)
+}
+
+// DemoteVoter demotes a voting member into a non-voting member.
+func (oc *OpConductor) DemoteVoter(_ context.Context, id string) error {
+	return oc.cons.DemoteVoter(id)
+}
+
+// RemoveServer removes a member (both voter or non-voter) from the cluster.
+func (oc *OpConductor) RemoveServer(_ context.Context, id string) error {
+	return oc.cons.RemoveServer(id)
+}
+
+// TransferLeader transfers leadership to another server.
+func (oc *OpConductor) TransferLeader(_ context.Context) error {
+	return oc.cons.TransferLeader()
+}
+
+// TransferLeaderTo transfers leadership to a specific server.
+func (oc *OpConductor) TransferLeaderTo(_ context.Context, id string, addr string) error {
+	return oc.cons.TransferLeaderTo(id, addr)
+}
+
+// ClusterMembership returns the current cluster membership configuration.
+func (oc *OpConductor) ClusterMembership(_ context.Context) ([]*consensus.ServerInfo, error) {
+	return oc.cons.ClusterMembership()
+}
+
+// CommitUnsafePayload commits an unsafe payload (latest head) to the cluster FSM.
+func (oc *OpConductor) CommitUnsafePayload(_ context.Context, payload *eth.ExecutionPayload) error {
+	return oc.cons.CommitUnsafePayload(*payload)
+}
+
+func (oc *OpConductor) loop() {
+	defer oc.wg.Done()
+
+	for {
+		select {
+		// We process status updates (health, leadership) regardless of the paused state,
+		// so that the sequencer can be brought to the desired state when resumed.
+		case result := <-oc.healthUpdateCh:
+			oc.handleHealthUpdate(result)
+		case leader := <-oc.leaderUpdateCh:
+			oc.handleLeaderUpdate(leader)
+		case <-oc.pauseCh:
+			oc.paused.Store(true)
+			oc.pauseDoneCh <- struct{}{}
+		case <-oc.resumeCh:
+			oc.paused.Store(false)
+			oc.resumeDoneCh <- struct{}{}
+			// queue an action to make sure the sequencer is in the desired state after resuming.
+			oc.queueAction()
+		case <-oc.actionCh:
+			oc.actionFn()
+		case <-oc.retryCh:
+			oc.retryCh = nil
+			oc.queueAction()
+		case <-oc.shutdownCtx.Done():
+			return
+		}
+	}
+}
+
+// queueAction queues an action to be executed by the control loop, unless one is already queued.
+func (oc *OpConductor) queueAction() {
+	select {
+	case oc.actionCh <- struct{}{}:
+	default:
+	}
+}
+
+// handleHealthUpdate handles health update from health monitor.
+func (oc *OpConductor) handleHealthUpdate(result health.HealthResult) {
+	healthy := result.Healthy
+	if !healthy {
+		oc.log.Error("sequencer is unhealthy", "server", oc.cons.ServerID(), "failures", result.FailureSummary())
+	}
+
+	if healthy != oc.healthy.Load() {
+		oc.healthy.Store(healthy)
+		oc.queueAction()
+	}
+}
+
+// handleLeaderUpdate handles leadership update from consensus.
+func (oc *OpConductor) handleLeaderUpdate(leader bool) {
+	oc.log.Info("leadership status changed", "server", oc.cons.ServerID(), "leader", leader)
+
+	if leader != oc.leader.Load() {
+		oc.leader.Store(leader)
+		oc.queueAction()
+	}
+}
+
+// action tries to bring the sequencer to the desired state, a retry will be scheduled if any action failed.
+func (oc *OpConductor) action() {
+	if oc.Paused() {
+		return
+	}
+
+	var err error
+	// exhaust all cases below for completeness, 3 states, 8 cases.
+	switch status := struct{ leader, healthy, active bool }{oc.leader.Load(), oc.healthy.Load(), oc.seqActive.Load()}; {
+	case !status.leader && !status.healthy && !status.active:
+		// if the follower is not healthy and not sequencing, just log an error
+		oc.log.Error("server (follower) is not healthy", "server", oc.cons.ServerID())
+	case !status.leader && !status.healthy && status.active:
+		// the sequencer is not the leader, not healthy, but it is sequencing, stop it
+		err = oc.stopSequencer()
+	case !status.leader && status.healthy && !status.active:
+		// normal follower, nothing to do
+	case !status.leader && status.healthy && status.active:
+		// stop the current follower from sequencing
+		err = oc.stopSequencer()
+	case status.leader && !status.healthy && !status.active:
+		// transfer leadership to another node
+		err = oc.transferLeader()
+	case status.leader && !status.healthy && status.active:
+		var result *multierror.Error
+		// Try to stop the sequencer first. It may fail since the sequencer is not healthy,
+		// but we still try to transfer leadership: if the transfer succeeds, the next action
+		// retries stopping the sequencer as a follower.
+		if e := oc.stopSequencer(); e != nil {
+			result = multierror.Append(result, e)
+		}
+		if e := oc.transferLeader(); e != nil {
+			result = multierror.Append(result, e)
+		}
+		err = result.ErrorOrNil()
+	case status.leader && status.healthy && !status.active:
+		// start sequencing as the new leader
+		err = oc.startSequencer()
+	case status.leader && status.healthy && status.active:
+		// normal leader, nothing to do
+	}
+
+	if err != nil {
+		oc.log.Error("failed to execute action, scheduling another one to retry", "err", err)
+		// the retry is scheduled as a control loop event so status updates are still handled while waiting.
+		if oc.retryCh == nil {
+			oc.retryCh = time.After(actionRetryBackoff)
+		}
+		return
+	}
+	oc.retryCh = nil
+}
+
+// transferLeader tries to transfer leadership to another server.
+func (oc *OpConductor) transferLeader() error {
+	// TransferLeader picks the most up-to-date follower to transfer leadership to.
+	if err := oc.cons.TransferLeader(); err != nil {
+		return errors.Wrap(err, "failed to transfer leadership")
+	}
+	oc.leader.Store(false)
+	return nil
+}
+
+func (oc *OpConductor) stopSequencer() error {
+	oc.log.Info("stopping sequencer", "server", oc.cons.ServerID(), "leader", oc.leader.Load(), "healthy", oc.healthy.Load(), "active", oc.seqActive.Load())
+
+	if _, err := oc.ctrl.StopSequencer(context.Background()); err != nil {
+		// the sequencer may have been stopped by an operator in the meantime.
+		if !strings.Contains(err.Error(), "sequencer not running") {
+			return errors.Wrap(err, "failed to stop sequencer")
+		}
+		oc.log.Warn("sequencer was already stopped", "server", oc.cons.ServerID())
+	}
+	oc.seqActive.Store(false)
+	return nil
+}
+
+// startSequencer starts the sequencer from the latest unsafe payload in the FSM. The sequencer
+// must have caught up to that payload, otherwise it would fork the unsafe chain.
+func (oc *OpConductor) startSequencer() error {
+	oc.log.Info("starting sequencer", "server", oc.cons.ServerID(), "leader", oc.leader.Load(), "healthy", oc.healthy.Load(), "active", oc.seqActive.Load())
+
+	ctx := context.Background()
+	unsafeInNode, err := oc.ctrl.LatestUnsafeBlock(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get latest unsafe block from EL")
+	}
+
+	hash := oc.cons.LatestUnsafePayload().BlockHash
+	if hash == (common.Hash{}) {
+		// nothing was committed to the FSM yet, e.g. in a freshly bootstrapped cluster.
+		oc.log.Warn("no unsafe payload in FSM, starting sequencer from the EL unsafe head", "hash", unsafeInNode.Hash())
+		hash = unsafeInNode.Hash()
+	} else if hash != unsafeInNode.Hash() {
+		oc.log.Warn("unsafe head of the EL doesn't match the FSM, waiting for the EL to catch up",
+			"fsm", hash, "el", unsafeInNode.Hash())
+		return ErrUnsafeHeadMismatch
+	}
+
+	if err := oc.ctrl.StartSequencer(ctx, hash); err != nil {
+		// the sequencer may have been started by an operator in the meantime.
+		if !strings.Contains(err.Error(), "sequencer already running") {
+			return errors.Wrap(err, "failed to start sequencer")
+		}
+		oc.log.Warn("sequencer was already running", "server", oc.cons.ServerID())
+	}
+	oc.seqActive.Store(true)
+	return nil
 }
@@ -0,0 +1,326 @@
+package conductor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	clientmocks "github.com/ethereum-optimism/optimism/op-conductor/client/mocks"
+	consensusmocks "github.com/ethereum-optimism/optimism/op-conductor/consensus/mocks"
+	"github.com/ethereum-optimism/optimism/op-conductor/health"
+	healthmocks "github.com/ethereum-optimism/optimism/op-conductor/health/mocks"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func mockConfig(t *testing.T) Config {
+	now := uint64(time.Now().Unix())
+	return Config{
+		ConsensusAddr:  "127.0.0.1",
+		ConsensusPort:  50050,
+		RaftServerID:   "SequencerA",
+		RaftStorageDir: t.TempDir(),
+		RaftBootstrap:  false,
+		NodeRPC:        "http://node:8545",
+		ExecutionRPC:   "http://geth:8545",
+		HealthCheck: HealthCheckConfig{
+			Interval:           1,
+			SafeInterval:       5,
+			MinPeerCount:       1,
+			L1MaxStaleness:     60,
+			UnhealthyThreshold: 3,
+			HealthyThreshold:   2,
+		},
+		RollupCfg: rollup.Config{
+			Genesis: rollup.Genesis{
+				L1: eth.BlockID{
+					Hash:   [32]byte{1, 2},
+					Number: 100,
+				},
+				L2: eth.BlockID{
+					Hash:   [32]byte{2, 3},
+					Number: 0,
+				},
+				L2Time: now,
+				SystemConfig: eth.SystemConfig{
+					BatcherAddr: [20]byte{1},
+					Overhead:    [32]byte{1},
+					Scalar:      [32]byte{1},
+					GasLimit:    30000000,
+				},
+			},
+			BlockTime:               2,
+			MaxSequencerDrift:       600,
+			SeqWindowSize:           3600,
+			ChannelTimeout:          300,
+			L1ChainID:               big.NewInt(1),
+			L2ChainID:               big.NewInt(2),
+			RegolithTime:            &now,
+			CanyonTime:              &now,
+			BatchInboxAddress:       [20]byte{1, 2},
+			DepositContractAddress:  [20]byte{2, 3},
+			L1SystemConfigAddress:   [20]byte{3, 4},
+			ProtocolVersionsAddress: [20]byte{4, 5},
+		},
+	}
+}
+
+type OpConductorTestSuite struct {
+	suite.Suite
+
+	conductor *OpConductor
+
+	healthUpdateCh chan health.HealthResult
+	leaderUpdateCh chan bool
+
+	ctx     context.Context
+	log     log.Logger
+	cfg     Config
+	version string
+	ctrl    *clientmocks.SequencerControl
+	cons    *consensusmocks.Consensus
+	hmon    *healthmocks.HealthMonitor
+
+	actionCh chan struct{}
+}
+
+func (s *OpConductorTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.log = testlog.Logger(s.T(), log.LvlDebug)
+	s.cfg = mockConfig(s.T())
+	s.version = "v0.0.1"
+}
+
+func (s *OpConductorTestSuite) SetupTest() {
+	s.ctrl = &clientmocks.SequencerControl{}
+	s.cons = &consensusmocks.Consensus{}
+	s.hmon = &healthmocks.HealthMonitor{}
+
+	s.healthUpdateCh = make(chan health.HealthResult)
+	s.leaderUpdateCh = make(chan bool)
+	s.actionCh = make(chan struct{}, 1)
+
+	s.ctrl.On("SequencerActive", mock.Anything).Return(true, nil)
+	s.cons.On("Leader").Return(true)
+	s.cons.On("ServerID").Return("SequencerA")
+	s.cons.On("LeaderCh").Return((<-chan bool)(s.leaderUpdateCh))
+	s.hmon.On("Subscribe").Return((<-chan health.HealthResult)(s.healthUpdateCh))
+
+	conductor, err := NewOpConductor(s.ctx, &s.cfg, s.log, s.version, s.ctrl, s.cons, s.hmon)
+	s.NoError(err)
+	s.conductor = conductor
+
+	// replace the action with a signal, so the control loop can be tested without executing actions.
+	s.conductor.actionFn = func() {
+		s.actionCh <- struct{}{}
+	}
+}
+
+func (s *OpConductorTestSuite) startConductor() {
+	s.hmon.On("Start").Return(nil)
+	s.NoError(s.conductor.Start(s.ctx))
+	// drain the action queued on startup.
+	s.waitForAction()
+}
+
+func (s *OpConductorTestSuite) stopConductor() {
+	s.hmon.On("Stop").Return(nil)
+	s.cons.On("Shutdown").Return(nil)
+	s.NoError(s.conductor.Stop(s.ctx))
+	s.True(s.conductor.Stopped())
+}
+
+func (s *OpConductorTestSuite) waitForAction() {
+	select {
+	case <-s.actionCh:
+	case <-time.After(5 * time.Second):
+		s.FailNow("timed out waiting for action")
+	}
+}
+
+func (s *OpConductorTestSuite) setStatus(leader, healthy, active bool) {
+	s.conductor.leader.Store(leader)
+	s.conductor.healthy.Store(healthy)
+	s.conductor.seqActive.Store(active)
+}
+
+// TestInit tests that the conductor picks up the initial state of its components.
+func (s *OpConductorTestSuite) TestInit() {
+	s.True(s.conductor.leader.Load())
+	s.True(s.conductor.healthy.Load())
+	s.True(s.conductor.seqActive.Load())
+	s.False(s.conductor.Paused())
+	s.False(s.conductor.Stopped())
+}
+
+// TestControlLoop tests that status updates and resuming queue actions in the control loop.
+func (s *OpConductorTestSuite) TestControlLoop() {
+	s.startConductor()
+
+	// leadership changes queue an action
+	s.leaderUpdateCh <- false
+	s.waitForAction()
+	s.False(s.conductor.leader.Load())
+
+	// health changes queue an action
+	unhealthy := health.HealthResult{
+		Healthy:  false,
+		Failures: []health.CheckFailure{{Reason: health.ReasonLowPeerCount, Err: errors.New("no peers")}},
+	}
+	s.healthUpdateCh <- unhealthy
+	s.waitForAction()
+	s.False(s.conductor.healthy.Load())
+
+	// unchanged status doesn't queue an action
+	s.healthUpdateCh <- unhealthy
+	s.Len(s.actionCh, 0)
+
+	s.NoError(s.conductor.Pause(s.ctx))
+	s.True(s.conductor.Paused())
+
+	// resuming queues an action to bring the sequencer to the desired state
+	s.NoError(s.conductor.Resume(s.ctx))
+	s.False(s.conductor.Paused())
+	s.waitForAction()
+
+	s.stopConductor()
+}
+
+// TestControlLoopRetry tests that a scheduled retry queues an action without blocking other events in the control loop.
+func (s *OpConductorTestSuite) TestControlLoopRetry() {
+	retryCh := make(chan time.Time, 1)
+	s.conductor.retryCh = retryCh
+	s.startConductor()
+
+	// the control loop keeps handling events while the retry is pending
+	s.NoError(s.conductor.Pause(s.ctx))
+	s.NoError(s.conductor.Resume(s.ctx))
+	s.waitForAction()
+
+	retryCh <- time.Now()
+	s.waitForAction()
+
+	s.stopConductor()
+}
+
+// TestActionFailureSchedulesRetry tests that a failed action schedules a retry instead of waiting for it, and that
+// a successful action clears it.
+func (s *OpConductorTestSuite) TestActionFailureSchedulesRetry() {
+	s.setStatus(false, true, true)
+	s.ctrl.On("StopSequencer", mock.Anything).Return(common.Hash{}, errors.New("failed")).Once()
+
+	s.conductor.action()
+	s.NotNil(s.conductor.retryCh)
+	s.Len(s.actionCh, 0)
+	s.True(s.conductor.seqActive.Load())
+
+	s.ctrl.On("StopSequencer", mock.Anything).Return(common.Hash{}, nil).Once()
+	s.conductor.action()
+	s.Nil(s.conductor.retryCh)
+	s.False(s.conductor.seqActive.Load())
+}
+
+// TestActionPaused tests that no action is executed while paused.
+func (s *OpConductorTestSuite) TestActionPaused() {
+	s.conductor.paused.Store(true)
+	s.setStatus(false, true, true)
+
+	s.conductor.action()
+	s.ctrl.AssertNotCalled(s.T(), "StopSequencer", mock.Anything)
+	s.True(s.conductor.seqActive.Load())
+}
+
+// TestActionFollowerStopsSequencer tests that an active follower stops sequencing.
+func (s *OpConductorTestSuite) TestActionFollowerStopsSequencer() {
+	s.setStatus(false, true, true)
+	s.ctrl.On("StopSequencer", mock.Anything).Return(common.Hash{}, nil).Once()
+
+	s.conductor.action()
+	s.ctrl.AssertNumberOfCalls(s.T(), "StopSequencer", 1)
+	s.False(s.conductor.seqActive.Load())
+}
+
+// TestActionUnhealthyFollowerStopsSequencer tests that an unhealthy, active follower stops sequencing.
+func (s *OpConductorTestSuite) TestActionUnhealthyFollowerStopsSequencer() {
+	s.setStatus(false, false, true)
+	s.ctrl.On("StopSequencer", mock.Anything).Return(common.Hash{}, nil).Once()
+
+	s.conductor.action()
+	s.ctrl.AssertNumberOfCalls(s.T(), "StopSequencer", 1)
+	s.False(s.conductor.seqActive.Load())
+}
+
+// TestActionLeaderStartsSequencer tests that a healthy leader starts sequencing from the FSM unsafe head.
+func (s *OpConductorTestSuite) TestActionLeaderStartsSequencer() {
+	s.setStatus(true, true, false)
+	hash := common.Hash{1, 2, 3}
+	s.cons.On("LatestUnsafePayload").Return(eth.ExecutionPayload{BlockHash: hash})
+	s.ctrl.On("LatestUnsafeBlock", mock.Anything).Return(&testutils.MockBlockInfo{InfoHash: hash}, nil)
+	s.ctrl.On("StartSequencer", mock.Anything, hash).Return(nil).Once()
+
+	s.conductor.action()
+	s.ctrl.AssertNumberOfCalls(s.T(), "StartSequencer", 1)
+	s.True(s.conductor.seqActive.Load())
+}
+
+// TestStartSequencerUnsafeHeadMismatch tests that the sequencer isn't started if the EL hasn't
+// caught up with the FSM unsafe head yet.
+func (s *OpConductorTestSuite) TestStartSequencerUnsafeHeadMismatch() {
+	s.setStatus(true, true, false)
+	s.cons.On("LatestUnsafePayload").Return(eth.ExecutionPayload{BlockHash: common.Hash{1, 2, 3}})
+	s.ctrl.On("LatestUnsafeBlock", mock.Anything).Return(&testutils.MockBlockInfo{InfoHash: common.Hash{4, 5, 6}}, nil)
+
+	s.ErrorIs(s.conductor.startSequencer(), ErrUnsafeHeadMismatch)
+	s.ctrl.AssertNotCalled(s.T(), "StartSequencer", mock.Anything, mock.Anything)
+	s.False(s.conductor.seqActive.Load())
+}
+
+// TestActionUnhealthyLeaderTransfersLeadership tests that an unhealthy leader transfers leadership.
+func (s *OpConductorTestSuite) TestActionUnhealthyLeaderTransfersLeadership() {
+	s.setStatus(true, false, false)
+	s.cons.On("TransferLeader").Return(nil).Once()
+
+	s.conductor.action()
+	s.cons.AssertNumberOfCalls(s.T(), "TransferLeader", 1)
+	s.False(s.conductor.leader.Load())
+}
+
+// TestActionUnhealthyActiveLeader tests that an unhealthy, active leader stops sequencing and
+// transfers leadership.
+func (s *OpConductorTestSuite) TestActionUnhealthyActiveLeader() {
+	s.setStatus(true, false, true)
+	s.ctrl.On("StopSequencer", mock.Anything).Return(common.Hash{}, nil).Once()
+	s.cons.On("TransferLeader").Return(nil).Once()
+
+	s.conductor.action()
+	s.ctrl.AssertNumberOfCalls(s.T(), "StopSequencer", 1)
+	s.cons.AssertNumberOfCalls(s.T(), "TransferLeader", 1)
+	s.False(s.conductor.leader.Load())
+	s.False(s.conductor.seqActive.Load())
+}
+
+// TestActionNoop tests that nothing is done for a healthy leader that is sequencing, or a
+// healthy follower that isn't.
+func (s *OpConductorTestSuite) TestActionNoop() {
+	s.setStatus(true, true, true)
+	s.conductor.action()
+	s.setStatus(false, true, false)
+	s.conductor.action()
+
+	s.ctrl.AssertNotCalled(s.T(), "StartSequencer", mock.Anything, mock.Anything)
+	s.ctrl.AssertNotCalled(s.T(), "StopSequencer", mock.Anything)
+	s.cons.AssertNotCalled(s.T(), "TransferLeader")
+}
+
+func TestControlLoop(t *testing.T) {
+	suite.Run(t, new(OpConductorTestSuite))
+}
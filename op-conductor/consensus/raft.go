@@ -144,6 +144,16 @@ func (rc *RaftConsensus) Leader() bool {
 	return id == rc.serverID
 }
 
+// LeaderWithID implements Consensus, it returns the leader's server ID and address.
+func (rc *RaftConsensus) LeaderWithID() *ServerInfo {
+	addr, id := rc.r.LeaderWithID()
+	return &ServerInfo{
+		ID:       string(id),
+		Addr:     string(addr),
+		Suffrage: Voter, // leader will always be Voter
+	}
+}
+
 // LeaderCh implements Consensus, it returns a channel that will be notified when leadership status changes (true = leader, false = follower).
 func (rc *RaftConsensus) LeaderCh() <-chan bool {
 	return rc.r.LeaderCh()
@@ -186,6 +196,28 @@ func (rc *RaftConsensus) TransferLeaderTo(id string, addr string) error {
 	return nil
 }
 
+// ClusterMembership implements Consensus, it returns the current cluster membership configuration.
+func (rc *RaftConsensus) ClusterMembership() ([]*ServerInfo, error) {
+	future := rc.r.GetConfiguration()
+	if err := future.Error(); err != nil {
+		return nil, errors.Wrap(err, "failed to get raft configuration")
+	}
+
+	var ret []*ServerInfo
+	for _, srv := range future.Configuration().Servers {
+		suffrage := Voter
+		if srv.Suffrage == raft.Nonvoter {
+			suffrage = Nonvoter
+		}
+		ret = append(ret, &ServerInfo{
+			ID:       string(srv.ID),
+			Addr:     string(srv.Address),
+			Suffrage: suffrage,
+		})
+	}
+	return ret, nil
+}
+
 // Shutdown implements Consensus, it shuts down the consensus protocol client.
 func (rc *RaftConsensus) Shutdown() error {
 	if err := rc.r.Shutdown().Error(); err != nil {
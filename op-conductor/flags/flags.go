@@ -48,6 +48,45 @@ var (
 		Usage:   "HTTP provider URL for execution layer",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "EXECUTION_RPC"),
 	}
+	HealthCheckInterval = &cli.Uint64Flag{
+		Name:    "healthcheck.interval",
+		Usage:   "Interval between health checks in seconds",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_INTERVAL"),
+	}
+	HealthCheckSafeInterval = &cli.Uint64Flag{
+		Name:    "healthcheck.safe-interval",
+		Usage:   "Interval between safe head progression measured in seconds",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_SAFE_INTERVAL"),
+	}
+	HealthCheckMinPeerCount = &cli.Uint64Flag{
+		Name:    "healthcheck.min-peer-count",
+		Usage:   "Minimum number of peers required to be considered healthy",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MIN_PEER_COUNT"),
+	}
+	HealthCheckL1MaxStaleness = &cli.Uint64Flag{
+		Name:    "healthcheck.l1-max-staleness",
+		Usage:   "Maximum age of the L1 head seen by the node in seconds, 0 disables the check",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_L1_MAX_STALENESS"),
+		Value:   60,
+	}
+	HealthCheckUnhealthyThreshold = &cli.Uint64Flag{
+		Name:    "healthcheck.unhealthy-threshold",
+		Usage:   "Number of consecutive failed health checks before the sequencer is considered unhealthy",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_UNHEALTHY_THRESHOLD"),
+		Value:   3,
+	}
+	HealthCheckHealthyThreshold = &cli.Uint64Flag{
+		Name:    "healthcheck.healthy-threshold",
+		Usage:   "Number of consecutive passed health checks before an unhealthy sequencer is considered healthy again",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_HEALTHY_THRESHOLD"),
+		Value:   2,
+	}
+	Paused = &cli.BoolFlag{
+		Name:    "paused",
+		Usage:   "Whether the conductor is paused",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "PAUSED"),
+		Value:   false,
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -57,9 +96,17 @@ var requiredFlags = []cli.Flag{
 	RaftStorageDir,
 	NodeRPC,
 	ExecutionRPC,
+	HealthCheckInterval,
+	HealthCheckSafeInterval,
+	HealthCheckMinPeerCount,
 }
 
-var optionalFlags = []cli.Flag{}
+var optionalFlags = []cli.Flag{
+	HealthCheckL1MaxStaleness,
+	HealthCheckUnhealthyThreshold,
+	HealthCheckHealthyThreshold,
+	Paused,
+}
 
 func init() {
 	optionalFlags = append(optionalFlags, oprpc.CLIFlags(EnvVarPrefix)...)
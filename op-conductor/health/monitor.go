@@ -2,57 +2,150 @@ package health
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 )
 
 // HealthMonitor defines the interface for monitoring the health of the sequencer.
+//
+//go:generate mockery --name HealthMonitor --output mocks/
 type HealthMonitor interface {
 	// Subscribe returns a channel that will be notified for every health check.
-	Subscribe() <-chan bool
+	Subscribe() <-chan HealthResult
 	// Start starts the health check.
 	Start() error
 	// Stop stops the health check.
 	Stop() error
 }
 
+// ExecutionClient is the subset of the execution engine RPC used by the health monitor.
+type ExecutionClient interface {
+	// SyncProgress returns nil if the execution engine is not syncing.
+	SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error)
+}
+
+// FailureReason identifies the health check that failed.
+type FailureReason string
+
+const (
+	ReasonSyncStatusUnavailable FailureReason = "sync_status_unavailable"
+	ReasonUnsafeHeadStalled     FailureReason = "unsafe_head_stalled"
+	ReasonSafeHeadStalled       FailureReason = "safe_head_stalled"
+	ReasonL1HeadStale           FailureReason = "l1_head_stale"
+	ReasonExecutionUnreachable  FailureReason = "execution_unreachable"
+	ReasonExecutionSyncing      FailureReason = "execution_syncing"
+	ReasonPeerStatsUnavailable  FailureReason = "peer_stats_unavailable"
+	ReasonLowPeerCount          FailureReason = "low_peer_count"
+	ReasonGossipNotPropagating  FailureReason = "gossip_not_propagating"
+)
+
+// CheckFailure describes a single failed health check.
+type CheckFailure struct {
+	Reason FailureReason
+	Err    error
+}
+
+func (f CheckFailure) String() string {
+	return fmt.Sprintf("%s: %v", f.Reason, f.Err)
+}
+
+// HealthResult is the outcome of a single round of health checks.
+type HealthResult struct {
+	// Healthy is the health status after applying the consecutive failure and recovery thresholds,
+	// so a single failed round does not flip it.
+	Healthy bool
+	// Failures lists the checks that failed in this round, it is empty if all checks passed.
+	Failures []CheckFailure
+	// Time is the time the checks were performed at.
+	Time time.Time
+}
+
+// Passed returns true if all checks passed in this round, regardless of the thresholds.
+func (r HealthResult) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// HasFailure returns true if the given check failed in this round.
+func (r HealthResult) HasFailure(reason FailureReason) bool {
+	for _, f := range r.Failures {
+		if f.Reason == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// FailureSummary returns a human-readable summary of the failed checks.
+func (r HealthResult) FailureSummary() string {
+	parts := make([]string, 0, len(r.Failures))
+	for _, f := range r.Failures {
+		parts = append(parts, f.String())
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Config defines the health monitor configuration.
+type Config struct {
+	// Interval is the interval between health checks measured in seconds.
+	Interval uint64
+	// SafeInterval is the interval between safe head progress measured in seconds.
+	SafeInterval uint64
+	// MinPeerCount is the minimum number of peers required for the sequencer to be healthy.
+	MinPeerCount uint64
+	// L1MaxStaleness is the maximum age of the L1 head seen by the node measured in seconds.
+	// Zero disables the check.
+	L1MaxStaleness uint64
+	// UnhealthyThreshold is the number of consecutive failed rounds before the sequencer is reported unhealthy.
+	UnhealthyThreshold uint64
+	// HealthyThreshold is the number of consecutive passed rounds before an unhealthy sequencer is reported healthy again.
+	HealthyThreshold uint64
+}
+
 // NewSequencerHealthMonitor creates a new sequencer health monitor.
-// interval is the interval between health checks measured in seconds.
-// safeInterval is the interval between safe head progress measured in seconds.
-// minPeerCount is the minimum number of peers required for the sequencer to be healthy.
-func NewSequencerHealthMonitor(log log.Logger, interval, safeInterval, minPeerCount uint64, rollupCfg *rollup.Config, node dial.RollupClientInterface, p2p p2p.API) HealthMonitor {
+func NewSequencerHealthMonitor(log log.Logger, clock clock.Clock, cfg Config, rollupCfg *rollup.Config, node dial.RollupClientInterface, el ExecutionClient, p2p p2p.API) *SequencerHealthMonitor {
 	return &SequencerHealthMonitor{
 		log:            log,
+		clock:          clock,
 		done:           make(chan struct{}),
-		interval:       interval,
-		healthUpdateCh: make(chan bool),
+		cfg:            cfg,
+		healthUpdateCh: make(chan HealthResult),
 		rollupCfg:      rollupCfg,
-		safeInterval:   safeInterval,
-		minPeerCount:   minPeerCount,
 		node:           node,
+		el:             el,
 		p2p:            p2p,
+		healthy:        true,
 	}
 }
 
 // SequencerHealthMonitor monitors sequencer health.
 type SequencerHealthMonitor struct {
-	log  log.Logger
-	done chan struct{}
-	wg   sync.WaitGroup
+	log   log.Logger
+	clock clock.Clock
+	done  chan struct{}
+	wg    sync.WaitGroup
 
+	cfg            Config
 	rollupCfg      *rollup.Config
-	safeInterval   uint64
-	minPeerCount   uint64
-	interval       uint64
-	healthUpdateCh chan bool
+	healthUpdateCh chan HealthResult
+
+	// healthy is the reported health status, it only changes once a threshold of consecutive
+	// failed (or passed) rounds is reached.
+	healthy              bool
+	consecutiveFailures  uint64
+	consecutiveSuccesses uint64
 
 	node dial.RollupClientInterface
+	el   ExecutionClient
 	p2p  p2p.API
 }
 
@@ -79,60 +172,129 @@ func (hm *SequencerHealthMonitor) Stop() error {
 }
 
 // Subscribe implements HealthMonitor.
-func (hm *SequencerHealthMonitor) Subscribe() <-chan bool {
+func (hm *SequencerHealthMonitor) Subscribe() <-chan HealthResult {
 	return hm.healthUpdateCh
 }
 
 func (hm *SequencerHealthMonitor) loop() {
 	defer hm.wg.Done()
 
-	duration := time.Duration(hm.interval) * time.Second
-	ticker := time.NewTicker(duration)
+	duration := time.Duration(hm.cfg.Interval) * time.Second
+	ticker := hm.clock.NewTicker(duration)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-hm.done:
 			return
-		case <-ticker.C:
-			hm.healthUpdateCh <- hm.healthCheck()
+		case <-ticker.Ch():
+			// bound each round by the check interval, so a slow RPC fails the round instead of stalling the monitor.
+			ctx, cancel := context.WithTimeout(context.Background(), duration)
+			result := hm.healthCheck(ctx)
+			cancel()
+
+			select {
+			case hm.healthUpdateCh <- result:
+			case <-hm.done:
+				return
+			}
 		}
 	}
 }
 
-// healthCheck checks the health of the sequencer by 3 criteria:
+// healthCheck runs all checks and applies the hysteresis thresholds to the outcome.
+func (hm *SequencerHealthMonitor) healthCheck(ctx context.Context) HealthResult {
+	now := hm.clock.Now()
+	failures := hm.runChecks(ctx, uint64(now.Unix()))
+	for _, f := range failures {
+		hm.log.Warn("sequencer health check failed", "reason", f.Reason, "err", f.Err)
+	}
+
+	if len(failures) == 0 {
+		hm.consecutiveFailures = 0
+		hm.consecutiveSuccesses++
+		if !hm.healthy && hm.consecutiveSuccesses >= hm.cfg.HealthyThreshold {
+			hm.log.Info("sequencer recovered", "consecutiveSuccesses", hm.consecutiveSuccesses)
+			hm.healthy = true
+		}
+	} else {
+		hm.consecutiveSuccesses = 0
+		hm.consecutiveFailures++
+		if hm.healthy && hm.consecutiveFailures >= hm.cfg.UnhealthyThreshold {
+			hm.log.Error("sequencer is unhealthy", "consecutiveFailures", hm.consecutiveFailures)
+			hm.healthy = false
+		}
+	}
+
+	return HealthResult{
+		Healthy:  hm.healthy,
+		Failures: failures,
+		Time:     now,
+	}
+}
+
+// runChecks checks the health of the sequencer by the following criteria:
 // 1. unsafe head is progressing per block time
 // 2. safe head is progressing every configured batch submission interval
-// 3. peer count is above the configured minimum
-func (hm *SequencerHealthMonitor) healthCheck() bool {
-	ctx := context.Background()
-	status, err := hm.node.SyncStatus(ctx)
-	if err != nil {
-		hm.log.Error("health monitor failed to get sync status", "err", err)
-		return false
+// 3. L1 head seen by the node is fresh
+// 4. execution engine is reachable and not syncing
+// 5. peer count is above the configured minimum
+// 6. enough peers are subscribed to the blocks topic of the active fork to receive the sequencer's gossip
+func (hm *SequencerHealthMonitor) runChecks(ctx context.Context, now uint64) []CheckFailure {
+	var failures []CheckFailure
+	fail := func(reason FailureReason, format string, args ...any) {
+		failures = append(failures, CheckFailure{Reason: reason, Err: fmt.Errorf(format, args...)})
 	}
 
-	now := uint64(time.Now().Unix())
-	// allow at most one block drift for unsafe head
-	if now-status.UnsafeL2.Time > hm.interval+hm.rollupCfg.BlockTime {
-		hm.log.Error("unsafe head is not progressing", "lastSeenUnsafeBlock", status.UnsafeL2)
-		return false
+	status, err := hm.node.SyncStatus(ctx)
+	if err != nil {
+		fail(ReasonSyncStatusUnavailable, "failed to get sync status: %w", err)
+	} else {
+		// allow at most one block drift for unsafe head
+		if age := timeSince(now, status.UnsafeL2.Time); age > hm.cfg.Interval+hm.rollupCfg.BlockTime {
+			fail(ReasonUnsafeHeadStalled, "unsafe head %s is %d seconds old", status.UnsafeL2, age)
+		}
+		if age := timeSince(now, status.SafeL2.Time); age > hm.cfg.SafeInterval {
+			fail(ReasonSafeHeadStalled, "safe head %s is %d seconds old", status.SafeL2, age)
+		}
+		if hm.cfg.L1MaxStaleness != 0 {
+			if age := timeSince(now, status.HeadL1.Time); age > hm.cfg.L1MaxStaleness {
+				fail(ReasonL1HeadStale, "L1 head %s is %d seconds old", status.HeadL1, age)
+			}
+		}
 	}
 
-	if now-status.SafeL2.Time > hm.safeInterval {
-		hm.log.Error("safe head is not progressing", "safe_head_time", status.SafeL2.Time, "now", now)
-		return false
+	progress, err := hm.el.SyncProgress(ctx)
+	if err != nil {
+		fail(ReasonExecutionUnreachable, "failed to get execution engine sync progress: %w", err)
+	} else if progress != nil {
+		fail(ReasonExecutionSyncing, "execution engine is syncing, current block %d, highest block %d", progress.CurrentBlock, progress.HighestBlock)
 	}
 
 	stats, err := hm.p2p.PeerStats(ctx)
 	if err != nil {
-		hm.log.Error("health monitor failed to get peer stats", "err", err)
-		return false
-	}
-	if uint64(stats.Connected) < hm.minPeerCount {
-		hm.log.Error("peer count is below minimum", "connected", stats.Connected, "minPeerCount", hm.minPeerCount)
-		return false
+		fail(ReasonPeerStatsUnavailable, "failed to get peer stats: %w", err)
+	} else {
+		if uint64(stats.Connected) < hm.cfg.MinPeerCount {
+			fail(ReasonLowPeerCount, "%d connected peers, minimum is %d", stats.Connected, hm.cfg.MinPeerCount)
+		}
+		// blocks are only gossiped to peers in the mesh of the blocks topic for the active fork
+		topic, gossipPeers := "v1", uint64(stats.BlocksTopic)
+		if hm.rollupCfg.IsCanyon(now) {
+			topic, gossipPeers = "v2", uint64(stats.BlocksTopicV2)
+		}
+		if gossipPeers < hm.cfg.MinPeerCount {
+			fail(ReasonGossipNotPropagating, "%d peers subscribed to the blocks %s topic, minimum is %d", gossipPeers, topic, hm.cfg.MinPeerCount)
+		}
 	}
 
-	return true
+	return failures
+}
+
+// timeSince returns the number of seconds between t and now, tolerating timestamps in the future.
+func timeSince(now, t uint64) uint64 {
+	if t > now {
+		return 0
+	}
+	return now - t
 }
@@ -2,15 +2,19 @@ package health
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	p2pMocks "github.com/ethereum-optimism/optimism/op-node/p2p/mocks"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
@@ -23,126 +27,245 @@ const (
 	blockTime          = 2
 )
 
+type mockExecutionClient struct {
+	progress *ethereum.SyncProgress
+	err      error
+}
+
+func (m *mockExecutionClient) SyncProgress(_ context.Context) (*ethereum.SyncProgress, error) {
+	return m.progress, m.err
+}
+
 type HealthMonitorTestSuite struct {
 	suite.Suite
 
-	log          log.Logger
-	rc           *testutils.MockRollupClient
-	pc           *p2pMocks.API
-	interval     uint64
-	safeInterval uint64
-	minPeerCount uint64
-	rollupCfg    *rollup.Config
-	monitor      HealthMonitor
+	log       log.Logger
+	clock     *clock.DeterministicClock
+	rc        *testutils.MockRollupClient
+	el        *mockExecutionClient
+	pc        *p2pMocks.API
+	cfg       Config
+	rollupCfg *rollup.Config
+	monitor   *SequencerHealthMonitor
 }
 
 func (s *HealthMonitorTestSuite) SetupSuite() {
 	s.log = testlog.Logger(s.T(), log.LvlInfo)
-	s.rc = &testutils.MockRollupClient{}
-	s.pc = &p2pMocks.API{}
-	s.interval = 1
-	s.safeInterval = 5
-	s.minPeerCount = minPeerCount
+	s.cfg = Config{
+		Interval:           1,
+		SafeInterval:       5,
+		MinPeerCount:       minPeerCount,
+		L1MaxStaleness:     60,
+		UnhealthyThreshold: 3,
+		HealthyThreshold:   2,
+	}
+	canyonTime := uint64(0)
 	s.rollupCfg = &rollup.Config{
-		BlockTime: blockTime,
+		BlockTime:  blockTime,
+		CanyonTime: &canyonTime,
 	}
 }
 
 func (s *HealthMonitorTestSuite) SetupTest() {
-	s.monitor = NewSequencerHealthMonitor(s.log, s.interval, s.safeInterval, s.minPeerCount, s.rollupCfg, s.rc, s.pc)
-	err := s.monitor.Start()
-	s.NoError(err)
+	s.clock = clock.NewDeterministicClock(time.Unix(1_700_000_000, 0))
+	s.rc = &testutils.MockRollupClient{}
+	s.el = &mockExecutionClient{}
+	s.pc = &p2pMocks.API{}
+	s.monitor = NewSequencerHealthMonitor(s.log, s.clock, s.cfg, s.rollupCfg, s.rc, s.el, s.pc)
 }
 
-func (s *HealthMonitorTestSuite) TearDownTest() {
-	err := s.monitor.Stop()
-	s.NoError(err)
+func (s *HealthMonitorTestSuite) now() uint64 {
+	return uint64(s.clock.Now().Unix())
 }
 
-func (s *HealthMonitorTestSuite) TestUnhealthyLowPeerCount() {
-	now := uint64(time.Now().Unix())
-	ss1 := &eth.SyncStatus{
-		UnsafeL2: eth.L2BlockRef{
-			Time: now - 1,
-		},
-		SafeL2: eth.L2BlockRef{
-			Time: now - 2,
-		},
+func (s *HealthMonitorTestSuite) healthySyncStatus() *eth.SyncStatus {
+	now := s.now()
+	return &eth.SyncStatus{
+		HeadL1:   eth.L1BlockRef{Time: now - 12},
+		UnsafeL2: eth.L2BlockRef{Time: now - 1},
+		SafeL2:   eth.L2BlockRef{Time: now - 2},
 	}
-	s.rc.ExpectSyncStatus(ss1, nil)
+}
 
-	ps1 := &p2p.PeerStats{
-		Connected: unhealthyPeerCount,
+func (s *HealthMonitorTestSuite) healthyPeerStats() *p2p.PeerStats {
+	return &p2p.PeerStats{
+		Connected:     healthyPeerCount,
+		BlocksTopicV2: healthyPeerCount,
 	}
-	s.pc.EXPECT().PeerStats(context.Background()).Return(ps1, nil).Times(1)
+}
 
-	healthUpdateCh := s.monitor.Subscribe()
-	healthy := <-healthUpdateCh
-	s.False(healthy)
+// check runs a single round of health checks against the given node state.
+func (s *HealthMonitorTestSuite) check(status *eth.SyncStatus, stats *p2p.PeerStats) HealthResult {
+	s.rc.ExpectSyncStatus(status, nil)
+	s.pc.EXPECT().PeerStats(mock.Anything).Return(stats, nil).Once()
+	return s.monitor.healthCheck(context.Background())
 }
 
-func (s *HealthMonitorTestSuite) TestUnhealthyUnsafeHeadNotProgressing() {
-	ps1 := &p2p.PeerStats{
-		Connected: healthyPeerCount,
-	}
-	s.pc.EXPECT().PeerStats(context.Background()).Return(ps1, nil).Times(3)
+func (s *HealthMonitorTestSuite) TestHealthy() {
+	result := s.check(s.healthySyncStatus(), s.healthyPeerStats())
+	s.True(result.Healthy)
+	s.True(result.Passed())
+	s.Equal(s.clock.Now(), result.Time)
+}
 
-	now := uint64(time.Now().Unix())
-	ss1 := &eth.SyncStatus{
-		UnsafeL2: eth.L2BlockRef{
-			Time: now - 1,
+func (s *HealthMonitorTestSuite) TestFailureReasons() {
+	tests := []struct {
+		name   string
+		setup  func(status *eth.SyncStatus, stats *p2p.PeerStats)
+		reason FailureReason
+	}{
+		{
+			name:   "LowPeerCount",
+			setup:  func(_ *eth.SyncStatus, stats *p2p.PeerStats) { stats.Connected = unhealthyPeerCount },
+			reason: ReasonLowPeerCount,
+		},
+		{
+			name:   "GossipNotPropagating",
+			setup:  func(_ *eth.SyncStatus, stats *p2p.PeerStats) { stats.BlocksTopicV2 = 0 },
+			reason: ReasonGossipNotPropagating,
+		},
+		{
+			name:   "UnsafeHeadStalled",
+			setup:  func(status *eth.SyncStatus, _ *p2p.PeerStats) { status.UnsafeL2.Time = s.now() - 4 },
+			reason: ReasonUnsafeHeadStalled,
 		},
-		SafeL2: eth.L2BlockRef{
-			Time: now - 2,
+		{
+			name:   "SafeHeadStalled",
+			setup:  func(status *eth.SyncStatus, _ *p2p.PeerStats) { status.SafeL2.Time = s.now() - 6 },
+			reason: ReasonSafeHeadStalled,
+		},
+		{
+			name:   "L1HeadStale",
+			setup:  func(status *eth.SyncStatus, _ *p2p.PeerStats) { status.HeadL1.Time = s.now() - 61 },
+			reason: ReasonL1HeadStale,
+		},
+		{
+			name:   "ExecutionUnreachable",
+			setup:  func(_ *eth.SyncStatus, _ *p2p.PeerStats) { s.el.err = errors.New("connection refused") },
+			reason: ReasonExecutionUnreachable,
+		},
+		{
+			name: "ExecutionSyncing",
+			setup: func(_ *eth.SyncStatus, _ *p2p.PeerStats) {
+				s.el.progress = &ethereum.SyncProgress{CurrentBlock: 10, HighestBlock: 20}
+			},
+			reason: ReasonExecutionSyncing,
 		},
 	}
-	s.rc.ExpectSyncStatus(ss1, nil)
-	s.rc.ExpectSyncStatus(ss1, nil)
-	s.rc.ExpectSyncStatus(ss1, nil)
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.SetupTest()
+			status, stats := s.healthySyncStatus(), s.healthyPeerStats()
+			test.setup(status, stats)
 
-	healthUpdateCh := s.monitor.Subscribe()
-	for i := 0; i < 3; i++ {
-		healthy := <-healthUpdateCh
-		if i < 2 {
-			s.True(healthy)
-		} else {
-			s.False(healthy)
-		}
+			result := s.check(status, stats)
+			s.False(result.Passed())
+			s.Len(result.Failures, 1)
+			s.True(result.HasFailure(test.reason))
+			// a single failure doesn't make the sequencer unhealthy
+			s.True(result.Healthy)
+		})
 	}
 }
 
-func (s *HealthMonitorTestSuite) TestUnhealthySafeHeadNotProgressing() {
-	ps1 := &p2p.PeerStats{
-		Connected: healthyPeerCount,
-	}
-	s.pc.EXPECT().PeerStats(context.Background()).Return(ps1, nil).Times(6)
+func (s *HealthMonitorTestSuite) TestGossipChecksActiveTopic() {
+	s.Run("InactiveV1TopicAfterCanyon", func() {
+		s.SetupTest()
+		stats := s.healthyPeerStats()
+		stats.BlocksTopic, stats.BlocksTopicV2 = healthyPeerCount, 0
 
-	now := uint64(time.Now().Unix())
-	syncStatusGenerator := func(unsafeTime uint64) *eth.SyncStatus {
-		return &eth.SyncStatus{
-			UnsafeL2: eth.L2BlockRef{
-				Time: unsafeTime,
-			},
-			SafeL2: eth.L2BlockRef{
-				Time: now,
-			},
-		}
+		result := s.check(s.healthySyncStatus(), stats)
+		s.True(result.HasFailure(ReasonGossipNotPropagating))
+	})
+
+	s.Run("InactiveV2TopicBeforeCanyon", func() {
+		s.SetupTest()
+		canyonTime := s.now() + 1000
+		s.monitor.rollupCfg = &rollup.Config{BlockTime: blockTime, CanyonTime: &canyonTime}
+		stats := s.healthyPeerStats()
+		stats.BlocksTopic, stats.BlocksTopicV2 = 0, healthyPeerCount
+
+		result := s.check(s.healthySyncStatus(), stats)
+		s.True(result.HasFailure(ReasonGossipNotPropagating))
+	})
+
+	s.Run("PeersOnBothTopicsCountedOnce", func() {
+		s.SetupTest()
+		s.monitor.cfg.MinPeerCount = healthyPeerCount
+		stats := s.healthyPeerStats()
+		stats.BlocksTopic, stats.BlocksTopicV2 = 1, 1
+
+		result := s.check(s.healthySyncStatus(), stats)
+		s.True(result.HasFailure(ReasonGossipNotPropagating))
+	})
+}
+
+func (s *HealthMonitorTestSuite) TestSyncStatusUnavailable() {
+	s.rc.ExpectSyncStatus((*eth.SyncStatus)(nil), errors.New("timeout"))
+	s.pc.EXPECT().PeerStats(mock.Anything).Return(nil, errors.New("timeout")).Once()
+
+	result := s.monitor.healthCheck(context.Background())
+	s.True(result.HasFailure(ReasonSyncStatusUnavailable))
+	s.True(result.HasFailure(ReasonPeerStatsUnavailable))
+	s.Contains(result.FailureSummary(), "timeout")
+}
+
+func (s *HealthMonitorTestSuite) TestL1StalenessCheckDisabled() {
+	s.monitor.cfg.L1MaxStaleness = 0
+	status := s.healthySyncStatus()
+	status.HeadL1.Time = 0
+
+	result := s.check(status, s.healthyPeerStats())
+	s.True(result.Passed())
+}
+
+func (s *HealthMonitorTestSuite) TestFutureTimestampsAreHealthy() {
+	status := s.healthySyncStatus()
+	status.UnsafeL2.Time = s.now() + 10
+
+	result := s.check(status, s.healthyPeerStats())
+	s.True(result.Passed())
+}
+
+func (s *HealthMonitorTestSuite) TestHysteresis() {
+	unhealthyStats := s.healthyPeerStats()
+	unhealthyStats.Connected = unhealthyPeerCount
+
+	// consecutive failures below the threshold keep the sequencer healthy
+	s.True(s.check(s.healthySyncStatus(), unhealthyStats).Healthy)
+	s.True(s.check(s.healthySyncStatus(), unhealthyStats).Healthy)
+	// a passed round resets the failure count
+	s.True(s.check(s.healthySyncStatus(), s.healthyPeerStats()).Healthy)
+	s.True(s.check(s.healthySyncStatus(), unhealthyStats).Healthy)
+	s.True(s.check(s.healthySyncStatus(), unhealthyStats).Healthy)
+	s.False(s.check(s.healthySyncStatus(), unhealthyStats).Healthy)
+
+	// recovery requires consecutive passed rounds
+	s.False(s.check(s.healthySyncStatus(), s.healthyPeerStats()).Healthy)
+	s.False(s.check(s.healthySyncStatus(), unhealthyStats).Healthy)
+	s.False(s.check(s.healthySyncStatus(), s.healthyPeerStats()).Healthy)
+	s.True(s.check(s.healthySyncStatus(), s.healthyPeerStats()).Healthy)
+}
+
+func (s *HealthMonitorTestSuite) TestUnsafeHeadNotProgressing() {
+	s.pc.EXPECT().PeerStats(mock.Anything).Return(s.healthyPeerStats(), nil)
+	status := s.healthySyncStatus()
+	for i := 0; i < 5; i++ {
+		s.rc.ExpectSyncStatus(status, nil)
 	}
-	s.rc.ExpectSyncStatus(syncStatusGenerator(now), nil)
-	s.rc.ExpectSyncStatus(syncStatusGenerator(now), nil)
-	s.rc.ExpectSyncStatus(syncStatusGenerator(now+2), nil)
-	s.rc.ExpectSyncStatus(syncStatusGenerator(now+2), nil)
-	s.rc.ExpectSyncStatus(syncStatusGenerator(now+4), nil)
-	s.rc.ExpectSyncStatus(syncStatusGenerator(now+4), nil)
 
+	s.NoError(s.monitor.Start())
+	defer func() { s.NoError(s.monitor.Stop()) }()
+
+	// wait for the ticker to be registered with the clock
+	s.True(s.clock.WaitForNewPendingTaskWithTimeout(time.Second))
 	healthUpdateCh := s.monitor.Subscribe()
-	for i := 0; i < 6; i++ {
-		healthy := <-healthUpdateCh
-		if i < 5 {
-			s.True(healthy)
-		} else {
-			s.False(healthy)
-		}
+	for i := 0; i < 5; i++ {
+		s.clock.AdvanceTime(time.Second)
+		result := <-healthUpdateCh
+		// the unsafe head becomes stale in the third round, and the sequencer is reported unhealthy after three failed rounds
+		s.Equal(i >= 2, result.HasFailure(ReasonUnsafeHeadStalled), "round %d", i)
+		s.Equal(i < 4, result.Healthy, "round %d", i)
 	}
 }
 
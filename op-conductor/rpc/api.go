@@ -0,0 +1,44 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// API defines the interface for the op-conductor API.
+type API interface {
+	// Pause pauses op-conductor.
+	Pause(ctx context.Context) error
+	// Resume resumes op-conductor.
+	Resume(ctx context.Context) error
+	// Paused returns true if op-conductor is paused.
+	Paused(ctx context.Context) (bool, error)
+	// SequencerHealthy returns true if the sequencer is healthy.
+	SequencerHealthy(ctx context.Context) (bool, error)
+
+	// Consensus related APIs
+	// Leader returns true if the server is the leader.
+	Leader(ctx context.Context) (bool, error)
+	// LeaderWithID returns the current leader's server info.
+	LeaderWithID(ctx context.Context) (*consensus.ServerInfo, error)
+	// AddVoter adds a voting member into the cluster, the voter is eligible to become leader.
+	AddVoter(ctx context.Context, id string, addr string) error
+	// AddNonVoter adds a non-voting member into the cluster, the non-voter is not eligible to become leader.
+	AddNonVoter(ctx context.Context, id string, addr string) error
+	// DemoteVoter demotes a voting member into a non-voting member.
+	DemoteVoter(ctx context.Context, id string) error
+	// RemoveServer removes a member (both voter or non-voter) from the cluster.
+	RemoveServer(ctx context.Context, id string) error
+	// TransferLeader transfers leadership to another server.
+	TransferLeader(ctx context.Context) error
+	// TransferLeaderTo transfers leadership to a specific server.
+	TransferLeaderTo(ctx context.Context, id string, addr string) error
+	// ClusterMembership returns the current cluster membership configuration.
+	ClusterMembership(ctx context.Context) ([]*consensus.ServerInfo, error)
+
+	// APIs called by op-node
+	// CommitUnsafePayload commits an unsafe payload (latest head) to the consensus layer.
+	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error
+}
@@ -0,0 +1,115 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type conductor interface {
+	Pause(ctx context.Context) error
+	Resume(ctx context.Context) error
+	Paused() bool
+	SequencerHealthy(ctx context.Context) bool
+
+	Leader(ctx context.Context) bool
+	LeaderWithID(ctx context.Context) *consensus.ServerInfo
+	AddVoter(ctx context.Context, id string, addr string) error
+	AddNonVoter(ctx context.Context, id string, addr string) error
+	DemoteVoter(ctx context.Context, id string) error
+	RemoveServer(ctx context.Context, id string) error
+	TransferLeader(ctx context.Context) error
+	TransferLeaderTo(ctx context.Context, id string, addr string) error
+	ClusterMembership(ctx context.Context) ([]*consensus.ServerInfo, error)
+
+	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error
+}
+
+// APIBackend is the backend implementation of the API.
+type APIBackend struct {
+	log log.Logger
+	con conductor
+}
+
+// NewAPIBackend creates a new APIBackend instance.
+func NewAPIBackend(log log.Logger, con conductor) *APIBackend {
+	return &APIBackend{
+		log: log,
+		con: con,
+	}
+}
+
+var _ API = (*APIBackend)(nil)
+
+// Pause implements API.
+func (api *APIBackend) Pause(ctx context.Context) error {
+	return api.con.Pause(ctx)
+}
+
+// Resume implements API.
+func (api *APIBackend) Resume(ctx context.Context) error {
+	return api.con.Resume(ctx)
+}
+
+// Paused implements API.
+func (api *APIBackend) Paused(ctx context.Context) (bool, error) {
+	return api.con.Paused(), nil
+}
+
+// SequencerHealthy implements API.
+func (api *APIBackend) SequencerHealthy(ctx context.Context) (bool, error) {
+	return api.con.SequencerHealthy(ctx), nil
+}
+
+// Leader implements API.
+func (api *APIBackend) Leader(ctx context.Context) (bool, error) {
+	return api.con.Leader(ctx), nil
+}
+
+// LeaderWithID implements API.
+func (api *APIBackend) LeaderWithID(ctx context.Context) (*consensus.ServerInfo, error) {
+	return api.con.LeaderWithID(ctx), nil
+}
+
+// AddVoter implements API.
+func (api *APIBackend) AddVoter(ctx context.Context, id string, addr string) error {
+	return api.con.AddVoter(ctx, id, addr)
+}
+
+// AddNonVoter implements API.
+func (api *APIBackend) AddNonVoter(ctx context.Context, id string, addr string) error {
+	return api.con.AddNonVoter(ctx, id, addr)
+}
+
+// DemoteVoter implements API.
+func (api *APIBackend) DemoteVoter(ctx context.Context, id string) error {
+	return api.con.DemoteVoter(ctx, id)
+}
+
+// RemoveServer implements API.
+func (api *APIBackend) RemoveServer(ctx context.Context, id string) error {
+	return api.con.RemoveServer(ctx, id)
+}
+
+// TransferLeader implements API.
+func (api *APIBackend) TransferLeader(ctx context.Context) error {
+	return api.con.TransferLeader(ctx)
+}
+
+// TransferLeaderTo implements API.
+func (api *APIBackend) TransferLeaderTo(ctx context.Context, id string, addr string) error {
+	return api.con.TransferLeaderTo(ctx, id, addr)
+}
+
+// ClusterMembership implements API.
+func (api *APIBackend) ClusterMembership(ctx context.Context) ([]*consensus.ServerInfo, error) {
+	return api.con.ClusterMembership(ctx)
+}
+
+// CommitUnsafePayload implements API.
+func (api *APIBackend) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	return api.con.CommitUnsafePayload(ctx, payload)
+}
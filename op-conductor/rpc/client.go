@@ -0,0 +1,108 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var RPCNamespace = "conductor"
+
+// APIClient provides a client for calling API methods.
+type APIClient struct {
+	c *rpc.Client
+}
+
+var _ API = (*APIClient)(nil)
+
+// NewAPIClient creates a new APIClient instance.
+func NewAPIClient(c *rpc.Client) *APIClient {
+	return &APIClient{c: c}
+}
+
+func prefixRPC(method string) string {
+	return RPCNamespace + "_" + method
+}
+
+// Pause implements API.
+func (c *APIClient) Pause(ctx context.Context) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("pause"))
+}
+
+// Resume implements API.
+func (c *APIClient) Resume(ctx context.Context) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("resume"))
+}
+
+// Paused implements API.
+func (c *APIClient) Paused(ctx context.Context) (bool, error) {
+	var paused bool
+	err := c.c.CallContext(ctx, &paused, prefixRPC("paused"))
+	return paused, err
+}
+
+// SequencerHealthy implements API.
+func (c *APIClient) SequencerHealthy(ctx context.Context) (bool, error) {
+	var healthy bool
+	err := c.c.CallContext(ctx, &healthy, prefixRPC("sequencerHealthy"))
+	return healthy, err
+}
+
+// Leader implements API.
+func (c *APIClient) Leader(ctx context.Context) (bool, error) {
+	var leader bool
+	err := c.c.CallContext(ctx, &leader, prefixRPC("leader"))
+	return leader, err
+}
+
+// LeaderWithID implements API.
+func (c *APIClient) LeaderWithID(ctx context.Context) (*consensus.ServerInfo, error) {
+	var info *consensus.ServerInfo
+	err := c.c.CallContext(ctx, &info, prefixRPC("leaderWithID"))
+	return info, err
+}
+
+// AddVoter implements API.
+func (c *APIClient) AddVoter(ctx context.Context, id string, addr string) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("addVoter"), id, addr)
+}
+
+// AddNonVoter implements API.
+func (c *APIClient) AddNonVoter(ctx context.Context, id string, addr string) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("addNonVoter"), id, addr)
+}
+
+// DemoteVoter implements API.
+func (c *APIClient) DemoteVoter(ctx context.Context, id string) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("demoteVoter"), id)
+}
+
+// RemoveServer implements API.
+func (c *APIClient) RemoveServer(ctx context.Context, id string) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("removeServer"), id)
+}
+
+// TransferLeader implements API.
+func (c *APIClient) TransferLeader(ctx context.Context) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("transferLeader"))
+}
+
+// TransferLeaderTo implements API.
+func (c *APIClient) TransferLeaderTo(ctx context.Context, id string, addr string) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("transferLeaderTo"), id, addr)
+}
+
+// ClusterMembership implements API.
+func (c *APIClient) ClusterMembership(ctx context.Context) ([]*consensus.ServerInfo, error) {
+	var infos []*consensus.ServerInfo
+	err := c.c.CallContext(ctx, &infos, prefixRPC("clusterMembership"))
+	return infos, err
+}
+
+// CommitUnsafePayload implements API.
+func (c *APIClient) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("commitUnsafePayload"), payload)
+}
@@ -0,0 +1,161 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeConductor struct {
+	paused  bool
+	healthy bool
+	leader  bool
+	servers []*consensus.ServerInfo
+
+	committed []*eth.ExecutionPayload
+}
+
+var _ conductor = (*fakeConductor)(nil)
+
+func (c *fakeConductor) Pause(_ context.Context) error {
+	c.paused = true
+	return nil
+}
+
+func (c *fakeConductor) Resume(_ context.Context) error {
+	c.paused = false
+	return nil
+}
+
+func (c *fakeConductor) Paused() bool {
+	return c.paused
+}
+
+func (c *fakeConductor) SequencerHealthy(_ context.Context) bool {
+	return c.healthy
+}
+
+func (c *fakeConductor) Leader(_ context.Context) bool {
+	return c.leader
+}
+
+func (c *fakeConductor) LeaderWithID(_ context.Context) *consensus.ServerInfo {
+	return c.servers[0]
+}
+
+func (c *fakeConductor) AddVoter(_ context.Context, id string, addr string) error {
+	c.servers = append(c.servers, &consensus.ServerInfo{ID: id, Addr: addr, Suffrage: consensus.Voter})
+	return nil
+}
+
+func (c *fakeConductor) AddNonVoter(_ context.Context, id string, addr string) error {
+	c.servers = append(c.servers, &consensus.ServerInfo{ID: id, Addr: addr, Suffrage: consensus.Nonvoter})
+	return nil
+}
+
+func (c *fakeConductor) DemoteVoter(_ context.Context, id string) error {
+	for _, s := range c.servers {
+		if s.ID == id {
+			s.Suffrage = consensus.Nonvoter
+			return nil
+		}
+	}
+	return errors.New("unknown server")
+}
+
+func (c *fakeConductor) RemoveServer(_ context.Context, id string) error {
+	for i, s := range c.servers {
+		if s.ID == id {
+			c.servers = append(c.servers[:i], c.servers[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("unknown server")
+}
+
+func (c *fakeConductor) TransferLeader(_ context.Context) error {
+	c.leader = false
+	return nil
+}
+
+func (c *fakeConductor) TransferLeaderTo(_ context.Context, id string, _ string) error {
+	for i, s := range c.servers {
+		if s.ID == id {
+			c.servers[0], c.servers[i] = c.servers[i], c.servers[0]
+			c.leader = false
+			return nil
+		}
+	}
+	return errors.New("unknown server")
+}
+
+func (c *fakeConductor) CommitUnsafePayload(_ context.Context, payload *eth.ExecutionPayload) error {
+	c.committed = append(c.committed, payload)
+	return nil
+}
+
+func (c *fakeConductor) ClusterMembership(_ context.Context) ([]*consensus.ServerInfo, error) {
+	return c.servers, nil
+}
+
+func TestAPIClientRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	con := &fakeConductor{
+		healthy: true,
+		leader:  true,
+		servers: []*consensus.ServerInfo{{ID: "SequencerA", Addr: "127.0.0.1:50050", Suffrage: consensus.Voter}},
+	}
+
+	server := rpc.NewServer()
+	t.Cleanup(server.Stop)
+	require.NoError(t, server.RegisterName(RPCNamespace, NewAPIBackend(testlog.Logger(t, log.LvlDebug), con)))
+	client := NewAPIClient(rpc.DialInProc(server))
+
+	healthy, err := client.SequencerHealthy(ctx)
+	require.NoError(t, err)
+	require.True(t, healthy)
+
+	require.NoError(t, client.Pause(ctx))
+	paused, err := client.Paused(ctx)
+	require.NoError(t, err)
+	require.True(t, paused)
+	require.NoError(t, client.Resume(ctx))
+	paused, err = client.Paused(ctx)
+	require.NoError(t, err)
+	require.False(t, paused)
+
+	require.NoError(t, client.AddVoter(ctx, "SequencerB", "127.0.0.1:50051"))
+	require.NoError(t, client.AddNonVoter(ctx, "SequencerC", "127.0.0.1:50052"))
+	require.NoError(t, client.DemoteVoter(ctx, "SequencerB"))
+	require.NoError(t, client.RemoveServer(ctx, "SequencerC"))
+	require.ErrorContains(t, client.RemoveServer(ctx, "SequencerD"), "unknown server")
+
+	membership, err := client.ClusterMembership(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*consensus.ServerInfo{
+		{ID: "SequencerA", Addr: "127.0.0.1:50050", Suffrage: consensus.Voter},
+		{ID: "SequencerB", Addr: "127.0.0.1:50051", Suffrage: consensus.Nonvoter},
+	}, membership)
+
+	require.NoError(t, client.TransferLeaderTo(ctx, "SequencerB", "127.0.0.1:50051"))
+	leader, err := client.Leader(ctx)
+	require.NoError(t, err)
+	require.False(t, leader)
+	info, err := client.LeaderWithID(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "SequencerB", info.ID)
+
+	payload := &eth.ExecutionPayload{BlockNumber: 1, BlockHash: common.Hash{1, 2, 3}, Transactions: []eth.Data{}}
+	require.NoError(t, client.CommitUnsafePayload(ctx, payload))
+	require.Len(t, con.committed, 1)
+	require.Equal(t, payload.BlockHash, con.committed[0].BlockHash)
+}
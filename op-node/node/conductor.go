@@ -0,0 +1,56 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	conductorRpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ConductorClient is a client for the op-conductor RPC service.
+// The op-node commits unsafe payloads through it before publishing them.
+type ConductorClient struct {
+	log     log.Logger
+	timeout time.Duration
+
+	rpcClient *rpc.Client
+	apiClient *conductorRpc.APIClient
+}
+
+var _ conductor.SequencerConductor = (*ConductorClient)(nil)
+
+// NewConductorClient returns a new conductor client for the op-conductor RPC service.
+// The connection to the conductor is only established once it is first used.
+func NewConductorClient(ctx context.Context, cfg *Config, log log.Logger) (*ConductorClient, error) {
+	rpcClient, err := rpc.DialContext(ctx, cfg.ConductorRpc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial conductor RPC %q: %w", cfg.ConductorRpc, err)
+	}
+	return &ConductorClient{
+		log:       log,
+		timeout:   cfg.ConductorRpcTimeout,
+		rpcClient: rpcClient,
+		apiClient: conductorRpc.NewAPIClient(rpcClient),
+	}, nil
+}
+
+// CommitUnsafePayload implements conductor.SequencerConductor.
+func (c *ConductorClient) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	if err := c.apiClient.CommitUnsafePayload(ctx, payload); err != nil {
+		return fmt.Errorf("failed to commit unsafe payload %s to conductor: %w", payload.ID(), err)
+	}
+	return nil
+}
+
+// Close closes the connection to the conductor.
+func (c *ConductorClient) Close() {
+	c.rpcClient.Close()
+}
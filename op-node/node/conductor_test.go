@@ -0,0 +1,58 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	conductorRpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeConductorAPI struct {
+	committed []*eth.ExecutionPayload
+	err       error
+}
+
+func (f *fakeConductorAPI) CommitUnsafePayload(_ context.Context, payload *eth.ExecutionPayload) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.committed = append(f.committed, payload)
+	return nil
+}
+
+func TestConductorClientCommitUnsafePayload(t *testing.T) {
+	api := &fakeConductorAPI{}
+	server := rpc.NewServer()
+	t.Cleanup(server.Stop)
+	require.NoError(t, server.RegisterName(conductorRpc.RPCNamespace, api))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	cfg := &Config{
+		ConductorEnabled:    true,
+		ConductorRpc:        httpServer.URL,
+		ConductorRpcTimeout: time.Second,
+	}
+	conductor, err := NewConductorClient(context.Background(), cfg, testlog.Logger(t, log.LvlInfo))
+	require.NoError(t, err)
+	t.Cleanup(conductor.Close)
+
+	payload := &eth.ExecutionPayload{BlockNumber: 10, BlockHash: common.Hash{0xaa}, Transactions: []eth.Data{}}
+	require.NoError(t, conductor.CommitUnsafePayload(context.Background(), payload))
+	require.Len(t, api.committed, 1)
+	require.Equal(t, payload.BlockHash, api.committed[0].BlockHash)
+
+	api.err = errors.New("not the leader")
+	err = conductor.CommitUnsafePayload(context.Background(), payload)
+	require.ErrorContains(t, err, "not the leader")
+}
@@ -20,6 +20,9 @@ type Config struct {
 	L1 L1EndpointSetup
 	L2 L2EndpointSetup
 
+	// Beacon is optional before Ecotone, and is used to fetch blobs from the L1 beacon node.
+	Beacon L1BeaconEndpointSetup
+
 	Driver driver.Config
 
 	Rollup rollup.Config
@@ -62,6 +65,13 @@ type Config struct {
 
 	// [OPTIONAL] The reth DB path to read receipts from
 	RethDBPath string
+
+	// ConductorEnabled enables committing unsafe payloads to op-conductor before publishing them.
+	ConductorEnabled bool
+	// ConductorRpc is the RPC endpoint of op-conductor.
+	ConductorRpc string
+	// ConductorRpcTimeout is the timeout of calls to op-conductor.
+	ConductorRpcTimeout time.Duration
 }
 
 type RPCConfig struct {
@@ -121,6 +131,11 @@ func (cfg *Config) Check() error {
 	if err := cfg.L1.Check(); err != nil {
 		return fmt.Errorf("l2 endpoint config error: %w", err)
 	}
+	if cfg.Beacon != nil {
+		if err := cfg.Beacon.Check(); err != nil {
+			return fmt.Errorf("beacon endpoint config error: %w", err)
+		}
+	}
 	if err := cfg.L2.Check(); err != nil {
 		return fmt.Errorf("l2 endpoint config error: %w", err)
 	}
@@ -141,5 +156,16 @@ func (cfg *Config) Check() error {
 	if !(cfg.RollupHalt == "" || cfg.RollupHalt == "major" || cfg.RollupHalt == "minor" || cfg.RollupHalt == "patch") {
 		return fmt.Errorf("invalid rollup halting option: %q", cfg.RollupHalt)
 	}
+	if cfg.ConductorEnabled {
+		if !cfg.Driver.SequencerEnabled {
+			return fmt.Errorf("conductor can only be enabled for a sequencer")
+		}
+		if cfg.ConductorRpc == "" {
+			return fmt.Errorf("conductor is enabled but conductor RPC is not set")
+		}
+		if cfg.ConductorRpcTimeout <= 0 {
+			return fmt.Errorf("invalid conductor RPC timeout: %v", cfg.ConductorRpcTimeout)
+		}
+	}
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 
 	"github.com/hashicorp/go-multierror"
@@ -21,6 +22,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/heartbeat"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-node/version"
@@ -44,14 +46,16 @@ type OpNode struct {
 	l1SafeSub      ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 	l1FinalizedSub ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 
-	l1Source  *sources.L1Client     // L1 Client to fetch data from
-	l2Driver  *driver.Driver        // L2 Engine to Sync
-	l2Source  *sources.EngineClient // L2 Execution Engine RPC bindings
-	server    *rpcServer            // RPC server hosting the rollup-node API
-	p2pNode   *p2p.NodeP2P          // P2P node functionality
-	p2pSigner p2p.Signer            // p2p gogssip application messages will be signed with this signer
-	tracer    Tracer                // tracer to get events for testing/debugging
-	runCfg    *RuntimeConfig        // runtime configurables
+	l1Source  *sources.L1Client       // L1 Client to fetch data from
+	beacon    *sources.L1BeaconClient // L1 Beacon Client to fetch blobs from, nil if not configured
+	l2Driver  *driver.Driver          // L2 Engine to Sync
+	l2Source  *sources.EngineClient   // L2 Execution Engine RPC bindings
+	conductor *ConductorClient        // op-conductor RPC bindings, nil if the conductor is not enabled
+	server    *rpcServer              // RPC server hosting the rollup-node API
+	p2pNode   *p2p.NodeP2P            // P2P node functionality
+	p2pSigner p2p.Signer              // p2p gogssip application messages will be signed with this signer
+	tracer    Tracer                  // tracer to get events for testing/debugging
+	runCfg    *RuntimeConfig          // runtime configurables
 
 	rollupHalt string // when to halt the rollup, disabled if empty
 
@@ -114,6 +118,9 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	if err := n.initL1(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init L1: %w", err)
 	}
+	if err := n.initL1BeaconAPI(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init L1 Beacon API client: %w", err)
+	}
 	if err := n.initL2(ctx, cfg, snapshotLog); err != nil {
 		return fmt.Errorf("failed to init L2: %w", err)
 	}
@@ -194,6 +201,21 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
+func (n *OpNode) initL1BeaconAPI(ctx context.Context, cfg *Config) error {
+	if cfg.Beacon == nil {
+		if cfg.Rollup.EcotoneTime != nil {
+			n.log.Warn("No L1 Beacon API endpoint configured, the node will fail to derive data after the Ecotone upgrade")
+		}
+		return nil
+	}
+	httpClient, err := cfg.Beacon.Setup(ctx, n.log)
+	if err != nil {
+		return fmt.Errorf("failed to setup L1 Beacon API HTTP client: %w", err)
+	}
+	n.beacon = sources.NewL1BeaconClient(httpClient)
+	return nil
+}
+
 func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 	// attempt to load runtime config, repeat N times
 	n.runCfg = NewRuntimeConfig(n.log, n.l1Source, &cfg.Rollup)
@@ -305,7 +327,22 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 		return err
 	}
 
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, &cfg.Sync)
+	// Only pass the beacon client on when it's configured, to avoid a non-nil interface holding a nil pointer.
+	var l1Blobs derive.L1BlobsFetcher
+	if n.beacon != nil {
+		l1Blobs = n.beacon
+	}
+	var sequencerConductor conductor.SequencerConductor = &conductor.NoOpConductor{}
+	if cfg.ConductorEnabled {
+		n.conductor, err = NewConductorClient(ctx, cfg, n.log)
+		if err != nil {
+			return fmt.Errorf("failed to create conductor client: %w", err)
+		}
+		sequencerConductor = n.conductor
+		n.log.Info("Sequencer conductor enabled, unsafe payloads are committed to the conductor before insertion", "rpc", cfg.ConductorRpc)
+	}
+
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, l1Blobs, n, n, sequencerConductor, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, &cfg.Sync)
 
 	return nil
 }
@@ -569,6 +606,11 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		}
 	}
 
+	// close the conductor client after the driver, which is the only user of it
+	if n.conductor != nil {
+		n.conductor.Close()
+	}
+
 	// Wait for the runtime config loader to be done using the data sources before closing them
 	if n.runtimeConfigReloaderDone != nil {
 		<-n.runtimeConfigReloaderDone
@@ -0,0 +1,22 @@
+package conductor
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SequencerConductor is an interface for the driver to communicate with the sequencer conductor.
+type SequencerConductor interface {
+	// CommitUnsafePayload commits an unsafe payload to the conductor log, and returns once it is acknowledged.
+	// It is called by the sequencer before the payload is inserted into the engine and published.
+	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error
+}
+
+// NoOpConductor is a no-op conductor that is used when the sequencer conductor is not enabled.
+type NoOpConductor struct{}
+
+// CommitUnsafePayload implements SequencerConductor.
+func (c *NoOpConductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	return nil
+}
@@ -0,0 +1,44 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type failingConductor struct {
+	err error
+}
+
+func (c *failingConductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	return c.err
+}
+
+func TestConfirmPayloadConductorCommitFails(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlError)
+	eng := &testutils.MockEngine{}
+	id := eth.PayloadID{0x01}
+	payload := &eth.ExecutionPayload{
+		BlockHash:    common.Hash{0x02},
+		Transactions: []eth.Data{{types.DepositTxType}},
+	}
+	eng.ExpectGetPayload(id, payload, nil)
+
+	// The payload must not be inserted into the engine if it isn't committed to the conductor.
+	commitErr := errors.New("boom")
+	out, errTyp, err := ConfirmPayload(context.Background(), logger, eng, eth.ForkchoiceState{}, id, false, &failingConductor{err: commitErr})
+	require.Nil(t, out)
+	require.Equal(t, BlockInsertTemporaryErr, errTyp)
+	require.ErrorIs(t, err, commitErr)
+	require.ErrorIs(t, err, ErrTemporary)
+	eng.AssertExpectations(t)
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -73,14 +74,17 @@ func (m *FakeEngineControl) StartPayload(ctx context.Context, parent eth.L2Block
 	return derive.BlockInsertOK, nil
 }
 
-func (m *FakeEngineControl) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPayload, errTyp derive.BlockInsertionErrType, err error) {
+func (m *FakeEngineControl) ConfirmPayload(ctx context.Context, sequencerConductor conductor.SequencerConductor) (out *eth.ExecutionPayload, errTyp derive.BlockInsertionErrType, err error) {
 	if m.err != nil {
 		return nil, m.errTyp, m.err
 	}
+	payload := m.makePayload(m.buildingOnto, m.buildingAttrs)
+	if err := sequencerConductor.CommitUnsafePayload(ctx, payload); err != nil {
+		return nil, derive.BlockInsertTemporaryErr, derive.NewTemporaryError(err)
+	}
 	buildTime := m.timeNow().Sub(m.buildingStart)
 	m.totalBuildingTime += buildTime
 	m.totalBuiltBlocks += 1
-	payload := m.makePayload(m.buildingOnto, m.buildingAttrs)
 	ref, err := derive.PayloadToBlockRef(payload, &m.cfg.Genesis)
 	if err != nil {
 		panic(err)
@@ -348,7 +352,7 @@ func TestSequencerChaosMonkey(t *testing.T) {
 		default:
 			// no error
 		}
-		payload, err := seq.RunNextSequencerAction(context.Background())
+		payload, err := seq.RunNextSequencerAction(context.Background(), &conductor.NoOpConductor{})
 		require.NoError(t, err)
 		if payload != nil {
 			require.Equal(t, engControl.UnsafeL2Head().ID(), payload.ID(), "head must stay in sync with emitted payloads")
@@ -376,3 +380,102 @@ func TestSequencerChaosMonkey(t *testing.T) {
 	require.Greater(t, engControl.avgBuildingTime(), time.Second, "With 2 second block time and 1 second error backoff and healthy-on-average errors, building time should at least be a second")
 	require.Greater(t, engControl.avgTxsPerBlock(), 3.0, "We expect at least 1 system tx per block, but with a mocked 0-10 txs we expect an higher avg")
 }
+
+type stubConductor struct {
+	err       error
+	committed []*eth.ExecutionPayload
+}
+
+func (c *stubConductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.committed = append(c.committed, payload)
+	return nil
+}
+
+// TestSequencerConductorCommitFailure checks that a block the conductor fails to commit does not become the unsafe
+// head, and that the same block is committed and confirmed on the next attempt.
+func TestSequencerConductorCommitFailure(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlError)
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1:     eth.BlockID{Hash: common.Hash{0x01}, Number: 100},
+			L2:     eth.BlockID{Hash: common.Hash{0x02}, Number: 200},
+			L2Time: 1000,
+		},
+		BlockTime:         2,
+		MaxSequencerDrift: 30,
+	}
+	genesisL2 := eth.L2BlockRef{
+		Hash:     cfg.Genesis.L2.Hash,
+		Number:   cfg.Genesis.L2.Number,
+		Time:     cfg.Genesis.L2Time,
+		L1Origin: cfg.Genesis.L1,
+	}
+	clockTime := time.Unix(int64(genesisL2.Time+cfg.BlockTime), 0)
+	clockFn := func() time.Time {
+		return clockTime
+	}
+	engControl := &FakeEngineControl{
+		finalized: genesisL2,
+		safe:      genesisL2,
+		unsafe:    genesisL2,
+		cfg:       cfg,
+		timeNow:   clockFn,
+		makePayload: func(onto eth.L2BlockRef, attrs *eth.PayloadAttributes) *eth.ExecutionPayload {
+			return &eth.ExecutionPayload{
+				ParentHash:   onto.Hash,
+				BlockNumber:  eth.Uint64Quantity(onto.Number) + 1,
+				Timestamp:    attrs.Timestamp,
+				BlockHash:    common.Hash{0x03},
+				Transactions: attrs.Transactions,
+			}
+		},
+	}
+	attrBuilder := testAttrBuilderFn(func(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID) (*eth.PayloadAttributes, error) {
+		l1Info := &testutils.MockBlockInfo{
+			InfoHash:    epoch.Hash,
+			InfoNum:     epoch.Number,
+			InfoTime:    l2Parent.Time,
+			InfoBaseFee: big.NewInt(1234),
+		}
+		infoDep, err := derive.L1InfoDepositBytes(l2Parent.SequenceNumber+1, l1Info, cfg.Genesis.SystemConfig, false)
+		require.NoError(t, err)
+		return &eth.PayloadAttributes{
+			Timestamp:    eth.Uint64Quantity(l2Parent.Time + cfg.BlockTime),
+			Transactions: []eth.Data{infoDep},
+		}, nil
+	})
+	originSelector := testOriginSelectorFn(func(ctx context.Context, l2Head eth.L2BlockRef) (eth.L1BlockRef, error) {
+		return eth.L1BlockRef{Hash: l2Head.L1Origin.Hash, Number: l2Head.L1Origin.Number, Time: genesisL2.Time}, nil
+	})
+	seq := NewSequencer(logger, cfg, engControl, attrBuilder, originSelector, metrics.NoopMetrics)
+	seq.timeNow = clockFn
+	sequencerConductor := &stubConductor{err: errors.New("boom")}
+
+	// Start building a block
+	payload, err := seq.RunNextSequencerAction(context.Background(), sequencerConductor)
+	require.NoError(t, err)
+	require.Nil(t, payload)
+	_, buildingID, _ := engControl.BuildingPayload()
+	require.NotEqual(t, eth.PayloadID{}, buildingID)
+
+	// The commit fails so the block must not become the unsafe head, but is still being built.
+	payload, err = seq.RunNextSequencerAction(context.Background(), sequencerConductor)
+	require.NoError(t, err)
+	require.Nil(t, payload)
+	require.Equal(t, genesisL2, engControl.UnsafeL2Head())
+	_, retryID, _ := engControl.BuildingPayload()
+	require.Equal(t, buildingID, retryID, "should retry the same block after a temporary error")
+	require.Equal(t, time.Second, seq.PlanNextSequencerAction(), "should retry after the temporary error backoff")
+
+	// Once the conductor accepts the block, it is confirmed
+	sequencerConductor.err = nil
+	clockTime = clockTime.Add(time.Second)
+	payload, err = seq.RunNextSequencerAction(context.Background(), sequencerConductor)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+	require.Equal(t, []*eth.ExecutionPayload{payload}, sequencerConductor.committed)
+	require.Equal(t, payload.ID(), engControl.UnsafeL2Head().ID())
+}
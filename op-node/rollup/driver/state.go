@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
@@ -82,6 +83,7 @@ type Driver struct {
 	l2        L2Chain
 	sequencer SequencerIface
 	network   Network // may be nil, network for is optional
+	conductor conductor.SequencerConductor
 
 	metrics     Metrics
 	log         log.Logger
@@ -272,7 +274,7 @@ func (s *Driver) eventLoop() {
 
 		select {
 		case <-sequencerCh:
-			payload, err := s.sequencer.RunNextSequencerAction(s.driverCtx)
+			payload, err := s.sequencer.RunNextSequencerAction(s.driverCtx, s.conductor)
 			if err != nil {
 				s.log.Error("Sequencer critical error", "err", err)
 				return
@@ -1,6 +1,7 @@
 package preimage
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"slices"
@@ -22,12 +23,21 @@ func WithVerification(source PreimageGetter) PreimageGetter {
 		switch KeyType(key[0]) {
 		case LocalKeyType:
 			return data, nil
+		case BlobKeyType, PrecompileKeyType:
+			// The key is a hash of the inputs rather than the data so can't be verified without the inputs.
+			return data, nil
 		case Keccak256KeyType:
 			hash := Keccak256(data)
 			if !slices.Equal(hash[1:], key[1:]) {
 				return nil, fmt.Errorf("%w for key %v, hash: %v data: %x", ErrIncorrectData, key, hash, data)
 			}
 			return data, nil
+		case Sha256KeyType:
+			hash := sha256.Sum256(data)
+			if !slices.Equal(hash[1:], key[1:]) {
+				return nil, fmt.Errorf("%w for key %v, hash: %v data: %x", ErrIncorrectData, key, hash, data)
+			}
+			return data, nil
 		default:
 			return nil, fmt.Errorf("%w: %v", ErrUnsupportedKeyType, key[0])
 		}
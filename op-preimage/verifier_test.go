@@ -1,6 +1,7 @@
 package preimage
 
 import (
+	"crypto/sha256"
 	"errors"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 func TestWithVerification(t *testing.T) {
 	validData := []byte{1, 2, 3, 4, 5, 6}
 	keccak256Key := Keccak256Key(Keccak256(validData))
+	sha256Key := Sha256Key(sha256.Sum256(validData))
 	anError := errors.New("boom")
 
 	tests := []struct {
@@ -45,6 +47,30 @@ func TestWithVerification(t *testing.T) {
 			data:        []byte{6, 7, 8},
 			expectedErr: ErrIncorrectData,
 		},
+		{
+			name:         "Sha256 Valid",
+			key:          sha256Key,
+			data:         validData,
+			expectedData: validData,
+		},
+		{
+			name:        "Sha256 InvalidData",
+			key:         sha256Key,
+			data:        []byte{6, 7, 8},
+			expectedErr: ErrIncorrectData,
+		},
+		{
+			name:         "BlobKey NoVerification",
+			key:          BlobKey(Keccak256(validData)),
+			data:         []byte{4, 3, 5, 7, 3},
+			expectedData: []byte{4, 3, 5, 7, 3},
+		},
+		{
+			name:         "PrecompileKey NoVerification",
+			key:          PrecompileKey(Keccak256(validData)),
+			data:         []byte{1},
+			expectedData: []byte{1},
+		},
 		{
 			name:        "EmptyData",
 			key:         keccak256Key,
@@ -0,0 +1,65 @@
+package l1
+
+import (
+	"context"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// KZGPointEvaluationAddress is the address of the KZG point evaluation precompile added in Cancun.
+var KZGPointEvaluationAddress = common.BytesToAddress([]byte{0x0a})
+
+// RootsOfUnity are the evaluation points of each field element in a blob, in the bit-reversed order used by EIP-4844.
+var RootsOfUnity = func() []fr.Element {
+	domain := fft.NewDomain(params.BlobTxFieldElementsPerBlob)
+	roots := make([]fr.Element, params.BlobTxFieldElementsPerBlob)
+	roots[0].SetOne()
+	for i := 1; i < len(roots); i++ {
+		roots[i].Mul(&roots[i-1], &domain.Generator)
+	}
+	fft.BitReverse(roots)
+	return roots
+}()
+
+// BlobFieldElementKeyData returns the data hashed to create the pre-image key for the field element at index in the
+// blob with the given KZG commitment: the 48 byte commitment followed by the field element's evaluation point.
+// Keying field elements by evaluation point allows each to be proven against the commitment with a KZG proof.
+func BlobFieldElementKeyData(commitment eth.Bytes48, index int) []byte {
+	point := RootsOfUnity[index].Bytes()
+	return append(commitment[:], point[:]...)
+}
+
+// PrecompileKeyData returns the data hashed to create the pre-image key for the result of calling the precompile
+// at address with input.
+func PrecompileKeyData(address common.Address, input []byte) []byte {
+	return append(address.Bytes(), input...)
+}
+
+// BlobFetcher implements derive.L1BlobsFetcher by reading blobs from the pre-image oracle.
+type BlobFetcher struct {
+	logger log.Logger
+	oracle Oracle
+}
+
+func NewBlobFetcher(logger log.Logger, oracle Oracle) *BlobFetcher {
+	return &BlobFetcher{
+		logger: logger,
+		oracle: oracle,
+	}
+}
+
+// GetBlobs fetches blobs that were confirmed in the given L1 block with the given indexed hashes.
+func (b *BlobFetcher) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	blobs := make([]*eth.Blob, len(hashes))
+	for i, hash := range hashes {
+		b.logger.Info("Loading blob", "block", ref.ID(), "index", hash.Index, "hash", hash.Hash)
+		blobs[i] = b.oracle.GetBlob(ref, hash)
+	}
+	return blobs, nil
+}
@@ -0,0 +1,43 @@
+package l1
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/l1/test"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootsOfUnity(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(testutils.RandomData(rng, 4000)))
+
+	// Evaluating the blob polynomial at each root of unity gives the field element at the same index.
+	for _, i := range []int{0, 1, 2, 127, 128, 4094, 4095} {
+		_, claim, err := kzg4844.ComputeProof(*blob.KZGBlob(), RootsOfUnity[i].Bytes())
+		require.NoError(t, err)
+		require.Equal(t, blob[i*32:(i+1)*32], claim[:], "field element %d", i)
+	}
+}
+
+func TestBlobFetcher(t *testing.T) {
+	stub := test.NewStubOracle(t)
+	fetcher := NewBlobFetcher(testlog.Logger(t, log.LvlInfo), stub)
+	blob1 := &eth.Blob{0x01}
+	blob2 := &eth.Blob{0x02}
+	stub.Blobs[common.Hash{0xaa}] = blob1
+	stub.Blobs[common.Hash{0xbb}] = blob2
+
+	hashes := []eth.IndexedBlobHash{{Index: 3, Hash: common.Hash{0xbb}}, {Index: 1, Hash: common.Hash{0xaa}}}
+	blobs, err := fetcher.GetBlobs(context.Background(), eth.L1BlockRef{}, hashes)
+	require.NoError(t, err)
+	require.Equal(t, []*eth.Blob{blob2, blob1}, blobs)
+}
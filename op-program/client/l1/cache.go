@@ -63,3 +63,12 @@ func (o *CachingOracle) ReceiptsByBlockHash(blockHash common.Hash) (eth.BlockInf
 	o.rcpts.Add(blockHash, rcpts)
 	return block, rcpts
 }
+
+// GetBlob is not cached as blobs are large and only read once during derivation.
+func (o *CachingOracle) GetBlob(ref eth.L1BlockRef, blobHash eth.IndexedBlobHash) *eth.Blob {
+	return o.oracle.GetBlob(ref, blobHash)
+}
+
+func (o *CachingOracle) KZGPointEvaluation(input []byte) bool {
+	return o.oracle.KZGPointEvaluation(input)
+}
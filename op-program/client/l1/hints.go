@@ -2,14 +2,17 @@ package l1
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 )
 
 const (
-	HintL1BlockHeader  = "l1-block-header"
-	HintL1Transactions = "l1-transactions"
-	HintL1Receipts     = "l1-receipts"
+	HintL1BlockHeader        = "l1-block-header"
+	HintL1Transactions       = "l1-transactions"
+	HintL1Receipts           = "l1-receipts"
+	HintL1Blob               = "l1-blob"
+	HintL1KZGPointEvaluation = "l1-kzg-point-evaluation"
 )
 
 type BlockHeaderHint common.Hash
@@ -35,3 +38,22 @@ var _ preimage.Hint = ReceiptsHint{}
 func (l ReceiptsHint) Hint() string {
 	return HintL1Receipts + " " + (common.Hash)(l).String()
 }
+
+// BlobHint is the versioned hash of a blob followed by its big-endian uint64 index in the block and the big-endian
+// uint64 timestamp of the L1 block it was included in, which is used to find the blob in the beacon chain.
+type BlobHint []byte
+
+var _ preimage.Hint = BlobHint{}
+
+func (l BlobHint) Hint() string {
+	return HintL1Blob + " " + hexutil.Encode(l)
+}
+
+// KZGPointEvaluationHint is the input to the KZG point evaluation precompile.
+type KZGPointEvaluationHint []byte
+
+var _ preimage.Hint = KZGPointEvaluationHint{}
+
+func (l KZGPointEvaluationHint) Hint() string {
+	return HintL1KZGPointEvaluation + " " + hexutil.Encode(l)
+}
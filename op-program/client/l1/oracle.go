@@ -1,10 +1,12 @@
 package l1
 
 import (
+	"encoding/binary"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
@@ -21,6 +23,12 @@ type Oracle interface {
 
 	// ReceiptsByBlockHash retrieves the receipts from the block with the given hash.
 	ReceiptsByBlockHash(blockHash common.Hash) (eth.BlockInfo, types.Receipts)
+
+	// GetBlob retrieves the blob with the given hash, confirmed in the given L1 block.
+	GetBlob(ref eth.L1BlockRef, blobHash eth.IndexedBlobHash) *eth.Blob
+
+	// KZGPointEvaluation retrieves whether the KZG point evaluation precompile succeeds for the given input.
+	KZGPointEvaluation(input []byte) bool
 }
 
 // PreimageOracle implements Oracle using by interfacing with the pure preimage.Oracle
@@ -86,3 +94,39 @@ func (p *PreimageOracle) ReceiptsByBlockHash(blockHash common.Hash) (eth.BlockIn
 
 	return info, receipts
 }
+
+func (p *PreimageOracle) GetBlob(ref eth.L1BlockRef, blobHash eth.IndexedBlobHash) *eth.Blob {
+	hint := make(BlobHint, 48)
+	copy(hint[:32], blobHash.Hash[:])
+	binary.BigEndian.PutUint64(hint[32:40], blobHash.Index)
+	binary.BigEndian.PutUint64(hint[40:48], ref.Time)
+	p.hint.Hint(hint)
+
+	// The versioned hash is the sha256 hash of the KZG commitment, with the first byte replaced by the version.
+	commitmentData := p.oracle.Get(preimage.Sha256Key(blobHash.Hash))
+	if len(commitmentData) != len(eth.Bytes48{}) {
+		panic(fmt.Errorf("invalid commitment for blob %s: %x", blobHash.Hash, commitmentData))
+	}
+	commitment := eth.Bytes48(commitmentData)
+
+	var blob eth.Blob
+	for i := 0; i < params.BlobTxFieldElementsPerBlob; i++ {
+		key := preimage.BlobKey(preimage.Keccak256(BlobFieldElementKeyData(commitment, i)))
+		element := p.oracle.Get(key)
+		if len(element) != params.BlobTxBytesPerFieldElement {
+			panic(fmt.Errorf("invalid field element %d for blob %s: %x", i, blobHash.Hash, element))
+		}
+		copy(blob[i*params.BlobTxBytesPerFieldElement:], element)
+	}
+	return &blob
+}
+
+func (p *PreimageOracle) KZGPointEvaluation(input []byte) bool {
+	p.hint.Hint(KZGPointEvaluationHint(input))
+	key := preimage.PrecompileKey(preimage.Keccak256(PrecompileKeyData(KZGPointEvaluationAddress, input)))
+	result := p.oracle.Get(key)
+	if len(result) != 1 || result[0] > 1 {
+		panic(fmt.Errorf("invalid KZG point evaluation result: %x", result))
+	}
+	return result[0] == 1
+}
@@ -19,14 +19,22 @@ type StubOracle struct {
 
 	// Rcpts maps Block hash to receipts
 	Rcpts map[common.Hash]types.Receipts
+
+	// Blobs maps blob hash to blob
+	Blobs map[common.Hash]*eth.Blob
+
+	// PointEvaluations maps hex encoded precompile input to the result
+	PointEvaluations map[string]bool
 }
 
 func NewStubOracle(t *testing.T) *StubOracle {
 	return &StubOracle{
-		t:      t,
-		Blocks: make(map[common.Hash]eth.BlockInfo),
-		Txs:    make(map[common.Hash]types.Transactions),
-		Rcpts:  make(map[common.Hash]types.Receipts),
+		t:                t,
+		Blocks:           make(map[common.Hash]eth.BlockInfo),
+		Txs:              make(map[common.Hash]types.Transactions),
+		Rcpts:            make(map[common.Hash]types.Receipts),
+		Blobs:            make(map[common.Hash]*eth.Blob),
+		PointEvaluations: make(map[string]bool),
 	}
 }
 func (o StubOracle) HeaderByBlockHash(blockHash common.Hash) eth.BlockInfo {
@@ -52,3 +60,19 @@ func (o StubOracle) ReceiptsByBlockHash(blockHash common.Hash) (eth.BlockInfo, t
 	}
 	return o.HeaderByBlockHash(blockHash), rcpts
 }
+
+func (o StubOracle) GetBlob(ref eth.L1BlockRef, blobHash eth.IndexedBlobHash) *eth.Blob {
+	blob, ok := o.Blobs[blobHash.Hash]
+	if !ok {
+		o.t.Fatalf("unknown blob %s", blobHash.Hash)
+	}
+	return blob
+}
+
+func (o StubOracle) KZGPointEvaluation(input []byte) bool {
+	result, ok := o.PointEvaluations[common.Bytes2Hex(input)]
+	if !ok {
+		o.t.Fatalf("unknown point evaluation %x", input)
+	}
+	return result
+}
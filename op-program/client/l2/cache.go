@@ -74,3 +74,7 @@ func (o *CachingOracle) OutputByRoot(root common.Hash) eth.Output {
 	o.outputs.Add(root, output)
 	return output
 }
+
+func (o *CachingOracle) HintAccount(blockHash common.Hash, address common.Address, storageKeys ...common.Hash) {
+	o.oracle.HintAccount(blockHash, address, storageKeys...)
+}
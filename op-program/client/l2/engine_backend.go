@@ -36,6 +36,10 @@ type OracleBackedL2Chain struct {
 	// Inserted blocks
 	blocks map[common.Hash]*types.Block
 	db     ethdb.KeyValueStore
+
+	// Block hash by state root, for the agreed head and inserted blocks.
+	// Used to identify the block when hinting state access.
+	blockByStateRoot map[common.Hash]common.Hash
 }
 
 var _ engineapi.EngineBackend = (*OracleBackedL2Chain)(nil)
@@ -66,6 +70,9 @@ func NewOracleBackedL2Chain(logger log.Logger, oracle Oracle, chainCfg *params.C
 		oracleHead: head.Header(),
 		blocks:     make(map[common.Hash]*types.Block),
 		db:         NewOracleBackedDB(oracle),
+		blockByStateRoot: map[common.Hash]common.Hash{
+			head.Root(): head.Hash(),
+		},
 	}, nil
 }
 
@@ -167,7 +174,11 @@ func (o *OracleBackedL2Chain) Engine() consensus.Engine {
 }
 
 func (o *OracleBackedL2Chain) StateAt(root common.Hash) (*state.StateDB, error) {
-	return state.New(root, state.NewDatabase(rawdb.NewDatabase(o.db)), nil)
+	db := state.NewDatabase(rawdb.NewDatabase(o.db))
+	if blockHash, ok := o.blockByStateRoot[root]; ok {
+		db = newHintingDatabase(db, o.oracle, blockHash)
+	}
+	return state.New(root, db, nil)
 }
 
 func (o *OracleBackedL2Chain) InsertBlockWithoutSetHead(block *types.Block) error {
@@ -193,6 +204,7 @@ func (o *OracleBackedL2Chain) InsertBlockWithoutSetHead(block *types.Block) erro
 		return fmt.Errorf("commit block: %w", err)
 	}
 	o.blocks[block.Hash()] = block
+	o.blockByStateRoot[block.Root()] = block.Hash()
 	return nil
 }
 
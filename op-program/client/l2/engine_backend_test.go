@@ -116,6 +116,24 @@ func TestUpdateStateDatabaseWhenImportingBlock(t *testing.T) {
 	require.NotEqual(t, big.NewInt(0), balance, "should have balance from imported block")
 }
 
+func TestHintAccountsWhenImportingBlock(t *testing.T) {
+	blocks, chain := setupOracleBackedChain(t, 3)
+	oracle := chain.oracle.(*l2test.StubBlockOracle)
+	newBlock := createBlock(t, chain)
+	clear(oracle.HintedAccounts)
+
+	require.NoError(t, chain.InsertBlockWithoutSetHead(newBlock))
+	hinted := oracle.HintedAccounts[blocks[3].Hash()]
+	require.True(t, hinted[fundedAddress], "should hint sender")
+	require.True(t, hinted[targetAddress], "should hint recipient")
+
+	// Accesses to the state of the new block are hinted with its hash
+	db, err := chain.StateAt(newBlock.Root())
+	require.NoError(t, err)
+	db.GetBalance(targetAddress)
+	require.True(t, oracle.HintedAccounts[newBlock.Hash()][targetAddress])
+}
+
 func TestRejectBlockWithStateRootMismatch(t *testing.T) {
 	_, chain := setupOracleBackedChain(t, 1)
 	newBlock := createBlock(t, chain)
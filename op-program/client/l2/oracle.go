@@ -34,6 +34,11 @@ type Oracle interface {
 	BlockByHash(blockHash common.Hash) *types.Block
 
 	OutputByRoot(root common.Hash) eth.Output
+
+	// HintAccount hints that the account, and any of the given storage slots, are about to be read from the state
+	// of the block with the given hash. The trie nodes are still retrieved with NodeByHash, but this allows the
+	// pre-images for the whole path to be prepared at once.
+	HintAccount(blockHash common.Hash, address common.Address, storageKeys ...common.Hash)
 }
 
 // PreimageOracle implements Oracle using by interfacing with the pure preimage.Oracle
@@ -102,3 +107,13 @@ func (p *PreimageOracle) OutputByRoot(l2OutputRoot common.Hash) eth.Output {
 	}
 	return output
 }
+
+func (p *PreimageOracle) HintAccount(blockHash common.Hash, address common.Address, storageKeys ...common.Hash) {
+	data := make([]byte, 0, common.HashLength+common.AddressLength+len(storageKeys)*common.HashLength)
+	data = append(data, blockHash.Bytes()...)
+	data = append(data, address.Bytes()...)
+	for _, key := range storageKeys {
+		data = append(data, key.Bytes()...)
+	}
+	p.hint.Hint(AccountProofHint(data))
+}
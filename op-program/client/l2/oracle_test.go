@@ -140,3 +140,28 @@ func TestPreimageOracleOutputByRoot(t *testing.T) {
 		})
 	}
 }
+
+func TestPreimageOracleHintAccount(t *testing.T) {
+	blockHash := common.Hash{0xaa}
+	address := common.Address{0xbb}
+	key1 := common.Hash{0xcc}
+	key2 := common.Hash{0xdd}
+
+	t.Run("AccountOnly", func(t *testing.T) {
+		po, hints, _ := mockPreimageOracle(t)
+		expected := append(blockHash.Bytes(), address.Bytes()...)
+		hints.On("hint", AccountProofHint(expected).Hint()).Once().Return()
+		po.HintAccount(blockHash, address)
+		hints.AssertExpectations(t)
+	})
+
+	t.Run("WithStorage", func(t *testing.T) {
+		po, hints, _ := mockPreimageOracle(t)
+		expected := append(blockHash.Bytes(), address.Bytes()...)
+		expected = append(expected, key1.Bytes()...)
+		expected = append(expected, key2.Bytes()...)
+		hints.On("hint", AccountProofHint(expected).Hint()).Once().Return()
+		po.HintAccount(blockHash, address, key1, key2)
+		hints.AssertExpectations(t)
+	})
+}
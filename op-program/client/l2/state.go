@@ -0,0 +1,62 @@
+package l2
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// hintingDatabase wraps a state.Database to hint each account and storage slot read from the state of a block
+// before the trie is traversed. This allows the host to prepare all trie nodes along the path together rather than
+// one node at a time.
+type hintingDatabase struct {
+	state.Database
+	oracle    Oracle
+	blockHash common.Hash
+}
+
+func newHintingDatabase(db state.Database, oracle Oracle, blockHash common.Hash) *hintingDatabase {
+	return &hintingDatabase{
+		Database:  db,
+		oracle:    oracle,
+		blockHash: blockHash,
+	}
+}
+
+func (d *hintingDatabase) OpenTrie(root common.Hash) (state.Trie, error) {
+	tr, err := d.Database.OpenTrie(root)
+	if err != nil {
+		return nil, err
+	}
+	return &hintingTrie{Trie: tr, db: d}, nil
+}
+
+func (d *hintingDatabase) OpenStorageTrie(stateRoot common.Hash, address common.Address, root common.Hash) (state.Trie, error) {
+	tr, err := d.Database.OpenStorageTrie(stateRoot, address, root)
+	if err != nil {
+		return nil, err
+	}
+	return &hintingTrie{Trie: tr, db: d}, nil
+}
+
+func (d *hintingDatabase) CopyTrie(tr state.Trie) state.Trie {
+	if h, ok := tr.(*hintingTrie); ok {
+		return &hintingTrie{Trie: d.Database.CopyTrie(h.Trie), db: d}
+	}
+	return d.Database.CopyTrie(tr)
+}
+
+type hintingTrie struct {
+	state.Trie
+	db *hintingDatabase
+}
+
+func (t *hintingTrie) GetAccount(address common.Address) (*types.StateAccount, error) {
+	t.db.oracle.HintAccount(t.db.blockHash, address)
+	return t.Trie.GetAccount(address)
+}
+
+func (t *hintingTrie) GetStorage(address common.Address, key []byte) ([]byte, error) {
+	t.db.oracle.HintAccount(t.db.blockHash, address, common.BytesToHash(key))
+	return t.Trie.GetStorage(address, key)
+}
@@ -20,16 +20,19 @@ type StubBlockOracle struct {
 	t       *testing.T
 	Blocks  map[common.Hash]*types.Block
 	Outputs map[common.Hash]eth.Output
+	// HintedAccounts records the accounts hinted via HintAccount, by block hash
+	HintedAccounts map[common.Hash]map[common.Address]bool
 	stateOracle
 }
 
 func NewStubOracle(t *testing.T) (*StubBlockOracle, *StubStateOracle) {
 	stateOracle := NewStubStateOracle(t)
 	blockOracle := StubBlockOracle{
-		t:           t,
-		Blocks:      make(map[common.Hash]*types.Block),
-		Outputs:     make(map[common.Hash]eth.Output),
-		stateOracle: stateOracle,
+		t:              t,
+		Blocks:         make(map[common.Hash]*types.Block),
+		Outputs:        make(map[common.Hash]eth.Output),
+		HintedAccounts: make(map[common.Hash]map[common.Address]bool),
+		stateOracle:    stateOracle,
 	}
 	return &blockOracle, stateOracle
 }
@@ -44,10 +47,11 @@ func NewStubOracleWithBlocks(t *testing.T, chain []*types.Block, outputs []eth.O
 		o[common.Hash(eth.OutputRoot(output))] = output
 	}
 	return &StubBlockOracle{
-		t:           t,
-		Blocks:      blocks,
-		Outputs:     o,
-		stateOracle: &KvStateOracle{t: t, Source: db},
+		t:              t,
+		Blocks:         blocks,
+		Outputs:        o,
+		HintedAccounts: make(map[common.Hash]map[common.Address]bool),
+		stateOracle:    &KvStateOracle{t: t, Source: db},
 	}
 }
 
@@ -67,6 +71,15 @@ func (o StubBlockOracle) OutputByRoot(root common.Hash) eth.Output {
 	return output
 }
 
+func (o StubBlockOracle) HintAccount(blockHash common.Hash, address common.Address, _ ...common.Hash) {
+	accounts, ok := o.HintedAccounts[blockHash]
+	if !ok {
+		accounts = make(map[common.Address]bool)
+		o.HintedAccounts[blockHash] = accounts
+	}
+	accounts[address] = true
+}
+
 // KvStateOracle loads data from a source ethdb.KeyValueStore
 type KvStateOracle struct {
 	t      *testing.T
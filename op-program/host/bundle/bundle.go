@@ -0,0 +1,342 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Version is the version of the bundle format written by this package.
+const Version = 1
+
+const (
+	indexFile     = "index.json"
+	preimagesFile = "preimages.bin"
+)
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported bundle version")
+	ErrMissingIndex       = errors.New("bundle index not found")
+	ErrInvalidPreimages   = errors.New("invalid bundle pre-images")
+	ErrPreimageNotFound   = errors.New("pre-image not found in bundle")
+)
+
+// Boot is the local program inputs used to run the fault proof program.
+type Boot struct {
+	L1Head              common.Hash         `json:"l1Head"`
+	L2Head              common.Hash         `json:"l2Head"`
+	L2OutputRoot        common.Hash         `json:"l2OutputRoot"`
+	L2Claim             common.Hash         `json:"l2Claim"`
+	L2ClaimBlockNumber  uint64              `json:"l2ClaimBlockNumber"`
+	L2ChainConfig       *params.ChainConfig `json:"l2ChainConfig"`
+	Rollup              *rollup.Config      `json:"rollup"`
+	IsCustomChainConfig bool                `json:"isCustomChainConfig"`
+}
+
+// Entry locates a single pre-image within the bundle's pre-image data.
+type Entry struct {
+	Key    common.Hash `json:"key"`
+	Offset uint64      `json:"offset"`
+	Length uint64      `json:"length"`
+}
+
+// Index describes the content of a bundle. It is the first file in the archive so the boot inputs can be read
+// without decompressing the pre-images.
+type Index struct {
+	Version   uint64  `json:"version"`
+	Boot      Boot    `json:"boot"`
+	Preimages []Entry `json:"preimages"`
+}
+
+// Bundle is a self-contained set of inputs to run the fault proof program offline.
+// It is stored as a gzip compressed tar archive containing the index and the concatenated pre-image data.
+type Bundle struct {
+	Boot      Boot
+	Preimages map[common.Hash][]byte
+}
+
+// Write writes the bundle to w. Pre-images are sorted by key so the same bundle always produces the same output.
+func (b *Bundle) Write(w io.Writer) error {
+	entries := make([]Entry, 0, len(b.Preimages))
+	for key, data := range b.Preimages {
+		entries = append(entries, Entry{Key: key, Length: uint64(len(data))})
+	}
+	return write(w, b.Boot, entries, func(entry Entry) io.Reader {
+		return bytes.NewReader(b.Preimages[entry.Key])
+	})
+}
+
+// WriteFile writes the bundle to a new file at path.
+func (b *Bundle) WriteFile(path string) error {
+	return writeFile(path, b.Write)
+}
+
+// write writes a bundle with the boot inputs and the pre-images in entries to w, reading the data of each entry
+// from open. Entries are sorted by key and their offsets set to their position in the bundle.
+func write(w io.Writer, boot Boot, entries []Entry, open func(entry Entry) io.Reader) error {
+	slices.SortFunc(entries, func(a, b Entry) int {
+		return a.Key.Cmp(b.Key)
+	})
+	index := Index{
+		Version:   Version,
+		Boot:      boot,
+		Preimages: make([]Entry, 0, len(entries)),
+	}
+	var size uint64
+	for _, entry := range entries {
+		index.Preimages = append(index.Preimages, Entry{Key: entry.Key, Offset: size, Length: entry.Length})
+		size += entry.Length
+	}
+	indexData, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to encode index: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+	if err := archive.WriteHeader(&tar.Header{Name: indexFile, Mode: 0644, Size: int64(len(indexData))}); err != nil {
+		return fmt.Errorf("failed to write index header: %w", err)
+	}
+	if _, err := archive.Write(indexData); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+	if err := archive.WriteHeader(&tar.Header{Name: preimagesFile, Mode: 0644, Size: int64(size)}); err != nil {
+		return fmt.Errorf("failed to write pre-images header: %w", err)
+	}
+	for _, entry := range entries {
+		if _, err := io.Copy(archive, open(entry)); err != nil {
+			return fmt.Errorf("failed to write pre-image %v: %w", entry.Key, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to close archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to close compressor: %w", err)
+	}
+	return nil
+}
+
+func writeFile(path string, write func(w io.Writer) error) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle file: %w", err)
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadBoot reads only the boot inputs from the bundle at path.
+func ReadBoot(path string) (Boot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Boot{}, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer f.Close()
+	archive, err := openArchive(f)
+	if err != nil {
+		return Boot{}, err
+	}
+	index, err := readIndex(archive)
+	if err != nil {
+		return Boot{}, err
+	}
+	return index.Boot, nil
+}
+
+// Reader serves the pre-images of a bundle. The pre-image data is decompressed once to a temporary file and each
+// pre-image is read from it at the offset in the index, so only the index is held in memory.
+// Close must be called to remove the temporary file. Reader is safe for concurrent use.
+type Reader struct {
+	Boot    Boot
+	entries map[common.Hash]Entry
+	file    *os.File
+}
+
+// Open opens the bundle at path to read its pre-images.
+func Open(path string) (*Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer f.Close()
+	return newReader(f)
+}
+
+func newReader(r io.Reader) (*Reader, error) {
+	archive, err := openArchive(r)
+	if err != nil {
+		return nil, err
+	}
+	index, err := readIndex(archive)
+	if err != nil {
+		return nil, err
+	}
+	header, err := archive.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pre-images header: %w", err)
+	}
+	if header.Name != preimagesFile {
+		return nil, fmt.Errorf("%w: unexpected file %v", ErrInvalidPreimages, header.Name)
+	}
+	file, err := os.CreateTemp("", "op-program-bundle-*.bin")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pre-images file: %w", err)
+	}
+	reader := &Reader{Boot: index.Boot, entries: make(map[common.Hash]Entry, len(index.Preimages)), file: file}
+	size, err := io.Copy(file, archive)
+	if err != nil {
+		_ = reader.Close()
+		return nil, fmt.Errorf("failed to extract pre-images: %w", err)
+	}
+	for _, entry := range index.Preimages {
+		if entry.Offset > uint64(size) || entry.Length > uint64(size)-entry.Offset {
+			_ = reader.Close()
+			return nil, fmt.Errorf("%w: pre-image %v out of range", ErrInvalidPreimages, entry.Key)
+		}
+		reader.entries[entry.Key] = entry
+	}
+	return reader, nil
+}
+
+// Get returns the pre-image for key, or ErrPreimageNotFound if it is not in the bundle.
+func (r *Reader) Get(key common.Hash) ([]byte, error) {
+	entry, ok := r.entries[key]
+	if !ok {
+		return nil, ErrPreimageNotFound
+	}
+	data := make([]byte, entry.Length)
+	if _, err := r.file.ReadAt(data, int64(entry.Offset)); err != nil {
+		return nil, fmt.Errorf("failed to read pre-image %v: %w", key, err)
+	}
+	return data, nil
+}
+
+// Len returns the number of pre-images in the bundle.
+func (r *Reader) Len() int {
+	return len(r.entries)
+}
+
+// Close closes and removes the temporary pre-images file.
+func (r *Reader) Close() error {
+	return closeTemp(r.file)
+}
+
+func closeTemp(file *os.File) error {
+	closeErr := file.Close()
+	if err := os.Remove(file.Name()); err != nil {
+		return fmt.Errorf("failed to remove pre-images file: %w", err)
+	}
+	return closeErr
+}
+
+func openArchive(r io.Reader) (*tar.Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress bundle: %w", err)
+	}
+	return tar.NewReader(gz), nil
+}
+
+func readIndex(archive *tar.Reader) (*Index, error) {
+	header, err := archive.Next()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrMissingIndex
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read index header: %w", err)
+	}
+	if header.Name != indexFile {
+		return nil, fmt.Errorf("%w: found %v", ErrMissingIndex, header.Name)
+	}
+	var index Index
+	if err := json.NewDecoder(archive).Decode(&index); err != nil {
+		return nil, fmt.Errorf("failed to decode index: %w", err)
+	}
+	if index.Version != Version {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedVersion, index.Version)
+	}
+	return &index, nil
+}
+
+// Recorder records the global pre-images served to the client program so they can be written to a bundle.
+// Local pre-images are derived from the boot inputs so are not recorded. The pre-image data is appended to a
+// temporary file as it is recorded, so only the index is held in memory.
+// Close must be called to remove the temporary file. Recorder is safe for concurrent use.
+type Recorder struct {
+	lock    sync.Mutex
+	file    *os.File
+	size    uint64
+	entries map[common.Hash]Entry
+}
+
+func NewRecorder() (*Recorder, error) {
+	file, err := os.CreateTemp("", "op-program-recorder-*.bin")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pre-images file: %w", err)
+	}
+	return &Recorder{file: file, entries: make(map[common.Hash]Entry)}, nil
+}
+
+// Wrap returns a PreimageGetter that records each pre-image successfully returned by source.
+// An error is returned if the pre-image cannot be recorded.
+func (r *Recorder) Wrap(source preimage.PreimageGetter) preimage.PreimageGetter {
+	return func(key [32]byte) ([]byte, error) {
+		data, err := source(key)
+		if err != nil || key[0] == byte(preimage.LocalKeyType) {
+			return data, err
+		}
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		if _, ok := r.entries[key]; ok {
+			return data, nil
+		}
+		if _, err := r.file.Write(data); err != nil {
+			return nil, fmt.Errorf("failed to record pre-image %v: %w", common.Hash(key), err)
+		}
+		r.entries[key] = Entry{Key: key, Offset: r.size, Length: uint64(len(data))}
+		r.size += uint64(len(data))
+		return data, nil
+	}
+}
+
+// Len returns the number of recorded pre-images.
+func (r *Recorder) Len() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.entries)
+}
+
+// WriteFile writes a bundle with the recorded pre-images and the supplied boot inputs to a new file at path.
+func (r *Recorder) WriteFile(path string, boot Boot) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	entries := make([]Entry, 0, len(r.entries))
+	for _, entry := range r.entries {
+		entries = append(entries, entry)
+	}
+	return writeFile(path, func(w io.Writer) error {
+		return write(w, boot, entries, func(entry Entry) io.Reader {
+			return io.NewSectionReader(r.file, int64(entry.Offset), int64(entry.Length))
+		})
+	})
+}
+
+// Close closes and removes the temporary pre-images file.
+func (r *Recorder) Close() error {
+	return closeTemp(r.file)
+}
@@ -0,0 +1,197 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var boot = Boot{
+	L1Head:             common.Hash{0x11},
+	L2Head:             common.Hash{0x22},
+	L2OutputRoot:       common.Hash{0x33},
+	L2Claim:            common.Hash{0x44},
+	L2ClaimBlockNumber: 1000,
+	L2ChainConfig:      chainconfig.OPGoerliChainConfig,
+	Rollup:             chaincfg.Goerli,
+}
+
+func TestRoundTrip(t *testing.T) {
+	b := &Bundle{
+		Boot: boot,
+		Preimages: map[common.Hash][]byte{
+			{0x02, 0xaa}: {1, 2, 3},
+			{0x02, 0xbb}: {},
+			{0x05, 0xcc}: bytes.Repeat([]byte{4}, 1000),
+		},
+	}
+	path := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	require.NoError(t, b.WriteFile(path))
+
+	reader, err := Open(path)
+	require.NoError(t, err)
+	require.Equal(t, b.Boot, reader.Boot)
+	require.Equal(t, b.Preimages, readPreimages(t, reader, b.Preimages))
+	_, err = reader.Get(common.Hash{0x02, 0xcc})
+	require.ErrorIs(t, err, ErrPreimageNotFound)
+
+	// The extracted pre-images are removed on close
+	require.NoError(t, reader.Close())
+	_, err = os.Stat(reader.file.Name())
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	actualBoot, err := ReadBoot(path)
+	require.NoError(t, err)
+	require.Equal(t, b.Boot, actualBoot)
+}
+
+func TestDeterministic(t *testing.T) {
+	preimages := make(map[common.Hash][]byte)
+	for i := 0; i < 100; i++ {
+		preimages[common.Hash{0x02, byte(i)}] = []byte{byte(i)}
+	}
+	b := &Bundle{Boot: boot, Preimages: preimages}
+	var first, second bytes.Buffer
+	require.NoError(t, b.Write(&first))
+	require.NoError(t, b.Write(&second))
+	require.Equal(t, first.Bytes(), second.Bytes())
+}
+
+func TestReadInvalid(t *testing.T) {
+	t.Run("NotCompressed", func(t *testing.T) {
+		_, err := newReader(bytes.NewReader([]byte("not a bundle")))
+		require.ErrorContains(t, err, "failed to decompress bundle")
+	})
+
+	t.Run("MissingIndex", func(t *testing.T) {
+		_, err := newReader(bytes.NewReader(archive(t)))
+		require.ErrorIs(t, err, ErrMissingIndex)
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		_, err := newReader(bytes.NewReader(archive(t, indexFile, index(t, Index{Version: 2}))))
+		require.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+
+	t.Run("PreimageOutOfRange", func(t *testing.T) {
+		idx := Index{
+			Version:   Version,
+			Preimages: []Entry{{Key: common.Hash{0x02}, Offset: 2, Length: 2}},
+		}
+		_, err := newReader(bytes.NewReader(archive(t, indexFile, index(t, idx), preimagesFile, []byte{1, 2, 3})))
+		require.ErrorIs(t, err, ErrInvalidPreimages)
+	})
+}
+
+func TestRecorder(t *testing.T) {
+	keccakKey := preimage.Keccak256Key{0xaa}.PreimageKey()
+	localKey := preimage.LocalIndexKey(1).PreimageKey()
+	missingKey := preimage.Keccak256Key{0xbb}.PreimageKey()
+	source := func(key [32]byte) ([]byte, error) {
+		switch key {
+		case keccakKey:
+			return []byte{1, 2, 3}, nil
+		case localKey:
+			return []byte{4, 5, 6}, nil
+		default:
+			return nil, errors.New("not found")
+		}
+	}
+
+	recorder, err := NewRecorder()
+	require.NoError(t, err)
+	getter := recorder.Wrap(source)
+	for _, key := range [][32]byte{keccakKey, localKey, missingKey, keccakKey} {
+		expected, expectedErr := source(key)
+		actual, err := getter(key)
+		require.Equal(t, expectedErr, err)
+		require.Equal(t, expected, actual)
+	}
+	require.Equal(t, 1, recorder.Len())
+
+	path := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	require.NoError(t, recorder.WriteFile(path, boot))
+	require.NoError(t, recorder.Close())
+	_, err = os.Stat(recorder.file.Name())
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	reader, err := Open(path)
+	require.NoError(t, err)
+	defer reader.Close()
+	require.Equal(t, boot, reader.Boot)
+	expected := map[common.Hash][]byte{keccakKey: {1, 2, 3}}
+	require.Equal(t, expected, readPreimages(t, reader, expected))
+}
+
+func TestRecorderMatchesBundle(t *testing.T) {
+	preimages := make(map[common.Hash][]byte)
+	for i := 0; i < 100; i++ {
+		preimages[common.Hash{0x02, byte(i)}] = bytes.Repeat([]byte{byte(i)}, i)
+	}
+	recorder, err := NewRecorder()
+	require.NoError(t, err)
+	defer recorder.Close()
+	getter := recorder.Wrap(func(key [32]byte) ([]byte, error) {
+		return preimages[key], nil
+	})
+	// Record in a different order to the bundle
+	for i := 99; i >= 0; i-- {
+		_, err := getter(common.Hash{0x02, byte(i)})
+		require.NoError(t, err)
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, recorder.WriteFile(filepath.Join(dir, "recorded.tar.gz"), boot))
+	b := &Bundle{Boot: boot, Preimages: preimages}
+	require.NoError(t, b.WriteFile(filepath.Join(dir, "bundle.tar.gz")))
+	recorded, err := os.ReadFile(filepath.Join(dir, "recorded.tar.gz"))
+	require.NoError(t, err)
+	expected, err := os.ReadFile(filepath.Join(dir, "bundle.tar.gz"))
+	require.NoError(t, err)
+	require.Equal(t, expected, recorded)
+}
+
+// readPreimages reads the pre-image for each key in expected from reader.
+func readPreimages(t *testing.T, reader *Reader, expected map[common.Hash][]byte) map[common.Hash][]byte {
+	require.Equal(t, len(expected), reader.Len())
+	actual := make(map[common.Hash][]byte, len(expected))
+	for key := range expected {
+		data, err := reader.Get(key)
+		require.NoError(t, err)
+		actual[key] = data
+	}
+	return actual
+}
+
+func index(t *testing.T, idx Index) []byte {
+	data, err := json.Marshal(idx)
+	require.NoError(t, err)
+	return data
+}
+
+// archive creates a compressed archive containing the supplied pairs of file names and content.
+func archive(t *testing.T, files ...any) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	w := tar.NewWriter(gz)
+	for i := 0; i < len(files); i += 2 {
+		data := files[i+1].([]byte)
+		require.NoError(t, w.WriteHeader(&tar.Header{Name: files[i].(string), Mode: 0644, Size: int64(len(data))}))
+		_, err := w.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
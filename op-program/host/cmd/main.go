@@ -57,6 +57,60 @@ func run(args []string, action ConfigAction) error {
 		}
 		return action(logger, cfg)
 	}
+	app.Commands = []*cli.Command{
+		{
+			Name:        "export",
+			Usage:       "Runs the program and exports every pre-image used to a bundle",
+			Description: "Runs the program, fetching pre-images as required, and writes the boot inputs and every pre-image used to a compressed bundle that can be run offline with --bundle.",
+			Flags:       flags.ExportFlags,
+			Action: func(ctx *cli.Context) error {
+				logger, err := setupLogging(ctx)
+				if err != nil {
+					return err
+				}
+				logger.Info("Starting fault proof program export", "version", VersionWithMeta)
+
+				cfg, err := config.NewConfigFromCLI(logger, ctx)
+				if err != nil {
+					return err
+				}
+				cfg.ExportPath = ctx.String(flags.ExportOutput.Name)
+				return action(logger, cfg)
+			},
+		},
+		{
+			Name:        "verify",
+			Usage:       "Verifies the output root of an L2 block reported by a rollup node",
+			Description: "Looks up the output root of the specified L2 block, the agreed output root of the previous block and an L1 head the block is safe at from the rollup node, then runs the program to verify the output root.",
+			Flags:       flags.VerifyFlags,
+			Action: func(ctx *cli.Context) error {
+				logger, err := setupLogging(ctx)
+				if err != nil {
+					return err
+				}
+				logger.Info("Starting fault proof program verification", "version", VersionWithMeta)
+
+				cfg, err := config.NewVerifyConfigFromCLI(logger, ctx)
+				if err != nil {
+					return err
+				}
+				return action(logger, cfg)
+			},
+		},
+		{
+			Name:        "migrate",
+			Usage:       "Migrates pre-images from the directory data format to the pebble data format",
+			Description: "Copies every pre-image in a data directory using the directory format to a new data directory using the pebble format. The source directory is not modified.",
+			Flags:       flags.MigrateFlags,
+			Action: func(ctx *cli.Context) error {
+				logger, err := setupLogging(ctx)
+				if err != nil {
+					return err
+				}
+				return host.MigrateDataDir(logger, ctx.String(flags.MigrateSource.Name), ctx.String(flags.MigrateDest.Name))
+			},
+		},
+	}
 
 	return app.Run(args)
 }
@@ -3,12 +3,15 @@ package main
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/host/bundle"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/flags"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -112,6 +115,20 @@ func TestDataDir(t *testing.T) {
 	require.Equal(t, expected, cfg.DataDir)
 }
 
+func TestDataFormat(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, config.DataFormatDirectory, cfg.DataFormat)
+	})
+	for _, format := range config.SupportedDataFormats {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			cfg := configForArgs(t, addRequiredArgs("--datadir", "/tmp/data", "--data.format", format))
+			require.Equal(t, format, cfg.DataFormat)
+		})
+	}
+}
+
 func TestL2(t *testing.T) {
 	expected := "https://example.com:8545"
 	cfg := configForArgs(t, addRequiredArgs("--l2", expected))
@@ -188,6 +205,18 @@ func TestL1(t *testing.T) {
 	require.Equal(t, expected, cfg.L1URL)
 }
 
+func TestL1Beacon(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.L1BeaconURL)
+	})
+	t.Run("Valid", func(t *testing.T) {
+		expected := "https://example.com:5052"
+		cfg := configForArgs(t, addRequiredArgs("--l1.beacon", expected))
+		require.Equal(t, expected, cfg.L1BeaconURL)
+	})
+}
+
 func TestL1TrustRPC(t *testing.T) {
 	t.Run("DefaultFalse", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs())
@@ -226,6 +255,21 @@ func TestL1RPCKind(t *testing.T) {
 	})
 }
 
+func TestL1Lookahead(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, flags.L1Lookahead.Value, cfg.L1Lookahead)
+	})
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--l1.lookahead", "3"))
+		require.EqualValues(t, 3, cfg.L1Lookahead)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--l1.lookahead", "0"))
+		require.Zero(t, cfg.L1Lookahead)
+	})
+}
+
 func TestL2Claim(t *testing.T) {
 	t.Run("Required", func(t *testing.T) {
 		verifyArgsInvalid(t, "flag l2.claim is required", addRequiredArgsExcept("--l2.claim"))
@@ -300,6 +344,122 @@ func TestServerMode(t *testing.T) {
 	})
 }
 
+func TestBundle(t *testing.T) {
+	b := &bundle.Bundle{
+		Boot: bundle.Boot{
+			L1Head:              common.HexToHash(l1HeadValue),
+			L2Head:              common.HexToHash(l2HeadValue),
+			L2OutputRoot:        common.HexToHash(l2OutputRoot),
+			L2Claim:             common.HexToHash(l2ClaimValue),
+			L2ClaimBlockNumber:  l2ClaimBlockNumber,
+			L2ChainConfig:       l2GenesisConfig,
+			Rollup:              chaincfg.Goerli,
+			IsCustomChainConfig: true,
+		},
+	}
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	require.NoError(t, b.WriteFile(bundlePath))
+
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.Bundle)
+	})
+
+	t.Run("LoadsBootInputs", func(t *testing.T) {
+		cfg := configForArgs(t, []string{"--bundle", bundlePath})
+		require.Equal(t, bundlePath, cfg.Bundle)
+		require.Equal(t, b.Boot, cfg.Boot())
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("RejectBootFlags", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag l1.head cannot be used with bundle", []string{"--bundle", bundlePath, "--l1.head", l1HeadValue})
+		verifyArgsInvalid(t, "flag network cannot be used with bundle", []string{"--bundle", bundlePath, "--network", "goerli"})
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid bundle", []string{"--bundle", filepath.Join(t.TempDir(), "missing.tar.gz")})
+	})
+}
+
+func TestExport(t *testing.T) {
+	t.Run("NotSetByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.ExportPath)
+	})
+
+	t.Run("RequireOutput", func(t *testing.T) {
+		verifyArgsInvalid(t, "Required flag \"output\" not set", append([]string{"export"}, addRequiredArgs()...))
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, append([]string{"export", "--output", "bundle.tar.gz"}, addRequiredArgs()...))
+		require.Equal(t, "bundle.tar.gz", cfg.ExportPath)
+		require.Equal(t, common.HexToHash(l1HeadValue), cfg.L1Head)
+	})
+}
+
+func TestVerify(t *testing.T) {
+	verifyArgs := func(args ...string) []string {
+		return append([]string{"verify", "--rollup", "http://localhost:7545", "--l1", "http://localhost:8545", "--l2", "http://localhost:9545"}, args...)
+	}
+
+	t.Run("RequireRollup", func(t *testing.T) {
+		verifyArgsInvalid(t, "Required flag \"rollup\" not set", []string{"verify", "--l2.blocknumber", "1000"})
+	})
+
+	t.Run("RequireBlockNumber", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag l2.blocknumber is required", verifyArgs())
+	})
+
+	t.Run("RequireL1", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag l1 is required", []string{"verify", "--rollup", "http://localhost:7545", "--l2", "http://localhost:9545", "--l2.blocknumber", "1000"})
+	})
+
+	t.Run("RejectNetworkAndRollupConfig", func(t *testing.T) {
+		verifyArgsInvalid(t, "cannot specify both rollup.config and network", verifyArgs("--l2.blocknumber", "1000", "--network", "goerli", "--rollup.config", "rollup.json"))
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, verifyArgs("--l2.blocknumber", "1000"))
+		require.Equal(t, "http://localhost:7545", cfg.RollupURL)
+		require.Equal(t, "http://localhost:8545", cfg.L1URL)
+		require.Equal(t, "http://localhost:9545", cfg.L2URL)
+		require.EqualValues(t, 1000, cfg.L2ClaimBlockNumber)
+		require.Nil(t, cfg.Rollup, "rollup config should be loaded from the rollup node")
+		require.Nil(t, cfg.L2ChainConfig, "chain config should be loaded by chain ID")
+		require.True(t, cfg.IsCustomChainConfig)
+		require.Zero(t, cfg.L1Head)
+		require.Zero(t, cfg.L2Claim)
+	})
+
+	t.Run("Network", func(t *testing.T) {
+		cfg := configForArgs(t, verifyArgs("--l2.blocknumber", "1000", "--network", "goerli"))
+		require.Equal(t, *chaincfg.Goerli, *cfg.Rollup)
+		require.Equal(t, chaincfg.Goerli.L2ChainID, cfg.L2ChainConfig.ChainID)
+		require.False(t, cfg.IsCustomChainConfig)
+	})
+
+	t.Run("CustomNetwork", func(t *testing.T) {
+		genesisFile := writeValidGenesis(t)
+		rollupFile := writeValidRollupConfig(t)
+		cfg := configForArgs(t, verifyArgs("--l2.blocknumber", "1000", "--rollup.config", rollupFile, "--l2.genesis", genesisFile))
+		require.Equal(t, *chaincfg.Goerli, *cfg.Rollup)
+		require.Equal(t, l2GenesisConfig, cfg.L2ChainConfig)
+		require.True(t, cfg.IsCustomChainConfig)
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	t.Run("RequireSource", func(t *testing.T) {
+		verifyArgsInvalid(t, "Required flag \"source\" not set", []string{"migrate", "--dest", "/tmp/dest"})
+	})
+
+	t.Run("RequireDest", func(t *testing.T) {
+		verifyArgsInvalid(t, "Required flag \"dest\" not set", []string{"migrate", "--source", "/tmp/source"})
+	})
+}
+
 func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
 	_, _, err := runWithArgs(cliArgs)
 	require.ErrorContains(t, err, messageContains)
@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 
 	opnode "github.com/ethereum-optimism/optimism/op-node"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-program/host/bundle"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
@@ -30,19 +32,41 @@ var (
 	ErrInvalidL2ClaimBlock = errors.New("invalid l2 claim block number")
 	ErrDataDirRequired     = errors.New("datadir must be specified when in non-fetching mode")
 	ErrNoExecInServerMode  = errors.New("exec command must not be set when in server mode")
+	ErrBundleWithFetching  = errors.New("bundle must not be used with l1 and l2 options")
+	ErrExportNeedsFetching = errors.New("l1 and l2 options must be specified to export a bundle")
+	ErrNoExportServerMode  = errors.New("export must not be used in server mode")
+	ErrInvalidDataFormat   = errors.New("invalid data format")
+	ErrVerifyNeedsFetching = errors.New("l1 and l2 options must be specified to verify an output")
 )
 
+const (
+	// DataFormatDirectory stores each pre-image as a separate file in the data directory.
+	DataFormatDirectory = "directory"
+	// DataFormatPebble stores pre-images in a pebble database in the data directory.
+	DataFormatPebble = "pebble"
+)
+
+// SupportedDataFormats are the valid values of DataFormat.
+var SupportedDataFormats = []string{DataFormatDirectory, DataFormatPebble}
+
 type Config struct {
 	Rollup *rollup.Config
 	// DataDir is the directory to read/write pre-image data from/to.
 	//If not set, an in-memory key-value store is used and fetching data must be enabled
 	DataDir string
+	// DataFormat is the format used to store pre-images in DataDir.
+	DataFormat string
 
 	// L1Head is the block has of the L1 chain head block
 	L1Head     common.Hash
 	L1URL      string
 	L1TrustRPC bool
 	L1RPCKind  sources.RPCProviderKind
+	// L1BeaconURL is the L1 beacon API endpoint used to fetch blobs.
+	// Optional, but blobs can't be fetched if it is not set.
+	L1BeaconURL string
+	// L1Lookahead is the number of L1 blocks after those requested by the client to speculatively fetch.
+	L1Lookahead uint64
 
 	// L2Head is the l2 block hash contained in the L2 Output referenced by the L2OutputRoot
 	// TODO(inphi): This can be made optional with hardcoded rollup configs and output oracle addresses by searching the oracle for the l2 output root
@@ -67,6 +91,18 @@ type Config struct {
 
 	// IsCustomChainConfig indicates that the program uses a custom chain configuration
 	IsCustomChainConfig bool
+
+	// Bundle is the path of a bundle to load pre-images from, running the program offline.
+	// The boot inputs of the program are also loaded from the bundle.
+	Bundle string
+
+	// ExportPath is the path to write a bundle of the boot inputs and every pre-image used by the program to.
+	// Set by the export command.
+	ExportPath string
+
+	// RollupURL is the rollup node endpoint used to look up the boot inputs to verify the output at
+	// L2ClaimBlockNumber, instead of them being supplied. Set by the verify command.
+	RollupURL string
 }
 
 func (c *Config) Check() error {
@@ -94,12 +130,27 @@ func (c *Config) Check() error {
 	if (c.L1URL != "") != (c.L2URL != "") {
 		return ErrL1AndL2Inconsistent
 	}
-	if !c.FetchingEnabled() && c.DataDir == "" {
+	if !c.FetchingEnabled() && c.DataDir == "" && c.Bundle == "" {
 		return ErrDataDirRequired
 	}
+	if !slices.Contains(SupportedDataFormats, c.DataFormat) {
+		return fmt.Errorf("%w: %v", ErrInvalidDataFormat, c.DataFormat)
+	}
 	if c.ServerMode && c.ExecCmd != "" {
 		return ErrNoExecInServerMode
 	}
+	if c.Bundle != "" && c.FetchingEnabled() {
+		return ErrBundleWithFetching
+	}
+	if c.ExportPath != "" && !c.FetchingEnabled() {
+		return ErrExportNeedsFetching
+	}
+	if c.ExportPath != "" && c.ServerMode {
+		return ErrNoExportServerMode
+	}
+	if c.RollupURL != "" && !c.FetchingEnabled() {
+		return ErrVerifyNeedsFetching
+	}
 	return nil
 }
 
@@ -127,7 +178,9 @@ func NewConfig(
 		L2OutputRoot:        l2OutputRoot,
 		L2Claim:             l2Claim,
 		L2ClaimBlockNumber:  l2ClaimBlockNum,
+		DataFormat:          DataFormatDirectory,
 		L1RPCKind:           sources.RPCKindStandard,
+		L1Lookahead:         flags.L1Lookahead.Value,
 		IsCustomChainConfig: isCustomConfig,
 	}
 }
@@ -136,6 +189,9 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	if err := flags.CheckRequired(ctx); err != nil {
 		return nil, err
 	}
+	if ctx.IsSet(flags.Bundle.Name) {
+		return newConfigFromBundle(ctx)
+	}
 	rollupCfg, err := opnode.NewRollupConfig(log, ctx)
 	if err != nil {
 		return nil, err
@@ -165,16 +221,10 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	var l2ChainConfig *params.ChainConfig
 	var isCustomConfig bool
 	if l2GenesisPath == "" {
-		networkName := ctx.String(flags.Network.Name)
-		ch := chaincfg.ChainByName(networkName)
-		if ch == nil {
-			return nil, fmt.Errorf("flag %s is required for network %s", flags.L2GenesisPath.Name, networkName)
-		}
-		cfg, err := params.LoadOPStackChainConfig(ch.ChainID)
+		l2ChainConfig, err = loadNetworkChainConfig(ctx.String(flags.Network.Name))
 		if err != nil {
-			return nil, fmt.Errorf("failed to load chain config for chain %d: %w", ch.ChainID, err)
+			return nil, err
 		}
-		l2ChainConfig = cfg
 	} else {
 		l2ChainConfig, err = loadChainConfigFromGenesis(l2GenesisPath)
 		isCustomConfig = true
@@ -185,6 +235,7 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	return &Config{
 		Rollup:              rollupCfg,
 		DataDir:             ctx.String(flags.DataDir.Name),
+		DataFormat:          ctx.String(flags.DataFormat.Name),
 		L2URL:               ctx.String(flags.L2NodeAddr.Name),
 		L2ChainConfig:       l2ChainConfig,
 		L2Head:              l2Head,
@@ -195,12 +246,124 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 		L1URL:               ctx.String(flags.L1NodeAddr.Name),
 		L1TrustRPC:          ctx.Bool(flags.L1TrustRPC.Name),
 		L1RPCKind:           sources.RPCProviderKind(ctx.String(flags.L1RPCProviderKind.Name)),
+		L1BeaconURL:         ctx.String(flags.L1BeaconAddr.Name),
+		L1Lookahead:         ctx.Uint64(flags.L1Lookahead.Name),
 		ExecCmd:             ctx.String(flags.Exec.Name),
 		ServerMode:          ctx.Bool(flags.Server.Name),
 		IsCustomChainConfig: isCustomConfig,
 	}, nil
 }
 
+// NewVerifyConfigFromCLI creates a Config for the verify command. The boot inputs are looked up from the rollup node
+// before the program is run, as is the rollup config if neither a network or rollup config file is specified.
+func NewVerifyConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
+	if err := flags.CheckVerifyRequired(ctx); err != nil {
+		return nil, err
+	}
+	var rollupCfg *rollup.Config
+	if ctx.String(flags.Network.Name) != "" || ctx.String(flags.RollupConfig.Name) != "" {
+		cfg, err := opnode.NewRollupConfig(log, ctx)
+		if err != nil {
+			return nil, err
+		}
+		rollupCfg = cfg
+	}
+	// Without a network or genesis, the chain config is loaded by chain ID once the rollup config is known.
+	var l2ChainConfig *params.ChainConfig
+	networkName := ctx.String(flags.Network.Name)
+	if l2GenesisPath := ctx.String(flags.L2GenesisPath.Name); l2GenesisPath != "" {
+		cfg, err := loadChainConfigFromGenesis(l2GenesisPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid genesis: %w", err)
+		}
+		l2ChainConfig = cfg
+	} else if networkName != "" {
+		cfg, err := loadNetworkChainConfig(networkName)
+		if err != nil {
+			return nil, err
+		}
+		l2ChainConfig = cfg
+	}
+	return &Config{
+		Rollup:              rollupCfg,
+		DataDir:             ctx.String(flags.DataDir.Name),
+		DataFormat:          ctx.String(flags.DataFormat.Name),
+		L2URL:               ctx.String(flags.L2NodeAddr.Name),
+		L2ChainConfig:       l2ChainConfig,
+		L2ClaimBlockNumber:  ctx.Uint64(flags.L2BlockNumber.Name),
+		L1URL:               ctx.String(flags.L1NodeAddr.Name),
+		L1TrustRPC:          ctx.Bool(flags.L1TrustRPC.Name),
+		L1RPCKind:           sources.RPCProviderKind(ctx.String(flags.L1RPCProviderKind.Name)),
+		L1BeaconURL:         ctx.String(flags.L1BeaconAddr.Name),
+		L1Lookahead:         ctx.Uint64(flags.L1Lookahead.Name),
+		ExecCmd:             ctx.String(flags.Exec.Name),
+		IsCustomChainConfig: networkName == "",
+		RollupURL:           ctx.String(flags.RollupNodeAddr.Name),
+	}, nil
+}
+
+// newConfigFromBundle creates a Config using the boot inputs from the bundle specified on the CLI.
+func newConfigFromBundle(ctx *cli.Context) (*Config, error) {
+	bundlePath := ctx.String(flags.Bundle.Name)
+	boot, err := bundle.ReadBoot(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+	if boot.Rollup == nil {
+		return nil, fmt.Errorf("invalid bundle: %w", ErrMissingRollupConfig)
+	}
+	if boot.L2ChainConfig == nil {
+		return nil, fmt.Errorf("invalid bundle: %w", ErrMissingL2Genesis)
+	}
+	return &Config{
+		Rollup:              boot.Rollup,
+		DataDir:             ctx.String(flags.DataDir.Name),
+		DataFormat:          ctx.String(flags.DataFormat.Name),
+		L2URL:               ctx.String(flags.L2NodeAddr.Name),
+		L2ChainConfig:       boot.L2ChainConfig,
+		L2Head:              boot.L2Head,
+		L2OutputRoot:        boot.L2OutputRoot,
+		L2Claim:             boot.L2Claim,
+		L2ClaimBlockNumber:  boot.L2ClaimBlockNumber,
+		L1Head:              boot.L1Head,
+		L1URL:               ctx.String(flags.L1NodeAddr.Name),
+		L1TrustRPC:          ctx.Bool(flags.L1TrustRPC.Name),
+		L1RPCKind:           sources.RPCProviderKind(ctx.String(flags.L1RPCProviderKind.Name)),
+		L1BeaconURL:         ctx.String(flags.L1BeaconAddr.Name),
+		L1Lookahead:         ctx.Uint64(flags.L1Lookahead.Name),
+		ExecCmd:             ctx.String(flags.Exec.Name),
+		ServerMode:          ctx.Bool(flags.Server.Name),
+		IsCustomChainConfig: boot.IsCustomChainConfig,
+		Bundle:              bundlePath,
+	}, nil
+}
+
+// Boot returns the boot inputs of the program.
+func (c *Config) Boot() bundle.Boot {
+	return bundle.Boot{
+		L1Head:              c.L1Head,
+		L2Head:              c.L2Head,
+		L2OutputRoot:        c.L2OutputRoot,
+		L2Claim:             c.L2Claim,
+		L2ClaimBlockNumber:  c.L2ClaimBlockNumber,
+		L2ChainConfig:       c.L2ChainConfig,
+		Rollup:              c.Rollup,
+		IsCustomChainConfig: c.IsCustomChainConfig,
+	}
+}
+
+func loadNetworkChainConfig(networkName string) (*params.ChainConfig, error) {
+	ch := chaincfg.ChainByName(networkName)
+	if ch == nil {
+		return nil, fmt.Errorf("flag %s is required for network %s", flags.L2GenesisPath.Name, networkName)
+	}
+	cfg, err := params.LoadOPStackChainConfig(ch.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chain config for chain %d: %w", ch.ChainID, err)
+	}
+	return cfg, nil
+}
+
 func loadChainConfigFromGenesis(path string) (*params.ChainConfig, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -72,6 +72,33 @@ func TestL2ClaimMayBeDefaultValue(t *testing.T) {
 	require.NoError(t, config.Check())
 }
 
+func TestDataFormat(t *testing.T) {
+	for _, format := range SupportedDataFormats {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			config := validConfig()
+			config.DataFormat = format
+			require.NoError(t, config.Check())
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		config := validConfig()
+		config.DataFormat = "leveldb"
+		require.ErrorIs(t, config.Check(), ErrInvalidDataFormat)
+	})
+}
+
+func TestVerifyNeedsFetching(t *testing.T) {
+	config := validConfig()
+	config.RollupURL = "http://localhost:7545"
+	require.ErrorIs(t, config.Check(), ErrVerifyNeedsFetching)
+
+	config.L1URL = "http://localhost:8545"
+	config.L2URL = "http://localhost:9545"
+	require.NoError(t, config.Check())
+}
+
 func TestL2ClaimBlockNumberRequired(t *testing.T) {
 	config := validConfig()
 	config.L2ClaimBlockNumber = 0
@@ -160,6 +187,45 @@ func TestRejectExecAndServerMode(t *testing.T) {
 	require.ErrorIs(t, err, ErrNoExecInServerMode)
 }
 
+func TestBundle(t *testing.T) {
+	t.Run("NoDataDirRequired", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.DataDir = ""
+		cfg.Bundle = "bundle.tar.gz"
+		require.NoError(t, cfg.Check())
+	})
+	t.Run("RejectFetching", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Bundle = "bundle.tar.gz"
+		cfg.L1URL = "https://example.com:1234"
+		cfg.L2URL = "https://example.com:5678"
+		require.ErrorIs(t, cfg.Check(), ErrBundleWithFetching)
+	})
+}
+
+func TestExport(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.ExportPath = "bundle.tar.gz"
+		cfg.L1URL = "https://example.com:1234"
+		cfg.L2URL = "https://example.com:5678"
+		require.NoError(t, cfg.Check())
+	})
+	t.Run("RequireFetching", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.ExportPath = "bundle.tar.gz"
+		require.ErrorIs(t, cfg.Check(), ErrExportNeedsFetching)
+	})
+	t.Run("RejectServerMode", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.ExportPath = "bundle.tar.gz"
+		cfg.L1URL = "https://example.com:1234"
+		cfg.L2URL = "https://example.com:5678"
+		cfg.ServerMode = true
+		require.ErrorIs(t, cfg.Check(), ErrNoExportServerMode)
+	})
+}
+
 func TestIsCustomChainConfig(t *testing.T) {
 	t.Run("nonCustom", func(t *testing.T) {
 		cfg := validConfig()
@@ -35,6 +35,12 @@ var (
 		Usage:   "Directory to use for preimage data storage. Default uses in-memory storage",
 		EnvVars: prefixEnvVars("DATADIR"),
 	}
+	DataFormat = &cli.StringFlag{
+		Name:    "data.format",
+		Usage:   "Format to use for pre-image data storage in datadir. Available formats: directory, pebble",
+		EnvVars: prefixEnvVars("DATA_FORMAT"),
+		Value:   "directory",
+	}
 	L2NodeAddr = &cli.StringFlag{
 		Name:    "l2",
 		Usage:   "Address of L2 JSON-RPC endpoint to use (eth and debug namespace required)",
@@ -80,6 +86,11 @@ var (
 		Usage:   "Trust the L1 RPC, sync faster at risk of malicious/buggy RPC providing bad or inconsistent L1 data",
 		EnvVars: prefixEnvVars("L1_TRUST_RPC"),
 	}
+	L1BeaconAddr = &cli.StringFlag{
+		Name:    "l1.beacon",
+		Usage:   "Address of L1 Beacon API endpoint to use for fetching blobs",
+		EnvVars: prefixEnvVars("L1_BEACON_API"),
+	}
 	L1RPCProviderKind = &cli.GenericFlag{
 		Name: "l1.rpckind",
 		Usage: "The kind of RPC provider, used to inform optimal transactions receipts fetching, and thus reduce costs. Valid options: " +
@@ -90,6 +101,12 @@ var (
 			return &out
 		}(),
 	}
+	L1Lookahead = &cli.Uint64Flag{
+		Name:    "l1.lookahead",
+		Usage:   "Number of L1 blocks after those requested by the client program to speculatively fetch transactions and receipts for. 0 disables fetching ahead.",
+		EnvVars: prefixEnvVars("L1_LOOKAHEAD"),
+		Value:   16,
+	}
 	Exec = &cli.StringFlag{
 		Name:    "exec",
 		Usage:   "Run the specified client program as a separate process detached from the host. Default is to run the client program in the host process.",
@@ -100,11 +117,49 @@ var (
 		Usage:   "Run in pre-image server mode without executing any client program.",
 		EnvVars: prefixEnvVars("SERVER"),
 	}
+	Bundle = &cli.StringFlag{
+		Name:    "bundle",
+		Usage:   "Run offline using the boot inputs and pre-images from a bundle created with the export command.",
+		EnvVars: prefixEnvVars("BUNDLE"),
+	}
+	ExportOutput = &cli.StringFlag{
+		Name:     "output",
+		Usage:    "Path to write the exported pre-image bundle to.",
+		EnvVars:  prefixEnvVars("EXPORT_OUTPUT"),
+		Required: true,
+	}
+	RollupNodeAddr = &cli.StringFlag{
+		Name:     "rollup",
+		Usage:    "Address of rollup node JSON-RPC endpoint to look up the outputs to verify (optimism namespace required)",
+		EnvVars:  prefixEnvVars("ROLLUP_RPC"),
+		Required: true,
+	}
+	MigrateSource = &cli.StringFlag{
+		Name:     "source",
+		Usage:    "Data directory containing pre-images stored in the directory format to migrate.",
+		EnvVars:  prefixEnvVars("MIGRATE_SOURCE"),
+		Required: true,
+	}
+	MigrateDest = &cli.StringFlag{
+		Name:     "dest",
+		Usage:    "Data directory to write the migrated pre-images to, in the pebble format.",
+		EnvVars:  prefixEnvVars("MIGRATE_DEST"),
+		Required: true,
+	}
 )
 
 // Flags contains the list of configuration options available to the binary.
 var Flags []cli.Flag
 
+// ExportFlags contains the list of configuration options available to the export command.
+var ExportFlags []cli.Flag
+
+// VerifyFlags contains the list of configuration options available to the verify command.
+var VerifyFlags []cli.Flag
+
+// MigrateFlags contains the list of configuration options available to the migrate command.
+var MigrateFlags []cli.Flag
+
 var requiredFlags = []cli.Flag{
 	L1Head,
 	L2Head,
@@ -116,22 +171,64 @@ var programFlags = []cli.Flag{
 	RollupConfig,
 	Network,
 	DataDir,
+	DataFormat,
 	L2NodeAddr,
 	L2GenesisPath,
 	L1NodeAddr,
 	L1TrustRPC,
+	L1BeaconAddr,
 	L1RPCProviderKind,
+	L1Lookahead,
 	Exec,
 	Server,
+	Bundle,
+}
+
+// verifyFlags are the options of the verify command, which looks up the boot inputs from the rollup node.
+var verifyFlags = []cli.Flag{
+	RollupNodeAddr,
+	L2BlockNumber,
+	RollupConfig,
+	Network,
+	DataDir,
+	DataFormat,
+	L2NodeAddr,
+	L2GenesisPath,
+	L1NodeAddr,
+	L1TrustRPC,
+	L1BeaconAddr,
+	L1RPCProviderKind,
+	L1Lookahead,
+	Exec,
 }
 
+// bootFlags are the flags that provide the boot inputs of the program, which are read from the bundle instead.
+var bootFlags = append([]cli.Flag{RollupConfig, Network, L2GenesisPath}, requiredFlags...)
+
 func init() {
 	Flags = append(Flags, oplog.CLIFlags(EnvVarPrefix)...)
 	Flags = append(Flags, requiredFlags...)
 	Flags = append(Flags, programFlags...)
+
+	ExportFlags = append(ExportFlags, Flags...)
+	ExportFlags = append(ExportFlags, ExportOutput)
+
+	VerifyFlags = append(VerifyFlags, oplog.CLIFlags(EnvVarPrefix)...)
+	VerifyFlags = append(VerifyFlags, verifyFlags...)
+
+	MigrateFlags = append(MigrateFlags, oplog.CLIFlags(EnvVarPrefix)...)
+	MigrateFlags = append(MigrateFlags, MigrateSource, MigrateDest)
 }
 
 func CheckRequired(ctx *cli.Context) error {
+	if ctx.IsSet(Bundle.Name) {
+		for _, flag := range bootFlags {
+			if ctx.IsSet(flag.Names()[0]) {
+				return fmt.Errorf("flag %s cannot be used with %s", flag.Names()[0], Bundle.Name)
+			}
+		}
+		return nil
+	}
 	rollupConfig := ctx.String(RollupConfig.Name)
 	network := ctx.String(Network.Name)
 	if rollupConfig == "" && network == "" {
@@ -150,3 +247,15 @@ func CheckRequired(ctx *cli.Context) error {
 	}
 	return nil
 }
+
+func CheckVerifyRequired(ctx *cli.Context) error {
+	if ctx.String(RollupConfig.Name) != "" && ctx.String(Network.Name) != "" {
+		return fmt.Errorf("cannot specify both %s and %s", RollupConfig.Name, Network.Name)
+	}
+	for _, flag := range []cli.Flag{L2BlockNumber, L1NodeAddr, L2NodeAddr} {
+		if !ctx.IsSet(flag.Names()[0]) {
+			return fmt.Errorf("flag %s is required", flag.Names()[0])
+		}
+	}
+	return nil
+}
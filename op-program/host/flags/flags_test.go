@@ -8,10 +8,13 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+// allFlags are the flags of the main program and each command.
+var allFlags = append(append([]cli.Flag{}, ExportFlags...), RollupNodeAddr, MigrateSource, MigrateDest)
+
 // TestUniqueFlags asserts that all flag names are unique, to avoid accidental conflicts between the many flags.
 func TestUniqueFlags(t *testing.T) {
 	seenCLI := make(map[string]struct{})
-	for _, flag := range Flags {
+	for _, flag := range allFlags {
 		for _, name := range flag.Names() {
 			if _, ok := seenCLI[name]; ok {
 				t.Errorf("duplicate flag %s", name)
@@ -25,7 +28,7 @@ func TestUniqueFlags(t *testing.T) {
 // TestUniqueEnvVars asserts that all flag env vars are unique, to avoid accidental conflicts between the many flags.
 func TestUniqueEnvVars(t *testing.T) {
 	seenCLI := make(map[string]struct{})
-	for _, flag := range Flags {
+	for _, flag := range allFlags {
 		envVar := envVarForFlag(flag)
 		if _, ok := seenCLI[envVar]; envVar != "" && ok {
 			t.Errorf("duplicate flag env var %s", envVar)
@@ -36,7 +39,7 @@ func TestUniqueEnvVars(t *testing.T) {
 }
 
 func TestCorrectEnvVarPrefix(t *testing.T) {
-	for _, flag := range Flags {
+	for _, flag := range allFlags {
 		envVar := envVarForFlag(flag)
 		if envVar == "" {
 			t.Errorf("Failed to find EnvVar for flag %v", flag.Names()[0])
@@ -8,11 +8,13 @@ import (
 	"io/fs"
 	"os"
 	"os/exec"
+	"path/filepath"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	cl "github.com/ethereum-optimism/optimism/op-program/client"
 	"github.com/ethereum-optimism/optimism/op-program/client/driver"
+	"github.com/ethereum-optimism/optimism/op-program/host/bundle"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
@@ -31,20 +33,37 @@ type L2Source struct {
 }
 
 func Main(logger log.Logger, cfg *config.Config) error {
+	ctx := context.Background()
+	// Validate env vars against the flags of the command being run so options for other commands are reported.
+	envFlags := flags.Flags
+	if cfg.ExportPath != "" {
+		envFlags = flags.ExportFlags
+	}
+	if cfg.RollupURL != "" {
+		envFlags = flags.VerifyFlags
+		if err := LoadVerifyInputs(ctx, logger, cfg); err != nil {
+			return fmt.Errorf("failed to load inputs to verify: %w", err)
+		}
+	}
 	if err := cfg.Check(); err != nil {
 		return fmt.Errorf("invalid config: %w", err)
 	}
-	opservice.ValidateEnvVars(flags.EnvVarPrefix, flags.Flags, logger)
+	opservice.ValidateEnvVars(flags.EnvVarPrefix, envFlags, logger)
 	cfg.Rollup.LogDescription(logger, chaincfg.L2ChainIDToNetworkDisplayName)
 
-	ctx := context.Background()
 	if cfg.ServerMode {
 		preimageChan := cl.CreatePreimageChannel()
 		hinterChan := cl.CreateHinterChannel()
 		return PreimageServer(ctx, logger, cfg, preimageChan, hinterChan)
 	}
 
-	if err := FaultProofProgram(ctx, logger, cfg); errors.Is(err, driver.ErrClaimNotValid) {
+	var err error
+	if cfg.ExportPath != "" {
+		err = ExportBundle(ctx, logger, cfg)
+	} else {
+		err = FaultProofProgram(ctx, logger, cfg)
+	}
+	if errors.Is(err, driver.ErrClaimNotValid) {
 		log.Crit("Claim is invalid", "err", err)
 	} else if err != nil {
 		return err
@@ -56,6 +75,62 @@ func Main(logger log.Logger, cfg *config.Config) error {
 
 // FaultProofProgram is the programmatic entry-point for the fault proof program
 func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Config) error {
+	return faultProofProgram(ctx, logger, cfg, nil)
+}
+
+// ExportBundle runs the fault proof program, then writes the boot inputs and every pre-image served to the client
+// program to a bundle at cfg.ExportPath. The bundle is written whether or not the claim is valid so that either
+// result can be reproduced offline with the bundle flag. The result of the program is returned.
+func ExportBundle(ctx context.Context, logger log.Logger, cfg *config.Config) error {
+	recorder, err := bundle.NewRecorder()
+	if err != nil {
+		return fmt.Errorf("failed to create pre-image recorder: %w", err)
+	}
+	defer func() {
+		if err := recorder.Close(); err != nil {
+			logger.Error("Failed to close pre-image recorder", "err", err)
+		}
+	}()
+	err = faultProofProgram(ctx, logger, cfg, recorder)
+	var exitErr *exec.ExitError
+	if err != nil && !errors.Is(err, driver.ErrClaimNotValid) && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1) {
+		return fmt.Errorf("not exporting bundle, program failed: %w", err)
+	}
+	if err := recorder.WriteFile(cfg.ExportPath, cfg.Boot()); err != nil {
+		return fmt.Errorf("failed to export bundle: %w", err)
+	}
+	logger.Info("Exported bundle", "path", cfg.ExportPath, "preimages", recorder.Len())
+	return err
+}
+
+// MigrateDataDir copies every pre-image from a data directory in the directory format at source to a new data
+// directory in the pebble format at dest. The source directory is not modified.
+func MigrateDataDir(logger log.Logger, source string, dest string) error {
+	if filepath.Clean(source) == filepath.Clean(dest) {
+		return errors.New("migration source and destination must be different directories")
+	}
+	if _, err := os.Stat(source); err != nil {
+		return fmt.Errorf("invalid migration source: %w", err)
+	}
+	kv, err := kvstore.NewPebbleKV(dest)
+	if err != nil {
+		return fmt.Errorf("failed to open migration destination: %w", err)
+	}
+	logger.Info("Migrating pre-images", "source", source, "dest", dest)
+	copied, err := kvstore.MigrateDiskKV(source, kv, func(copied int) {
+		logger.Info("Migrating pre-images", "copied", copied)
+	})
+	if closeErr := kv.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close migration destination: %w", closeErr)
+	}
+	if err != nil {
+		return err
+	}
+	logger.Info("Migrated pre-images", "preimages", copied)
+	return nil
+}
+
+func faultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Config, recorder *bundle.Recorder) error {
 	var (
 		serverErr chan error
 		pClientRW oppio.FileChannel
@@ -92,7 +167,7 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 	serverErr = make(chan error)
 	go func() {
 		defer close(serverErr)
-		serverErr <- PreimageServer(ctx, logger, cfg, pHostRW, hHostRW)
+		serverErr <- preimageServer(ctx, logger, cfg, pHostRW, hHostRW, recorder)
 	}()
 
 	var cmd *exec.Cmd
@@ -125,8 +200,15 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 // If either returns an error both handlers are stopped.
 // The supplied preimageChannel and hintChannel will be closed before this function returns.
 func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel oppio.FileChannel, hintChannel oppio.FileChannel) error {
+	return preimageServer(ctx, logger, cfg, preimageChannel, hintChannel, nil)
+}
+
+// preimageServer runs the pre-image server, recording every pre-image served if recorder is not nil.
+func preimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel oppio.FileChannel, hintChannel oppio.FileChannel, recorder *bundle.Recorder) error {
 	var serverDone chan error
 	var hinterDone chan error
+	var kv kvstore.KV
+	var prefetch *prefetcher.Prefetcher
 	defer func() {
 		preimageChannel.Close()
 		hintChannel.Close()
@@ -138,18 +220,42 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 			// Wait for hinter to complete
 			<-hinterDone
 		}
+		if prefetch != nil {
+			// Stop speculative fetches before closing the store they write to
+			prefetch.Close()
+		}
+		if closer, ok := kv.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				logger.Error("Failed to close pre-image store", "err", err)
+			}
+		}
 	}()
 	logger.Info("Starting preimage server")
-	var kv kvstore.KV
-	if cfg.DataDir == "" {
+	if cfg.Bundle != "" {
+		logger.Info("Loading bundle", "bundle", cfg.Bundle)
+		b, err := bundle.Open(cfg.Bundle)
+		if err != nil {
+			return fmt.Errorf("failed to load bundle: %w", err)
+		}
+		kv = &bundleKV{b}
+	} else if cfg.DataDir == "" {
 		logger.Info("Using in-memory storage")
 		kv = kvstore.NewMemKV()
 	} else {
-		logger.Info("Creating disk storage", "datadir", cfg.DataDir)
+		logger.Info("Creating disk storage", "datadir", cfg.DataDir, "format", cfg.DataFormat)
 		if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
 			return fmt.Errorf("creating datadir: %w", err)
 		}
-		kv = kvstore.NewDiskKV(cfg.DataDir)
+		switch cfg.DataFormat {
+		case config.DataFormatPebble:
+			pebbleKV, err := kvstore.NewPebbleKV(cfg.DataDir)
+			if err != nil {
+				return err
+			}
+			kv = pebbleKV
+		default:
+			kv = kvstore.NewDiskKV(cfg.DataDir)
+		}
 	}
 
 	var (
@@ -157,7 +263,8 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 		hinter      preimage.HintHandler
 	)
 	if cfg.FetchingEnabled() {
-		prefetch, err := makePrefetcher(ctx, logger, kv, cfg)
+		var err error
+		prefetch, err = makePrefetcher(ctx, logger, kv, cfg)
 		if err != nil {
 			return fmt.Errorf("failed to create prefetcher: %w", err)
 		}
@@ -175,6 +282,9 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 	localPreimageSource := kvstore.NewLocalPreimageSource(cfg)
 	splitter := kvstore.NewPreimageSourceSplitter(localPreimageSource.Get, getPreimage)
 	preimageGetter := preimage.WithVerification(splitter.Get)
+	if recorder != nil {
+		preimageGetter = recorder.Wrap(preimageGetter)
+	}
 
 	serverDone = launchOracleServer(logger, preimageChannel, preimageGetter)
 	hinterDone = routeHints(logger, hintChannel, hinter)
@@ -186,6 +296,23 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 	}
 }
 
+// bundleKV serves the pre-images in a bundle as a read-only KV store.
+type bundleKV struct {
+	*bundle.Reader
+}
+
+func (b *bundleKV) Get(key common.Hash) ([]byte, error) {
+	data, err := b.Reader.Get(key)
+	if errors.Is(err, bundle.ErrPreimageNotFound) {
+		return nil, kvstore.ErrNotFound
+	}
+	return data, err
+}
+
+func (b *bundleKV) Put(common.Hash, []byte) error {
+	return errors.New("bundle pre-images are read-only")
+}
+
 func makePrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *config.Config) (*prefetcher.Prefetcher, error) {
 	logger.Info("Connecting to L1 node", "l1", cfg.L1URL)
 	l1RPC, err := client.NewRPC(ctx, logger, cfg.L1URL, client.WithDialBackoff(10))
@@ -209,8 +336,13 @@ func makePrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L2 client: %w", err)
 	}
+	var l1BlobCl prefetcher.L1BlobSource
+	if cfg.L1BeaconURL != "" {
+		logger.Info("Connecting to L1 beacon", "l1", cfg.L1BeaconURL)
+		l1BlobCl = sources.NewL1BeaconClient(client.NewBasicHTTPClient(cfg.L1BeaconURL, logger))
+	}
 	l2DebugCl := &L2Source{L2Client: l2Cl, DebugClient: sources.NewDebugClient(l2RPC.CallContext)}
-	return prefetcher.NewPrefetcher(logger, l1Cl, l2DebugCl, kv), nil
+	return prefetcher.NewPrefetcher(logger, l1Cl, l1BlobCl, l2DebugCl, kv, cfg.L1Lookahead, l1RPC, l2RPC), nil
 }
 
 func routeHints(logger log.Logger, hHostRW io.ReadWriter, hinter preimage.HintHandler) chan error {
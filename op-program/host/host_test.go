@@ -3,6 +3,7 @@ package host
 import (
 	"context"
 	"errors"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -11,11 +12,13 @@ import (
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum-optimism/optimism/op-program/client"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/host/bundle"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
 	"github.com/ethereum-optimism/optimism/op-program/io"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
@@ -55,6 +58,84 @@ func TestServerMode(t *testing.T) {
 	require.ErrorIs(t, waitFor(result), kvstore.ErrNotFound)
 }
 
+func TestBundleMode(t *testing.T) {
+	l1Head := common.Hash{0x11}
+	l2OutputRoot := common.Hash{0x33}
+	cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, l1Head, common.Hash{0x22}, l2OutputRoot, common.Hash{0x44}, 1000)
+	cfg.ServerMode = true
+	data := []byte{1, 2, 3}
+	key := preimage.Keccak256Key(crypto.Keccak256Hash(data))
+	b := &bundle.Bundle{Boot: cfg.Boot(), Preimages: map[common.Hash][]byte{key.PreimageKey(): data}}
+	cfg.Bundle = filepath.Join(t.TempDir(), "bundle.tar.gz")
+	require.NoError(t, b.WriteFile(cfg.Bundle))
+
+	pServer, preimageClient, err := io.CreateBidirectionalChannel()
+	require.NoError(t, err)
+	defer preimageClient.Close()
+	hServer, hintClient, err := io.CreateBidirectionalChannel()
+	require.NoError(t, err)
+	defer hintClient.Close()
+	recorder, err := bundle.NewRecorder()
+	require.NoError(t, err)
+	defer recorder.Close()
+	result := make(chan error)
+	go func() {
+		result <- preimageServer(context.Background(), testlog.Logger(t, log.LvlTrace), cfg, pServer, hServer, recorder)
+	}()
+
+	pClient := preimage.NewOracleClient(preimageClient)
+	require.Equal(t, l1Head.Bytes(), pClient.Get(client.L1HeadLocalIndex), "Should get l1 head preimages")
+	require.Equal(t, data, pClient.Get(key), "Should get preimage from bundle")
+	require.Panics(t, func() {
+		pClient.Get(preimage.Keccak256Key{0xaa})
+	}, "Preimage should not be available")
+	require.ErrorIs(t, waitFor(result), kvstore.ErrNotFound)
+
+	// Only the global pre-images served are recorded
+	recorded := filepath.Join(t.TempDir(), "recorded.tar.gz")
+	require.NoError(t, recorder.WriteFile(recorded, cfg.Boot()))
+	reader, err := bundle.Open(recorded)
+	require.NoError(t, err)
+	defer reader.Close()
+	require.Equal(t, 1, reader.Len())
+	actual, err := reader.Get(key.PreimageKey())
+	require.NoError(t, err)
+	require.Equal(t, data, actual)
+}
+
+func TestMigrateDataDir(t *testing.T) {
+	source := t.TempDir()
+	dest := filepath.Join(t.TempDir(), "pebble")
+	srcKV := kvstore.NewDiskKV(source)
+	preimages := map[common.Hash][]byte{
+		{0x02, 0xaa}: {1, 2, 3},
+		{0x02, 0xbb}: {4, 5, 6},
+	}
+	for key, data := range preimages {
+		require.NoError(t, srcKV.Put(key, data))
+	}
+
+	logger := testlog.Logger(t, log.LvlInfo)
+	require.NoError(t, MigrateDataDir(logger, source, dest))
+
+	destKV, err := kvstore.NewPebbleKV(dest)
+	require.NoError(t, err)
+	defer destKV.Close()
+	for key, data := range preimages {
+		actual, err := destKV.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, data, actual)
+	}
+
+	t.Run("SameDirectory", func(t *testing.T) {
+		require.ErrorContains(t, MigrateDataDir(logger, source, source+"/"), "must be different")
+	})
+
+	t.Run("MissingSource", func(t *testing.T) {
+		require.ErrorContains(t, MigrateDataDir(logger, filepath.Join(source, "missing"), t.TempDir()), "invalid migration source")
+	})
+}
+
 func waitFor(ch chan error) error {
 	timeout := time.After(30 * time.Second)
 	select {
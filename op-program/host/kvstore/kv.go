@@ -20,3 +20,26 @@ type KV interface {
 	// KV store implementations may return additional errors specific to the KV storage.
 	Get(k common.Hash) ([]byte, error)
 }
+
+// BatchKV is a KV store that can put multiple pre-images atomically.
+type BatchKV interface {
+	KV
+
+	// PutBatch puts all the pre-images in batch into the key-value store.
+	// Either all or none of the pre-images are stored.
+	PutBatch(batch map[common.Hash][]byte) error
+}
+
+// PutBatch puts all the pre-images in batch into kv.
+// The pre-images are stored atomically if kv implements BatchKV, otherwise they are put one at a time.
+func PutBatch(kv KV, batch map[common.Hash][]byte) error {
+	if b, ok := kv.(BatchKV); ok {
+		return b.PutBatch(batch)
+	}
+	for k, v := range batch {
+		if err := kv.Put(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
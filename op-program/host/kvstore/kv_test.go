@@ -51,3 +51,25 @@ func kvTest(t *testing.T, kv KV) {
 		require.NoError(t, kv.Put(common.Hash{0xdd}, []byte{4, 2}))
 	})
 }
+
+func TestPutBatch(t *testing.T) {
+	batch := map[common.Hash][]byte{
+		{0xaa}: {1, 2},
+		{0xbb}: {3},
+	}
+	t.Run("BatchKV", func(t *testing.T) {
+		kv := NewMemKV()
+		require.NoError(t, PutBatch(kv, batch))
+		require.Equal(t, batch, kv.m)
+	})
+
+	t.Run("Fallback", func(t *testing.T) {
+		kv := NewDiskKV(t.TempDir())
+		require.NoError(t, PutBatch(kv, batch))
+		for k, v := range batch {
+			actual, err := kv.Get(k)
+			require.NoError(t, err)
+			require.Equal(t, v, actual)
+		}
+	})
+}
@@ -14,7 +14,7 @@ type MemKV struct {
 	m map[common.Hash][]byte
 }
 
-var _ KV = (*MemKV)(nil)
+var _ BatchKV = (*MemKV)(nil)
 
 func NewMemKV() *MemKV {
 	return &MemKV{m: make(map[common.Hash][]byte)}
@@ -27,6 +27,15 @@ func (m *MemKV) Put(k common.Hash, v []byte) error {
 	return nil
 }
 
+func (m *MemKV) PutBatch(batch map[common.Hash][]byte) error {
+	m.Lock()
+	defer m.Unlock()
+	for k, v := range batch {
+		m.m[k] = v
+	}
+	return nil
+}
+
 func (m *MemKV) Get(k common.Hash) ([]byte, error) {
 	m.RLock()
 	defer m.RUnlock()
@@ -0,0 +1,85 @@
+package kvstore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// migrateBatchSize is the number of pre-images copied to the destination in each batch when migrating.
+const migrateBatchSize = 10_000
+
+// MigrateDiskKV copies every pre-image stored in the DiskKV directory srcPath to dest, returning the number of
+// pre-images copied. Files in the directory that are not pre-images, such as incomplete writes, are ignored.
+// progress, if not nil, is called with the total copied so far after each batch.
+func MigrateDiskKV(srcPath string, dest KV, progress func(copied int)) (int, error) {
+	src := NewDiskKV(srcPath)
+	dir, err := os.Open(srcPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open source directory: %w", err)
+	}
+	defer dir.Close()
+
+	copied := 0
+	batch := make(map[common.Hash][]byte, migrateBatchSize)
+	flush := func() error {
+		if err := PutBatch(dest, batch); err != nil {
+			return err
+		}
+		copied += len(batch)
+		clear(batch)
+		if progress != nil {
+			progress(copied)
+		}
+		return nil
+	}
+	for {
+		// Read the directory incrementally as it may contain millions of files
+		entries, err := dir.ReadDir(migrateBatchSize)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return copied, fmt.Errorf("failed to read source directory: %w", err)
+		}
+		for _, entry := range entries {
+			key, ok := diskKVKey(entry.Name())
+			if !ok || !entry.Type().IsRegular() {
+				continue
+			}
+			value, err := src.Get(key)
+			if err != nil {
+				return copied, fmt.Errorf("failed to read pre-image %s: %w", key, err)
+			}
+			batch[key] = value
+			if len(batch) >= migrateBatchSize {
+				if err := flush(); err != nil {
+					return copied, err
+				}
+			}
+		}
+	}
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return copied, err
+		}
+	}
+	return copied, nil
+}
+
+// diskKVKey returns the pre-image key of a DiskKV file name and true, or false if the file isn't a pre-image.
+func diskKVKey(name string) (common.Hash, bool) {
+	hex, ok := strings.CutSuffix(name, ".txt")
+	if !ok {
+		return common.Hash{}, false
+	}
+	key, err := hexutil.Decode(hex)
+	if err != nil || len(key) != common.HashLength {
+		return common.Hash{}, false
+	}
+	return common.Hash(key), true
+}
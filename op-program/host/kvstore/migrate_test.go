@@ -0,0 +1,42 @@
+package kvstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateDiskKV(t *testing.T) {
+	src := t.TempDir()
+	disk := NewDiskKV(src)
+	expected := make(map[common.Hash][]byte)
+	for i := 0; i < migrateBatchSize+5; i++ {
+		value := []byte{byte(i), byte(i >> 8)}
+		key := crypto.Keccak256Hash(value)
+		require.NoError(t, disk.Put(key, value))
+		expected[key] = value
+	}
+	require.NoError(t, disk.Put(common.Hash{0xaa}, []byte{}))
+	expected[common.Hash{0xaa}] = []byte{}
+	// Files that aren't pre-images are skipped
+	require.NoError(t, os.WriteFile(filepath.Join(src, common.Hash{0xbb}.String()+".txt.123"), []byte("01"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "other.txt"), []byte("01"), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(src, common.Hash{0xcc}.String()+".txt"), 0755))
+
+	dest := NewMemKV()
+	var progress []int
+	copied, err := MigrateDiskKV(src, dest, func(c int) { progress = append(progress, c) })
+	require.NoError(t, err)
+	require.Equal(t, len(expected), copied)
+	require.Equal(t, copied, progress[len(progress)-1])
+	require.Equal(t, expected, dest.m)
+}
+
+func TestMigrateDiskKVMissingSource(t *testing.T) {
+	_, err := MigrateDiskKV(filepath.Join(t.TempDir(), "missing"), NewMemKV(), nil)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,69 @@
+package kvstore
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Pre-images can always be fetched again so writes are not synced to disk. The write-ahead log still ensures
+// the database is consistent and batches are atomic after a crash, but the most recent writes may be lost.
+var pebbleWriteOpts = pebble.NoSync
+
+// PebbleKV is a disk-backed key-value store, with every key-value pair stored in a pebble database.
+// Unlike DiskKV it does not create a file per pre-image, so is suitable for programs that require millions of
+// pre-images. PebbleKV is safe for concurrent use but the database may only be opened by one PebbleKV at a time.
+type PebbleKV struct {
+	db *pebble.DB
+}
+
+// NewPebbleKV opens the pebble database in the given directory path, creating it if it doesn't exist.
+// The PebbleKV must be closed when no longer required.
+func NewPebbleKV(path string) (*PebbleKV, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pebble database %v: %w", path, err)
+	}
+	return &PebbleKV{db: db}, nil
+}
+
+func (d *PebbleKV) Put(k common.Hash, v []byte) error {
+	if err := d.db.Set(k.Bytes(), v, pebbleWriteOpts); err != nil {
+		return fmt.Errorf("failed to write pre-image %s: %w", k, err)
+	}
+	return nil
+}
+
+func (d *PebbleKV) PutBatch(batch map[common.Hash][]byte) error {
+	b := d.db.NewBatch()
+	defer b.Close()
+	for k, v := range batch {
+		if err := b.Set(k.Bytes(), v, nil); err != nil {
+			return fmt.Errorf("failed to add pre-image %s to batch: %w", k, err)
+		}
+	}
+	if err := b.Commit(pebbleWriteOpts); err != nil {
+		return fmt.Errorf("failed to write batch of %d pre-images: %w", len(batch), err)
+	}
+	return nil
+}
+
+func (d *PebbleKV) Get(k common.Hash) ([]byte, error) {
+	dat, closer, err := d.db.Get(k.Bytes())
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read pre-image %s: %w", k, err)
+	}
+	defer closer.Close()
+	// The returned slice is only valid until closer is closed
+	return common.CopyBytes(dat), nil
+}
+
+func (d *PebbleKV) Close() error {
+	return d.db.Close()
+}
+
+var _ BatchKV = (*PebbleKV)(nil)
@@ -0,0 +1,39 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPebbleKV(t *testing.T) {
+	tmp := t.TempDir() // automatically removed by testing cleanup
+	kv, err := NewPebbleKV(tmp)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, kv.Close())
+	})
+	kvTest(t, kv)
+}
+
+func TestPebbleKVPersistent(t *testing.T) {
+	tmp := t.TempDir()
+	kv, err := NewPebbleKV(tmp)
+	require.NoError(t, err)
+	require.NoError(t, kv.Put(common.Hash{0xaa}, []byte{1, 2, 3}))
+	require.NoError(t, kv.PutBatch(map[common.Hash][]byte{
+		{0xbb}: {4, 5},
+		{0xcc}: {6},
+	}))
+	require.NoError(t, kv.Close())
+
+	kv, err = NewPebbleKV(tmp)
+	require.NoError(t, err)
+	defer kv.Close()
+	for k, expected := range map[common.Hash][]byte{{0xaa}: {1, 2, 3}, {0xbb}: {4, 5}, {0xcc}: {6}} {
+		actual, err := kv.Get(k)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+}
@@ -0,0 +1,196 @@
+package prefetcher
+
+import (
+	"context"
+	"fmt"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// BatchCaller sends multiple JSON-RPC requests in a single batch.
+type BatchCaller interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+// rpcBlockTxs is the part of an eth_getBlockByHash response, with full transactions, that is used by the prefetcher.
+type rpcBlockTxs struct {
+	Transactions types.Transactions `json:"transactions"`
+}
+
+// fetchL1Blocks fetches the pre-images for speculative L1 transactions and receipts hints. A single batch request is
+// used when an L1 batch client is available. The fetched data is checked against the roots in the block headers,
+// which have already been stored when the hints are created.
+func (p *Prefetcher) fetchL1Blocks(ctx context.Context, hints []string) []error {
+	errs := make([]error, len(hints))
+	hintTypes := make([]string, len(hints))
+	hashes := make([]common.Hash, len(hints))
+	for i, hint := range hints {
+		hintType, hintData, err := parseHint(hint)
+		if err == nil {
+			hashes[i], err = parseHash(hintData)
+		}
+		hintTypes[i], errs[i] = hintType, err
+	}
+	if p.l1Batch == nil {
+		for i := range hints {
+			if errs[i] != nil {
+				continue
+			}
+			switch hintTypes[i] {
+			case l1.HintL1Transactions:
+				errs[i] = p.prefetchL1Transactions(ctx, p.l1Source, hashes[i])
+			case l1.HintL1Receipts:
+				errs[i] = p.prefetchL1Receipts(ctx, p.l1Source, hashes[i])
+			}
+		}
+		return errs
+	}
+
+	p.logger.Debug("Prefetching L1 blocks", "hints", len(hints))
+	var elems []rpc.BatchElem
+	var elemHints []int
+	for i := range hints {
+		if errs[i] != nil {
+			continue
+		}
+		switch hintTypes[i] {
+		case l1.HintL1Transactions:
+			elems = append(elems, rpc.BatchElem{Method: "eth_getBlockByHash", Args: []any{hashes[i], true}, Result: new(rpcBlockTxs)})
+		case l1.HintL1Receipts:
+			elems = append(elems, rpc.BatchElem{Method: "eth_getBlockReceipts", Args: []any{hashes[i]}, Result: new(types.Receipts)})
+		default:
+			errs[i] = fmt.Errorf("unexpected L1 block hint: %s", hints[i])
+			continue
+		}
+		elemHints = append(elemHints, i)
+	}
+	if len(elems) == 0 {
+		return errs
+	}
+	if err := p.l1Batch.BatchCallContext(ctx, elems); err != nil {
+		for _, i := range elemHints {
+			errs[i] = fmt.Errorf("failed to fetch L1 blocks: %w", err)
+		}
+		return errs
+	}
+	for j, elem := range elems {
+		i := elemHints[j]
+		switch result := elem.Result.(type) {
+		case *rpcBlockTxs:
+			errs[i] = p.storeL1Trie(hashes[i], "txs", elem.Error, func(header *types.Header) (common.Hash, []hexutil.Bytes, error) {
+				values, err := eth.EncodeTransactions(result.Transactions)
+				return header.TxHash, values, err
+			})
+		case *types.Receipts:
+			if elem.Error != nil {
+				// Not all nodes support eth_getBlockReceipts so fall back to the configured receipts fetching method.
+				errs[i] = p.prefetchL1Receipts(ctx, p.l1Source, hashes[i])
+				continue
+			}
+			errs[i] = p.storeL1Trie(hashes[i], "receipts", nil, func(header *types.Header) (common.Hash, []hexutil.Bytes, error) {
+				values, err := eth.EncodeReceipts(*result)
+				return header.ReceiptHash, values, err
+			})
+		}
+	}
+	return errs
+}
+
+// storeL1Trie stores the trie nodes of the values returned by encode if they match the root it returns from the
+// header of the L1 block with the given hash.
+func (p *Prefetcher) storeL1Trie(hash common.Hash, name string, fetchErr error, encode func(header *types.Header) (common.Hash, []hexutil.Bytes, error)) error {
+	if fetchErr != nil {
+		return fmt.Errorf("failed to fetch L1 block %s %s: %w", hash, name, fetchErr)
+	}
+	data, err := p.kvStore.Get(preimage.Keccak256Key(hash).PreimageKey())
+	if err != nil {
+		return fmt.Errorf("failed to load L1 block %s header: %w", hash, err)
+	}
+	var header types.Header
+	if err := rlp.DecodeBytes(data, &header); err != nil {
+		return fmt.Errorf("failed to decode L1 block %s header: %w", hash, err)
+	}
+	expected, values, err := encode(&header)
+	if err != nil {
+		return fmt.Errorf("failed to encode L1 block %s %s: %w", hash, name, err)
+	}
+	root, nodes := mpt.WriteTrie(values)
+	if root != expected {
+		return fmt.Errorf("L1 block %s %s root mismatch: expected %s but got %s", hash, name, expected, root)
+	}
+	return p.storeNodes(nodes)
+}
+
+// fetchAccountProofs fetches the proofs for account proof hints. A single batch request is used when an L2 batch
+// client is available.
+func (p *Prefetcher) fetchAccountProofs(ctx context.Context, hints []string) []error {
+	errs := make([]error, len(hints))
+	if p.l2Batch == nil {
+		for i, hint := range hints {
+			errs[i] = p.prefetchAccountProof(ctx, p.l2Source, hint)
+		}
+		return errs
+	}
+
+	p.logger.Debug("Prefetching account proofs", "hints", len(hints))
+	var elems []rpc.BatchElem
+	var elemHints []int
+	storageKeys := make([][]common.Hash, len(hints))
+	for i, hint := range hints {
+		blockHash, address, keys, err := parseAccountProofHint(hint)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		storageKeys[i] = keys
+		elems = append(elems, rpc.BatchElem{Method: "eth_getProof", Args: []any{address, keys, blockHash.String()}, Result: new(*eth.AccountResult)})
+		elemHints = append(elemHints, i)
+	}
+	if len(elems) == 0 {
+		return errs
+	}
+	if err := p.l2Batch.BatchCallContext(ctx, elems); err != nil {
+		for _, i := range elemHints {
+			errs[i] = fmt.Errorf("failed to fetch account proofs: %w", err)
+		}
+		return errs
+	}
+	for j, elem := range elems {
+		i := elemHints[j]
+		result := *elem.Result.(**eth.AccountResult)
+		err := elem.Error
+		if err == nil && result == nil {
+			err = ethereum.NotFound
+		} else if err == nil {
+			err = checkStorageProof(result, storageKeys[i])
+		}
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to fetch account proof for hint %s: %w", hints[i], err)
+			continue
+		}
+		errs[i] = p.storeAccountProof(result)
+	}
+	return errs
+}
+
+// checkStorageProof checks that result contains a proof for each of the requested storage keys.
+func checkStorageProof(result *eth.AccountResult, storageKeys []common.Hash) error {
+	if len(result.StorageProof) != len(storageKeys) {
+		return fmt.Errorf("missing storage proof data, got %d proof entries but requested %d storage keys", len(result.StorageProof), len(storageKeys))
+	}
+	for i, key := range storageKeys {
+		if key != result.StorageProof[i].Key {
+			return fmt.Errorf("unexpected storage proof key difference for entry %d: got %s but requested %s", i, result.StorageProof[i].Key, key)
+		}
+	}
+	return nil
+}
@@ -2,9 +2,12 @@ package prefetcher
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
@@ -15,55 +18,174 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 )
 
+var ErrNoL1BlobSource = errors.New("no L1 beacon source configured")
+
 type L1Source interface {
 	InfoByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, error)
 	InfoAndTxsByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Transactions, error)
 	FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error)
 }
 
+type L1BlobSource interface {
+	GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error)
+}
+
 type L2Source interface {
 	InfoAndTxsByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Transactions, error)
 	NodeByHash(ctx context.Context, hash common.Hash) ([]byte, error)
 	CodeByHash(ctx context.Context, hash common.Hash) ([]byte, error)
 	OutputByRoot(ctx context.Context, root common.Hash) (eth.Output, error)
+	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
+}
+
+// maxSpeculativeFetches limits the number of speculative fetches that are run concurrently.
+const maxSpeculativeFetches = 16
+
+// speculativeTimeout limits the time spent on a single speculative fetch.
+const speculativeTimeout = 30 * time.Second
+
+// maxAccountProofBatch limits the number of account proof hints that are queued before they are fetched.
+const maxAccountProofBatch = 32
+
+// fetch tracks an in-progress fetch of the pre-images for a hint.
+type fetch struct {
+	done chan struct{}
+	err  error
 }
 
+// Prefetcher retrieves the pre-images requested by the client program.
+// Pre-images for each hint are fetched when first requested. Hints for account proofs, and the L1 blocks following
+// those the client is processing, are also fetched speculatively in the background so that they are likely to be
+// available before they are requested. Account proof hints are queued until a pre-image is missing or the queue is
+// full, and are then fetched together with the lookahead L1 blocks using batch requests when a batch client is
+// available. Prefetcher is safe for concurrent use and concurrent requests for the same hint share a single fetch.
+// Close must be called to stop speculative fetches.
 type Prefetcher struct {
-	logger    log.Logger
-	l1Fetcher L1Source
-	l2Fetcher L2Source
-	lastHint  string
-	kvStore   kvstore.KV
+	logger        log.Logger
+	l1Fetcher     L1Source
+	l1BlobFetcher L1BlobSource
+	l2Fetcher     L2Source
+	kvStore       kvstore.KV
+
+	// Speculative fetches don't retry as the data may not be required. The normal fetch is used if it fails.
+	l1Source    L1Source
+	l2Source    L2Source
+	l1Batch     BatchCaller
+	l2Batch     BatchCaller
+	l1Lookahead uint64
+	speculative chan struct{}
+	ctx         context.Context
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+
+	lock          sync.Mutex
+	lastHint      string
+	fetches       map[string]*fetch
+	speculated    map[string]bool
+	pendingProofs []string
+	// l1Blocks records the hash of fetched L1 block headers by number, and l1BlockNumbers the reverse, to find the
+	// blocks to fetch ahead.
+	l1Blocks       map[uint64]common.Hash
+	l1BlockNumbers map[common.Hash]uint64
 }
 
-func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
+// NewPrefetcher creates a Prefetcher. l1BlobFetcher may be nil, in which case blobs cannot be fetched.
+// l1Lookahead is the number of L1 blocks after those requested by the client to fetch speculatively.
+// l1Batch and l2Batch are used to batch speculative requests to the L1 and L2 nodes. They may be nil, in which case a
+// separate request is made for each block or account.
+func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, l2Fetcher L2Source, kvStore kvstore.KV, l1Lookahead uint64, l1Batch BatchCaller, l2Batch BatchCaller) *Prefetcher {
+	var blobFetcher L1BlobSource
+	if l1BlobFetcher != nil {
+		blobFetcher = NewRetryingL1BlobSource(logger, l1BlobFetcher)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Prefetcher{
-		logger:    logger,
-		l1Fetcher: NewRetryingL1Source(logger, l1Fetcher),
-		l2Fetcher: NewRetryingL2Source(logger, l2Fetcher),
-		kvStore:   kvStore,
+		logger:         logger,
+		l1Fetcher:      NewRetryingL1Source(logger, l1Fetcher),
+		l1BlobFetcher:  blobFetcher,
+		l2Fetcher:      NewRetryingL2Source(logger, l2Fetcher),
+		kvStore:        kvStore,
+		l1Source:       l1Fetcher,
+		l2Source:       l2Fetcher,
+		l1Batch:        l1Batch,
+		l2Batch:        l2Batch,
+		l1Lookahead:    l1Lookahead,
+		speculative:    make(chan struct{}, maxSpeculativeFetches),
+		ctx:            ctx,
+		cancel:         cancel,
+		fetches:        make(map[string]*fetch),
+		speculated:     make(map[string]bool),
+		l1Blocks:       make(map[uint64]common.Hash),
+		l1BlockNumbers: make(map[common.Hash]uint64),
 	}
 }
 
+// Close cancels any in-progress speculative fetches and waits for them to stop. No further speculative fetches are
+// started once the Prefetcher is closed.
+func (p *Prefetcher) Close() {
+	p.lock.Lock()
+	p.cancel()
+	p.lock.Unlock()
+	p.wg.Wait()
+}
+
 func (p *Prefetcher) Hint(hint string) error {
 	p.logger.Trace("Received hint", "hint", hint)
+	p.lock.Lock()
 	p.lastHint = hint
+	// Account proofs are only hints of what will be read, the trie nodes are requested separately.
+	// Queue them to be fetched together before the nodes are needed.
+	var proofs []string
+	if strings.HasPrefix(hint, l2.HintL2AccountProof+" ") {
+		p.pendingProofs = append(p.pendingProofs, hint)
+		if len(p.pendingProofs) >= maxAccountProofBatch {
+			proofs, p.pendingProofs = p.pendingProofs, nil
+		}
+	}
+	p.lock.Unlock()
+	if len(proofs) > 0 {
+		p.speculate(proofs, p.fetchAccountProofs)
+	}
+	// Move the L1 lookahead forward as the client processes each block, even if its data was already fetched.
+	if hintData, ok := strings.CutPrefix(hint, l1.HintL1Transactions+" "); ok {
+		if hash, err := parseHash(hintData); err == nil {
+			p.speculateL1Blocks(hash)
+		}
+	}
 	return nil
 }
 
 func (p *Prefetcher) GetPreimage(ctx context.Context, key common.Hash) ([]byte, error) {
 	p.logger.Trace("Pre-image requested", "key", key)
 	pre, err := p.kvStore.Get(key)
+	if errors.Is(err, kvstore.ErrNotFound) {
+		// The key may be included in a queued account proof or one that is still being fetched.
+		p.lock.Lock()
+		proofs := p.pendingProofs
+		p.pendingProofs = nil
+		p.lock.Unlock()
+		p.speculate(proofs, p.fetchAccountProofs)
+		if err := p.waitForAccountProofs(ctx); err != nil {
+			return nil, err
+		}
+		pre, err = p.kvStore.Get(key)
+	}
 	// Use a loop to keep retrying the prefetch as long as the key is not found
 	// This handles the case where the prefetch downloads a preimage, but it is then deleted unexpectedly
 	// before we get to read it.
-	for errors.Is(err, kvstore.ErrNotFound) && p.lastHint != "" {
-		hint := p.lastHint
-		if err := p.prefetch(ctx, hint); err != nil {
+	for errors.Is(err, kvstore.ErrNotFound) {
+		hint := p.currentHint()
+		if hint == "" {
+			break
+		}
+		if err := p.fetch(ctx, hint); err != nil {
 			return nil, fmt.Errorf("prefetch failed: %w", err)
 		}
 		pre, err = p.kvStore.Get(key)
@@ -74,8 +196,136 @@ func (p *Prefetcher) GetPreimage(ctx context.Context, key common.Hash) ([]byte,
 	return pre, err
 }
 
+func (p *Prefetcher) currentHint() string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.lastHint
+}
+
+// fetch prefetches the pre-images for hint, or waits for an in-progress fetch of the same hint to complete.
+func (p *Prefetcher) fetch(ctx context.Context, hint string) error {
+	for {
+		p.lock.Lock()
+		f, ok := p.fetches[hint]
+		if !ok {
+			f = &fetch{done: make(chan struct{})}
+			p.fetches[hint] = f
+			p.lock.Unlock()
+			f.err = p.prefetch(ctx, hint)
+			p.complete(hint, f)
+			return f.err
+		}
+		p.lock.Unlock()
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if f.err == nil {
+			return nil
+		}
+		// The other fetch failed, which is expected for speculative fetches or if its caller gave up.
+		// Try again to give this request its own chance to succeed.
+	}
+}
+
+// speculate runs fn in the background to fetch the pre-images for hints, skipping any that are already in progress or
+// have previously been fetched speculatively. fn is called with the remaining hints and returns the error for each of
+// them. Requests for the same hints wait for the speculative fetch to complete.
+func (p *Prefetcher) speculate(hints []string, fn func(ctx context.Context, hints []string) []error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.ctx.Err() != nil {
+		return
+	}
+	var pending []string
+	var fetches []*fetch
+	for _, hint := range hints {
+		if _, ok := p.fetches[hint]; ok || p.speculated[hint] {
+			continue
+		}
+		p.speculated[hint] = true
+		f := &fetch{done: make(chan struct{})}
+		p.fetches[hint] = f
+		pending = append(pending, hint)
+		fetches = append(fetches, f)
+	}
+	if len(pending) == 0 {
+		return
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		errs := p.runSpeculative(pending, fn)
+		for i, f := range fetches {
+			f.err = errs[i]
+			if f.err != nil {
+				p.logger.Debug("Speculative fetch failed", "hint", pending[i], "err", f.err)
+			}
+			p.complete(pending[i], f)
+		}
+	}()
+}
+
+// runSpeculative calls fn once one of the limited speculative fetch slots is available, unless the Prefetcher is
+// closed first.
+func (p *Prefetcher) runSpeculative(hints []string, fn func(ctx context.Context, hints []string) []error) []error {
+	select {
+	case p.speculative <- struct{}{}:
+		defer func() { <-p.speculative }()
+	case <-p.ctx.Done():
+		errs := make([]error, len(hints))
+		for i := range errs {
+			errs[i] = p.ctx.Err()
+		}
+		return errs
+	}
+	ctx, cancel := context.WithTimeout(p.ctx, speculativeTimeout)
+	defer cancel()
+	return fn(ctx, hints)
+}
+
+func (p *Prefetcher) complete(hint string, f *fetch) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.fetches, hint)
+	close(f.done)
+}
+
+// waitForAccountProofs waits for all in-progress account proof fetches to complete.
+func (p *Prefetcher) waitForAccountProofs(ctx context.Context) error {
+	p.lock.Lock()
+	var pending []*fetch
+	for hint, f := range p.fetches {
+		if strings.HasPrefix(hint, l2.HintL2AccountProof+" ") {
+			pending = append(pending, f)
+		}
+	}
+	p.lock.Unlock()
+	for _, f := range pending {
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
 func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
-	hintType, hash, err := parseHint(hint)
+	hintType, hintData, err := parseHint(hint)
+	if err != nil {
+		return err
+	}
+	switch hintType {
+	case l1.HintL1Blob:
+		return p.prefetchBlob(ctx, hintData)
+	case l1.HintL1KZGPointEvaluation:
+		return p.prefetchKZGPointEvaluation(hintData)
+	case l2.HintL2AccountProof:
+		return p.prefetchAccountProof(ctx, p.l2Fetcher, hint)
+	}
+	hash, err := parseHash(hintData)
 	if err != nil {
 		return err
 	}
@@ -86,23 +336,15 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		if err != nil {
 			return fmt.Errorf("failed to fetch L1 block %s header: %w", hash, err)
 		}
-		data, err := header.HeaderRLP()
-		if err != nil {
-			return fmt.Errorf("marshall header: %w", err)
-		}
-		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), data)
+		return p.storeL1Header(header)
 	case l1.HintL1Transactions:
-		_, txs, err := p.l1Fetcher.InfoAndTxsByHash(ctx, hash)
-		if err != nil {
-			return fmt.Errorf("failed to fetch L1 block %s txs: %w", hash, err)
+		if err := p.prefetchL1Transactions(ctx, p.l1Fetcher, hash); err != nil {
+			return err
 		}
-		return p.storeTransactions(txs)
+		p.speculateL1Blocks(hash)
+		return nil
 	case l1.HintL1Receipts:
-		_, receipts, err := p.l1Fetcher.FetchReceipts(ctx, hash)
-		if err != nil {
-			return fmt.Errorf("failed to fetch L1 block %s receipts: %w", hash, err)
-		}
-		return p.storeReceipts(receipts)
+		return p.prefetchL1Receipts(ctx, p.l1Fetcher, hash)
 	case l2.HintL2BlockHeader, l2.HintL2Transactions:
 		header, txs, err := p.l2Fetcher.InfoAndTxsByHash(ctx, hash)
 		if err != nil {
@@ -139,6 +381,150 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 	return fmt.Errorf("unknown hint type: %v", hintType)
 }
 
+func (p *Prefetcher) storeL1Header(header eth.BlockInfo) error {
+	data, err := header.HeaderRLP()
+	if err != nil {
+		return fmt.Errorf("marshall header: %w", err)
+	}
+	if err := p.kvStore.Put(preimage.Keccak256Key(header.Hash()).PreimageKey(), data); err != nil {
+		return err
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.l1Blocks[header.NumberU64()] = header.Hash()
+	p.l1BlockNumbers[header.Hash()] = header.NumberU64()
+	return nil
+}
+
+func (p *Prefetcher) prefetchL1Transactions(ctx context.Context, source L1Source, hash common.Hash) error {
+	header, txs, err := source.InfoAndTxsByHash(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 block %s txs: %w", hash, err)
+	}
+	if err := p.storeL1Header(header); err != nil {
+		return err
+	}
+	return p.storeTransactions(txs)
+}
+
+func (p *Prefetcher) prefetchL1Receipts(ctx context.Context, source L1Source, hash common.Hash) error {
+	_, receipts, err := source.FetchReceipts(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 block %s receipts: %w", hash, err)
+	}
+	return p.storeReceipts(receipts)
+}
+
+// speculateL1Blocks starts fetching the transactions and receipts of the L1 blocks following the block with the
+// given hash. The client walks back from the L1 head to find its starting point before processing blocks in order,
+// so the hashes of the following blocks are usually known from the headers it has already requested.
+// The lookahead is only refilled once less than half of it remains, so that blocks are fetched in larger batches.
+func (p *Prefetcher) speculateL1Blocks(hash common.Hash) {
+	p.lock.Lock()
+	number, found := p.l1BlockNumbers[hash]
+	if refill, ok := p.l1Blocks[number+(p.l1Lookahead+1)/2]; ok && p.speculated[l1.TransactionsHint(refill).Hint()] {
+		found = false
+	}
+	var hints []string
+	for i := uint64(1); found && i <= p.l1Lookahead; i++ {
+		h, ok := p.l1Blocks[number+i]
+		if !ok {
+			break
+		}
+		hints = append(hints, l1.TransactionsHint(h).Hint(), l1.ReceiptsHint(h).Hint())
+	}
+	p.lock.Unlock()
+	if len(hints) > 0 {
+		p.speculate(hints, p.fetchL1Blocks)
+	}
+}
+
+// prefetchAccountProof fetches the proof of the account and storage slots in an account proof hint and stores the
+// trie nodes it contains. This retrieves all nodes along the path to the account and slots with a single request.
+func (p *Prefetcher) prefetchAccountProof(ctx context.Context, source L2Source, hint string) error {
+	blockHash, address, storageKeys, err := parseAccountProofHint(hint)
+	if err != nil {
+		return err
+	}
+	p.logger.Debug("Prefetching", "type", l2.HintL2AccountProof, "block", blockHash, "address", address, "storage", storageKeys)
+	result, err := source.GetProof(ctx, address, storageKeys, blockHash.String())
+	if err != nil {
+		return fmt.Errorf("failed to fetch proof of account %s at block %s: %w", address, blockHash, err)
+	}
+	return p.storeAccountProof(result)
+}
+
+func (p *Prefetcher) storeAccountProof(result *eth.AccountResult) error {
+	nodes := result.AccountProof
+	for _, entry := range result.StorageProof {
+		nodes = append(nodes, entry.Proof...)
+	}
+	return p.storeNodes(nodes)
+}
+
+func (p *Prefetcher) prefetchBlob(ctx context.Context, hintData string) error {
+	if p.l1BlobFetcher == nil {
+		return ErrNoL1BlobSource
+	}
+	data, err := hexutil.Decode(hintData)
+	if err != nil || len(data) != 48 {
+		return fmt.Errorf("invalid blob hint: %s", hintData)
+	}
+	blobHash := eth.IndexedBlobHash{
+		Hash:  common.BytesToHash(data[:32]),
+		Index: binary.BigEndian.Uint64(data[32:40]),
+	}
+	// Only the timestamp is required to find the beacon chain slot the blob was included in.
+	ref := eth.L1BlockRef{Time: binary.BigEndian.Uint64(data[40:48])}
+	p.logger.Debug("Prefetching", "type", l1.HintL1Blob, "hash", blobHash.Hash, "index", blobHash.Index, "time", ref.Time)
+	sidecars, err := p.l1BlobFetcher.GetBlobSidecars(ctx, ref, []eth.IndexedBlobHash{blobHash})
+	if err != nil {
+		return fmt.Errorf("failed to fetch blob %s: %w", blobHash.Hash, err)
+	}
+	if len(sidecars) != 1 {
+		return fmt.Errorf("expected 1 sidecar for blob %s but got %d", blobHash.Hash, len(sidecars))
+	}
+	sidecar := sidecars[0]
+	commitment := kzg4844.Commitment(sidecar.KZGCommitment)
+	if actual := eth.KZGToVersionedHash(commitment); actual != blobHash.Hash {
+		return fmt.Errorf("expected hash %s for blob at index %d but got %s", blobHash.Hash, blobHash.Index, actual)
+	}
+	if err := eth.VerifyBlobProof(&sidecar.Blob, commitment, kzg4844.Proof(sidecar.KZGProof)); err != nil {
+		return fmt.Errorf("invalid blob %s: %w", blobHash.Hash, err)
+	}
+
+	// Store the field elements before the commitment so the blob is only found once it is complete.
+	batch := make(map[common.Hash][]byte, 2*params.BlobTxFieldElementsPerBlob)
+	for i := 0; i < params.BlobTxFieldElementsPerBlob; i++ {
+		keyData := l1.BlobFieldElementKeyData(sidecar.KZGCommitment, i)
+		keyHash := crypto.Keccak256Hash(keyData)
+		batch[preimage.Keccak256Key(keyHash).PreimageKey()] = keyData
+		batch[preimage.BlobKey(keyHash).PreimageKey()] = sidecar.Blob[i*params.BlobTxBytesPerFieldElement : (i+1)*params.BlobTxBytesPerFieldElement]
+	}
+	if err := kvstore.PutBatch(p.kvStore, batch); err != nil {
+		return fmt.Errorf("failed to store field elements of blob %s: %w", blobHash.Hash, err)
+	}
+	return p.kvStore.Put(preimage.Sha256Key(blobHash.Hash).PreimageKey(), sidecar.KZGCommitment[:])
+}
+
+func (p *Prefetcher) prefetchKZGPointEvaluation(hintData string) error {
+	input, err := hexutil.Decode(hintData)
+	if err != nil {
+		return fmt.Errorf("invalid point evaluation hint: %s", hintData)
+	}
+	p.logger.Debug("Prefetching", "type", l1.HintL1KZGPointEvaluation, "input", hexutil.Bytes(input))
+	result := []byte{0}
+	if _, err := vm.PrecompiledContractsCancun[l1.KZGPointEvaluationAddress].Run(input); err == nil {
+		result[0] = 1
+	}
+	keyData := l1.PrecompileKeyData(l1.KZGPointEvaluationAddress, input)
+	keyHash := crypto.Keccak256Hash(keyData)
+	if err := p.kvStore.Put(preimage.Keccak256Key(keyHash).PreimageKey(), keyData); err != nil {
+		return err
+	}
+	return p.kvStore.Put(preimage.PrecompileKey(keyHash).PreimageKey(), result)
+}
+
 func (p *Prefetcher) storeReceipts(receipts types.Receipts) error {
 	opaqueReceipts, err := eth.EncodeReceipts(receipts)
 	if err != nil {
@@ -157,24 +543,54 @@ func (p *Prefetcher) storeTransactions(txs types.Transactions) error {
 
 func (p *Prefetcher) storeTrieNodes(values []hexutil.Bytes) error {
 	_, nodes := mpt.WriteTrie(values)
+	return p.storeNodes(nodes)
+}
+
+// storeNodes stores the keccak256 pre-images of nodes in a single batch.
+func (p *Prefetcher) storeNodes(nodes []hexutil.Bytes) error {
+	batch := make(map[common.Hash][]byte, len(nodes))
 	for _, node := range nodes {
-		key := preimage.Keccak256Key(crypto.Keccak256Hash(node)).PreimageKey()
-		if err := p.kvStore.Put(key, node); err != nil {
-			return fmt.Errorf("failed to store node: %w", err)
-		}
+		batch[preimage.Keccak256Key(crypto.Keccak256Hash(node)).PreimageKey()] = node
+	}
+	if err := kvstore.PutBatch(p.kvStore, batch); err != nil {
+		return fmt.Errorf("failed to store nodes: %w", err)
 	}
 	return nil
 }
 
-// parseHint parses a hint string in wire protocol. Returns the hint type, requested hash and error (if any).
-func parseHint(hint string) (string, common.Hash, error) {
-	hintType, hashStr, found := strings.Cut(hint, " ")
+// parseHint parses a hint string in wire protocol. Returns the hint type, requested data and error (if any).
+func parseHint(hint string) (string, string, error) {
+	hintType, hintData, found := strings.Cut(hint, " ")
 	if !found {
-		return "", common.Hash{}, fmt.Errorf("unsupported hint: %s", hint)
+		return "", "", fmt.Errorf("unsupported hint: %s", hint)
 	}
+	return hintType, hintData, nil
+}
+
+// parseAccountProofHint parses an account proof hint into the block hash, account address and storage keys.
+func parseAccountProofHint(hint string) (common.Hash, common.Address, []common.Hash, error) {
+	_, hintData, err := parseHint(hint)
+	if err != nil {
+		return common.Hash{}, common.Address{}, nil, err
+	}
+	data, err := hexutil.Decode(hintData)
+	if err != nil || len(data) < common.HashLength+common.AddressLength || (len(data)-common.HashLength-common.AddressLength)%common.HashLength != 0 {
+		return common.Hash{}, common.Address{}, nil, fmt.Errorf("invalid account proof hint: %s", hintData)
+	}
+	blockHash := common.BytesToHash(data[:common.HashLength])
+	address := common.BytesToAddress(data[common.HashLength : common.HashLength+common.AddressLength])
+	storageKeys := make([]common.Hash, 0, (len(data)-common.HashLength-common.AddressLength)/common.HashLength)
+	for i := common.HashLength + common.AddressLength; i < len(data); i += common.HashLength {
+		storageKeys = append(storageKeys, common.BytesToHash(data[i:i+common.HashLength]))
+	}
+	return blockHash, address, storageKeys, nil
+}
+
+// parseHash parses the requested data of hints that request the pre-images for a hash.
+func parseHash(hashStr string) (common.Hash, error) {
 	hash := common.HexToHash(hashStr)
 	if hash == (common.Hash{}) {
-		return "", common.Hash{}, fmt.Errorf("invalid hash: %s", hashStr)
+		return common.Hash{}, fmt.Errorf("invalid hash: %s", hashStr)
 	}
-	return hintType, hash, nil
+	return hash, nil
 }
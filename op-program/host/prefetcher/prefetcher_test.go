@@ -2,14 +2,27 @@ package prefetcher
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
 	"math/rand"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
@@ -24,14 +37,14 @@ import (
 
 func TestNoHint(t *testing.T) {
 	t.Run("NotFound", func(t *testing.T) {
-		prefetcher, _, _, _ := createPrefetcher(t)
+		prefetcher, _, _, _, _ := createPrefetcher(t)
 		res, err := prefetcher.GetPreimage(context.Background(), common.Hash{0xab})
 		require.ErrorIs(t, err, kvstore.ErrNotFound)
 		require.Nil(t, res)
 	})
 
 	t.Run("Exists", func(t *testing.T) {
-		prefetcher, _, _, kv := createPrefetcher(t)
+		prefetcher, _, _, _, kv := createPrefetcher(t)
 		data := []byte{1, 2, 3}
 		hash := crypto.Keccak256Hash(data)
 		require.NoError(t, kv.Put(hash, data))
@@ -51,7 +64,7 @@ func TestFetchL1BlockHeader(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("AlreadyKnown", func(t *testing.T) {
-		prefetcher, _, _, kv := createPrefetcher(t)
+		prefetcher, _, _, _, kv := createPrefetcher(t)
 		storeBlock(t, kv, block, rcpts)
 
 		oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
@@ -60,7 +73,7 @@ func TestFetchL1BlockHeader(t *testing.T) {
 	})
 
 	t.Run("Unknown", func(t *testing.T) {
-		prefetcher, l1Cl, _, _ := createPrefetcher(t)
+		prefetcher, l1Cl, _, _, _ := createPrefetcher(t)
 		l1Cl.ExpectInfoByHash(hash, eth.HeaderBlockInfo(block.Header()), nil)
 		defer l1Cl.AssertExpectations(t)
 
@@ -77,7 +90,7 @@ func TestFetchL1Transactions(t *testing.T) {
 	hash := block.Hash()
 
 	t.Run("AlreadyKnown", func(t *testing.T) {
-		prefetcher, _, _, kv := createPrefetcher(t)
+		prefetcher, _, _, _, kv := createPrefetcher(t)
 
 		storeBlock(t, kv, block, rcpts)
 
@@ -89,7 +102,7 @@ func TestFetchL1Transactions(t *testing.T) {
 	})
 
 	t.Run("Unknown", func(t *testing.T) {
-		prefetcher, l1Cl, _, _ := createPrefetcher(t)
+		prefetcher, l1Cl, _, _, _ := createPrefetcher(t)
 		l1Cl.ExpectInfoByHash(hash, eth.BlockToInfo(block), nil)
 		l1Cl.ExpectInfoAndTxsByHash(hash, eth.BlockToInfo(block), block.Transactions(), nil)
 		defer l1Cl.AssertExpectations(t)
@@ -107,7 +120,7 @@ func TestFetchL1Receipts(t *testing.T) {
 	hash := block.Hash()
 
 	t.Run("AlreadyKnown", func(t *testing.T) {
-		prefetcher, _, _, kv := createPrefetcher(t)
+		prefetcher, _, _, _, kv := createPrefetcher(t)
 		storeBlock(t, kv, block, receipts)
 
 		// Check the data is available (note the oracle does not know about the block, only the kvstore does)
@@ -118,7 +131,7 @@ func TestFetchL1Receipts(t *testing.T) {
 	})
 
 	t.Run("Unknown", func(t *testing.T) {
-		prefetcher, l1Cl, _, _ := createPrefetcher(t)
+		prefetcher, l1Cl, _, _, _ := createPrefetcher(t)
 		l1Cl.ExpectInfoByHash(hash, eth.BlockToInfo(block), nil)
 		l1Cl.ExpectInfoAndTxsByHash(hash, eth.BlockToInfo(block), block.Transactions(), nil)
 		l1Cl.ExpectFetchReceipts(hash, eth.BlockToInfo(block), receipts, nil)
@@ -133,7 +146,7 @@ func TestFetchL1Receipts(t *testing.T) {
 	// Blocks may have identical RLP receipts for different transactions.
 	// Check that the node already existing is handled
 	t.Run("CommonTrieNodes", func(t *testing.T) {
-		prefetcher, l1Cl, _, kv := createPrefetcher(t)
+		prefetcher, l1Cl, _, _, kv := createPrefetcher(t)
 		l1Cl.ExpectInfoByHash(hash, eth.BlockToInfo(block), nil)
 		l1Cl.ExpectInfoAndTxsByHash(hash, eth.BlockToInfo(block), block.Transactions(), nil)
 		l1Cl.ExpectFetchReceipts(hash, eth.BlockToInfo(block), receipts, nil)
@@ -159,7 +172,7 @@ func TestFetchL2Block(t *testing.T) {
 	hash := block.Hash()
 
 	t.Run("AlreadyKnown", func(t *testing.T) {
-		prefetcher, _, _, kv := createPrefetcher(t)
+		prefetcher, _, _, _, kv := createPrefetcher(t)
 		storeBlock(t, kv, block, rcpts)
 
 		oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
@@ -169,7 +182,7 @@ func TestFetchL2Block(t *testing.T) {
 	})
 
 	t.Run("Unknown", func(t *testing.T) {
-		prefetcher, _, l2Cl, _ := createPrefetcher(t)
+		prefetcher, _, _, l2Cl, _ := createPrefetcher(t)
 		l2Cl.ExpectInfoAndTxsByHash(hash, eth.BlockToInfo(block), block.Transactions(), nil)
 		defer l2Cl.MockL2Client.AssertExpectations(t)
 
@@ -186,7 +199,7 @@ func TestFetchL2Transactions(t *testing.T) {
 	hash := block.Hash()
 
 	t.Run("AlreadyKnown", func(t *testing.T) {
-		prefetcher, _, _, kv := createPrefetcher(t)
+		prefetcher, _, _, _, kv := createPrefetcher(t)
 		storeBlock(t, kv, block, rcpts)
 
 		oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
@@ -195,7 +208,7 @@ func TestFetchL2Transactions(t *testing.T) {
 	})
 
 	t.Run("Unknown", func(t *testing.T) {
-		prefetcher, _, l2Cl, _ := createPrefetcher(t)
+		prefetcher, _, _, l2Cl, _ := createPrefetcher(t)
 		l2Cl.ExpectInfoAndTxsByHash(hash, eth.BlockToInfo(block), block.Transactions(), nil)
 		defer l2Cl.MockL2Client.AssertExpectations(t)
 
@@ -212,7 +225,7 @@ func TestFetchL2Node(t *testing.T) {
 	key := preimage.Keccak256Key(hash).PreimageKey()
 
 	t.Run("AlreadyKnown", func(t *testing.T) {
-		prefetcher, _, _, kv := createPrefetcher(t)
+		prefetcher, _, _, _, kv := createPrefetcher(t)
 		require.NoError(t, kv.Put(key, node))
 
 		oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
@@ -221,7 +234,7 @@ func TestFetchL2Node(t *testing.T) {
 	})
 
 	t.Run("Unknown", func(t *testing.T) {
-		prefetcher, _, l2Cl, _ := createPrefetcher(t)
+		prefetcher, _, _, l2Cl, _ := createPrefetcher(t)
 		l2Cl.ExpectNodeByHash(hash, node, nil)
 		defer l2Cl.MockDebugClient.AssertExpectations(t)
 
@@ -231,6 +244,47 @@ func TestFetchL2Node(t *testing.T) {
 	})
 }
 
+func TestFetchL2AccountProof(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	accountNodes := []hexutil.Bytes{testutils.RandomData(rng, 30), testutils.RandomData(rng, 30)}
+	storageNodes := []hexutil.Bytes{testutils.RandomData(rng, 30)}
+	blockHash := common.Hash{0xaa}
+	address := common.Address{0xbb}
+	storageKey := common.Hash{0xcc}
+	proof := &eth.AccountResult{
+		Address:      address,
+		AccountProof: accountNodes,
+		StorageProof: []eth.StorageProofEntry{{Key: storageKey, Proof: storageNodes}},
+	}
+
+	t.Run("NodesFromProof", func(t *testing.T) {
+		prefetcher, _, _, l2Cl, _ := createPrefetcher(t)
+		l2Cl.ExpectGetProof(address, []common.Hash{storageKey}, blockHash.String(), proof, nil)
+		defer l2Cl.MockL2Client.AssertExpectations(t)
+
+		oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		oracle.HintAccount(blockHash, address, storageKey)
+		// Nodes are served from the proof without being requested individually
+		for _, node := range append(accountNodes, storageNodes...) {
+			require.EqualValues(t, node, oracle.NodeByHash(crypto.Keccak256Hash(node)))
+		}
+	})
+
+	t.Run("ProofUnavailable", func(t *testing.T) {
+		prefetcher, _, _, l2Cl, _ := createPrefetcher(t)
+		l2Cl.ExpectGetProof(address, []common.Hash{}, blockHash.String(), nil, ethereum.NotFound)
+		node := accountNodes[0]
+		l2Cl.ExpectNodeByHash(crypto.Keccak256Hash(node), node, nil)
+		defer l2Cl.MockL2Client.AssertExpectations(t)
+		defer l2Cl.MockDebugClient.AssertExpectations(t)
+
+		oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		oracle.HintAccount(blockHash, address)
+		// Falls back to fetching the node individually
+		require.EqualValues(t, node, oracle.NodeByHash(crypto.Keccak256Hash(node)))
+	})
+}
+
 func TestFetchL2Code(t *testing.T) {
 	rng := rand.New(rand.NewSource(123))
 	code := testutils.RandomData(rng, 30)
@@ -238,7 +292,7 @@ func TestFetchL2Code(t *testing.T) {
 	key := preimage.Keccak256Key(hash).PreimageKey()
 
 	t.Run("AlreadyKnown", func(t *testing.T) {
-		prefetcher, _, _, kv := createPrefetcher(t)
+		prefetcher, _, _, _, kv := createPrefetcher(t)
 		require.NoError(t, kv.Put(key, code))
 
 		oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
@@ -247,7 +301,7 @@ func TestFetchL2Code(t *testing.T) {
 	})
 
 	t.Run("Unknown", func(t *testing.T) {
-		prefetcher, _, l2Cl, _ := createPrefetcher(t)
+		prefetcher, _, _, l2Cl, _ := createPrefetcher(t)
 		l2Cl.ExpectCodeByHash(hash, code, nil)
 		defer l2Cl.MockDebugClient.AssertExpectations(t)
 
@@ -257,8 +311,238 @@ func TestFetchL2Code(t *testing.T) {
 	})
 }
 
+func TestFetchL1Blob(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	blob, sidecar, blobHash := createBlob(t, rng)
+	ref := eth.L1BlockRef{Time: 1000}
+
+	t.Run("AlreadyKnown", func(t *testing.T) {
+		prefetcher, _, blobSource, _, kv := createPrefetcher(t)
+		storeBlob(t, kv, sidecar)
+
+		oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		result := oracle.GetBlob(ref, blobHash)
+		require.Equal(t, blob, result)
+		require.Zero(t, blobSource.requests)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		prefetcher, _, blobSource, _, kv := createPrefetcher(t)
+		blobSource.sidecars[blobHash] = sidecar
+
+		oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		result := oracle.GetBlob(ref, blobHash)
+		require.Equal(t, blob, result)
+		require.Equal(t, 1, blobSource.requests)
+
+		// The field element keys can be reversed to load the elements on chain
+		keyData := l1.BlobFieldElementKeyData(sidecar.KZGCommitment, 5)
+		pre, err := kv.Get(preimage.Keccak256Key(crypto.Keccak256Hash(keyData)).PreimageKey())
+		require.NoError(t, err)
+		require.Equal(t, keyData, pre)
+	})
+
+	t.Run("IncorrectHash", func(t *testing.T) {
+		prefetcher, _, blobSource, _, _ := createPrefetcher(t)
+		wrongHash := eth.IndexedBlobHash{Index: blobHash.Index, Hash: common.Hash{0x01, 0xaa}}
+		blobSource.sidecars[wrongHash] = sidecar
+
+		require.NoError(t, prefetcher.Hint(blobHint(wrongHash).Hint()))
+		_, err := prefetcher.GetPreimage(context.Background(), preimage.Sha256Key(wrongHash.Hash).PreimageKey())
+		require.ErrorContains(t, err, "expected hash")
+	})
+
+	t.Run("NoBlobSource", func(t *testing.T) {
+		_, l1Source, _, l2Cl, kv := createPrefetcher(t)
+		prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlInfo), l1Source, nil, l2Cl, kv, 0, nil, nil)
+
+		require.NoError(t, prefetcher.Hint(blobHint(blobHash).Hint()))
+		_, err := prefetcher.GetPreimage(context.Background(), preimage.Sha256Key(blobHash.Hash).PreimageKey())
+		require.ErrorIs(t, err, ErrNoL1BlobSource)
+	})
+}
+
+func TestFetchKZGPointEvaluation(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	blob, sidecar, blobHash := createBlob(t, rng)
+	point := l1.RootsOfUnity[3].Bytes()
+	proof, claim, err := kzg4844.ComputeProof(*blob.KZGBlob(), point)
+	require.NoError(t, err)
+	validInput := append(blobHash.Hash.Bytes(), point[:]...)
+	validInput = append(validInput, claim[:]...)
+	validInput = append(validInput, sidecar.KZGCommitment[:]...)
+	validInput = append(validInput, proof[:]...)
+
+	t.Run("Valid", func(t *testing.T) {
+		prefetcher, _, _, _, _ := createPrefetcher(t)
+		oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		require.True(t, oracle.KZGPointEvaluation(validInput))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		prefetcher, _, _, _, _ := createPrefetcher(t)
+		oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		input := slices.Clone(validInput)
+		input[70] ^= 0x01 // Modify the claimed value
+		require.False(t, oracle.KZGPointEvaluation(input))
+		require.False(t, oracle.KZGPointEvaluation([]byte{1, 2, 3}))
+	})
+
+	t.Run("AlreadyKnown", func(t *testing.T) {
+		prefetcher, _, _, _, kv := createPrefetcher(t)
+		key := preimage.PrecompileKey(crypto.Keccak256Hash(l1.PrecompileKeyData(l1.KZGPointEvaluationAddress, []byte{1, 2, 3})))
+		require.NoError(t, kv.Put(key.PreimageKey(), []byte{1}))
+		oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		require.True(t, oracle.KZGPointEvaluation([]byte{1, 2, 3}))
+	})
+}
+
+func TestL1Lookahead(t *testing.T) {
+	source, blocks := createL1Chain(4)
+	kv := kvstore.NewMemKV()
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlDebug), source, nil, new(l2Client), kv, 2, nil, nil)
+	defer prefetcher.Close()
+	oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+
+	// Walk back from the last block to learn the block hashes, as the client does to find its starting point
+	for i := len(blocks) - 1; i >= 0; i-- {
+		oracle.HeaderByBlockHash(blocks[i].Hash())
+	}
+	oracle.TransactionsByBlockHash(blocks[0].Hash())
+
+	// The following blocks within the lookahead are fetched in the background
+	for _, block := range blocks[1:3] {
+		txsRoot, receiptsRoot := trieRoots(t, block.Transactions(), source.receipts[block.Hash()])
+		require.Eventually(t, func() bool {
+			_, txsErr := kv.Get(preimage.Keccak256Key(txsRoot).PreimageKey())
+			_, receiptsErr := kv.Get(preimage.Keccak256Key(receiptsRoot).PreimageKey())
+			return txsErr == nil && receiptsErr == nil
+		}, 10*time.Second, 10*time.Millisecond)
+	}
+	// Only the header of the block beyond the lookahead is fetched
+	beyondLookahead := 0
+	for _, hash := range source.Requests() {
+		if hash == blocks[3].Hash() {
+			beyondLookahead++
+		}
+	}
+	require.Equal(t, 1, beyondLookahead, "should not fetch beyond lookahead")
+}
+
+func TestL1LookaheadBatched(t *testing.T) {
+	source, blocks := createL1Chain(6)
+	batch := &stubBatchCaller{l1: source}
+	kv := kvstore.NewMemKV()
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlDebug), source, nil, new(l2Client), kv, 4, batch, nil)
+	defer prefetcher.Close()
+	oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+
+	for i := len(blocks) - 1; i >= 0; i-- {
+		oracle.HeaderByBlockHash(blocks[i].Hash())
+	}
+	oracle.TransactionsByBlockHash(blocks[0].Hash())
+	for _, block := range blocks[1:5] {
+		txsRoot, receiptsRoot := trieRoots(t, block.Transactions(), source.receipts[block.Hash()])
+		require.Eventually(t, func() bool {
+			_, txsErr := kv.Get(preimage.Keccak256Key(txsRoot).PreimageKey())
+			_, receiptsErr := kv.Get(preimage.Keccak256Key(receiptsRoot).PreimageKey())
+			return txsErr == nil && receiptsErr == nil
+		}, 10*time.Second, 10*time.Millisecond)
+	}
+	// The whole lookahead is fetched with a single batch request, not individual requests per block
+	require.Equal(t, []int{8}, batch.BatchSizes())
+	require.Len(t, source.Requests(), len(blocks)+1, "should only request headers and the first block individually")
+
+	// The lookahead moves forward with the client, but is not refilled until less than half of it remains
+	oracle.TransactionsByBlockHash(blocks[1].Hash())
+	oracle.TransactionsByBlockHash(blocks[2].Hash())
+	require.Equal(t, []int{8}, batch.BatchSizes())
+	oracle.TransactionsByBlockHash(blocks[3].Hash())
+	require.Eventually(t, func() bool {
+		return slices.Equal([]int{8, 2}, batch.BatchSizes())
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestAccountProofsBatched(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	blockHash := common.Hash{0xaa}
+	batch := &stubBatchCaller{proofs: make(map[common.Address]*eth.AccountResult)}
+	var nodes []hexutil.Bytes
+	for i := 0; i < 3; i++ {
+		node := testutils.RandomData(rng, 30)
+		address := common.Address{byte(i)}
+		batch.proofs[address] = &eth.AccountResult{Address: address, AccountProof: []hexutil.Bytes{node}}
+		nodes = append(nodes, node)
+	}
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlDebug), new(testutils.MockL1Source), nil, new(l2Client), kvstore.NewMemKV(), 0, nil, batch)
+	defer prefetcher.Close()
+	oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+
+	for address := range batch.proofs {
+		oracle.HintAccount(blockHash, address)
+	}
+	require.Empty(t, batch.BatchSizes(), "should queue account proofs until a node is required")
+	for _, node := range nodes {
+		require.EqualValues(t, node, oracle.NodeByHash(crypto.Keccak256Hash(node)))
+	}
+	require.Equal(t, []int{3}, batch.BatchSizes())
+}
+
+func TestCloseCancelsSpeculativeFetches(t *testing.T) {
+	source, blocks := createL1Chain(2)
+	batch := &stubBatchCaller{l1: source, block: make(chan error, 1)}
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlDebug), source, nil, new(l2Client), kvstore.NewMemKV(), 1, batch, nil)
+	oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+
+	oracle.HeaderByBlockHash(blocks[1].Hash())
+	oracle.HeaderByBlockHash(blocks[0].Hash())
+	oracle.TransactionsByBlockHash(blocks[0].Hash())
+	require.Eventually(t, func() bool {
+		return len(batch.BatchSizes()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	prefetcher.Close()
+	require.ErrorIs(t, <-batch.block, context.Canceled)
+
+	// No further speculative fetches are started
+	prefetcher.speculate([]string{l1.TransactionsHint(blocks[1].Hash()).Hint()}, func(_ context.Context, _ []string) []error {
+		t.Fatal("should not start speculative fetch after close")
+		return nil
+	})
+	prefetcher.wg.Wait()
+}
+
+func TestConcurrentRequests(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	node := testutils.RandomData(rng, 30)
+	hash := crypto.Keccak256Hash(node)
+	source := &blockingL2Source{node: node, started: make(chan struct{}), release: make(chan struct{})}
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlDebug), new(testutils.MockL1Source), nil, source, kvstore.NewMemKV(), 0, nil, nil)
+	require.NoError(t, prefetcher.Hint(l2.StateNodeHint(hash).Hint()))
+
+	type result struct {
+		data []byte
+		err  error
+	}
+	results := make(chan result, 2)
+	request := func() {
+		data, err := prefetcher.GetPreimage(context.Background(), preimage.Keccak256Key(hash).PreimageKey())
+		results <- result{data, err}
+	}
+	go request()
+	<-source.started
+	go request()
+	close(source.release)
+	for i := 0; i < 2; i++ {
+		res := <-results
+		require.NoError(t, res.err)
+		require.EqualValues(t, node, res.data)
+	}
+	require.EqualValues(t, 1, source.calls.Load(), "should share a single fetch")
+}
+
 func TestBadHints(t *testing.T) {
-	prefetcher, _, _, kv := createPrefetcher(t)
+	prefetcher, _, _, _, kv := createPrefetcher(t)
 	hash := common.Hash{0xad}
 
 	t.Run("NoSpace", func(t *testing.T) {
@@ -311,10 +595,10 @@ func TestRetryWhenNotAvailableAfterPrefetching(t *testing.T) {
 	node := testutils.RandomData(rng, 30)
 	hash := crypto.Keccak256Hash(node)
 
-	_, l1Source, l2Cl, kv := createPrefetcher(t)
+	_, l1Source, _, l2Cl, kv := createPrefetcher(t)
 	putsToIgnore := 2
 	kv = &unreliableKvStore{KV: kv, putsToIgnore: putsToIgnore}
-	prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlInfo), l1Source, l2Cl, kv)
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlInfo), l1Source, nil, l2Cl, kv, 0, nil, nil)
 
 	// Expect one call for each ignored put, plus one more request for when the put succeeds
 	for i := 0; i < putsToIgnore+1; i++ {
@@ -355,18 +639,183 @@ func (m *l2Client) ExpectOutputByRoot(root common.Hash, output eth.Output, err e
 	m.Mock.On("OutputByRoot", root).Once().Return(output, &err)
 }
 
-func createPrefetcher(t *testing.T) (*Prefetcher, *testutils.MockL1Source, *l2Client, kvstore.KV) {
+type stubL1Source struct {
+	blocks   map[common.Hash]*types.Block
+	receipts map[common.Hash]types.Receipts
+
+	lock     sync.Mutex
+	requests []common.Hash
+}
+
+func (s *stubL1Source) block(hash common.Hash) (*types.Block, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.requests = append(s.requests, hash)
+	block, ok := s.blocks[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return block, nil
+}
+
+func (s *stubL1Source) Requests() []common.Hash {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return slices.Clone(s.requests)
+}
+
+func (s *stubL1Source) InfoByHash(_ context.Context, blockHash common.Hash) (eth.BlockInfo, error) {
+	block, err := s.block(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return eth.HeaderBlockInfo(block.Header()), nil
+}
+
+func (s *stubL1Source) InfoAndTxsByHash(_ context.Context, blockHash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	block, err := s.block(blockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return eth.BlockToInfo(block), block.Transactions(), nil
+}
+
+func (s *stubL1Source) FetchReceipts(_ context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	block, err := s.block(blockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return eth.BlockToInfo(block), s.receipts[blockHash], nil
+}
+
+// stubBatchCaller serves batch requests for the L1 blocks in l1 and the account proofs in proofs.
+// If block is set, each batch instead waits until its context is done and sends the context error to block.
+type stubBatchCaller struct {
+	l1     *stubL1Source
+	proofs map[common.Address]*eth.AccountResult
+	block  chan error
+
+	lock  sync.Mutex
+	sizes []int
+}
+
+func (s *stubBatchCaller) BatchSizes() []int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return slices.Clone(s.sizes)
+}
+
+func (s *stubBatchCaller) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	s.lock.Lock()
+	s.sizes = append(s.sizes, len(b))
+	s.lock.Unlock()
+	if s.block != nil {
+		<-ctx.Done()
+		s.block <- ctx.Err()
+		return ctx.Err()
+	}
+	for i := range b {
+		var result any
+		switch b[i].Method {
+		case "eth_getBlockByHash":
+			hash := b[i].Args[0].(common.Hash)
+			result = map[string]any{"transactions": s.l1.blocks[hash].Transactions()}
+		case "eth_getBlockReceipts":
+			result = s.l1.receipts[b[i].Args[0].(common.Hash)]
+		case "eth_getProof":
+			result = s.proofs[b[i].Args[0].(common.Address)]
+		default:
+			b[i].Error = fmt.Errorf("unsupported method: %s", b[i].Method)
+			continue
+		}
+		// Round trip through JSON to decode the result as the RPC client would
+		data, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		b[i].Error = json.Unmarshal(data, b[i].Result)
+	}
+	return nil
+}
+
+// blockingL2Source serves a single state node once released, signalling when the first request starts.
+type blockingL2Source struct {
+	L2Source
+	node    []byte
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingL2Source) NodeByHash(_ context.Context, _ common.Hash) ([]byte, error) {
+	if s.calls.Add(1) == 1 {
+		close(s.started)
+	}
+	<-s.release
+	return s.node, nil
+}
+
+type stubBlobSource struct {
+	sidecars map[eth.IndexedBlobHash]*eth.BlobSidecar
+	requests int
+}
+
+func (s *stubBlobSource) GetBlobSidecars(_ context.Context, _ eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error) {
+	s.requests++
+	var sidecars []*eth.BlobSidecar
+	for _, hash := range hashes {
+		sidecar, ok := s.sidecars[hash]
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		sidecars = append(sidecars, sidecar)
+	}
+	return sidecars, nil
+}
+
+func createPrefetcher(t *testing.T) (*Prefetcher, *testutils.MockL1Source, *stubBlobSource, *l2Client, kvstore.KV) {
 	logger := testlog.Logger(t, log.LvlDebug)
 	kv := kvstore.NewMemKV()
 
 	l1Source := new(testutils.MockL1Source)
+	l1BlobSource := &stubBlobSource{sidecars: make(map[eth.IndexedBlobHash]*eth.BlobSidecar)}
 	l2Source := &l2Client{
 		MockL2Client:    new(testutils.MockL2Client),
 		MockDebugClient: new(testutils.MockDebugClient),
 	}
 
-	prefetcher := NewPrefetcher(logger, l1Source, l2Source, kv)
-	return prefetcher, l1Source, l2Source, kv
+	prefetcher := NewPrefetcher(logger, l1Source, l1BlobSource, l2Source, kv, 0, nil, nil)
+	return prefetcher, l1Source, l1BlobSource, l2Source, kv
+}
+
+// createL1Chain creates a chain of random L1 blocks and a source that serves them.
+func createL1Chain(count int) (*stubL1Source, []*types.Block) {
+	rng := rand.New(rand.NewSource(123))
+	source := &stubL1Source{
+		blocks:   make(map[common.Hash]*types.Block),
+		receipts: make(map[common.Hash]types.Receipts),
+	}
+	var blocks []*types.Block
+	for i := 0; i < count; i++ {
+		block, receipts := testutils.RandomBlock(rng, 2)
+		header := block.Header()
+		header.Number = big.NewInt(int64(100 + i))
+		block = types.NewBlockWithHeader(header).WithBody(block.Transactions(), nil)
+		blocks = append(blocks, block)
+		source.blocks[block.Hash()] = block
+		source.receipts[block.Hash()] = receipts
+	}
+	return source, blocks
+}
+
+func trieRoots(t *testing.T, txs types.Transactions, receipts types.Receipts) (common.Hash, common.Hash) {
+	opaqueTxs, err := eth.EncodeTransactions(txs)
+	require.NoError(t, err)
+	txsRoot, _ := mpt.WriteTrie(opaqueTxs)
+	opaqueRcpts, err := eth.EncodeReceipts(receipts)
+	require.NoError(t, err)
+	receiptsRoot, _ := mpt.WriteTrie(opaqueRcpts)
+	return txsRoot, receiptsRoot
 }
 
 func storeBlock(t *testing.T, kv kvstore.KV, block *types.Block, receipts types.Receipts) {
@@ -392,6 +841,39 @@ func storeBlock(t *testing.T, kv kvstore.KV, block *types.Block, receipts types.
 	require.NoError(t, kv.Put(preimage.Keccak256Key(block.Hash()).PreimageKey(), headerRlp))
 }
 
+func createBlob(t *testing.T, rng *rand.Rand) (*eth.Blob, *eth.BlobSidecar, eth.IndexedBlobHash) {
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(testutils.RandomData(rng, 1000)))
+	commitment, err := blob.ComputeKZGCommitment()
+	require.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(*blob.KZGBlob(), commitment)
+	require.NoError(t, err)
+	sidecar := &eth.BlobSidecar{
+		Blob:          blob,
+		Index:         2,
+		KZGCommitment: eth.Bytes48(commitment),
+		KZGProof:      eth.Bytes48(proof),
+	}
+	blobHash := eth.IndexedBlobHash{Index: 2, Hash: eth.KZGToVersionedHash(commitment)}
+	return &blob, sidecar, blobHash
+}
+
+func blobHint(blobHash eth.IndexedBlobHash) l1.BlobHint {
+	hint := make(l1.BlobHint, 48)
+	copy(hint, blobHash.Hash[:])
+	binary.BigEndian.PutUint64(hint[32:], blobHash.Index)
+	return hint
+}
+
+func storeBlob(t *testing.T, kv kvstore.KV, sidecar *eth.BlobSidecar) {
+	commitment := kzg4844.Commitment(sidecar.KZGCommitment)
+	require.NoError(t, kv.Put(preimage.Sha256Key(eth.KZGToVersionedHash(commitment)).PreimageKey(), sidecar.KZGCommitment[:]))
+	for i := 0; i < params.BlobTxFieldElementsPerBlob; i++ {
+		key := preimage.BlobKey(crypto.Keccak256Hash(l1.BlobFieldElementKeyData(sidecar.KZGCommitment, i)))
+		require.NoError(t, kv.Put(key.PreimageKey(), sidecar.Blob[i*32:(i+1)*32]))
+	}
+}
+
 func asOracleFn(t *testing.T, prefetcher *Prefetcher) preimage.OracleFn {
 	return func(key preimage.Key) []byte {
 		pre, err := prefetcher.GetPreimage(context.Background(), key.PreimageKey())
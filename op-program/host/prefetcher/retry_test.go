@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -123,6 +124,9 @@ func TestRetryingL2Source(t *testing.T) {
 	data := []byte{1, 2, 3, 4, 5}
 	output := &eth.OutputV0{}
 	wrongOutput := &eth.OutputV0{BlockHash: common.Hash{0x99}}
+	address := common.Address{0xcd}
+	storage := []common.Hash{{0xef}}
+	proof := &eth.AccountResult{Address: address, AccountProof: []hexutil.Bytes{{1, 2, 3}}}
 
 	t.Run("InfoAndTxsByHash Success", func(t *testing.T) {
 		source, mock := createL2Source(t)
@@ -213,6 +217,28 @@ func TestRetryingL2Source(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, output, actualOutput)
 	})
+
+	t.Run("GetProof Success", func(t *testing.T) {
+		source, mock := createL2Source(t)
+		defer mock.AssertExpectations(t)
+		mock.ExpectGetProof(address, storage, hash.String(), proof, nil)
+
+		actual, err := source.GetProof(ctx, address, storage, hash.String())
+		require.NoError(t, err)
+		require.Equal(t, proof, actual)
+	})
+
+	t.Run("GetProof Error", func(t *testing.T) {
+		source, mock := createL2Source(t)
+		defer mock.AssertExpectations(t)
+		expectedErr := errors.New("boom")
+		mock.ExpectGetProof(address, storage, hash.String(), nil, expectedErr)
+		mock.ExpectGetProof(address, storage, hash.String(), proof, nil)
+
+		actual, err := source.GetProof(ctx, address, storage, hash.String())
+		require.NoError(t, err)
+		require.Equal(t, proof, actual)
+	})
 }
 
 func createL2Source(t *testing.T) (*RetryingL2Source, *MockL2Source) {
@@ -248,6 +274,11 @@ func (m *MockL2Source) OutputByRoot(ctx context.Context, root common.Hash) (eth.
 	return out[0].(eth.Output), *out[1].(*error)
 }
 
+func (m *MockL2Source) GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error) {
+	out := m.Mock.MethodCalled("GetProof", address, storage, blockTag)
+	return out[0].(*eth.AccountResult), *out[1].(*error)
+}
+
 func (m *MockL2Source) ExpectInfoAndTxsByHash(blockHash common.Hash, info eth.BlockInfo, txs types.Transactions, err error) {
 	m.Mock.On("InfoAndTxsByHash", blockHash).Once().Return(info, txs, &err)
 }
@@ -264,4 +295,8 @@ func (m *MockL2Source) ExpectOutputByRoot(root common.Hash, output eth.Output, e
 	m.Mock.On("OutputByRoot", root).Once().Return(output, &err)
 }
 
+func (m *MockL2Source) ExpectGetProof(address common.Address, storage []common.Hash, blockTag string, result *eth.AccountResult, err error) {
+	m.Mock.On("GetProof", address, storage, blockTag).Once().Return(result, &err)
+}
+
 var _ L2Source = (*MockL2Source)(nil)
@@ -0,0 +1,88 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var ErrClaimNotSafe = errors.New("claimed block is not safe")
+
+// RollupClient is the subset of the rollup node API used to look up the inputs to verify an output.
+type RollupClient interface {
+	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
+	RollupConfig(ctx context.Context) (*rollup.Config, error)
+}
+
+// LoadVerifyInputs looks up the boot inputs to verify the output root of block cfg.L2ClaimBlockNumber from the
+// rollup node at cfg.RollupURL.
+func LoadVerifyInputs(ctx context.Context, logger log.Logger, cfg *config.Config) error {
+	rollupCl, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, logger, cfg.RollupURL)
+	if err != nil {
+		return fmt.Errorf("failed to dial rollup node: %w", err)
+	}
+	defer rollupCl.Close()
+	return setVerifyInputs(ctx, logger, rollupCl, cfg)
+}
+
+// setVerifyInputs sets the boot inputs of cfg to verify the output root reported by the rollup node for block
+// cfg.L2ClaimBlockNumber, starting from the output of the previous block. The claimed block must be safe so that it
+// can be derived from the L1 head. The finalized L1 head is used when possible so that it can't be reorged out.
+func setVerifyInputs(ctx context.Context, logger log.Logger, rollupCl RollupClient, cfg *config.Config) error {
+	if cfg.L2ClaimBlockNumber == 0 {
+		return config.ErrInvalidL2ClaimBlock
+	}
+	if cfg.Rollup == nil {
+		rollupCfg, err := rollupCl.RollupConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch rollup config: %w", err)
+		}
+		cfg.Rollup = rollupCfg
+	}
+	if cfg.L2ChainConfig == nil {
+		chainID := cfg.Rollup.L2ChainID.Uint64()
+		chainCfg, err := params.LoadOPStackChainConfig(chainID)
+		if err != nil {
+			return fmt.Errorf("failed to load chain config for chain %d: %w", chainID, err)
+		}
+		cfg.L2ChainConfig = chainCfg
+	}
+
+	claim, err := rollupCl.OutputAtBlock(ctx, cfg.L2ClaimBlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch claimed output at block %d: %w", cfg.L2ClaimBlockNumber, err)
+	}
+	if claim.Status == nil {
+		return fmt.Errorf("no sync status for claimed output at block %d", cfg.L2ClaimBlockNumber)
+	}
+	status := claim.Status
+	if cfg.L2ClaimBlockNumber > status.SafeL2.Number {
+		return fmt.Errorf("%w: block %d is after safe head %d", ErrClaimNotSafe, cfg.L2ClaimBlockNumber, status.SafeL2.Number)
+	}
+	agreed, err := rollupCl.OutputAtBlock(ctx, cfg.L2ClaimBlockNumber-1)
+	if err != nil {
+		return fmt.Errorf("failed to fetch agreed output at block %d: %w", cfg.L2ClaimBlockNumber-1, err)
+	}
+
+	l1Head := status.CurrentL1
+	if cfg.L2ClaimBlockNumber <= status.FinalizedL2.Number && status.CurrentL1Finalized.Hash != (common.Hash{}) {
+		l1Head = status.CurrentL1Finalized
+	}
+	cfg.L1Head = l1Head.Hash
+	cfg.L2Head = agreed.BlockRef.Hash
+	cfg.L2OutputRoot = common.Hash(agreed.OutputRoot)
+	cfg.L2Claim = common.Hash(claim.OutputRoot)
+	logger.Info("Verifying output",
+		"l1Head", cfg.L1Head, "l1HeadNum", l1Head.Number,
+		"l2Head", cfg.L2Head, "l2OutputRoot", cfg.L2OutputRoot,
+		"l2Claim", cfg.L2Claim, "l2ClaimBlockNumber", cfg.L2ClaimBlockNumber)
+	return nil
+}
@@ -0,0 +1,121 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetVerifyInputs(t *testing.T) {
+	status := &eth.SyncStatus{
+		CurrentL1:          eth.L1BlockRef{Hash: common.Hash{0x11}, Number: 200},
+		CurrentL1Finalized: eth.L1BlockRef{Hash: common.Hash{0x12}, Number: 100},
+		SafeL2:             eth.L2BlockRef{Number: 2000},
+		FinalizedL2:        eth.L2BlockRef{Number: 1000},
+	}
+	rollupCl := &stubRollupClient{status: status}
+
+	t.Run("Finalized", func(t *testing.T) {
+		cfg := verifyConfig(1000)
+		require.NoError(t, setVerifyInputs(context.Background(), testlog.Logger(t, log.LvlInfo), rollupCl, cfg))
+		require.Equal(t, common.Hash{0x12}, cfg.L1Head)
+		require.Equal(t, blockHash(999), cfg.L2Head)
+		require.Equal(t, outputRoot(999), cfg.L2OutputRoot)
+		require.Equal(t, outputRoot(1000), cfg.L2Claim)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("Safe", func(t *testing.T) {
+		cfg := verifyConfig(2000)
+		require.NoError(t, setVerifyInputs(context.Background(), testlog.Logger(t, log.LvlInfo), rollupCl, cfg))
+		require.Equal(t, common.Hash{0x11}, cfg.L1Head)
+		require.Equal(t, blockHash(1999), cfg.L2Head)
+		require.Equal(t, outputRoot(1999), cfg.L2OutputRoot)
+		require.Equal(t, outputRoot(2000), cfg.L2Claim)
+	})
+
+	t.Run("NoFinalizedL1", func(t *testing.T) {
+		status := *status
+		status.CurrentL1Finalized = eth.L1BlockRef{}
+		cfg := verifyConfig(1000)
+		require.NoError(t, setVerifyInputs(context.Background(), testlog.Logger(t, log.LvlInfo), &stubRollupClient{status: &status}, cfg))
+		require.Equal(t, common.Hash{0x11}, cfg.L1Head)
+	})
+
+	t.Run("NotSafe", func(t *testing.T) {
+		cfg := verifyConfig(2001)
+		err := setVerifyInputs(context.Background(), testlog.Logger(t, log.LvlInfo), rollupCl, cfg)
+		require.ErrorIs(t, err, ErrClaimNotSafe)
+	})
+
+	t.Run("BlockZero", func(t *testing.T) {
+		cfg := verifyConfig(0)
+		err := setVerifyInputs(context.Background(), testlog.Logger(t, log.LvlInfo), rollupCl, cfg)
+		require.ErrorIs(t, err, config.ErrInvalidL2ClaimBlock)
+	})
+
+	t.Run("OutputUnavailable", func(t *testing.T) {
+		cfg := verifyConfig(1000)
+		err := setVerifyInputs(context.Background(), testlog.Logger(t, log.LvlInfo), &stubRollupClient{}, cfg)
+		require.ErrorIs(t, err, errOutputUnavailable)
+	})
+
+	t.Run("LoadChainConfigs", func(t *testing.T) {
+		cfg := verifyConfig(1000)
+		cfg.Rollup = nil
+		cfg.L2ChainConfig = nil
+		rollupCl := &stubRollupClient{status: status, rollupCfg: chaincfg.Goerli}
+		require.NoError(t, setVerifyInputs(context.Background(), testlog.Logger(t, log.LvlInfo), rollupCl, cfg))
+		require.Equal(t, chaincfg.Goerli, cfg.Rollup)
+		require.Equal(t, chaincfg.Goerli.L2ChainID, cfg.L2ChainConfig.ChainID)
+	})
+}
+
+func verifyConfig(blockNum uint64) *config.Config {
+	cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, common.Hash{}, common.Hash{}, common.Hash{}, common.Hash{}, blockNum)
+	cfg.L1URL = "http://localhost:8545"
+	cfg.L2URL = "http://localhost:9545"
+	cfg.RollupURL = "http://localhost:7545"
+	return cfg
+}
+
+var errOutputUnavailable = errors.New("output unavailable")
+
+type stubRollupClient struct {
+	status    *eth.SyncStatus
+	rollupCfg *rollup.Config
+}
+
+func (s *stubRollupClient) OutputAtBlock(_ context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	if s.status == nil {
+		return nil, errOutputUnavailable
+	}
+	return &eth.OutputResponse{
+		OutputRoot: eth.Bytes32(outputRoot(blockNum)),
+		BlockRef:   eth.L2BlockRef{Hash: blockHash(blockNum), Number: blockNum},
+		Status:     s.status,
+	}, nil
+}
+
+func (s *stubRollupClient) RollupConfig(_ context.Context) (*rollup.Config, error) {
+	return s.rollupCfg, nil
+}
+
+func blockHash(num uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(num))
+}
+
+func outputRoot(num uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(num + 1_000_000))
+}
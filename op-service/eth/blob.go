@@ -15,6 +15,9 @@ import (
 const (
 	BlobSize        = 4096 * 32
 	MaxBlobDataSize = 4096*31 - 4
+	// MaxBlobsPerBlobTx is the maximum number of blobs a single transaction can carry,
+	// as it's limited by the maximum blob gas per block.
+	MaxBlobsPerBlobTx = params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob
 )
 
 type Blob [BlobSize]byte
@@ -16,4 +16,5 @@ func (*NoopTxMetrics) TxConfirmed(*types.Receipt)        {}
 func (*NoopTxMetrics) TxPublished(string)                {}
 func (*NoopTxMetrics) RecordBasefee(*big.Int)            {}
 func (*NoopTxMetrics) RecordTipCap(*big.Int)             {}
+func (*NoopTxMetrics) RecordBlobBaseFee(*big.Int)        {}
 func (*NoopTxMetrics) RPCError()                         {}
@@ -19,6 +19,7 @@ type TxMetricer interface {
 	TxPublished(string)
 	RecordBasefee(*big.Int)
 	RecordTipCap(*big.Int)
+	RecordBlobBaseFee(*big.Int)
 	RPCError()
 }
 
@@ -35,6 +36,7 @@ type TxMetrics struct {
 	confirmEvent       metrics.EventVec
 	basefee            prometheus.Gauge
 	tipCap             prometheus.Gauge
+	blobBaseFee        prometheus.Gauge
 	rpcError           prometheus.Counter
 }
 
@@ -116,6 +118,12 @@ func MakeTxMetrics(ns string, factory metrics.Factory) TxMetrics {
 			Help:      "Latest L1 suggested tip cap (in Wei)",
 			Subsystem: "txmgr",
 		}),
+		blobBaseFee: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "blob_basefee_wei",
+			Help:      "Latest L1 blob basefee (in Wei)",
+			Subsystem: "txmgr",
+		}),
 		rpcError: factory.NewCounter(prometheus.CounterOpts{
 			Namespace: ns,
 			Name:      "rpc_error_count",
@@ -168,6 +176,11 @@ func (t *TxMetrics) RecordTipCap(tipcap *big.Int) {
 	t.tipCap.Set(tcf)
 }
 
+func (t *TxMetrics) RecordBlobBaseFee(blobBaseFee *big.Int) {
+	bbff, _ := blobBaseFee.Float64()
+	t.blobBaseFee.Set(bbff)
+}
+
 func (t *TxMetrics) RPCError() {
 	t.rpcError.Inc()
 }
@@ -8,11 +8,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
 type priceBumpTest struct {
+	isBlobTx    bool
 	prevGasTip  int64
 	prevBasefee int64
 	newGasTip   int64
@@ -25,7 +27,7 @@ func (tc *priceBumpTest) run(t *testing.T) {
 	prevFC := calcGasFeeCap(big.NewInt(tc.prevBasefee), big.NewInt(tc.prevGasTip))
 	lgr := testlog.Logger(t, log.LvlCrit)
 
-	tip, fc := updateFees(big.NewInt(tc.prevGasTip), prevFC, big.NewInt(tc.newGasTip), big.NewInt(tc.newBasefee), lgr)
+	tip, fc := updateFees(big.NewInt(tc.prevGasTip), prevFC, big.NewInt(tc.newGasTip), big.NewInt(tc.newBasefee), tc.isBlobTx, lgr)
 
 	require.Equal(t, tc.expectedTip, tip.Int64(), "tip must be as expected")
 	require.Equal(t, tc.expectedFC, fc.Int64(), "fee cap must be as expected")
@@ -91,3 +93,48 @@ func TestUpdateFees(t *testing.T) {
 		t.Run(fmt.Sprint(i), test.run)
 	}
 }
+
+func TestUpdateFeesBlobTx(t *testing.T) {
+	require.Equal(t, int64(100), blobPriceBump, "test must be updated if blobPriceBump is adjusted")
+	tests := []priceBumpTest{
+		{
+			isBlobTx:   true,
+			prevGasTip: 100, prevBasefee: 1000,
+			newGasTip: 90, newBasefee: 900,
+			expectedTip: 200, expectedFC: 4200,
+		},
+		{
+			isBlobTx:   true,
+			prevGasTip: 100, prevBasefee: 1000,
+			newGasTip: 101, newBasefee: 2000,
+			expectedTip: 200, expectedFC: 4200,
+		},
+		{
+			isBlobTx:   true,
+			prevGasTip: 100, prevBasefee: 1000,
+			newGasTip: 100, newBasefee: 2500,
+			expectedTip: 200, expectedFC: 5200,
+		},
+		{
+			isBlobTx:   true,
+			prevGasTip: 100, prevBasefee: 1000,
+			newGasTip: 250, newBasefee: 2500,
+			expectedTip: 250, expectedFC: 5250,
+		},
+	}
+	for i, test := range tests {
+		i := i
+		test := test
+		t.Run(fmt.Sprint(i), test.run)
+	}
+}
+
+func TestUpdateBlobFee(t *testing.T) {
+	lgr := testlog.Logger(t, log.LvlCrit)
+	gwei := int64(params.GWei)
+
+	// old blob fee cap is doubled if the suggested one isn't higher
+	require.Equal(t, big.NewInt(4*gwei), updateBlobFee(big.NewInt(2*gwei), big.NewInt(1), lgr))
+	// the suggested blob fee cap is used if higher than the doubled old value
+	require.Equal(t, big.NewInt(10*gwei), updateBlobFee(big.NewInt(2*gwei), big.NewInt(5*gwei), lgr))
+}
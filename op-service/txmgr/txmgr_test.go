@@ -9,8 +9,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 
@@ -95,6 +97,7 @@ type gasPricer struct {
 	mineAtEpoch   int64
 	baseGasTipFee *big.Int
 	baseBaseFee   *big.Int
+	excessBlobGas uint64
 	err           error
 	mu            sync.Mutex
 }
@@ -210,9 +213,11 @@ func (b *mockBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*typ
 	if number != nil {
 		num.Set(number)
 	}
+	excessBlobGas := b.g.excessBlobGas
 	return &types.Header{
-		Number:  num,
-		BaseFee: b.g.basefee(),
+		Number:        num,
+		BaseFee:       b.g.basefee(),
+		ExcessBlobGas: &excessBlobGas,
 	}, nil
 }
 
@@ -417,6 +422,41 @@ func TestTxMgr_CraftTx(t *testing.T) {
 	require.Equal(t, candidate.GasLimit, tx.Gas())
 }
 
+// TestTxMgr_CraftBlobTx ensures that the tx manager will create blob transactions as expected.
+func TestTxMgr_CraftBlobTx(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	candidate := h.createTxCandidate()
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(eth.Data("blob data")))
+	candidate.Blobs = []*eth.Blob{&blob}
+
+	// Craft the transaction.
+	gasTipCap, gasFeeCap := h.gasPricer.feesForEpoch(h.gasPricer.epoch + 1)
+	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), tx.Type())
+
+	// Validate the fee caps. The blob basefee of the mock backend is 1 wei, so the min blob fee
+	// cap applies.
+	require.Equal(t, gasTipCap, tx.GasTipCap())
+	require.Equal(t, gasFeeCap, tx.GasFeeCap())
+	require.Equal(t, minBlobTxFee, tx.BlobGasFeeCap())
+	require.Equal(t, candidate.GasLimit, tx.Gas())
+	require.Equal(t, *candidate.To, *tx.To())
+
+	// Validate the blob hashes and sidecar.
+	sidecar, blobHashes, err := MakeSidecar(candidate.Blobs)
+	require.NoError(t, err)
+	require.Equal(t, blobHashes, tx.BlobHashes())
+	require.Equal(t, sidecar, tx.BlobTxSidecar())
+
+	// Blob txs can't create contracts.
+	candidate.To = nil
+	_, err = h.mgr.craftTx(context.Background(), candidate)
+	require.ErrorContains(t, err, "cannot deploy contracts")
+}
+
 // TestTxMgr_EstimateGas ensures that the tx manager will estimate
 // the gas when candidate gas limit is zero in [CraftTx].
 func TestTxMgr_EstimateGas(t *testing.T) {
@@ -707,6 +747,7 @@ type failingBackend struct {
 	returnSuccessHeader      bool
 	returnSuccessReceipt     bool
 	baseFee, gasTip          *big.Int
+	excessBlobGas            *uint64
 }
 
 // BlockNumber for the failingBackend returns errRpcFailure on the first
@@ -743,8 +784,9 @@ func (b *failingBackend) HeaderByNumber(ctx context.Context, _ *big.Int) (*types
 	}
 
 	return &types.Header{
-		Number:  big.NewInt(1),
-		BaseFee: b.baseFee,
+		Number:        big.NewInt(1),
+		BaseFee:       b.baseFee,
+		ExcessBlobGas: b.excessBlobGas,
 	}, nil
 }
 
@@ -847,6 +889,61 @@ func doGasPriceIncrease(t *testing.T, txTipCap, txFeeCap, newTip, newBaseFee int
 	return tx, newTx
 }
 
+func TestIncreaseGasPriceBlobTx(t *testing.T) {
+	require.Equal(t, int64(100), blobPriceBump, "test must be updated if blobPriceBump is adjusted")
+	excessBlobGas := uint64(0) // blob basefee of 1 wei
+	borkedBackend := failingBackend{
+		gasTip:              big.NewInt(10),
+		baseFee:             big.NewInt(45),
+		excessBlobGas:       &excessBlobGas,
+		returnSuccessHeader: true,
+	}
+	mgr := &SimpleTxManager{
+		cfg: Config{
+			FeeLimitMultiplier: 5,
+			Signer: func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, nil
+			},
+		},
+		name:    "TEST",
+		backend: &borkedBackend,
+		l:       testlog.Logger(t, log.LvlCrit),
+		metr:    &metrics.NoopTxMetrics{},
+	}
+	sidecar, blobHashes, err := MakeSidecar([]*eth.Blob{{}})
+	require.NoError(t, err)
+	tx := types.NewTx(&types.BlobTx{
+		ChainID:    uint256.NewInt(1),
+		GasTipCap:  uint256.NewInt(10),
+		GasFeeCap:  uint256.NewInt(100),
+		BlobFeeCap: uint256.NewInt(params.GWei),
+		Value:      new(uint256.Int),
+		BlobHashes: blobHashes,
+		Sidecar:    sidecar,
+	})
+
+	// All fee caps must be doubled, and the blobs carried over.
+	newTx, err := mgr.increaseGasPrice(context.Background(), tx)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), newTx.Type())
+	require.Equal(t, big.NewInt(20), newTx.GasTipCap())
+	require.Equal(t, big.NewInt(200), newTx.GasFeeCap())
+	require.Equal(t, big.NewInt(2*params.GWei), newTx.BlobGasFeeCap())
+	require.Equal(t, blobHashes, newTx.BlobHashes())
+	require.Equal(t, sidecar, newTx.BlobTxSidecar())
+
+	// Bumping eventually hits the fee limit.
+	for err == nil {
+		newTx, err = mgr.increaseGasPrice(context.Background(), newTx)
+	}
+	require.ErrorContains(t, err, "over 5x multiple")
+
+	// A blob tx can't be bumped without a blob basefee.
+	borkedBackend.excessBlobGas = nil
+	_, err = mgr.increaseGasPrice(context.Background(), tx)
+	require.ErrorIs(t, err, ErrBlobBaseFeeUnavailable)
+}
+
 func TestIncreaseGasPrice(t *testing.T) {
 	// t.Parallel()
 	require.Equal(t, int64(10), priceBump, "test must be updated if priceBump is adjusted")
@@ -1103,7 +1200,7 @@ func TestMinFees(t *testing.T) {
 			conf.MinTipCap = tt.minTipCap
 			h := newTestHarnessWithConfig(t, conf)
 
-			tip, basefee, err := h.mgr.suggestGasPriceCaps(context.TODO())
+			tip, basefee, _, err := h.mgr.suggestGasPriceCaps(context.TODO())
 			require.NoError(err)
 
 			if tt.expectMinBasefee {
@@ -3,7 +3,9 @@ pragma solidity 0.8.15;
 
 import { IPreimageOracle } from "./interfaces/IPreimageOracle.sol";
 import { PreimageKeyLib } from "./PreimageKeyLib.sol";
+import { LibKeccak } from "./libraries/LibKeccak.sol";
 import "./libraries/CannonErrors.sol";
+import "./libraries/CannonTypes.sol";
 
 /// @title PreimageOracle
 /// @notice A contract for storing permissioned pre-images.
@@ -15,6 +17,50 @@ contract PreimageOracle is IPreimageOracle {
     /// @notice Mapping of pre-image keys to pre-image part offsets.
     mapping(bytes32 => mapping(uint256 => bool)) public preimagePartOk;
 
+    ////////////////////////////////////////////////////////////////
+    //                  Large Preimage Proposals                  //
+    ////////////////////////////////////////////////////////////////
+
+    /// @notice The depth of the merkle tree of large preimage proposal leaves.
+    uint256 public constant KECCAK_TREE_DEPTH = 16;
+    /// @notice The maximum number of leaves in a large preimage proposal.
+    uint256 public constant MAX_LEAF_COUNT = 2 ** KECCAK_TREE_DEPTH - 1;
+    /// @notice The time a finalized large preimage proposal can be challenged for before it can be squeezed.
+    uint256 internal constant CHALLENGE_PERIOD = 1 days;
+
+    /// @notice A leaf of a large preimage proposal: a single block of the padded input, its index and the hash
+    ///         of the ABI encoded state matrix after the block was absorbed.
+    struct Leaf {
+        bytes input;
+        uint256 index;
+        bytes32 stateCommitment;
+    }
+
+    /// @notice Identifies a large preimage proposal by the account that created it and the UUID it chose.
+    struct LargePreimageProposalKeys {
+        address claimant;
+        uint256 uuid;
+    }
+
+    /// @notice The roots of empty subtrees of the proposal merkle tree, by height.
+    bytes32[KECCAK_TREE_DEPTH] public zeroHashes;
+    /// @notice All large preimage proposals that have been initialized.
+    LargePreimageProposalKeys[] public proposals;
+    /// @notice Mapping of claimants to proposal UUIDs to the branch of the incremental merkle tree of leaves.
+    mapping(address => mapping(uint256 => bytes32[KECCAK_TREE_DEPTH])) public proposalBranches;
+    /// @notice Mapping of claimants to proposal UUIDs to the packed proposal metadata.
+    mapping(address => mapping(uint256 => LPPMetaData)) public proposalMetadata;
+    /// @notice Mapping of claimants to proposal UUIDs to the preimage part at the proposal's part offset.
+    mapping(address => mapping(uint256 => bytes32)) public proposalParts;
+    /// @notice Mapping of claimants to proposal UUIDs to the L1 block numbers leaves were added in.
+    mapping(address => mapping(uint256 => uint64[])) public proposalBlocks;
+
+    constructor() {
+        for (uint256 height = 0; height < KECCAK_TREE_DEPTH - 1; height++) {
+            zeroHashes[height + 1] = keccak256(abi.encodePacked(zeroHashes[height], zeroHashes[height]));
+        }
+    }
+
     /// @inheritdoc IPreimageOracle
     function readPreimage(bytes32 _key, uint256 _offset) external view returns (bytes32 dat_, uint256 datLen_) {
         require(preimagePartOk[_key][_offset], "pre-image must exist");
@@ -106,4 +152,299 @@ contract PreimageOracle is IPreimageOracle {
         preimageParts[key][_partOffset] = part;
         preimageLengths[key] = size;
     }
+
+    /// @notice Returns the number of large preimage proposals that have been initialized.
+    /// @return count_ The number of proposals.
+    function proposalCount() external view returns (uint256 count_) {
+        count_ = proposals.length;
+    }
+
+    /// @notice Returns the number of L1 blocks that leaves were added to a large preimage proposal in.
+    /// @param _claimant The account that created the proposal.
+    /// @param _uuid The UUID of the proposal.
+    /// @return len_ The number of blocks.
+    function proposalBlocksLen(address _claimant, uint256 _uuid) external view returns (uint256 len_) {
+        len_ = proposalBlocks[_claimant][_uuid].length;
+    }
+
+    /// @notice Returns the time a finalized large preimage proposal can be challenged for.
+    /// @return challengePeriod_ The challenge period in seconds.
+    function challengePeriod() external pure returns (uint256 challengePeriod_) {
+        challengePeriod_ = CHALLENGE_PERIOD;
+    }
+
+    /// @notice Initializes a large preimage proposal for the caller.
+    /// @param _uuid The UUID of the proposal, chosen by the caller.
+    /// @param _partOffset The offset of the preimage part to load once the proposal is squeezed.
+    /// @param _claimedSize The size of the preimage in bytes, excluding the length prefix.
+    function initLPP(uint256 _uuid, uint32 _partOffset, uint32 _claimedSize) external {
+        if (_claimedSize == 0) revert InvalidInputSize();
+        if (proposalMetadata[msg.sender][_uuid].claimedSize() != 0) revert AlreadyInitialized();
+        if (uint256(_partOffset) >= uint256(_claimedSize) + 8) revert PartOffsetOOB();
+
+        // Part offsets within the length prefix take the prefix bytes from the claimed size.
+        if (_partOffset < 8) {
+            proposalParts[msg.sender][_uuid] = bytes32((uint256(_claimedSize) << 192) << (uint256(_partOffset) * 8));
+        }
+
+        proposalMetadata[msg.sender][_uuid] =
+            LPPMetaData.wrap(0).setPartOffset(_partOffset).setClaimedSize(_claimedSize);
+        proposals.push(LargePreimageProposalKeys({ claimant: msg.sender, uuid: _uuid }));
+    }
+
+    /// @notice Adds leaves to a large preimage proposal of the caller. The caller must be an EOA so that the input
+    ///         data is available in the transaction for challengers to verify.
+    /// @param _uuid The UUID of the proposal.
+    /// @param _inputStartBlock The index of the first block of `_input`, which must be the next block of the proposal.
+    /// @param _input The input data. Must be a multiple of `LibKeccak.BLOCK_SIZE_BYTES` unless finalizing, in which
+    ///               case it is padded by the contract.
+    /// @param _stateCommitments The commitments to the state matrix after each block of the input is absorbed.
+    /// @param _finalize Whether `_input` is the end of the preimage.
+    function addLeavesLPP(
+        uint256 _uuid,
+        uint256 _inputStartBlock,
+        bytes calldata _input,
+        bytes32[] calldata _stateCommitments,
+        bool _finalize
+    )
+        external
+    {
+        if (msg.sender != tx.origin) revert NotEOA();
+
+        LPPMetaData metaData = proposalMetadata[msg.sender][_uuid];
+        if (metaData.claimedSize() == 0) revert NotInitialized();
+        if (metaData.timestamp() != 0) revert AlreadyFinalized();
+        if (metaData.blocksProcessed() != _inputStartBlock) revert WrongStartingBlock();
+
+        uint256 bytesProcessed = metaData.bytesProcessed() + _input.length;
+        // The final call may carry no new bytes, so the claimed size can be reached before finalizing.
+        if (_finalize ? bytesProcessed != metaData.claimedSize() : bytesProcessed > metaData.claimedSize()) {
+            revert InvalidInputSize();
+        }
+
+        _recordPart(metaData, _uuid, _input);
+
+        bytes memory input = _input;
+        if (_finalize) input = LibKeccak.pad(input);
+        if (input.length % LibKeccak.BLOCK_SIZE_BYTES != 0) revert InvalidInputSize();
+        if (_stateCommitments.length != input.length / LibKeccak.BLOCK_SIZE_BYTES) revert InvalidInputSize();
+        uint256 blocksProcessed = _inputStartBlock + _stateCommitments.length;
+        if (blocksProcessed > MAX_LEAF_COUNT) revert TreeSizeOverflow();
+
+        _addLeaves(_uuid, _inputStartBlock, input, _stateCommitments);
+
+        metaData = metaData.setBlocksProcessed(uint32(blocksProcessed)).setBytesProcessed(uint32(bytesProcessed));
+        if (_finalize) metaData = metaData.setTimestamp(uint64(block.timestamp));
+        proposalMetadata[msg.sender][_uuid] = metaData;
+        proposalBlocks[msg.sender][_uuid].push(uint64(block.number));
+    }
+
+    /// @notice Squeezes a finalized, unchallenged large preimage proposal once its challenge period has passed,
+    ///         loading the proposal's preimage part into the oracle.
+    /// @param _claimant The account that created the proposal.
+    /// @param _uuid The UUID of the proposal.
+    /// @param _stateMatrix The state matrix before the final leaf was absorbed.
+    /// @param _preState The second to last leaf of the proposal.
+    /// @param _preStateProof The merkle proof of `_preState`.
+    /// @param _postState The final leaf of the proposal.
+    /// @param _postStateProof The merkle proof of `_postState`.
+    function squeezeLPP(
+        address _claimant,
+        uint256 _uuid,
+        LibKeccak.StateMatrix memory _stateMatrix,
+        Leaf calldata _preState,
+        bytes32[] calldata _preStateProof,
+        Leaf calldata _postState,
+        bytes32[] calldata _postStateProof
+    )
+        external
+    {
+        LPPMetaData metaData = proposalMetadata[_claimant][_uuid];
+        if (metaData.timestamp() == 0 || block.timestamp - metaData.timestamp() <= CHALLENGE_PERIOD) {
+            revert ActiveProposal();
+        }
+        if (metaData.countered()) revert BadProposal();
+        if (_postState.index + 1 != metaData.blocksProcessed()) revert StatesNotContiguous();
+
+        _verifyTransition(_claimant, _uuid, _stateMatrix, _preState, _preStateProof, _postState, _postStateProof);
+        if (keccak256(abi.encode(_stateMatrix)) != _postState.stateCommitment) revert InvalidPreimage();
+
+        bytes32 key = LibKeccak.squeeze(_stateMatrix);
+        assembly {
+            // Mask out the prefix byte, replace with the type 2 byte
+            key := or(and(key, not(shl(248, 0xFF))), shl(248, 2))
+        }
+        uint256 partOffset = metaData.partOffset();
+        preimagePartOk[key][partOffset] = true;
+        preimageParts[key][partOffset] = proposalParts[_claimant][_uuid];
+        preimageLengths[key] = metaData.claimedSize();
+    }
+
+    /// @notice Challenges a large preimage proposal by showing that absorbing `_postState` into the state committed
+    ///         to by `_preState` does not result in the state committed to by `_postState`.
+    /// @param _claimant The account that created the proposal.
+    /// @param _uuid The UUID of the proposal.
+    /// @param _stateMatrix The state matrix committed to by `_preState`.
+    /// @param _preState The leaf before the invalid leaf.
+    /// @param _preStateProof The merkle proof of `_preState`.
+    /// @param _postState The invalid leaf.
+    /// @param _postStateProof The merkle proof of `_postState`.
+    function challengeLPP(
+        address _claimant,
+        uint256 _uuid,
+        LibKeccak.StateMatrix memory _stateMatrix,
+        Leaf calldata _preState,
+        bytes32[] calldata _preStateProof,
+        Leaf calldata _postState,
+        bytes32[] calldata _postStateProof
+    )
+        external
+    {
+        _verifyTransition(_claimant, _uuid, _stateMatrix, _preState, _preStateProof, _postState, _postStateProof);
+        if (keccak256(abi.encode(_stateMatrix)) == _postState.stateCommitment) revert PostStateMatches();
+
+        proposalMetadata[_claimant][_uuid] = proposalMetadata[_claimant][_uuid].setCountered(true);
+    }
+
+    /// @notice Challenges a large preimage proposal by showing that absorbing its first leaf into the empty state
+    ///         does not result in the state committed to by the leaf.
+    /// @param _claimant The account that created the proposal.
+    /// @param _uuid The UUID of the proposal.
+    /// @param _postState The first leaf of the proposal.
+    /// @param _postStateProof The merkle proof of `_postState`.
+    function challengeFirstLPP(
+        address _claimant,
+        uint256 _uuid,
+        Leaf calldata _postState,
+        bytes32[] calldata _postStateProof
+    )
+        external
+    {
+        if (_postState.index != 0) revert StatesNotContiguous();
+        if (!_verify(_postStateProof, getTreeRootLPP(_claimant, _uuid), _postState)) revert InvalidProof();
+
+        LibKeccak.StateMatrix memory stateMatrix;
+        LibKeccak.absorb(stateMatrix, _postState.input);
+        LibKeccak.permutation(stateMatrix);
+        if (keccak256(abi.encode(stateMatrix)) == _postState.stateCommitment) revert PostStateMatches();
+
+        proposalMetadata[_claimant][_uuid] = proposalMetadata[_claimant][_uuid].setCountered(true);
+    }
+
+    /// @notice Returns the root of the merkle tree of leaves of a large preimage proposal.
+    /// @param _claimant The account that created the proposal.
+    /// @param _uuid The UUID of the proposal.
+    /// @return treeRoot_ The merkle root.
+    function getTreeRootLPP(address _claimant, uint256 _uuid) public view returns (bytes32 treeRoot_) {
+        uint256 size = proposalMetadata[_claimant][_uuid].blocksProcessed();
+        for (uint256 height = 0; height < KECCAK_TREE_DEPTH; height++) {
+            if ((size & 1) == 1) {
+                treeRoot_ = keccak256(abi.encodePacked(proposalBranches[_claimant][_uuid][height], treeRoot_));
+            } else {
+                treeRoot_ = keccak256(abi.encodePacked(treeRoot_, zeroHashes[height]));
+            }
+            size >>= 1;
+        }
+    }
+
+    /// @notice Verifies the proofs of two contiguous leaves of a large preimage proposal, and that `_stateMatrix`
+    ///         matches the commitment of `_preState`. On success, `_postState` has been absorbed into `_stateMatrix`.
+    function _verifyTransition(
+        address _claimant,
+        uint256 _uuid,
+        LibKeccak.StateMatrix memory _stateMatrix,
+        Leaf calldata _preState,
+        bytes32[] calldata _preStateProof,
+        Leaf calldata _postState,
+        bytes32[] calldata _postStateProof
+    )
+        internal
+        view
+    {
+        if (_preState.index + 1 != _postState.index) revert StatesNotContiguous();
+
+        bytes32 root = getTreeRootLPP(_claimant, _uuid);
+        if (!_verify(_preStateProof, root, _preState)) revert InvalidProof();
+        if (!_verify(_postStateProof, root, _postState)) revert InvalidProof();
+
+        if (keccak256(abi.encode(_stateMatrix)) != _preState.stateCommitment) revert InvalidPreimage();
+        LibKeccak.absorb(_stateMatrix, _postState.input);
+        LibKeccak.permutation(_stateMatrix);
+    }
+
+    /// @notice Checks a merkle proof of a large preimage proposal leaf against the tree root.
+    function _verify(bytes32[] calldata _proof, bytes32 _root, Leaf calldata _leaf) internal pure returns (bool) {
+        if (_proof.length != KECCAK_TREE_DEPTH) return false;
+
+        bytes32 node = keccak256(abi.encodePacked(_leaf.input, _leaf.index, _leaf.stateCommitment));
+        for (uint256 height = 0; height < KECCAK_TREE_DEPTH; height++) {
+            if (((_leaf.index >> height) & 1) == 1) {
+                node = keccak256(abi.encodePacked(_proof[height], node));
+            } else {
+                node = keccak256(abi.encodePacked(node, _proof[height]));
+            }
+        }
+        return node == _root;
+    }
+
+    /// @notice Inserts the blocks of `_input` into the merkle tree of the caller's proposal as leaves.
+    function _addLeaves(
+        uint256 _uuid,
+        uint256 _inputStartBlock,
+        bytes memory _input,
+        bytes32[] calldata _stateCommitments
+    )
+        internal
+    {
+        bytes32[KECCAK_TREE_DEPTH] memory branch = proposalBranches[msg.sender][_uuid];
+        for (uint256 i = 0; i < _stateCommitments.length; i++) {
+            uint256 index = _inputStartBlock + i;
+            bytes32 commitment = _stateCommitments[i];
+            bytes32 node;
+            assembly {
+                // Hash the block, its index and the state commitment in scratch memory past the free memory
+                // pointer: block (136 bytes) .. index (32 bytes) .. commitment (32 bytes).
+                let ptr := mload(0x40)
+                let blockPtr := add(add(_input, 0x20), mul(i, 136))
+                mstore(ptr, mload(blockPtr))
+                mstore(add(ptr, 0x20), mload(add(blockPtr, 0x20)))
+                mstore(add(ptr, 0x40), mload(add(blockPtr, 0x40)))
+                mstore(add(ptr, 0x60), mload(add(blockPtr, 0x60)))
+                mstore(add(ptr, 0x80), mload(add(blockPtr, 0x80)))
+                mstore(add(ptr, 136), index)
+                mstore(add(ptr, 168), commitment)
+                node := keccak256(ptr, 200)
+            }
+
+            // Insert the leaf into the incremental merkle tree.
+            uint256 size = index + 1;
+            for (uint256 height = 0; height < KECCAK_TREE_DEPTH; height++) {
+                if ((size & 1) == 1) {
+                    branch[height] = node;
+                    break;
+                }
+                node = keccak256(abi.encodePacked(branch[height], node));
+                size >>= 1;
+            }
+        }
+        proposalBranches[msg.sender][_uuid] = branch;
+    }
+
+    /// @notice Records the bytes of `_input` that fall within the preimage part of the caller's proposal. The part
+    ///         may span several calls, in which case each call fills in its bytes.
+    function _recordPart(LPPMetaData _metaData, uint256 _uuid, bytes calldata _input) internal {
+        uint256 partStart = _metaData.partOffset();
+        // Offsets include the 8 byte length prefix of the preimage.
+        uint256 inputStart = uint256(_metaData.bytesProcessed()) + 8;
+        uint256 inputEnd = inputStart + _input.length;
+        if (partStart >= inputEnd || partStart + 32 <= inputStart) return;
+
+        bytes32 part = proposalParts[msg.sender][_uuid];
+        uint256 from = partStart > inputStart ? partStart : inputStart;
+        uint256 to = partStart + 32 < inputEnd ? partStart + 32 : inputEnd;
+        for (uint256 i = from; i < to; i++) {
+            part |= bytes32(_input[i - inputStart]) >> ((i - partStart) * 8);
+        }
+        proposalParts[msg.sender][_uuid] = part;
+    }
 }
@@ -3,3 +3,42 @@ pragma solidity 0.8.15;
 
 /// @notice Thrown when a passed part offset is out of bounds.
 error PartOffsetOOB();
+
+/// @notice Thrown when leaves are added to a large preimage proposal by a contract rather than an EOA.
+error NotEOA();
+
+/// @notice Thrown when a large preimage proposal is initialized more than once.
+error AlreadyInitialized();
+
+/// @notice Thrown when leaves are added to a large preimage proposal that has not been initialized.
+error NotInitialized();
+
+/// @notice Thrown when leaves are added to a large preimage proposal that has already been finalized.
+error AlreadyFinalized();
+
+/// @notice Thrown when the input added to a large preimage proposal does not match the blocks or size expected.
+error InvalidInputSize();
+
+/// @notice Thrown when the leaves added to a large preimage proposal do not start at the next block.
+error WrongStartingBlock();
+
+/// @notice Thrown when a large preimage proposal would exceed the capacity of its merkle tree.
+error TreeSizeOverflow();
+
+/// @notice Thrown when a merkle proof for a large preimage proposal leaf is invalid.
+error InvalidProof();
+
+/// @notice Thrown when the leaves passed for a large preimage proposal are not contiguous or not the final leaves.
+error StatesNotContiguous();
+
+/// @notice Thrown when the state matrix does not match the state commitment of a large preimage proposal leaf.
+error InvalidPreimage();
+
+/// @notice Thrown when a challenge fails because the post state of a large preimage proposal leaf is correct.
+error PostStateMatches();
+
+/// @notice Thrown when a large preimage proposal is squeezed before its challenge period has passed.
+error ActiveProposal();
+
+/// @notice Thrown when a large preimage proposal that has been successfully challenged is squeezed.
+error BadProposal();
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.15;
+
+using LPPMetadataLib for LPPMetaData global;
+
+/// @notice Packed metadata of a large preimage proposal.
+/// @dev Layout, from the most significant byte:
+///      ┌─────────┬─────────────────────────────────────────────┐
+///      │ Bytes   │ Description                                 │
+///      ├─────────┼─────────────────────────────────────────────┤
+///      │ [0, 8)  │ Timestamp the proposal was finalized at     │
+///      │ [8, 12) │ Part offset                                 │
+///      │ [12,16) │ Claimed size                                │
+///      │ [16,20) │ Blocks processed, including padding         │
+///      │ [20,24) │ Bytes processed, excluding padding          │
+///      │ [24,32) │ Countered, non-zero if a challenge succeeded│
+///      └─────────┴─────────────────────────────────────────────┘
+type LPPMetaData is bytes32;
+
+/// @title LPPMetadataLib
+/// @notice Accessors for the packed fields of `LPPMetaData`.
+library LPPMetadataLib {
+    uint256 private constant U64_MASK = 0xFFFFFFFFFFFFFFFF;
+    uint256 private constant U32_MASK = 0xFFFFFFFF;
+
+    function setTimestamp(LPPMetaData _self, uint64 _timestamp) internal pure returns (LPPMetaData self_) {
+        assembly {
+            self_ := or(shl(192, _timestamp), and(_self, not(shl(192, U64_MASK))))
+        }
+    }
+
+    function setPartOffset(LPPMetaData _self, uint32 _partOffset) internal pure returns (LPPMetaData self_) {
+        assembly {
+            self_ := or(shl(160, _partOffset), and(_self, not(shl(160, U32_MASK))))
+        }
+    }
+
+    function setClaimedSize(LPPMetaData _self, uint32 _claimedSize) internal pure returns (LPPMetaData self_) {
+        assembly {
+            self_ := or(shl(128, _claimedSize), and(_self, not(shl(128, U32_MASK))))
+        }
+    }
+
+    function setBlocksProcessed(LPPMetaData _self, uint32 _blocksProcessed) internal pure returns (LPPMetaData self_) {
+        assembly {
+            self_ := or(shl(96, _blocksProcessed), and(_self, not(shl(96, U32_MASK))))
+        }
+    }
+
+    function setBytesProcessed(LPPMetaData _self, uint32 _bytesProcessed) internal pure returns (LPPMetaData self_) {
+        assembly {
+            self_ := or(shl(64, _bytesProcessed), and(_self, not(shl(64, U32_MASK))))
+        }
+    }
+
+    function setCountered(LPPMetaData _self, bool _countered) internal pure returns (LPPMetaData self_) {
+        assembly {
+            self_ := or(_countered, and(_self, not(U64_MASK)))
+        }
+    }
+
+    function timestamp(LPPMetaData _self) internal pure returns (uint64 timestamp_) {
+        assembly {
+            timestamp_ := shr(192, _self)
+        }
+    }
+
+    function partOffset(LPPMetaData _self) internal pure returns (uint32 partOffset_) {
+        assembly {
+            partOffset_ := and(shr(160, _self), U32_MASK)
+        }
+    }
+
+    function claimedSize(LPPMetaData _self) internal pure returns (uint32 claimedSize_) {
+        assembly {
+            claimedSize_ := and(shr(128, _self), U32_MASK)
+        }
+    }
+
+    function blocksProcessed(LPPMetaData _self) internal pure returns (uint32 blocksProcessed_) {
+        assembly {
+            blocksProcessed_ := and(shr(96, _self), U32_MASK)
+        }
+    }
+
+    function bytesProcessed(LPPMetaData _self) internal pure returns (uint32 bytesProcessed_) {
+        assembly {
+            bytesProcessed_ := and(shr(64, _self), U32_MASK)
+        }
+    }
+
+    function countered(LPPMetaData _self) internal pure returns (bool countered_) {
+        assembly {
+            countered_ := iszero(iszero(and(_self, U64_MASK)))
+        }
+    }
+}
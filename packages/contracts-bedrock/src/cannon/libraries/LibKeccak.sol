@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.15;
+
+/// @title LibKeccak
+/// @notice An implementation of the Keccak-256 sponge over the `keccak-f[1600]` permutation, exposing the
+///         intermediate state so that the absorption of large inputs can be verified one block at a time.
+library LibKeccak {
+    /// @notice The number of bytes absorbed into the state per block (the rate of Keccak-256).
+    uint256 internal constant BLOCK_SIZE_BYTES = 136;
+
+    /// @notice The Keccak state matrix. Lanes are indexed by `x + 5 * y`.
+    struct StateMatrix {
+        uint64[25] state;
+    }
+
+    /// @notice Absorbs a single block of input into the state matrix. The permutation is not applied.
+    /// @param _stateMatrix The state matrix to absorb the block into.
+    /// @param _input The block of input, exactly `BLOCK_SIZE_BYTES` in length.
+    function absorb(StateMatrix memory _stateMatrix, bytes memory _input) internal pure {
+        require(_input.length == BLOCK_SIZE_BYTES, "LibKeccak: input must be exactly one block");
+
+        // XOR the input into the first 17 lanes, each read as a little-endian 64-bit word.
+        for (uint256 i = 0; i < BLOCK_SIZE_BYTES / 8; i++) {
+            uint64 lane;
+            for (uint256 j = 0; j < 8; j++) {
+                lane |= uint64(uint8(_input[i * 8 + j])) << (j * 8);
+            }
+            _stateMatrix.state[i] ^= lane;
+        }
+    }
+
+    /// @notice Applies the `keccak-f[1600]` permutation to the state matrix in place.
+    /// @param _stateMatrix The state matrix to permute.
+    function permutation(StateMatrix memory _stateMatrix) internal pure {
+        uint64[25] memory a = _stateMatrix.state;
+        uint64[25] memory b;
+        uint64[5] memory c;
+        uint64[24] memory roundConstants = [
+            uint64(0x0000000000000001),
+            0x0000000000008082,
+            0x800000000000808A,
+            0x8000000080008000,
+            0x000000000000808B,
+            0x0000000080000001,
+            0x8000000080008081,
+            0x8000000000008009,
+            0x000000000000008A,
+            0x0000000000000088,
+            0x0000000080008009,
+            0x000000008000000A,
+            0x000000008000808B,
+            0x800000000000008B,
+            0x8000000000008089,
+            0x8000000000008003,
+            0x8000000000008002,
+            0x8000000000000080,
+            0x000000000000800A,
+            0x800000008000000A,
+            0x8000000080008081,
+            0x8000000000008080,
+            0x0000000080000001,
+            0x8000000080008008
+        ];
+        uint8[25] memory rotations = [
+            uint8(0),
+            1,
+            62,
+            28,
+            27,
+            36,
+            44,
+            6,
+            55,
+            20,
+            3,
+            10,
+            43,
+            25,
+            39,
+            41,
+            45,
+            15,
+            21,
+            8,
+            18,
+            2,
+            61,
+            56,
+            14
+        ];
+
+        for (uint256 round = 0; round < 24; round++) {
+            // Theta step
+            for (uint256 x = 0; x < 5; x++) {
+                c[x] = a[x] ^ a[x + 5] ^ a[x + 10] ^ a[x + 15] ^ a[x + 20];
+            }
+            for (uint256 x = 0; x < 5; x++) {
+                uint64 d = c[(x + 4) % 5] ^ _rotl(c[(x + 1) % 5], 1);
+                for (uint256 y = 0; y < 25; y += 5) {
+                    a[x + y] ^= d;
+                }
+            }
+
+            // Rho and pi steps
+            for (uint256 x = 0; x < 5; x++) {
+                for (uint256 y = 0; y < 5; y++) {
+                    b[y + 5 * ((2 * x + 3 * y) % 5)] = _rotl(a[x + 5 * y], rotations[x + 5 * y]);
+                }
+            }
+
+            // Chi step
+            for (uint256 y = 0; y < 25; y += 5) {
+                for (uint256 x = 0; x < 5; x++) {
+                    a[x + y] = b[x + y] ^ (~b[(x + 1) % 5 + y] & b[(x + 2) % 5 + y]);
+                }
+            }
+
+            // Iota step
+            a[0] ^= roundConstants[round];
+        }
+    }
+
+    /// @notice Squeezes the Keccak-256 digest out of the state matrix. Only meaningful once the final, padded
+    ///         block of the input has been absorbed and permuted.
+    /// @param _stateMatrix The state matrix to squeeze.
+    /// @return hash_ The Keccak-256 digest.
+    function squeeze(StateMatrix memory _stateMatrix) internal pure returns (bytes32 hash_) {
+        // The digest is the first 4 lanes, each written as a little-endian 64-bit word.
+        for (uint256 i = 0; i < 4; i++) {
+            uint64 lane = _stateMatrix.state[i];
+            for (uint256 j = 0; j < 8; j++) {
+                hash_ |= bytes32(uint256(uint8(lane >> (j * 8))) << (8 * (31 - (i * 8 + j))));
+            }
+        }
+    }
+
+    /// @notice Applies the Keccak-256 multi-rate padding to the input, extending it to a multiple of
+    ///         `BLOCK_SIZE_BYTES`. Input that is already a multiple of the block size gains a full block of padding.
+    /// @param _input The input to pad.
+    /// @return padded_ The padded input.
+    function pad(bytes memory _input) internal pure returns (bytes memory padded_) {
+        uint256 length = _input.length;
+        uint256 paddedLength = length + BLOCK_SIZE_BYTES - (length % BLOCK_SIZE_BYTES);
+        padded_ = bytes.concat(_input, new bytes(paddedLength - length));
+        padded_[length] = bytes1(0x01);
+        padded_[paddedLength - 1] |= bytes1(0x80);
+    }
+
+    /// @notice Rotates a lane left by `_n` bits.
+    function _rotl(uint64 _x, uint256 _n) private pure returns (uint64) {
+        if (_n == 0) return _x;
+        return (_x << _n) | (_x >> (64 - _n));
+    }
+}
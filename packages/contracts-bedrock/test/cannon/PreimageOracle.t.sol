@@ -5,7 +5,9 @@ import { Test } from "forge-std/Test.sol";
 
 import { PreimageOracle } from "src/cannon/PreimageOracle.sol";
 import { PreimageKeyLib } from "src/cannon/PreimageKeyLib.sol";
+import { LibKeccak } from "src/cannon/libraries/LibKeccak.sol";
 import "src/cannon/libraries/CannonErrors.sol";
+import "src/cannon/libraries/CannonTypes.sol";
 
 contract PreimageOracle_Test is Test {
     PreimageOracle oracle;
@@ -160,3 +162,299 @@ contract PreimageOracle_Test is Test {
         oracle.readPreimage(key, offset);
     }
 }
+
+contract PreimageOracle_LargePreimageProposals_Test is Test {
+    PreimageOracle oracle;
+
+    /// @notice The size of the preimage used by the tests, spanning 4 padded blocks.
+    uint32 constant PREIMAGE_SIZE = 500;
+
+    /// @notice Sets up the testing suite.
+    function setUp() public {
+        oracle = new PreimageOracle();
+        vm.label(address(oracle), "PreimageOracle");
+    }
+
+    /// @notice Tests that a large preimage proposal can be initialized.
+    function test_initLPP_succeeds() public {
+        oracle.initLPP(1, 16, PREIMAGE_SIZE);
+
+        LPPMetaData metaData = oracle.proposalMetadata(address(this), 1);
+        assertEq(uint256(metaData.partOffset()), 16);
+        assertEq(uint256(metaData.claimedSize()), PREIMAGE_SIZE);
+        assertEq(uint256(metaData.timestamp()), 0);
+        assertFalse(metaData.countered());
+
+        assertEq(oracle.proposalCount(), 1);
+        (address claimant, uint256 uuid) = oracle.proposals(0);
+        assertEq(claimant, address(this));
+        assertEq(uuid, 1);
+    }
+
+    /// @notice Tests that a large preimage proposal cannot be initialized twice.
+    function test_initLPP_alreadyInitialized_reverts() public {
+        oracle.initLPP(1, 0, PREIMAGE_SIZE);
+
+        vm.expectRevert(AlreadyInitialized.selector);
+        oracle.initLPP(1, 0, PREIMAGE_SIZE);
+    }
+
+    /// @notice Tests that a large preimage proposal cannot be initialized with an out-of-bounds part offset.
+    function test_initLPP_outOfBoundsOffset_reverts() public {
+        vm.expectRevert(PartOffsetOOB.selector);
+        oracle.initLPP(1, PREIMAGE_SIZE + 8, PREIMAGE_SIZE);
+    }
+
+    /// @notice Tests that leaves can only be added by an EOA.
+    function test_addLeavesLPP_notEOA_reverts() public {
+        oracle.initLPP(1, 0, PREIMAGE_SIZE);
+        (PreimageOracle.Leaf[] memory leaves,) = _generateLeaves(_preimage());
+
+        vm.expectRevert(NotEOA.selector);
+        oracle.addLeavesLPP(1, 0, _preimage(), _commitments(leaves), true);
+    }
+
+    /// @notice Tests that leaves must be added starting from the next block of the proposal.
+    function test_addLeavesLPP_wrongStartingBlock_reverts() public {
+        oracle.initLPP(1, 0, PREIMAGE_SIZE);
+        (PreimageOracle.Leaf[] memory leaves,) = _generateLeaves(_preimage());
+
+        vm.prank(address(this), address(this));
+        vm.expectRevert(WrongStartingBlock.selector);
+        oracle.addLeavesLPP(1, 1, _preimage(), _commitments(leaves), true);
+    }
+
+    /// @notice Tests that leaves can be added in multiple calls, finalizing the proposal.
+    function test_addLeavesLPP_multipleCalls_succeeds() public {
+        bytes memory data = _preimage();
+        oracle.initLPP(1, 0, PREIMAGE_SIZE);
+        (PreimageOracle.Leaf[] memory leaves,) = _generateLeaves(data);
+        bytes32[] memory commitments = _commitments(leaves);
+
+        bytes32[] memory first = new bytes32[](2);
+        first[0] = commitments[0];
+        first[1] = commitments[1];
+        bytes32[] memory rest = new bytes32[](2);
+        rest[0] = commitments[2];
+        rest[1] = commitments[3];
+
+        vm.startPrank(address(this), address(this));
+        oracle.addLeavesLPP(1, 0, _slice(data, 0, 272), first, false);
+        oracle.addLeavesLPP(1, 2, _slice(data, 272, data.length), rest, true);
+        vm.stopPrank();
+
+        LPPMetaData metaData = oracle.proposalMetadata(address(this), 1);
+        assertEq(uint256(metaData.blocksProcessed()), 4);
+        assertEq(uint256(metaData.bytesProcessed()), PREIMAGE_SIZE);
+        assertEq(uint256(metaData.timestamp()), block.timestamp);
+        assertEq(oracle.proposalBlocksLen(address(this), 1), 2);
+        assertEq(uint256(oracle.proposalBlocks(address(this), 1, 0)), block.number);
+        assertEq(oracle.getTreeRootLPP(address(this), 1), _root(leaves));
+    }
+
+    /// @notice Tests that a finalized, unchallenged proposal can be squeezed once its challenge period has passed.
+    function test_squeezeLPP_succeeds() public {
+        bytes memory data = _preimage();
+        uint32 partOffset = 150;
+        (PreimageOracle.Leaf[] memory leaves, LibKeccak.StateMatrix[] memory preStates) = _submit(data, partOffset);
+
+        vm.warp(block.timestamp + oracle.challengePeriod() + 1);
+        oracle.squeezeLPP(address(this), 1, preStates[3], leaves[2], _proof(leaves, 2), leaves[3], _proof(leaves, 3));
+
+        bytes32 key = PreimageKeyLib.keccak256PreimageKey(data);
+        assertTrue(oracle.preimagePartOk(key, partOffset));
+        assertEq(oracle.preimageLengths(key), PREIMAGE_SIZE);
+        bytes memory prefixed = abi.encodePacked(uint64(PREIMAGE_SIZE), data);
+        assertEq(oracle.preimageParts(key, partOffset), bytes32(_slice(prefixed, partOffset, partOffset + 32)));
+    }
+
+    /// @notice Tests that a proposal cannot be squeezed during its challenge period.
+    function test_squeezeLPP_challengePeriodActive_reverts() public {
+        (PreimageOracle.Leaf[] memory leaves, LibKeccak.StateMatrix[] memory preStates) = _submit(_preimage(), 0);
+
+        vm.expectRevert(ActiveProposal.selector);
+        oracle.squeezeLPP(address(this), 1, preStates[3], leaves[2], _proof(leaves, 2), leaves[3], _proof(leaves, 3));
+    }
+
+    /// @notice Tests that a proposal with an invalid state commitment can be challenged and is not squeezed.
+    function test_challengeLPP_succeeds() public {
+        bytes memory data = _preimage();
+        oracle.initLPP(1, 0, PREIMAGE_SIZE);
+        (PreimageOracle.Leaf[] memory leaves, LibKeccak.StateMatrix[] memory preStates) = _generateLeaves(data);
+        leaves[2].stateCommitment = keccak256("invalid");
+
+        vm.prank(address(this), address(this));
+        oracle.addLeavesLPP(1, 0, data, _commitments(leaves), true);
+
+        oracle.challengeLPP(address(this), 1, preStates[2], leaves[1], _proof(leaves, 1), leaves[2], _proof(leaves, 2));
+        assertTrue(oracle.proposalMetadata(address(this), 1).countered());
+
+        vm.warp(block.timestamp + oracle.challengePeriod() + 1);
+        vm.expectRevert(BadProposal.selector);
+        oracle.squeezeLPP(address(this), 1, preStates[3], leaves[2], _proof(leaves, 2), leaves[3], _proof(leaves, 3));
+    }
+
+    /// @notice Tests that a valid state transition cannot be challenged.
+    function test_challengeLPP_validPostState_reverts() public {
+        (PreimageOracle.Leaf[] memory leaves, LibKeccak.StateMatrix[] memory preStates) = _submit(_preimage(), 0);
+
+        vm.expectRevert(PostStateMatches.selector);
+        oracle.challengeLPP(address(this), 1, preStates[2], leaves[1], _proof(leaves, 1), leaves[2], _proof(leaves, 2));
+    }
+
+    /// @notice Tests that a challenge with an invalid merkle proof reverts.
+    function test_challengeLPP_invalidProof_reverts() public {
+        (PreimageOracle.Leaf[] memory leaves, LibKeccak.StateMatrix[] memory preStates) = _submit(_preimage(), 0);
+
+        vm.expectRevert(InvalidProof.selector);
+        oracle.challengeLPP(address(this), 1, preStates[2], leaves[1], _proof(leaves, 0), leaves[2], _proof(leaves, 2));
+    }
+
+    /// @notice Tests that a proposal with an invalid first state commitment can be challenged.
+    function test_challengeFirstLPP_succeeds() public {
+        bytes memory data = _preimage();
+        oracle.initLPP(1, 0, PREIMAGE_SIZE);
+        (PreimageOracle.Leaf[] memory leaves,) = _generateLeaves(data);
+        leaves[0].stateCommitment = keccak256("invalid");
+
+        vm.prank(address(this), address(this));
+        oracle.addLeavesLPP(1, 0, data, _commitments(leaves), true);
+
+        oracle.challengeFirstLPP(address(this), 1, leaves[0], _proof(leaves, 0));
+        assertTrue(oracle.proposalMetadata(address(this), 1).countered());
+    }
+
+    /// @notice Tests that a valid first state transition cannot be challenged.
+    function test_challengeFirstLPP_validPostState_reverts() public {
+        (PreimageOracle.Leaf[] memory leaves,) = _submit(_preimage(), 0);
+
+        vm.expectRevert(PostStateMatches.selector);
+        oracle.challengeFirstLPP(address(this), 1, leaves[0], _proof(leaves, 0));
+    }
+
+    /// @notice Tests that the Keccak-256 sponge matches the `keccak256` opcode.
+    function testFuzz_libKeccak_matchesOpcode_succeeds(bytes memory _data) public {
+        vm.assume(_data.length < 1000);
+        bytes memory padded = LibKeccak.pad(_data);
+        LibKeccak.StateMatrix memory stateMatrix;
+        for (uint256 i = 0; i < padded.length; i += LibKeccak.BLOCK_SIZE_BYTES) {
+            LibKeccak.absorb(stateMatrix, _slice(padded, i, i + LibKeccak.BLOCK_SIZE_BYTES));
+            LibKeccak.permutation(stateMatrix);
+        }
+        assertEq(LibKeccak.squeeze(stateMatrix), keccak256(_data));
+    }
+
+    /// @notice Initializes and submits a valid, finalized proposal for `_data` in a single call.
+    function _submit(
+        bytes memory _data,
+        uint32 _partOffset
+    )
+        internal
+        returns (PreimageOracle.Leaf[] memory leaves_, LibKeccak.StateMatrix[] memory preStates_)
+    {
+        oracle.initLPP(1, _partOffset, uint32(_data.length));
+        (leaves_, preStates_) = _generateLeaves(_data);
+
+        vm.prank(address(this), address(this));
+        oracle.addLeavesLPP(1, 0, _data, _commitments(leaves_), true);
+    }
+
+    /// @notice Generates the leaves of a valid proposal for `_data`, along with the state matrix before each leaf
+    ///         was absorbed.
+    function _generateLeaves(
+        bytes memory _data
+    )
+        internal
+        pure
+        returns (PreimageOracle.Leaf[] memory leaves_, LibKeccak.StateMatrix[] memory preStates_)
+    {
+        bytes memory padded = LibKeccak.pad(_data);
+        uint256 numLeaves = padded.length / LibKeccak.BLOCK_SIZE_BYTES;
+        leaves_ = new PreimageOracle.Leaf[](numLeaves);
+        preStates_ = new LibKeccak.StateMatrix[](numLeaves);
+
+        LibKeccak.StateMatrix memory stateMatrix;
+        for (uint256 i = 0; i < numLeaves; i++) {
+            // Copy the state matrix so later absorptions don't modify the snapshot.
+            preStates_[i] = abi.decode(abi.encode(stateMatrix), (LibKeccak.StateMatrix));
+
+            bytes memory input = _slice(padded, i * LibKeccak.BLOCK_SIZE_BYTES, (i + 1) * LibKeccak.BLOCK_SIZE_BYTES);
+            LibKeccak.absorb(stateMatrix, input);
+            LibKeccak.permutation(stateMatrix);
+            leaves_[i] =
+                PreimageOracle.Leaf({ input: input, index: i, stateCommitment: keccak256(abi.encode(stateMatrix)) });
+        }
+    }
+
+    /// @notice Returns the merkle root of the tree of `_leaves`.
+    function _root(PreimageOracle.Leaf[] memory _leaves) internal view returns (bytes32 root_) {
+        (root_,) = _merkleize(_leaves, 0);
+    }
+
+    /// @notice Returns the merkle proof of the leaf at `_index`.
+    function _proof(
+        PreimageOracle.Leaf[] memory _leaves,
+        uint256 _index
+    )
+        internal
+        view
+        returns (bytes32[] memory proof_)
+    {
+        (, proof_) = _merkleize(_leaves, _index);
+    }
+
+    /// @notice Computes the merkle root of the tree of `_leaves` along with the proof of the leaf at `_index`.
+    function _merkleize(
+        PreimageOracle.Leaf[] memory _leaves,
+        uint256 _index
+    )
+        internal
+        view
+        returns (bytes32 root_, bytes32[] memory proof_)
+    {
+        bytes32[] memory level = new bytes32[](_leaves.length);
+        for (uint256 i = 0; i < _leaves.length; i++) {
+            level[i] = keccak256(abi.encodePacked(_leaves[i].input, _leaves[i].index, _leaves[i].stateCommitment));
+        }
+
+        proof_ = new bytes32[](oracle.KECCAK_TREE_DEPTH());
+        for (uint256 height = 0; height < proof_.length; height++) {
+            bytes32 zero = oracle.zeroHashes(height);
+            uint256 sibling = _index ^ 1;
+            proof_[height] = sibling < level.length ? level[sibling] : zero;
+
+            bytes32[] memory next = new bytes32[]((level.length + 1) / 2);
+            for (uint256 i = 0; i < next.length; i++) {
+                bytes32 right = 2 * i + 1 < level.length ? level[2 * i + 1] : zero;
+                next[i] = keccak256(abi.encodePacked(level[2 * i], right));
+            }
+            level = next;
+            _index >>= 1;
+        }
+        root_ = level[0];
+    }
+
+    /// @notice Returns the state commitments of `_leaves`.
+    function _commitments(PreimageOracle.Leaf[] memory _leaves) internal pure returns (bytes32[] memory commitments_) {
+        commitments_ = new bytes32[](_leaves.length);
+        for (uint256 i = 0; i < _leaves.length; i++) {
+            commitments_[i] = _leaves[i].stateCommitment;
+        }
+    }
+
+    /// @notice Returns the preimage used by the tests.
+    function _preimage() internal pure returns (bytes memory data_) {
+        data_ = new bytes(PREIMAGE_SIZE);
+        for (uint256 i = 0; i < data_.length; i++) {
+            data_[i] = bytes1(uint8(i));
+        }
+    }
+
+    /// @notice Returns a copy of `_data[_start:_end]`.
+    function _slice(bytes memory _data, uint256 _start, uint256 _end) internal pure returns (bytes memory slice_) {
+        slice_ = new bytes(_end - _start);
+        for (uint256 i = 0; i < slice_.length; i++) {
+            slice_[i] = _data[_start + i];
+        }
+    }
+}